@@ -1,17 +1,25 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/getlawrence/cli/internal/baseline"
 	"github.com/getlawrence/cli/internal/detector"
 	"github.com/getlawrence/cli/internal/detector/issues"
 	"github.com/getlawrence/cli/internal/detector/languages"
+	"github.com/getlawrence/cli/internal/detector/rules"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/gate"
+	"github.com/getlawrence/cli/internal/ignore"
 	"github.com/getlawrence/cli/internal/logger"
+	"github.com/getlawrence/cli/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -28,7 +36,16 @@ var analyzeCmd = &cobra.Command{
 Example usage:
   lawrence analyze                    # Analyze current directory
   lawrence analyze /path/to/project   # Analyze specific directory
-  lawrence analyze --output json      # Output results as JSON`,
+  lawrence analyze --output json      # Output results as JSON
+  lawrence analyze --output yaml      # Output results as YAML
+  lawrence analyze --output sarif     # Output results as SARIF 2.1.0
+  lawrence analyze --output markdown  # Markdown report for PR comments
+  lawrence analyze --output html > report.html  # Standalone HTML report
+  lawrence analyze --update-baseline  # Accept current issues; later runs report only new ones
+  lawrence analyze --rules ./policies # Also evaluate custom YAML rules
+  lawrence analyze --outdated-only    # Only list OpenTelemetry libraries behind their latest release
+  lawrence analyze --fail-on warning  # Exit non-zero when warnings or errors are found
+  lawrence analyze --max-issues instrumentation=5  # Fail on more than 5 instrumentation issues`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runAnalyze,
 }
@@ -39,7 +56,15 @@ func init() {
 	// Add analyze-specific flags
 	analyzeCmd.Flags().BoolP("detailed", "d", false, "Show detailed analysis including file-level information")
 	analyzeCmd.Flags().StringSliceP("languages", "l", []string{}, "Limit analysis to specific languages (go, python, java, etc.)")
-	analyzeCmd.Flags().StringSliceP("categories", "", []string{}, "Limit issues to specific categories (missing_library, configuration, etc.)")
+	analyzeCmd.Flags().StringSliceP("categories", "", []string{}, "Limit issues to specific categories (missing_otel, configuration, instrumentation, etc.)")
+	analyzeCmd.Flags().String("baseline", "", "Path to the baseline file (default <path>/"+baseline.DefaultFileName+")")
+	analyzeCmd.Flags().Bool("update-baseline", false, "Record all current issues in the baseline file so later runs only report new issues")
+	analyzeCmd.Flags().String("fail-on", "", "Exit with a non-zero code when any issue is at or above this severity (error, warning, info)")
+	analyzeCmd.Flags().Int("concurrency", runtime.GOMAXPROCS(0), "Number of directories to analyze in parallel")
+	analyzeCmd.Flags().StringSlice("exclude", []string{}, "Additional paths to ignore, in .gitignore syntax (e.g. 'generated/', '**/*_mock.go')")
+	analyzeCmd.Flags().String("rules", "", "Directory of YAML rule files to run alongside the built-in detectors")
+	analyzeCmd.Flags().Bool("outdated-only", false, "Only report OpenTelemetry libraries behind their latest release")
+	analyzeCmd.Flags().StringToInt("max-issues", map[string]int{}, "Exit with a non-zero code when a category has more issues than allowed (e.g. instrumentation=5)")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
@@ -65,6 +90,22 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	detailed, _ := cmd.Flags().GetBool("detailed")
 	outputFormat, _ := cmd.Flags().GetString("output")
 
+	renderer, err := report.NewRegistry().Get(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	languageFilter, _ := cmd.Flags().GetStringSlice("languages")
+	categoryFilter, _ := cmd.Flags().GetStringSlice("categories")
+	categories, err := parseCategories(categoryFilter)
+	if err != nil {
+		return err
+	}
+	policy, err := gatePolicyFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
 	uiLogger := logger.NewUILogger()
 
 	if verbose {
@@ -72,253 +113,275 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create analysis engine
-	codebaseAnalyzer := detector.NewCodebaseAnalyzer([]detector.IssueDetector{
+	issueDetectors := []detector.IssueDetector{
 		issues.NewMissingOTelDetector(),
-	}, map[string]detector.Language{
+		issues.NewVersionSkewDetector(),
+		issues.NewDeprecatedComponentDetector(),
+		issues.NewOutdatedDetector(),
+		issues.NewMisconfigurationDetector(),
+		issues.NewMissingShutdownDetector(),
+	}
+	if rulesDir, _ := cmd.Flags().GetString("rules"); rulesDir != "" {
+		customRules, err := rules.LoadDir(rulesDir)
+		if err != nil {
+			return err
+		}
+		for _, r := range customRules {
+			issueDetectors = append(issueDetectors, r)
+		}
+	}
+	languageDetectors, err := filterLanguageDetectors(map[string]detector.Language{
 		"go":         languages.NewGoDetector(),
 		"python":     languages.NewPythonDetector(),
 		"javascript": languages.NewJavaScriptDetector(),
+		"typescript": languages.NewTypeScriptDetector(),
 		"java":       languages.NewJavaDetector(),
+		"kotlin":     languages.NewKotlinDetector(),
 		"csharp":     languages.NewDotNetDetector(),
 		"ruby":       languages.NewRubyDetector(),
 		"php":        languages.NewPHPDetector(),
-	}, uiLogger)
+		"rust":       languages.NewRustDetector(),
+		"elixir":     languages.NewElixirDetector(),
+		"cpp":        languages.NewCppDetector(),
+	}, languageFilter)
+	if err != nil {
+		return err
+	}
+	codebaseAnalyzer := detector.NewCodebaseAnalyzer(issueDetectors, languageDetectors, uiLogger)
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	codebaseAnalyzer.SetConcurrency(concurrency)
 
-	analysis, err := codebaseAnalyzer.AnalyzeCodebase(cmd.Context(), absPath)
+	excludes, _ := cmd.Flags().GetStringSlice("exclude")
+	ctx, err := withIgnoreMatcher(cmd.Context(), absPath, excludes)
 	if err != nil {
 		return err
 	}
 
-	switch outputFormat {
-	case "json":
-		return outputJSON(analysis)
-	default:
-		return outputText(analysis, detailed, uiLogger)
+	analysis, err := codebaseAnalyzer.AnalyzeCodebase(ctx, absPath)
+	if err != nil {
+		return err
 	}
-}
 
-func outputText(analysis *detector.Analysis, detailed bool, logger logger.Logger) error {
-	if analysis == nil || len(analysis.DirectoryAnalyses) == 0 {
-		logger.Logf("No analysis results to display.\n")
-		return nil
+	// The baseline is updated from every category so that filtering the output doesn't
+	// drop the other accepted issues
+	if err := applyBaseline(cmd, analysis, absPath, analysisScope(ctx, absPath, issueDetectors, languageDetectors, languageFilter)); err != nil {
+		return err
 	}
 
-	// Stable ordering of directories
-	directories := make([]string, 0, len(analysis.DirectoryAnalyses))
-	for dir := range analysis.DirectoryAnalyses {
-		directories = append(directories, dir)
+	filterIssuesByCategory(analysis, categories)
+
+	reportedDetectors := issueDetectors
+	if outdatedOnly, _ := cmd.Flags().GetBool("outdated-only"); outdatedOnly {
+		// A quick inventory of the libraries to upgrade, without the other findings. Every
+		// detector still runs so that the baseline keeps their issues.
+		outdated := issues.NewOutdatedDetector()
+		filterIssuesByDetector(analysis, outdated.ID())
+		reportedDetectors = []detector.IssueDetector{outdated}
 	}
-	sort.Strings(directories)
 
-	// Totals for summary
-	var totalLibraries, totalPackages, totalInstrumentations, totalIssues int
-	detectedLanguages := make(map[string]bool)
+	var verdict *gate.Verdict
+	if policy.Enabled() {
+		verdict = policy.Evaluate(analysis)
+	}
 
-	// Helpers
-	joinNonEmpty := func(parts ...string) string {
-		out := make([]string, 0, len(parts))
-		for _, p := range parts {
-			if strings.TrimSpace(p) != "" {
-				out = append(out, p)
-			}
-		}
-		return strings.Join(out, " ")
+	if err := renderer.Render(os.Stdout, &report.Report{
+		Analysis:  analysis,
+		Detectors: reportedDetectors,
+		Version:   Version,
+		Detailed:  detailed,
+		Gate:      verdict,
+	}); err != nil {
+		return err
 	}
 
-	for _, dir := range directories {
-		dirAnalysis := analysis.DirectoryAnalyses[dir]
-		if dirAnalysis == nil {
-			continue
-		}
-		detectedLanguages[strings.ToLower(dirAnalysis.Language)] = true
-		totalLibraries += len(dirAnalysis.Libraries)
-		totalPackages += len(dirAnalysis.Packages)
-		totalInstrumentations += len(dirAnalysis.AvailableInstrumentations)
-		totalIssues += len(dirAnalysis.Issues)
-
-		// Header
-		logger.Logf("Directory: %s\n", dirAnalysis.Directory)
-		logger.Logf("Language: %s\n", dirAnalysis.Language)
-
-		// Libraries
-		if detailed {
-			logger.Logf("Libraries:\n")
-			if len(dirAnalysis.Libraries) == 0 {
-				logger.Logf("  - none\n")
-			} else {
-				for _, lib := range dirAnalysis.Libraries {
-					name := lib.Name
-					ver := lib.Version
-					file := lib.PackageFile
-					label := name
-					if ver != "" {
-						label = joinNonEmpty(label, fmt.Sprintf("(%s)", ver))
-					}
-					if file != "" {
-						label = joinNonEmpty(label, fmt.Sprintf("[%s]", file))
-					}
-					logger.Logf("  - %s\n", label)
-				}
-			}
-		} else {
-			logger.Logf("Libraries: %d\n", len(dirAnalysis.Libraries))
-		}
+	if verdict != nil && !verdict.Passed {
+		// The report already explains the failure; don't follow it with usage help
+		cmd.SilenceUsage = true
+		return &ExitCodeError{Code: ExitCodeGateFailed, Err: fmt.Errorf("quality gate failed with %d violation(s)", len(verdict.Violations))}
+	}
+	return nil
+}
 
-		// Packages
-		if detailed {
-			logger.Logf("Packages:\n")
-			if len(dirAnalysis.Packages) == 0 {
-				logger.Logf("  - none\n")
-			} else {
-				for _, pkg := range dirAnalysis.Packages {
-					name := pkg.Name
-					ver := pkg.Version
-					file := pkg.PackageFile
-					label := name
-					if ver != "" {
-						label = joinNonEmpty(label, fmt.Sprintf("(%s)", ver))
-					}
-					if file != "" {
-						label = joinNonEmpty(label, fmt.Sprintf("[%s]", file))
-					}
-					logger.Logf("  - %s\n", label)
-				}
-			}
-		} else {
-			logger.Logf("Packages: %d\n", len(dirAnalysis.Packages))
-		}
+// languageAliases maps user-facing language names to analyzer keys
+var languageAliases = map[string]string{
+	"dotnet": "csharp",
+	"c#":     "csharp",
+	"js":     "javascript",
+	"node":   "javascript",
+	"ts":     "typescript",
+	"golang": "go",
+	"py":     "python",
+	"rs":     "rust",
+	"kt":     "kotlin",
+	"ex":     "elixir",
+	"exs":    "elixir",
+	"c++":    "cpp",
+	"cxx":    "cpp",
+	"cc":     "cpp",
+}
 
-		// Instrumentations
-		if detailed {
-			logger.Logf("Instrumentations:\n")
-			if len(dirAnalysis.AvailableInstrumentations) == 0 {
-				logger.Logf("  - none\n")
-			} else {
-				for _, inst := range dirAnalysis.AvailableInstrumentations {
-					tags := make([]string, 0, 3)
-					if inst.IsFirstParty {
-						tags = append(tags, "first-party")
-					}
-					if inst.IsAvailable {
-						tags = append(tags, "available")
-					} else {
-						tags = append(tags, "unavailable")
-					}
-					if inst.RegistryType != "" {
-						tags = append(tags, inst.RegistryType)
-					}
-					meta := ""
-					if len(tags) > 0 {
-						meta = fmt.Sprintf(" (%s)", strings.Join(tags, ", "))
-					}
-					link := inst.URLs.Repo
-					suffix := meta
-					if link != "" {
-						suffix = joinNonEmpty(suffix, fmt.Sprintf("- %s", link))
-					}
-					logger.Logf("  - %s: %s%s\n", inst.Package.Name, inst.Title, suffix)
-				}
-			}
-		} else {
-			logger.Logf("Instrumentations: %d\n", len(dirAnalysis.AvailableInstrumentations))
-		}
+// withIgnoreMatcher returns a context carrying the ignore matcher for root, built from
+// its .gitignore and .lawrenceignore files plus the --exclude patterns
+func withIgnoreMatcher(ctx context.Context, root string, excludes []string) (context.Context, error) {
+	matcher, err := ignore.New(root, excludes)
+	if err != nil {
+		return nil, err
+	}
+	return ignore.WithMatcher(ctx, matcher), nil
+}
 
-		// Issues
-		if len(dirAnalysis.Issues) > 0 {
-			logger.Logf("Issues (%d):\n", len(dirAnalysis.Issues))
-			for _, issue := range dirAnalysis.Issues {
-				header := fmt.Sprintf("[%s][%s] %s", strings.ToUpper(string(issue.Severity)), string(issue.Category), issue.Title)
-				logger.Logf("  - %s\n", header)
-				if strings.TrimSpace(issue.Description) != "" {
-					logger.Logf("    Description: %s\n", issue.Description)
-				}
-				if strings.TrimSpace(issue.Suggestion) != "" {
-					logger.Logf("    Suggestion: %s\n", issue.Suggestion)
-				}
-				if len(issue.References) > 0 {
-					logger.Logf("    References:\n")
-					for _, ref := range issue.References {
-						logger.Logf("      - %s\n", ref)
-					}
-				}
-				locParts := make([]string, 0, 2)
-				if strings.TrimSpace(issue.File) != "" {
-					locParts = append(locParts, issue.File)
-				}
-				if issue.Line > 0 {
-					locParts = append(locParts, fmt.Sprintf("line %d", issue.Line))
-				}
-				if len(locParts) > 0 {
-					logger.Logf("    Location: %s\n", strings.Join(locParts, ": "))
-				}
+// filterLanguageDetectors limits the language detectors to the requested languages (empty = all)
+func filterLanguageDetectors(all map[string]detector.Language, requested []string) (map[string]detector.Language, error) {
+	if len(requested) == 0 {
+		return all, nil
+	}
+	filtered := make(map[string]detector.Language, len(requested))
+	for _, name := range requested {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if alias, ok := languageAliases[key]; ok {
+			key = alias
+		}
+		lang, ok := all[key]
+		if !ok {
+			available := make([]string, 0, len(all))
+			for k := range all {
+				available = append(available, k)
 			}
-		} else {
-			logger.Logf("Issues: 0\n")
+			sort.Strings(available)
+			return nil, fmt.Errorf("unsupported language: %s (available: %s)", name, strings.Join(available, ", "))
 		}
-
-		// Spacer between directories
-		logger.Logf("\n")
+		filtered[key] = lang
 	}
+	return filtered, nil
+}
 
-	// Summary footer
-	languages := make([]string, 0, len(detectedLanguages))
-	for lang := range detectedLanguages {
-		languages = append(languages, lang)
+// parseCategories validates the --categories flag
+func parseCategories(values []string) (map[domain.Category]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
 	}
-	sort.Strings(languages)
-	logger.Logf("Summary: %d directories, %d languages [%s], %d libraries, %d packages, %d instrumentations, %d issues\n",
-		len(analysis.DirectoryAnalyses), len(languages), strings.Join(languages, ", "), totalLibraries, totalPackages, totalInstrumentations, totalIssues,
-	)
-
-	return nil
+	categories := make(map[domain.Category]bool, len(values))
+	for _, v := range values {
+		c, err := gate.ParseCategory(v)
+		if err != nil {
+			return nil, err
+		}
+		categories[c] = true
+	}
+	return categories, nil
 }
 
-func outputJSON(analysis *detector.Analysis) error {
-	// Aggregate data from all directories for backward compatibility
-	var allIssues []interface{}
-	var allLibraries []interface{}
-	var allPackages []interface{}
-	var allInstrumentations []interface{}
-	detectedLanguages := make(map[string]bool)
+// filterIssuesByCategory drops issues outside the requested categories, suppressed
+// or not (nil = keep all)
+func filterIssuesByCategory(analysis *detector.Analysis, categories map[domain.Category]bool) {
+	if len(categories) == 0 {
+		return
+	}
+	for _, dirAnalysis := range analysis.DirectoryAnalyses {
+		dirAnalysis.Issues = keepCategories(dirAnalysis.Issues, categories)
+		dirAnalysis.SuppressedIssues = keepCategories(dirAnalysis.SuppressedIssues, categories)
+	}
+}
 
+// filterIssuesByDetector drops the issues of every other detector, suppressed or not
+func filterIssuesByDetector(analysis *detector.Analysis, detectorID string) {
 	for _, dirAnalysis := range analysis.DirectoryAnalyses {
-		for _, it := range dirAnalysis.Issues {
-			allIssues = append(allIssues, it)
-		}
-		for _, it := range dirAnalysis.Libraries {
-			allLibraries = append(allLibraries, it)
+		dirAnalysis.Issues = keepDetector(dirAnalysis.Issues, detectorID)
+		dirAnalysis.SuppressedIssues = keepDetector(dirAnalysis.SuppressedIssues, detectorID)
+	}
+}
+
+func keepDetector(all []domain.Issue, detectorID string) []domain.Issue {
+	kept := all[:0]
+	for _, issue := range all {
+		if issue.DetectorID == detectorID {
+			kept = append(kept, issue)
 		}
-		for _, it := range dirAnalysis.Packages {
-			allPackages = append(allPackages, it)
+	}
+	return kept
+}
+
+func keepCategories(all []domain.Issue, categories map[domain.Category]bool) []domain.Issue {
+	kept := all[:0]
+	for _, issue := range all {
+		if categories[issue.Category] {
+			kept = append(kept, issue)
 		}
-		for _, it := range dirAnalysis.AvailableInstrumentations {
-			allInstrumentations = append(allInstrumentations, it)
+	}
+	return kept
+}
+
+// gatePolicyFromFlags builds the quality gate policy from --fail-on and --max-issues
+func gatePolicyFromFlags(cmd *cobra.Command) (gate.Policy, error) {
+	var policy gate.Policy
+
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	severity, err := gate.ParseSeverity(failOn)
+	if err != nil {
+		return policy, err
+	}
+	policy.FailOn = severity
+
+	maxIssues, _ := cmd.Flags().GetStringToInt("max-issues")
+	if len(maxIssues) > 0 {
+		policy.MaxIssues = make(map[domain.Category]int, len(maxIssues))
+		for name, max := range maxIssues {
+			category, err := gate.ParseCategory(name)
+			if err != nil {
+				return policy, err
+			}
+			if max < 0 {
+				return policy, fmt.Errorf("invalid --max-issues value for %s: must be >= 0", name)
+			}
+			policy.MaxIssues[category] = max
 		}
-		if dirAnalysis.Language != "" {
-			detectedLanguages[dirAnalysis.Language] = true
+	}
+	return policy, nil
+}
+
+// analysisScope describes what the run analyzed, so that updating the baseline keeps
+// the issues of detectors, languages and directories left out by --rules, --languages
+// or --exclude
+func analysisScope(ctx context.Context, rootPath string, issueDetectors []detector.IssueDetector, languageDetectors map[string]detector.Language, languageFilter []string) baseline.Scope {
+	var scope baseline.Scope
+	for _, d := range issueDetectors {
+		scope.Detectors = append(scope.Detectors, d.ID())
+	}
+	if len(languageFilter) > 0 {
+		for name := range languageDetectors {
+			scope.Languages = append(scope.Languages, name)
 		}
 	}
+	matcher := ignore.FromContext(ctx, rootPath)
+	scope.Excluded = func(directory string) bool {
+		return matcher.Match(filepath.Join(rootPath, directory), true)
+	}
+	return scope
+}
+
+// applyBaseline loads the baseline file, optionally rewrites it with the current
+// issues, and hides baselined or suppressed issues from the analysis
+func applyBaseline(cmd *cobra.Command, analysis *detector.Analysis, rootPath string, scope baseline.Scope) error {
+	baselinePath, _ := cmd.Flags().GetString("baseline")
+	updateBaseline, _ := cmd.Flags().GetBool("update-baseline")
+	if baselinePath == "" {
+		baselinePath = filepath.Join(rootPath, baseline.DefaultFileName)
+	}
 
-	// Convert detected languages map to slice
-	var languageSlice []string
-	for lang := range detectedLanguages {
-		languageSlice = append(languageSlice, lang)
+	bl, err := baseline.Load(baselinePath)
+	if err != nil {
+		return err
 	}
 
-	result := map[string]interface{}{
-		"analysis":   analysis,
-		"all_issues": allIssues,
-		"summary": map[string]interface{}{
-			"total_directories":      len(analysis.DirectoryAnalyses),
-			"total_languages":        len(languageSlice),
-			"total_libraries":        len(allLibraries),
-			"total_packages":         len(allPackages),
-			"total_instrumentations": len(allInstrumentations),
-			"total_issues":           len(allIssues),
-		},
+	now := time.Now()
+	if updateBaseline {
+		bl.Update(analysis, scope, now)
+		if err := bl.Save(baselinePath); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Baseline updated with %d issues: %s\n", len(bl.Issues), baselinePath)
 	}
 
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
+	bl.Apply(analysis, now)
+	return nil
 }
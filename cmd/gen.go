@@ -51,13 +51,14 @@ var (
 	showPrompt     bool
 	savePrompt     string
 	configPath     string
+	excludes       []string
 )
 
 func init() {
 	rootCmd.AddCommand(genCmd)
 
 	genCmd.Flags().StringVarP(&language, "language", "l", "",
-		"Target language (go, javascript, python, java, dotnet, ruby, php)")
+		"Target language (go, javascript, typescript, python, java, kotlin, dotnet, ruby, php, rust, elixir, cpp)")
 	genCmd.Flags().StringVarP(&agentType, "agent", "a", "",
 		"Preferred coding agent (gemini, claude, openai, github)")
 	genCmd.Flags().BoolVar(&listAgents, "list-agents", false,
@@ -79,6 +80,8 @@ func init() {
 		"Save the generated agent prompt to the given file path (AI mode only)")
 	// Advanced config
 	genCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to advanced OpenTelemetry config YAML")
+	genCmd.Flags().StringSliceVar(&excludes, "exclude", []string{},
+		"Additional paths to ignore, in .gitignore syntax (e.g. 'generated/', '**/*_mock.go')")
 }
 
 func runGen(cmd *cobra.Command, args []string) error {
@@ -95,19 +98,30 @@ func runGen(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to resolve path: %w", err)
 	}
 
+	ctx, err = withIgnoreMatcher(ctx, absPath, excludes)
+	if err != nil {
+		return err
+	}
+
 	ui := logger.NewUILogger()
 
 	// Create analysis engine
 	codebaseAnalyzer := detector.NewCodebaseAnalyzer([]detector.IssueDetector{
 		issues.NewMissingOTelDetector(),
+		issues.NewMissingShutdownDetector(),
 	}, map[string]detector.Language{
 		"go":         languages.NewGoDetector(),
 		"javascript": languages.NewJavaScriptDetector(),
+		"typescript": languages.NewTypeScriptDetector(),
 		"python":     languages.NewPythonDetector(),
 		"java":       languages.NewJavaDetector(),
+		"kotlin":     languages.NewKotlinDetector(),
 		"csharp":     languages.NewDotNetDetector(),
 		"ruby":       languages.NewRubyDetector(),
 		"php":        languages.NewPHPDetector(),
+		"rust":       languages.NewRustDetector(),
+		"elixir":     languages.NewElixirDetector(),
+		"cpp":        languages.NewCppDetector(),
 	}, ui)
 
 	codeGenerator, err := generator.NewGenerator(codebaseAnalyzer, ui)
@@ -163,6 +163,9 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 			types.ComponentLanguageCSharp,
 			types.ComponentLanguagePHP,
 			types.ComponentLanguageRuby,
+			types.ComponentLanguageRust,
+			types.ComponentLanguageElixir,
+			types.ComponentLanguageCpp,
 		}
 	} else {
 		language, err := parseLanguage(languageStr)
@@ -214,13 +217,19 @@ func parseLanguage(languageStr string) (types.ComponentLanguage, error) {
 		return types.ComponentLanguagePHP, nil
 	case "ruby":
 		return types.ComponentLanguageRuby, nil
+	case "rust", "rs":
+		return types.ComponentLanguageRust, nil
+	case "elixir", "ex":
+		return types.ComponentLanguageElixir, nil
+	case "cpp", "c++":
+		return types.ComponentLanguageCpp, nil
 	default:
 		return "", fmt.Errorf("unsupported language: %s", languageStr)
 	}
 }
 
 func getSupportedLanguages() string {
-	languages := []string{"javascript", "python", "go", "java", "csharp", "php", "ruby"}
+	languages := []string{"javascript", "python", "go", "java", "csharp", "php", "ruby", "rust", "elixir", "cpp"}
 	return strings.Join(languages, ", ")
 }
 
@@ -1,9 +1,37 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 )
 
+// Process exit codes
+const (
+	// ExitCodeFailure is used for any failure that is not a quality gate result
+	ExitCodeFailure = 1
+	// ExitCodeGateFailed is used when analyze completes but the quality gate fails
+	ExitCodeGateFailed = 2
+)
+
+// ExitCodeError carries a specific process exit code alongside an error
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string { return e.Err.Error() }
+func (e *ExitCodeError) Unwrap() error { return e.Err }
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitCodeFailure
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "lawrence",
@@ -24,5 +52,5 @@ func Execute() error {
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
-	rootCmd.PersistentFlags().StringP("output", "o", "text", "output format (text, json, yaml)")
+	rootCmd.PersistentFlags().StringP("output", "o", "text", "output format (text, json, yaml, sarif, markdown, html)")
 }
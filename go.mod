@@ -5,6 +5,7 @@ go 1.23.0
 toolchain go1.24.6
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/go-enry/go-enry/v2 v2.9.2
 	github.com/google/go-github/v74 v74.0.0
 	github.com/mattn/go-sqlite3 v1.14.22
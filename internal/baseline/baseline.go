@@ -0,0 +1,288 @@
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+// DefaultFileName is the baseline file looked up in the analyzed root
+const DefaultFileName = ".lawrence-baseline.json"
+
+// fileVersion is the current baseline file format version
+const fileVersion = 1
+
+// dateLayout is the format used for suppression expiry dates
+const dateLayout = "2006-01-02"
+
+// Sources recorded in Issue.SuppressedBy
+const (
+	SourceBaseline = "baseline"
+	SourceConfig   = "config"
+	SourceInline   = "inline"
+)
+
+// Entry is an accepted finding recorded in the baseline
+type Entry struct {
+	Fingerprint string `json:"fingerprint"`
+	DetectorID  string `json:"detector_id,omitempty"`
+	Directory   string `json:"directory,omitempty"`
+	Language    string `json:"language,omitempty"`
+	File        string `json:"file,omitempty"`
+	Title       string `json:"title,omitempty"`
+}
+
+// Scope describes what an analysis covered. Updating the baseline replaces only the
+// recorded issues inside it, so that a run narrowed to some detectors, languages or
+// directories keeps the others.
+type Scope struct {
+	// Detectors are the IDs of the detectors that ran
+	Detectors []string
+	// Languages are the analyzed languages; empty means every language
+	Languages []string
+	// Excluded reports whether a root-relative directory was left out of the analysis;
+	// nil means none was
+	Excluded func(directory string) bool
+}
+
+// covers reports whether the run described by the scope would have found e again.
+// Entries without a language predate recording it and are kept by a narrowed run.
+func (s Scope) covers(e Entry) bool {
+	if !contains(s.Detectors, e.DetectorID) {
+		return false
+	}
+	if len(s.Languages) > 0 && !contains(s.Languages, e.Language) {
+		return false
+	}
+	return s.Excluded == nil || !s.Excluded(e.Directory)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Suppression hides matching findings, optionally until an expiry date.
+// A suppression matches by fingerprint, or by detector ID narrowed by
+// optional directory and file glob patterns.
+type Suppression struct {
+	Fingerprint string `json:"fingerprint,omitempty"`
+	DetectorID  string `json:"detector_id,omitempty"`
+	Directory   string `json:"directory,omitempty"`
+	File        string `json:"file,omitempty"`
+	Reason      string `json:"reason"`
+	Expires     string `json:"expires,omitempty"`
+}
+
+// File is the on-disk representation of .lawrence-baseline.json
+type File struct {
+	Version      int           `json:"version"`
+	GeneratedAt  time.Time     `json:"generated_at,omitempty"`
+	Issues       []Entry       `json:"issues"`
+	Suppressions []Suppression `json:"suppressions,omitempty"`
+}
+
+// Load reads a baseline file. A missing file yields an empty baseline.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &File{Version: fileVersion}, nil
+		}
+		return nil, fmt.Errorf("failed to read baseline %s: %w", path, err)
+	}
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	for i, s := range f.Suppressions {
+		if s.Fingerprint == "" && s.DetectorID == "" {
+			return nil, fmt.Errorf("suppression %d in %s must set fingerprint or detector_id", i, path)
+		}
+		if s.Expires != "" {
+			if _, err := time.Parse(dateLayout, s.Expires); err != nil {
+				return nil, fmt.Errorf("suppression %d in %s has invalid expires %q (want YYYY-MM-DD)", i, path, s.Expires)
+			}
+		}
+	}
+	return &f, nil
+}
+
+// Save writes the baseline file with stable ordering
+func (f *File) Save(path string) error {
+	f.Version = fileVersion
+	sort.Slice(f.Issues, func(i, j int) bool { return f.Issues[i].Fingerprint < f.Issues[j].Fingerprint })
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline %s: %w", path, err)
+	}
+	return nil
+}
+
+// Update replaces the recorded issues within scope with every current finding.
+// Recorded issues outside scope and suppressions are kept as-is.
+func (f *File) Update(analysis *detector.Analysis, scope Scope, now time.Time) {
+	f.GeneratedAt = now.UTC().Truncate(time.Second)
+	kept := f.Issues[:0]
+	seen := make(map[string]bool)
+	for _, e := range f.Issues {
+		if !scope.covers(e) && !seen[e.Fingerprint] {
+			seen[e.Fingerprint] = true
+			kept = append(kept, e)
+		}
+	}
+	f.Issues = kept
+	for _, dirAnalysis := range analysis.DirectoryAnalyses {
+		for _, issue := range dirAnalysis.Issues {
+			fp := Fingerprint(analysis.RootPath, dirAnalysis.Directory, issue)
+			if seen[fp] {
+				continue
+			}
+			seen[fp] = true
+			f.Issues = append(f.Issues, Entry{
+				Fingerprint: fp,
+				DetectorID:  issue.DetectorID,
+				Directory:   dirAnalysis.Directory,
+				Language:    strings.ToLower(dirAnalysis.Language),
+				File:        relativeFile(analysis.RootPath, issue.File),
+				Title:       issue.Title,
+			})
+		}
+	}
+}
+
+// Apply fingerprints every issue and moves suppressed or baselined issues out of
+// DirectoryAnalysis.Issues into DirectoryAnalysis.SuppressedIssues.
+// Config suppressions take precedence over inline ones, which take precedence over the baseline.
+func (f *File) Apply(analysis *detector.Analysis, now time.Time) {
+	baselined := make(map[string]bool, len(f.Issues))
+	for _, e := range f.Issues {
+		baselined[e.Fingerprint] = true
+	}
+	inline := newInlineIndex(analysis.RootPath, now)
+
+	for _, dirAnalysis := range analysis.DirectoryAnalyses {
+		var active, suppressed []domain.Issue
+		for _, issue := range dirAnalysis.Issues {
+			issue.Fingerprint = Fingerprint(analysis.RootPath, dirAnalysis.Directory, issue)
+			rel := relativeFile(analysis.RootPath, issue.File)
+
+			if s := f.matchSuppression(issue, dirAnalysis.Directory, rel, now); s != nil {
+				markSuppressed(&issue, SourceConfig, s.Reason)
+			} else if reason, ok := inline.match(issue, dirAnalysis.Directory); ok {
+				markSuppressed(&issue, SourceInline, reason)
+			} else if baselined[issue.Fingerprint] {
+				markSuppressed(&issue, SourceBaseline, "present in baseline")
+			}
+
+			if issue.Suppressed {
+				suppressed = append(suppressed, issue)
+			} else {
+				active = append(active, issue)
+			}
+		}
+		dirAnalysis.Issues = active
+		dirAnalysis.SuppressedIssues = append(dirAnalysis.SuppressedIssues, suppressed...)
+	}
+}
+
+// matchSuppression returns the first unexpired suppression matching the issue
+func (f *File) matchSuppression(issue domain.Issue, directory, file string, now time.Time) *Suppression {
+	for i := range f.Suppressions {
+		s := &f.Suppressions[i]
+		if isExpired(s.Expires, now) {
+			continue
+		}
+		if s.Fingerprint != "" {
+			if s.Fingerprint == issue.Fingerprint {
+				return s
+			}
+			continue
+		}
+		if s.DetectorID != issue.DetectorID {
+			continue
+		}
+		if s.Directory != "" && !globMatch(s.Directory, directory) {
+			continue
+		}
+		if s.File != "" && !globMatch(s.File, file) {
+			continue
+		}
+		return s
+	}
+	return nil
+}
+
+// Fingerprint returns a stable identifier for an issue built from its detector ID,
+// directory, language, root-relative file and title. The language keeps findings of
+// different stacks in the same directory apart.
+func Fingerprint(rootPath, directory string, issue domain.Issue) string {
+	detectorID := issue.DetectorID
+	if detectorID == "" {
+		detectorID = issue.ID
+	}
+	key := strings.Join([]string{detectorID, directory, strings.ToLower(issue.Language), relativeFile(rootPath, issue.File), issue.Title}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// relativeFile makes absolute issue paths relative to the analyzed root so
+// fingerprints do not depend on where the repository is checked out
+func relativeFile(rootPath, file string) string {
+	if file == "" {
+		return ""
+	}
+	if filepath.IsAbs(file) && rootPath != "" {
+		if rel, err := filepath.Rel(rootPath, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// isExpired reports whether an expiry date (inclusive) has passed
+func isExpired(expires string, now time.Time) bool {
+	if expires == "" {
+		return false
+	}
+	t, err := time.Parse(dateLayout, expires)
+	if err != nil {
+		return true
+	}
+	return now.After(t.Add(24 * time.Hour))
+}
+
+// globMatch matches a slash-separated path against a glob pattern, treating a
+// plain directory pattern as matching everything below it
+func globMatch(pattern, path string) bool {
+	if pattern == path {
+		return true
+	}
+	if ok, _ := filepath.Match(pattern, path); ok {
+		return true
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(pattern, "/")+"/")
+}
+
+func markSuppressed(issue *domain.Issue, source, reason string) {
+	issue.Suppressed = true
+	issue.SuppressedBy = source
+	issue.SuppressionReason = reason
+}
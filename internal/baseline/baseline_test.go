@@ -0,0 +1,175 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+func newAnalysis(root string, issues ...domain.Issue) *detector.Analysis {
+	return &detector.Analysis{
+		RootPath: root,
+		DirectoryAnalyses: map[string]*detector.DirectoryAnalysis{
+			"svc": {Directory: "svc", Language: "Go", Issues: issues},
+		},
+	}
+}
+
+func TestFingerprint_IndependentOfCheckoutLocation(t *testing.T) {
+	issue := func(root string) domain.Issue {
+		return domain.Issue{DetectorID: "det", Title: "t", File: filepath.Join(root, "svc", "go.mod")}
+	}
+	a := Fingerprint("/a/repo", "svc", issue("/a/repo"))
+	b := Fingerprint("/b/checkout", "svc", issue("/b/checkout"))
+	if a != b {
+		t.Fatalf("fingerprints differ across roots: %s vs %s", a, b)
+	}
+	if c := Fingerprint("/a/repo", "svc", domain.Issue{DetectorID: "det", Title: "other"}); c == a {
+		t.Fatalf("different titles produced the same fingerprint")
+	}
+}
+
+func TestUpdateSaveLoadApply_HidesBaselinedIssues(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, DefaultFileName)
+	known := domain.Issue{ID: "a", DetectorID: "det", Title: "known"}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	bl, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of missing file failed: %v", err)
+	}
+	bl.Update(newAnalysis(root, known), Scope{Detectors: []string{"det"}}, now)
+	if err := bl.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	fresh := domain.Issue{ID: "b", DetectorID: "det", Title: "new"}
+	analysis := newAnalysis(root, known, fresh)
+	reloaded.Apply(analysis, now)
+
+	dir := analysis.DirectoryAnalyses["svc"]
+	if len(dir.Issues) != 1 || dir.Issues[0].Title != "new" {
+		t.Fatalf("expected only the new issue to remain, got %+v", dir.Issues)
+	}
+	if len(dir.SuppressedIssues) != 1 || dir.SuppressedIssues[0].SuppressedBy != SourceBaseline || !dir.SuppressedIssues[0].Suppressed {
+		t.Fatalf("expected known issue to be baselined, got %+v", dir.SuppressedIssues)
+	}
+	if dir.Issues[0].Fingerprint == "" {
+		t.Fatalf("expected active issues to be fingerprinted")
+	}
+}
+
+func TestUpdate_KeepsIssuesOutsideScope(t *testing.T) {
+	root := t.TempDir()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	bl := &File{Issues: []Entry{
+		{Fingerprint: "stale", DetectorID: "det", Directory: "svc", Language: "go"},
+		{Fingerprint: "rule", DetectorID: "rule:custom", Directory: "svc", Language: "go"},
+		{Fingerprint: "python", DetectorID: "det", Directory: "svc", Language: "python"},
+		{Fingerprint: "excluded", DetectorID: "det", Directory: "generated", Language: "go"},
+	}}
+
+	bl.Update(newAnalysis(root, domain.Issue{DetectorID: "det", Language: "go", Title: "current"}), Scope{
+		Detectors: []string{"det"},
+		Languages: []string{"go"},
+		Excluded:  func(directory string) bool { return directory == "generated" },
+	}, now)
+
+	var got []string
+	for _, e := range bl.Issues {
+		got = append(got, e.Fingerprint)
+	}
+	current := Fingerprint(root, "svc", domain.Issue{DetectorID: "det", Language: "go", Title: "current"})
+	want := map[string]bool{"rule": true, "python": true, "excluded": true, current: true}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d entries, got %v", len(want), got)
+	}
+	for _, fp := range got {
+		if !want[fp] {
+			t.Errorf("unexpected entry %s in %v", fp, got)
+		}
+	}
+}
+
+func TestApply_ConfigSuppressionHonoursExpiry(t *testing.T) {
+	issue := domain.Issue{DetectorID: "det", Title: "x", File: "/repo/svc/go.mod"}
+	bl := &File{Suppressions: []Suppression{{DetectorID: "det", Directory: "svc", File: "svc/*.mod", Reason: "accepted", Expires: "2025-06-30"}}}
+
+	before := newAnalysis("/repo", issue)
+	bl.Apply(before, time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC))
+	if got := before.DirectoryAnalyses["svc"].SuppressedIssues; len(got) != 1 || got[0].SuppressionReason != "accepted" || got[0].SuppressedBy != SourceConfig {
+		t.Fatalf("expected issue to be suppressed on expiry day, got %+v", got)
+	}
+
+	after := newAnalysis("/repo", issue)
+	bl.Apply(after, time.Date(2025, 7, 2, 0, 0, 0, 0, time.UTC))
+	if len(after.DirectoryAnalyses["svc"].Issues) != 1 {
+		t.Fatalf("expected expired suppression to be ignored")
+	}
+}
+
+func TestApply_InlineDirective(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "main.go")
+	content := "package main\n\n// lawrence:ignore det -- legacy entrypoint\nfunc main() {}\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := newAnalysis(root,
+		domain.Issue{DetectorID: "det", Title: "on line", File: file, Line: 4},
+		domain.Issue{DetectorID: "det", Title: "far away", File: file, Line: 1},
+		domain.Issue{DetectorID: "other", Title: "other detector", File: file, Line: 4},
+	)
+	(&File{}).Apply(analysis, time.Now())
+
+	dir := analysis.DirectoryAnalyses["svc"]
+	if len(dir.SuppressedIssues) != 1 || dir.SuppressedIssues[0].SuppressionReason != "legacy entrypoint" {
+		t.Fatalf("expected one inline suppression, got %+v", dir.SuppressedIssues)
+	}
+	if len(dir.Issues) != 2 {
+		t.Fatalf("expected two active issues, got %d", len(dir.Issues))
+	}
+}
+
+func TestApply_InlineDirectiveForRule(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "otel.py")
+	content := "# lawrence:ignore rule:no-console-exporter,det -- local debugging only\nexporter = ConsoleSpanExporter()\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := newAnalysis(root,
+		domain.Issue{DetectorID: "rule:no-console-exporter", Title: "console exporter", File: file, Line: 2},
+		domain.Issue{DetectorID: "rule:other", Title: "other rule", File: file, Line: 2},
+	)
+	(&File{}).Apply(analysis, time.Now())
+
+	dir := analysis.DirectoryAnalyses["svc"]
+	if len(dir.SuppressedIssues) != 1 || dir.SuppressedIssues[0].DetectorID != "rule:no-console-exporter" {
+		t.Fatalf("expected the rule finding to be suppressed, got %+v", dir.SuppressedIssues)
+	}
+	if len(dir.Issues) != 1 {
+		t.Fatalf("expected one active issue, got %d", len(dir.Issues))
+	}
+}
+
+func TestLoad_RejectsInvalidSuppressions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultFileName)
+	if err := os.WriteFile(path, []byte(`{"issues":[],"suppressions":[{"detector_id":"x","reason":"r","expires":"soon"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatalf("expected invalid expiry to be rejected")
+	}
+}
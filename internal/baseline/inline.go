@@ -0,0 +1,113 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+// inlineDirectiveRe matches comments such as
+//
+//	// lawrence:ignore missing_otel_libraries until=2025-12-31 -- handled by sidecar
+//	# lawrence:ignore * -- generated file
+//	# lawrence:ignore rule:no-console-exporter -- local debugging only
+var inlineDirectiveRe = regexp.MustCompile(`lawrence:ignore\s+([\w*,\-.:]+)(?:\s+until=(\d{4}-\d{2}-\d{2}))?(?:\s+--\s*(.*))?`)
+
+// maxInlineScanSize bounds how much of a file is scanned for inline directives
+const maxInlineScanSize = 1 << 20
+
+// inlineDirective is a parsed lawrence:ignore comment
+type inlineDirective struct {
+	line      int
+	detectors []string
+	expires   string
+	reason    string
+}
+
+// inlineIndex lazily parses inline directives per file
+type inlineIndex struct {
+	rootPath string
+	now      time.Time
+	files    map[string][]inlineDirective
+}
+
+func newInlineIndex(rootPath string, now time.Time) *inlineIndex {
+	return &inlineIndex{rootPath: rootPath, now: now, files: make(map[string][]inlineDirective)}
+}
+
+// match reports whether the issue is suppressed by a directive in its file.
+// Issues with a line number honour directives on the same or the preceding line;
+// file-level issues honour a directive anywhere in the file.
+func (idx *inlineIndex) match(issue domain.Issue, directory string) (string, bool) {
+	if issue.File == "" {
+		return "", false
+	}
+	path := issue.File
+	if !filepath.IsAbs(path) {
+		if directory != "" && directory != "root" {
+			path = filepath.Join(directory, path)
+		}
+		path = filepath.Join(idx.rootPath, path)
+	}
+	for _, d := range idx.directives(path) {
+		if issue.Line > 0 && d.line != issue.Line && d.line != issue.Line-1 {
+			continue
+		}
+		if isExpired(d.expires, idx.now) || !d.appliesTo(issue.DetectorID) {
+			continue
+		}
+		reason := d.reason
+		if reason == "" {
+			reason = "inline suppression"
+		}
+		return reason, true
+	}
+	return "", false
+}
+
+// directives returns the cached directives for a file, parsing it on first use
+func (idx *inlineIndex) directives(path string) []inlineDirective {
+	if ds, ok := idx.files[path]; ok {
+		return ds
+	}
+	var ds []inlineDirective
+	if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Size() <= maxInlineScanSize {
+		if content, err := os.ReadFile(path); err == nil {
+			ds = parseInlineDirectives(string(content))
+		}
+	}
+	idx.files[path] = ds
+	return ds
+}
+
+// parseInlineDirectives extracts lawrence:ignore directives from file content
+func parseInlineDirectives(content string) []inlineDirective {
+	var out []inlineDirective
+	for i, line := range strings.Split(content, "\n") {
+		m := inlineDirectiveRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		out = append(out, inlineDirective{
+			line:      i + 1,
+			detectors: strings.Split(m[1], ","),
+			expires:   m[2],
+			reason:    strings.TrimSpace(m[3]),
+		})
+	}
+	return out
+}
+
+// appliesTo reports whether the directive covers the given detector
+func (d inlineDirective) appliesTo(detectorID string) bool {
+	for _, id := range d.detectors {
+		if id == "*" || id == detectorID {
+			return true
+		}
+	}
+	return false
+}
@@ -54,6 +54,8 @@ func (dm *DependencyWriter) AddDependencies(
 	operationsData *generatorTypes.OperationsData,
 	req generatorTypes.GenerationRequest,
 ) error {
+	language = packageLanguage(language)
+
 	// Convert OperationsData to InstallPlan
 	plan := types.InstallPlan{
 		Language:                language,
@@ -85,6 +87,8 @@ func (dm *DependencyWriter) AddDependencies(
 
 // GetRequiredDependencies returns all dependencies that would be added for the given operations
 func (dm *DependencyWriter) GetRequiredDependencies(language string, operationsData *generatorTypes.OperationsData) ([]types.Dependency, error) {
+	language = packageLanguage(language)
+
 	// Convert to InstallPlan
 	plan := types.InstallPlan{
 		Language:                language,
@@ -149,6 +153,7 @@ func (dm *DependencyWriter) GetRequiredDependencies(language string, operationsD
 
 // GetEnhancedDependencies returns enhanced dependency information using the unified client
 func (dm *DependencyWriter) GetEnhancedDependencies(language string, operationsData *generatorTypes.OperationsData) ([]EnhancedDependency, error) {
+	language = packageLanguage(language)
 	var enhancedDeps []EnhancedDependency
 
 	// Get core packages with enhanced metadata
@@ -255,6 +260,8 @@ func hasOnlyUnknownVersions(component kbtypes.Component) bool {
 
 // ValidateProjectStructure checks if the project has the required dependency management files
 func (dm *DependencyWriter) ValidateProjectStructure(projectPath, language string) error {
+	language = packageLanguage(language)
+
 	// Create a temporary registry to get scanner
 	commander := commander.NewReal()
 	reg := registry.New(commander)
@@ -273,7 +280,21 @@ func (dm *DependencyWriter) ValidateProjectStructure(projectPath, language strin
 
 // GetSupportedLanguages returns all languages supported by dependency management
 func (dm *DependencyWriter) GetSupportedLanguages() []string {
-	return []string{"go", "javascript", "python", "ruby", "php", "java", "csharp", "dotnet"}
+	return []string{"go", "javascript", "typescript", "python", "ruby", "php", "java", "kotlin", "csharp", "dotnet", "rust", "elixir", "cpp"}
+}
+
+// packageLanguage maps a language to the one whose packages and package manager it uses:
+// TypeScript projects install the same npm packages as JavaScript ones, and Kotlin
+// projects the same Maven artifacts as Java ones
+func packageLanguage(language string) string {
+	switch language {
+	case "typescript":
+		return "javascript"
+	case "kotlin":
+		return "java"
+	default:
+		return language
+	}
 }
 
 // findRepoRoot walks up directory tree to find go.mod
@@ -0,0 +1,205 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/dependency/types"
+)
+
+// cargoEntryNameRe matches the crate name of a `name = ...` line in a dependency table
+var cargoEntryNameRe = regexp.MustCompile(`^([A-Za-z0-9_-]+)(?:\.[A-Za-z-]+)?\s*=`)
+
+// cargoOpenTelemetryRe matches the opentelemetry requirement of Cargo.toml, either a
+// version string or an inline table with a version
+var cargoOpenTelemetryRe = regexp.MustCompile(`(?m)^\s*opentelemetry\s*=\s*(?:"([^"]*)"|\{[^}]*\bversion\s*=\s*"([^"]*)")`)
+
+// defaultOpenTelemetryMinor is the opentelemetry-rust 0.x release the Rust template is
+// written against
+const defaultOpenTelemetryMinor = 28
+
+// crateReleaseOffsets are the crates released together with opentelemetry-rust, keyed by
+// normalized crate name, with the offset of their minor version from the opentelemetry
+// one. Crates of different releases can't be mixed, so crates added without a version
+// follow the release the project requires.
+var crateReleaseOffsets = map[string]int{
+	"opentelemetry":                      0,
+	"opentelemetry_sdk":                  0,
+	"opentelemetry_otlp":                 0,
+	"opentelemetry_zipkin":               0,
+	"opentelemetry_stdout":               0,
+	"opentelemetry_semantic_conventions": 0,
+	"tracing_opentelemetry":              1,
+}
+
+// CargoInstaller installs Rust crates using `cargo add` or edits Cargo.toml
+type CargoInstaller struct {
+	commander types.Commander
+}
+
+// NewCargoInstaller creates a new cargo installer
+func NewCargoInstaller(commander types.Commander) Installer {
+	return &CargoInstaller{commander: commander}
+}
+
+// Install installs Rust dependencies
+func (i *CargoInstaller) Install(ctx context.Context, projectPath string, dependencies []string, dryRun bool) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	manifestPath := filepath.Join(projectPath, "Cargo.toml")
+	if _, err := os.Stat(manifestPath); os.IsNotExist(err) {
+		return fmt.Errorf("Cargo.toml not found in %s", projectPath)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	// Check if cargo is available
+	if _, err := i.commander.LookPath("cargo"); err == nil {
+		content, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return err
+		}
+		// cargo add accepts crate@version and resolves the latest compatible version otherwise
+		otelMinor := openTelemetryMinor(content, dependencies)
+		args := []string{"add"}
+		for _, dep := range dependencies {
+			if name, version := splitCrateSpec(dep); version == "" {
+				if version = crateVersion(name, otelMinor); version != "*" {
+					dep = name + "@" + version
+				}
+			}
+			args = append(args, dep)
+		}
+		if out, err := i.commander.Run(ctx, "cargo", args, projectPath); err != nil {
+			return fmt.Errorf("cargo add failed: %w\nOutput: %s", err, out)
+		}
+		return nil
+	}
+
+	// Fallback: edit Cargo.toml directly
+	return i.editCargoToml(manifestPath, dependencies)
+}
+
+// editCargoToml adds crate entries to the [dependencies] table of Cargo.toml,
+// creating the table when it does not exist
+func (i *CargoInstaller) editCargoToml(manifestPath string, dependencies []string) error {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+
+	// Locate the [dependencies] table and collect the crates it already declares
+	start, end := -1, len(lines)
+	existing := make(map[string]bool)
+	for idx, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start < 0 {
+			if trimmed == "[dependencies]" {
+				start = idx
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			end = idx
+			break
+		}
+		if m := cargoEntryNameRe.FindStringSubmatch(trimmed); m != nil {
+			existing[normalizeCrateName(m[1])] = true
+		}
+	}
+
+	otelMinor := openTelemetryMinor(content, dependencies)
+	var toAdd []string
+	for _, dep := range dependencies {
+		name, version := splitCrateSpec(dep)
+		if name == "" || existing[normalizeCrateName(name)] {
+			continue
+		}
+		if version == "" {
+			version = crateVersion(name, otelMinor)
+		}
+		existing[normalizeCrateName(name)] = true
+		toAdd = append(toAdd, fmt.Sprintf("%s = %q", name, version))
+	}
+
+	if len(toAdd) == 0 {
+		return nil
+	}
+
+	var out []string
+	if start < 0 {
+		out = append(lines, "", "[dependencies]")
+		out = append(out, toAdd...)
+	} else {
+		// Insert after the last entry of the table, before any blank lines separating it from the next one
+		insertAt := end
+		for insertAt > start+1 && strings.TrimSpace(lines[insertAt-1]) == "" {
+			insertAt--
+		}
+		out = append(out, lines[:insertAt]...)
+		out = append(out, toAdd...)
+		out = append(out, lines[insertAt:]...)
+	}
+
+	return os.WriteFile(manifestPath, []byte(strings.Join(out, "\n")+"\n"), 0644)
+}
+
+// splitCrateSpec splits crate@version (or crate:version) into name and version, which
+// is empty when the crate has none
+func splitCrateSpec(dep string) (string, string) {
+	dep = strings.TrimSpace(dep)
+	if idx := strings.IndexAny(dep, "@:"); idx > 0 {
+		return dep[:idx], strings.TrimPrefix(dep[idx+1:], "v")
+	}
+	return dep, ""
+}
+
+// openTelemetryMinor returns the opentelemetry-rust 0.x minor version requested in
+// dependencies or already required by the manifest, or the template's default
+func openTelemetryMinor(manifest []byte, dependencies []string) int {
+	var versions []string
+	for _, dep := range dependencies {
+		if name, version := splitCrateSpec(dep); normalizeCrateName(name) == "opentelemetry" {
+			versions = append(versions, version)
+		}
+	}
+	if m := cargoOpenTelemetryRe.FindSubmatch(manifest); m != nil {
+		versions = append(versions, string(m[1])+string(m[2]))
+	}
+	for _, version := range versions {
+		parts := strings.Split(strings.TrimLeft(version, "^~=<> "), ".")
+		if len(parts) < 2 || parts[0] != "0" {
+			continue
+		}
+		if minor, err := strconv.Atoi(parts[1]); err == nil {
+			return minor
+		}
+	}
+	return defaultOpenTelemetryMinor
+}
+
+// crateVersion returns the version requirement of a crate added without a version: the
+// opentelemetry release for the crates released with it, or "*" so cargo picks the
+// latest release
+func crateVersion(name string, otelMinor int) string {
+	if offset, ok := crateReleaseOffsets[normalizeCrateName(name)]; ok {
+		return fmt.Sprintf("0.%d", otelMinor+offset)
+	}
+	return "*"
+}
+
+// normalizeCrateName treats - and _ as equivalent, as crates.io does
+func normalizeCrateName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+}
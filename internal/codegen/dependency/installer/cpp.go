@@ -0,0 +1,131 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/cpp"
+)
+
+// CppInstaller adds C++ packages to vcpkg.json or the conanfile and links them in CMakeLists.txt
+type CppInstaller struct{}
+
+// NewCppInstaller creates a new C++ installer. Packages are fetched when the project is
+// next configured (vcpkg manifest mode) or installed (conan install), so no command runs.
+func NewCppInstaller() Installer {
+	return &CppInstaller{}
+}
+
+// Install declares the packages in the first package manager manifest found (vcpkg.json,
+// conanfile.txt, then conanfile.py), then imports them with find_package in CMakeLists.txt
+// and links them to the first executable target
+func (i *CppInstaller) Install(ctx context.Context, projectPath string, dependencies []string, dryRun bool) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	var manifest string
+	for _, name := range []string{cpp.VcpkgFileName, cpp.ConanfileTxt, cpp.ConanfilePy} {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			manifest = name
+			break
+		}
+	}
+	cmakePath := filepath.Join(projectPath, cpp.CMakeFileName)
+	_, err := os.Stat(cmakePath)
+	hasCMake := err == nil
+	if manifest == "" && !hasCMake {
+		return fmt.Errorf("no vcpkg.json, conanfile or CMakeLists.txt found in %s", projectPath)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	packages := make([]cpp.Package, 0, len(dependencies))
+	for _, dep := range dependencies {
+		name, version := parseCppSpec(dep)
+		packages = append(packages, cpp.Package{Name: name, Version: version})
+	}
+
+	if manifest != "" {
+		if err := editFile(filepath.Join(projectPath, manifest), func(content []byte) ([]byte, error) {
+			return addCppPackages(manifest, content, packages)
+		}); err != nil {
+			return err
+		}
+	}
+	if hasCMake {
+		return editFile(cmakePath, func(content []byte) ([]byte, error) {
+			return linkCppPackages(content, packages)
+		})
+	}
+	return nil
+}
+
+// addCppPackages declares packages in a vcpkg or Conan manifest
+func addCppPackages(manifest string, content []byte, packages []cpp.Package) ([]byte, error) {
+	switch manifest {
+	case cpp.VcpkgFileName:
+		ports := make([]cpp.VcpkgPort, 0, len(packages))
+		for _, pkg := range packages {
+			ports = append(ports, cpp.VcpkgPort{Name: pkg.Name, Version: pkg.Version, Features: cpp.VcpkgFeatures(pkg.Name)})
+		}
+		updated, _, err := cpp.AddVcpkgPorts(content, ports)
+		return updated, err
+	case cpp.ConanfileTxt:
+		var options []string
+		for _, pkg := range packages {
+			options = append(options, cpp.ConanOptions(pkg.Name)...)
+		}
+		updated, _ := cpp.AddConanfileTxtRequires(content, packages, options)
+		return updated, nil
+	default:
+		updated, _, err := cpp.AddConanfilePyRequires(content, packages)
+		return updated, err
+	}
+}
+
+// linkCppPackages imports packages with find_package and links their targets to the
+// executable. Projects without an executable (libraries) only get the imports.
+func linkCppPackages(content []byte, packages []cpp.Package) ([]byte, error) {
+	var targets []string
+	for _, pkg := range packages {
+		content, _ = cpp.AddFindPackage(content, pkg.Name)
+		targets = append(targets, cpp.LinkTargets(pkg.Name)...)
+	}
+	updated, _, err := cpp.LinkLibraries(content, targets)
+	if errors.Is(err, cpp.ErrNoExecutable) {
+		return content, nil
+	}
+	return updated, err
+}
+
+// editFile rewrites path with the result of edit when the content changes
+func editFile(path string, edit func([]byte) ([]byte, error)) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, err := edit(content)
+	if err != nil {
+		return fmt.Errorf("failed to edit %s: %w", path, err)
+	}
+	if string(updated) == string(content) {
+		return nil
+	}
+	return os.WriteFile(path, updated, 0644)
+}
+
+// parseCppSpec splits package@version into name and version
+func parseCppSpec(dep string) (string, string) {
+	dep = strings.TrimSpace(dep)
+	if idx := strings.LastIndex(dep, "@"); idx > 0 {
+		return dep[:idx], dep[idx+1:]
+	}
+	return dep, ""
+}
@@ -3,15 +3,13 @@ package installer
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/dependency/types"
+	"github.com/getlawrence/cli/internal/dotnet"
 )
 
-// DotNetInstaller installs .NET packages using dotnet CLI or edits .csproj
+// DotNetInstaller installs .NET packages using dotnet CLI or edits the project and props files
 type DotNetInstaller struct {
 	commander types.Commander
 }
@@ -21,14 +19,19 @@ func NewDotNetInstaller(commander types.Commander) Installer {
 	return &DotNetInstaller{commander: commander}
 }
 
-// Install installs .NET dependencies
+// Install installs .NET dependencies. Projects using central package management get a
+// version-less PackageReference, with the version added to Directory.Packages.props.
 func (i *DotNetInstaller) Install(ctx context.Context, projectPath string, dependencies []string, dryRun bool) error {
 	if len(dependencies) == 0 {
 		return nil
 	}
 
-	// Find .csproj file
-	csprojPath, err := i.findCsproj(projectPath)
+	// Find the project file, or the application project of a solution
+	projectFile, err := dotnet.FindProjectFile(projectPath)
+	if err != nil {
+		return err
+	}
+	project, err := dotnet.LoadProject(projectFile)
 	if err != nil {
 		return err
 	}
@@ -43,12 +46,29 @@ func (i *DotNetInstaller) Install(ctx context.Context, projectPath string, depen
 		return nil
 	}
 
+	_, lookErr := i.commander.LookPath("dotnet")
+	if project.CentralPackageManagement && project.PackagesProps != "" {
+		// Packages without a version are left to the dotnet CLI, which knows the latest one
+		var pinned, floating []string
+		for _, dep := range resolved {
+			if _, version, _ := strings.Cut(dep, "@"); version != "" || lookErr != nil {
+				pinned = append(pinned, dep)
+			} else {
+				floating = append(floating, dep)
+			}
+		}
+		if err := i.editCentralVersions(project, pinned); err != nil {
+			return err
+		}
+		resolved = floating
+	}
+
 	// Check if dotnet CLI is available
-	if _, err := i.commander.LookPath("dotnet"); err == nil {
+	if lookErr == nil {
 		// Use dotnet add package
 		for _, dep := range resolved {
 			parts := strings.Split(dep, "@")
-			args := []string{"add", csprojPath, "package", parts[0]}
+			args := []string{"add", projectFile, "package", parts[0]}
 			if len(parts) > 1 && parts[1] != "" {
 				args = append(args, "--version", parts[1])
 			}
@@ -60,24 +80,8 @@ func (i *DotNetInstaller) Install(ctx context.Context, projectPath string, depen
 		return nil
 	}
 
-	// Fallback: edit .csproj directly
-	return i.editCsproj(csprojPath, resolved)
-}
-
-// findCsproj finds the first .csproj file in the project
-func (i *DotNetInstaller) findCsproj(projectPath string) (string, error) {
-	entries, err := os.ReadDir(projectPath)
-	if err != nil {
-		return "", err
-	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".csproj") {
-			return filepath.Join(projectPath, entry.Name()), nil
-		}
-	}
-
-	return "", fmt.Errorf("no .csproj file found in %s", projectPath)
+	// Fallback: edit the project file directly
+	return i.editProject(projectFile, resolved)
 }
 
 // resolveVersions adds versions to dependencies
@@ -98,51 +102,46 @@ func (i *DotNetInstaller) resolveVersions(ctx context.Context, projectPath strin
 	return resolved, nil
 }
 
-// editCsproj adds PackageReference entries to .csproj
-func (i *DotNetInstaller) editCsproj(csprojPath string, dependencies []string) error {
-	content, err := os.ReadFile(csprojPath)
-	if err != nil {
-		return err
-	}
-
-	// Get existing packages
-	existing := make(map[string]bool)
-	re := regexp.MustCompile(`(?i)<PackageReference\s+Include="([^"]+)"`)
-	for _, match := range re.FindAllStringSubmatch(string(content), -1) {
-		if len(match) > 1 {
-			existing[strings.ToLower(match[1])] = true
-		}
+// editCentralVersions adds the versions of dependencies to Directory.Packages.props and
+// version-less PackageReference items to the project
+func (i *DotNetInstaller) editCentralVersions(project *dotnet.Project, dependencies []string) error {
+	if len(dependencies) == 0 {
+		return nil
 	}
-
-	// Build new PackageReference lines
-	var lines []string
+	var versions, references []dotnet.PackageReference
 	for _, dep := range dependencies {
-		parts := strings.Split(dep, "@")
-		pkgName := parts[0]
-		version := "*"
-		if len(parts) > 1 && parts[1] != "" {
-			version = parts[1]
-		}
-
-		if !existing[strings.ToLower(pkgName)] {
-			lines = append(lines, fmt.Sprintf("    <PackageReference Include=\"%s\" Version=\"%s\" />", pkgName, version))
+		name, version, _ := strings.Cut(dep, "@")
+		if version == "" {
+			return fmt.Errorf("a version is required to add %s to %s", name, project.PackagesProps)
 		}
+		versions = append(versions, dotnet.PackageReference{Name: name, Version: version})
+		references = append(references, dotnet.PackageReference{Name: name})
 	}
 
-	if len(lines) == 0 {
-		return nil
+	if err := editFile(project.PackagesProps, func(content []byte) ([]byte, error) {
+		updated, _, err := dotnet.AddPackageVersions(content, versions)
+		return updated, err
+	}); err != nil {
+		return err
 	}
+	return editFile(project.Path, func(content []byte) ([]byte, error) {
+		updated, _, err := dotnet.AddPackageReferences(content, references)
+		return updated, err
+	})
+}
 
-	// Insert into .csproj
-	contentStr := string(content)
-	itemGroup := "  <ItemGroup>\n" + strings.Join(lines, "\n") + "\n  </ItemGroup>\n"
-
-	// Find insertion point (before </Project>)
-	idx := strings.LastIndex(strings.ToLower(contentStr), "</project>")
-	if idx == -1 {
-		return fmt.Errorf("malformed .csproj: missing </Project>")
+// editProject adds PackageReference entries to the project file
+func (i *DotNetInstaller) editProject(projectFile string, dependencies []string) error {
+	var refs []dotnet.PackageReference
+	for _, dep := range dependencies {
+		name, version, _ := strings.Cut(dep, "@")
+		if version == "" {
+			version = "*"
+		}
+		refs = append(refs, dotnet.PackageReference{Name: name, Version: version})
 	}
-
-	newContent := contentStr[:idx] + itemGroup + contentStr[idx:]
-	return os.WriteFile(csprojPath, []byte(newContent), 0644)
+	return editFile(projectFile, func(content []byte) ([]byte, error) {
+		updated, _, err := dotnet.AddPackageReferences(content, refs)
+		return updated, err
+	})
 }
@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/dependency/types"
+	"github.com/getlawrence/cli/internal/gomod"
 )
 
 // GoInstaller installs go modules using `go get` or edits go.mod
@@ -27,17 +27,22 @@ func (i *GoInstaller) Install(ctx context.Context, projectPath string, dependenc
 		return nil
 	}
 
-	goModPath := filepath.Join(projectPath, "go.mod")
-	if _, err := os.Stat(goModPath); os.IsNotExist(err) {
-		return fmt.Errorf("go.mod not found in %s", projectPath)
+	// Dependencies go to the module owning projectPath, which may be a go.work member
+	owner, ok, err := gomod.FindOwner(projectPath)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("go.mod not found in %s or its parents", projectPath)
 	}
+	moduleDir := owner.ModuleDir
 
 	if dryRun {
 		return nil // Caller handles logging
 	}
 
 	// Resolve versions for dependencies that need them
-	resolved, err := i.resolveVersions(ctx, projectPath, dependencies)
+	resolved, err := i.resolveVersions(ctx, moduleDir, dependencies)
 	if err != nil {
 		return err
 	}
@@ -47,19 +52,26 @@ func (i *GoInstaller) Install(ctx context.Context, projectPath string, dependenc
 		// Use go get
 		for _, dep := range resolved {
 			args := []string{"get", dep}
-			if out, err := i.commander.Run(ctx, "go", args, projectPath); err != nil {
+			if out, err := i.commander.Run(ctx, "go", args, moduleDir); err != nil {
 				return fmt.Errorf("go get %s failed: %w\nOutput: %s", dep, err, out)
 			}
 		}
 
+		// Push the upgraded requirements to the other modules of the workspace
+		if owner.Workspace != nil {
+			if out, err := i.commander.Run(ctx, "go", []string{"work", "sync"}, owner.Workspace.Dir); err != nil {
+				return fmt.Errorf("go work sync failed: %w\nOutput: %s", err, out)
+			}
+		}
+
 		// Do not run as the otel init code has not been added yet and this will remove the new dependencies
 		// Best-effort tidy
-		// _, _ = i.commander.Run(ctx, "go", []string{"mod", "tidy"}, projectPath)
+		// _, _ = i.commander.Run(ctx, "go", []string{"mod", "tidy"}, moduleDir)
 		return nil
 	}
 
 	// Fallback: edit go.mod directly
-	return i.editGoMod(goModPath, resolved)
+	return i.editGoMod(owner.ModFile(), resolved)
 }
 
 // resolveVersions adds versions to dependencies that need them
@@ -0,0 +1,352 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/gradle"
+)
+
+var (
+	// gradleCoordinateRe matches quoted coordinates in string notation, e.g. "g:a:v"
+	gradleCoordinateRe = regexp.MustCompile(`['"]([A-Za-z0-9_.\-]+):([A-Za-z0-9_.\-]+)(?::[^'"\s]*)?['"]`)
+	// gradleMapNotationRe matches map notation: group: 'g', name: 'a' or group = "g", name = "a"
+	gradleMapNotationRe = regexp.MustCompile(`\bgroup\s*[:=]\s*['"]([^'"]+)['"]\s*,\s*name\s*[:=]\s*['"]([^'"]+)['"]`)
+)
+
+// addDependenciesToGradle adds dependencies to the top-level dependencies block of a
+// Gradle build script, using call syntax (implementation("g:a:v")) for the Kotlin DSL.
+// When the build has a version catalog, the dependencies are added to the catalog and
+// referenced through libs. The script is created when it doesn't exist yet.
+func (i *MavenInstaller) addDependenciesToGradle(gradlePath string, build *gradle.Build, dependencies []string) error {
+	content, err := os.ReadFile(gradlePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	text := string(content)
+	kotlinDSL := strings.HasSuffix(gradlePath, ".kts")
+
+	existing := make(map[string]bool)
+	for _, re := range []*regexp.Regexp{gradleCoordinateRe, gradleMapNotationRe} {
+		for _, m := range re.FindAllStringSubmatch(text, -1) {
+			existing[m[1]+":"+m[2]] = true
+		}
+	}
+	var catalog *gradle.Catalog
+	if build != nil {
+		catalog = build.Catalog
+	}
+	for _, dep := range gradle.ParseBuildScript(content, catalog) {
+		existing[dep.Coordinate()] = true
+	}
+
+	// A BOM (platform) manages the versions of the artifacts added with it
+	hasBOM := false
+	for _, dep := range dependencies {
+		if isBOMCoordinate(dep) {
+			hasBOM = true
+			break
+		}
+	}
+
+	var missing [][]string
+	for _, dep := range dependencies {
+		parts := strings.Split(dep, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		coord := parts[0] + ":" + parts[1]
+		if existing[coord] {
+			continue
+		}
+		existing[coord] = true
+		missing = append(missing, parts)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var entries []string
+	if catalog != nil {
+		if entries, err = i.addDependenciesToCatalog(build, missing, kotlinDSL, hasBOM); err != nil {
+			return fmt.Errorf("failed to update %s: %w", gradle.CatalogFileName, err)
+		}
+	} else {
+		for _, parts := range missing {
+			entries = append(entries, formatGradleDependency(parts, kotlinDSL, hasBOM))
+		}
+	}
+
+	openIdx, closeIdx := findGradleBlock(text, "dependencies")
+	if openIdx < 0 {
+		// No dependencies block found, create one at the end of the file
+		var b strings.Builder
+		if strings.TrimSpace(text) != "" {
+			b.WriteString(strings.TrimRight(text, "\n") + "\n\n")
+		}
+		b.WriteString("dependencies {\n")
+		for _, e := range entries {
+			b.WriteString("    " + e + "\n")
+		}
+		b.WriteString("}\n")
+		return os.WriteFile(gradlePath, []byte(b.String()), 0644)
+	}
+
+	lineStart := strings.LastIndex(text[:closeIdx], "\n") + 1
+	closingIndent := text[lineStart:closeIdx]
+	indent := gradleBlockIndent(text[openIdx+1:closeIdx], closingIndent)
+
+	var b strings.Builder
+	if strings.TrimSpace(closingIndent) == "" {
+		// Closing brace on its own line: add the entries right above it
+		b.WriteString(text[:lineStart])
+		for _, e := range entries {
+			b.WriteString(indent + e + "\n")
+		}
+		b.WriteString(text[lineStart:])
+	} else {
+		// Single-line block, e.g. dependencies { implementation("x:y:1") }
+		b.WriteString(strings.TrimRight(text[:closeIdx], " \t"))
+		b.WriteString("\n")
+		for _, e := range entries {
+			b.WriteString(indent + e + "\n")
+		}
+		b.WriteString(text[closeIdx:])
+	}
+	return os.WriteFile(gradlePath, []byte(b.String()), 0644)
+}
+
+// addDependenciesToCatalog adds group:artifact[:version] dependencies to the build's
+// version catalog and returns the build script entries referencing them. Libraries the
+// catalog already has are reused. Versions go to [versions], under one key per group so
+// that e.g. all io.opentelemetry artifacts share one; artifacts added with a BOM stay
+// unversioned. Several OpenTelemetry libraries are grouped in an opentelemetry bundle.
+func (i *MavenInstaller) addDependenciesToCatalog(build *gradle.Build, dependencies [][]string, kotlinDSL bool, hasBOM bool) ([]string, error) {
+	catalog := build.Catalog
+	versions := make(map[string]string)
+	var libraries []gradle.Library
+	var platforms, aliases []string
+	for _, parts := range dependencies {
+		group, name := parts[0], parts[1]
+		version := ""
+		if len(parts) >= 3 {
+			version = parts[2]
+		}
+		bom := isBOMCoordinate(strings.Join(parts, ":"))
+
+		alias, ok := catalog.AliasFor(group, name)
+		if !ok {
+			alias = catalogAlias(catalog, group, name)
+			lib := gradle.Library{Alias: alias, Group: group, Name: name}
+			if bom || !hasBOM {
+				lib.VersionRef = catalogVersionKey(catalog, versions, group, name, version)
+			}
+			libraries = append(libraries, lib)
+			catalog.Libraries[alias] = lib
+		}
+		if bom {
+			platforms = append(platforms, alias)
+		} else {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	bundles := make(map[string][]string)
+	if _, exists := catalog.Bundles["opentelemetry"]; !exists && len(aliases) >= 2 && allOpenTelemetry(catalog, aliases) {
+		bundles["opentelemetry"] = aliases
+		catalog.Bundles["opentelemetry"] = aliases
+	}
+
+	content, err := os.ReadFile(build.CatalogPath)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := gradle.AddCatalogEntries(content, versions, libraries, bundles)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(build.CatalogPath, updated, 0644); err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	for _, alias := range platforms {
+		refs = append(refs, "platform("+catalog.Reference(alias)+")")
+	}
+	if len(bundles) > 0 {
+		refs = append(refs, gradle.BundleReference("opentelemetry"))
+	} else {
+		for _, alias := range aliases {
+			refs = append(refs, catalog.Reference(alias))
+		}
+	}
+	entries := make([]string, len(refs))
+	for idx, ref := range refs {
+		if kotlinDSL {
+			entries[idx] = "implementation(" + ref + ")"
+		} else {
+			entries[idx] = "implementation " + ref
+		}
+	}
+	return entries, nil
+}
+
+// catalogAlias returns a new alias for group:name: the artifact, prefixed with the last
+// segment of the group when another library already uses it
+func catalogAlias(catalog *gradle.Catalog, group, name string) string {
+	alias := strings.ToLower(name[:1]) + name[1:]
+	if _, taken := catalog.Libraries[alias]; taken {
+		alias = group[strings.LastIndex(group, ".")+1:] + "-" + alias
+	}
+	return alias
+}
+
+// catalogVersionKey returns the [versions] key holding version, adding it to versions and
+// the catalog when needed. The key is named after the group (opentelemetry for
+// io.opentelemetry), or after the artifact when the group's key holds another version.
+// Without a version the group's existing key is reused, or set to latest.release.
+func catalogVersionKey(catalog *gradle.Catalog, versions map[string]string, group, name, version string) string {
+	key := strings.ReplaceAll(group, ".", "-")
+	for _, prefix := range []string{"io-", "com-", "org-", "net-", "dev-"} {
+		key = strings.TrimPrefix(key, prefix)
+	}
+	if version == "" || version == "LATEST" {
+		if _, ok := catalog.Versions[key]; ok {
+			return key
+		}
+		version = "latest.release"
+	}
+	for _, candidate := range []string{key, name} {
+		current, ok := catalog.Versions[candidate]
+		if !ok {
+			catalog.Versions[candidate] = version
+			versions[candidate] = version
+			return candidate
+		}
+		if current == version {
+			return candidate
+		}
+	}
+	return name
+}
+
+// allOpenTelemetry reports whether the aliases are all OpenTelemetry libraries
+func allOpenTelemetry(catalog *gradle.Catalog, aliases []string) bool {
+	for _, alias := range aliases {
+		if !strings.HasPrefix(catalog.Libraries[alias].Group, "io.opentelemetry") {
+			return false
+		}
+	}
+	return true
+}
+
+// formatGradleDependency renders an implementation entry for group:artifact[:version].
+// BOMs are added as platforms, and artifacts added with a BOM are left unversioned.
+func formatGradleDependency(parts []string, kotlinDSL bool, hasBOM bool) string {
+	coord := parts[0] + ":" + parts[1]
+	version := ""
+	if len(parts) >= 3 {
+		version = parts[2]
+	}
+	bom := isBOMCoordinate(strings.Join(parts, ":"))
+	switch {
+	case version == "LATEST" && !bom && !hasBOM:
+		// Gradle's dynamic version for the newest release
+		coord += ":latest.release"
+	case version != "" && version != "LATEST" && (bom || !hasBOM):
+		coord += ":" + version
+	}
+
+	if kotlinDSL {
+		if bom {
+			return fmt.Sprintf("implementation(platform(%q))", coord)
+		}
+		return fmt.Sprintf("implementation(%q)", coord)
+	}
+	if bom {
+		return fmt.Sprintf("implementation platform('%s')", coord)
+	}
+	return fmt.Sprintf("implementation '%s'", coord)
+}
+
+// isBOMCoordinate reports whether a Maven coordinate refers to a bill of materials
+func isBOMCoordinate(dep string) bool {
+	return strings.Contains(dep, "-bom:") || strings.HasSuffix(dep, "-bom") || strings.Contains(dep, "opentelemetry-bom")
+}
+
+// findGradleBlock returns the offsets of the opening and closing braces of the top-level
+// block with the given name, skipping nested blocks such as buildscript { dependencies {} },
+// strings and comments. It returns -1, -1 when there is no such block.
+func findGradleBlock(text, name string) (int, int) {
+	depth := 0
+	openIdx := -1
+	for idx := 0; idx < len(text); idx++ {
+		c := text[idx]
+		switch {
+		case strings.HasPrefix(text[idx:], "//"):
+			if end := strings.IndexByte(text[idx:], '\n'); end >= 0 {
+				idx += end
+			} else {
+				idx = len(text)
+			}
+		case strings.HasPrefix(text[idx:], "/*"):
+			if end := strings.Index(text[idx+2:], "*/"); end >= 0 {
+				idx += end + 3
+			} else {
+				idx = len(text)
+			}
+		case c == '"' || c == '\'':
+			// Skip the string literal, honoring escapes
+			for idx++; idx < len(text) && text[idx] != c; idx++ {
+				if text[idx] == '\\' {
+					idx++
+				}
+			}
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if openIdx >= 0 && depth == 0 {
+				return openIdx, idx
+			}
+		case depth == 0 && openIdx < 0 && strings.HasPrefix(text[idx:], name) && isGradleBlockStart(text, idx, name):
+			idx += len(name)
+			for idx < len(text) && (text[idx] == ' ' || text[idx] == '\t' || text[idx] == '\n' || text[idx] == '\r') {
+				idx++
+			}
+			openIdx = idx
+			depth++
+		}
+	}
+	return -1, -1
+}
+
+// isGradleBlockStart reports whether name at offset idx is a whole identifier followed by '{'
+func isGradleBlockStart(text string, idx int, name string) bool {
+	if idx > 0 && isGradleIdentChar(text[idx-1]) {
+		return false
+	}
+	rest := strings.TrimLeft(text[idx+len(name):], " \t\r\n")
+	return strings.HasPrefix(rest, "{")
+}
+
+func isGradleIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// gradleBlockIndent returns the indentation of the block's first entry on its own line,
+// defaulting to four spaces more than the closing brace
+func gradleBlockIndent(body, closingIndent string) string {
+	lines := strings.Split(body, "\n")
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(strings.TrimSpace(line), "}") {
+			return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		}
+	}
+	if strings.TrimSpace(closingIndent) != "" {
+		closingIndent = ""
+	}
+	return closingIndent + "    "
+}
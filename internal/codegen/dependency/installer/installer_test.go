@@ -117,6 +117,47 @@ func TestGoInstaller(t *testing.T) {
 		}
 	})
 
+	t.Run("go.work member installs in owning module and syncs", func(t *testing.T) {
+		mock := commander.NewMock()
+		mock.Commands["go"] = true
+
+		installer := NewGoInstaller(mock)
+
+		// Workspace of two modules; dependencies are added from a package directory of one of them
+		root := t.TempDir()
+		files := map[string]string{
+			"go.work":                 "go 1.23\n\nuse (\n\t./services/api\n\t./libs/shared\n)\n",
+			"services/api/go.mod":     "module example.com/api\n\ngo 1.23\n",
+			"libs/shared/go.mod":      "module example.com/shared\n\ngo 1.23\n",
+			"services/api/cmd/api.go": "package main\n",
+		}
+		for name, content := range files {
+			path := filepath.Join(root, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		deps := []string{"go.opentelemetry.io/otel@v1.24.0"}
+		if err := installer.Install(ctx, filepath.Join(root, "services/api/cmd"), deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(mock.RecordedCalls) != 2 {
+			t.Fatalf("Expected go get and go work sync, got %+v", mock.RecordedCalls)
+		}
+		getCall, syncCall := mock.RecordedCalls[0], mock.RecordedCalls[1]
+		if getCall.Args[0] != "get" || getCall.Dir != filepath.Join(root, "services/api") {
+			t.Errorf("Expected 'go get' in the owning module, got %v in %s", getCall.Args, getCall.Dir)
+		}
+		if strings.Join(syncCall.Args, " ") != "work sync" || syncCall.Dir != root {
+			t.Errorf("Expected 'go work sync' in the workspace root, got %v in %s", syncCall.Args, syncCall.Dir)
+		}
+	})
+
 	t.Run("dry run", func(t *testing.T) {
 		mock := commander.NewMock()
 		installer := NewGoInstaller(mock)
@@ -298,6 +339,85 @@ func TestPipInstaller(t *testing.T) {
 			t.Error("Expected original flask dependency to remain")
 		}
 	})
+
+	t.Run("poetry.lock - poetry add", func(t *testing.T) {
+		mock := commander.NewMock()
+		mock.Commands["poetry"] = true
+		mock.Commands["pip"] = true
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), []byte("[tool.poetry]\nname = \"billing\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "poetry.lock"), []byte(""), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewPipInstaller(mock).Install(ctx, dir, []string{"opentelemetry-sdk==1.27.0"}, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mock.RecordedCalls) != 1 {
+			t.Fatalf("Expected a single poetry call, got %+v", mock.RecordedCalls)
+		}
+		call := mock.RecordedCalls[0]
+		if call.Name != "poetry" || strings.Join(call.Args, " ") != "add opentelemetry-sdk==1.27.0" {
+			t.Errorf("Expected 'poetry add opentelemetry-sdk==1.27.0', got %s %v", call.Name, call.Args)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "requirements.txt")); err == nil {
+			t.Error("requirements.txt should not be created for a Poetry project")
+		}
+	})
+
+	t.Run("uv.lock without uv - edit pyproject.toml", func(t *testing.T) {
+		mock := commander.NewMock()
+
+		dir := t.TempDir()
+		pyprojectPath := filepath.Join(dir, "pyproject.toml")
+		if err := os.WriteFile(pyprojectPath, []byte("[project]\nname = \"checkout\"\ndependencies = [\n    \"flask>=3.0\",\n]\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "uv.lock"), []byte("version = 1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewPipInstaller(mock).Install(ctx, dir, []string{"opentelemetry-api==1.27.0", "flask"}, false); err != nil {
+			t.Fatal(err)
+		}
+		content, err := os.ReadFile(pyprojectPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "[project]\nname = \"checkout\"\ndependencies = [\n    \"flask>=3.0\",\n    \"opentelemetry-api==1.27.0\",\n]\n"
+		if string(content) != want {
+			t.Errorf("Expected pyproject.toml:\n%s\ngot:\n%s", want, content)
+		}
+	})
+
+	t.Run("Pipfile without pipenv - edit Pipfile", func(t *testing.T) {
+		mock := commander.NewMock()
+		mock.Commands["pip"] = true
+
+		dir := t.TempDir()
+		pipfilePath := filepath.Join(dir, "Pipfile")
+		if err := os.WriteFile(pipfilePath, []byte("[packages]\ndjango = \"*\"\n\n[dev-packages]\npytest = \"*\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewPipInstaller(mock).Install(ctx, dir, []string{"opentelemetry-distro"}, false); err != nil {
+			t.Fatal(err)
+		}
+		if len(mock.RecordedCalls) != 0 {
+			t.Errorf("pip should not run for a Pipenv project, got %+v", mock.RecordedCalls)
+		}
+		content, err := os.ReadFile(pipfilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "[packages]\ndjango = \"*\"\nopentelemetry-distro = \"*\"\n\n[dev-packages]\npytest = \"*\"\n"
+		if string(content) != want {
+			t.Errorf("Expected Pipfile:\n%s\ngot:\n%s", want, content)
+		}
+	})
 }
 
 func TestDotNetInstaller(t *testing.T) {
@@ -376,6 +496,270 @@ func TestDotNetInstaller(t *testing.T) {
 			t.Error("Expected PackageReference to be added")
 		}
 	})
+
+	t.Run("central package management", func(t *testing.T) {
+		mock := commander.NewMock()
+		mock.Commands["dotnet"] = true
+
+		installer := NewDotNetInstaller(mock)
+
+		// Solution root with Directory.Packages.props; the project lives below it
+		root := t.TempDir()
+		propsPath := filepath.Join(root, "Directory.Packages.props")
+		if err := os.WriteFile(propsPath, []byte(`<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="Serilog" Version="3.1.1" />
+  </ItemGroup>
+</Project>`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		dir := filepath.Join(root, "src", "Api")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		projectFile := filepath.Join(dir, "Api.fsproj")
+		if err := os.WriteFile(projectFile, []byte(`<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="Serilog" />
+  </ItemGroup>
+</Project>`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{"OpenTelemetry@1.9.0", "OpenTelemetry.Extensions.Hosting"}
+		if err := installer.Install(ctx, dir, deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		props, _ := os.ReadFile(propsPath)
+		if !strings.Contains(string(props), "    <PackageVersion Include=\"Serilog\" Version=\"3.1.1\" />\n    <PackageVersion Include=\"OpenTelemetry\" Version=\"1.9.0\" />") {
+			t.Errorf("Expected the central version next to the existing ones, got:\n%s", props)
+		}
+		project, _ := os.ReadFile(projectFile)
+		if !strings.Contains(string(project), `<PackageReference Include="OpenTelemetry" />`) {
+			t.Errorf("Expected a version-less PackageReference, got:\n%s", project)
+		}
+
+		// The package without a version is left to the CLI, which resolves and centralizes it
+		if len(mock.RecordedCalls) != 1 || mock.RecordedCalls[0].Args[3] != "OpenTelemetry.Extensions.Hosting" {
+			t.Errorf("Expected a single 'dotnet add package OpenTelemetry.Extensions.Hosting', got %+v", mock.RecordedCalls)
+		}
+	})
+}
+
+func TestCargoInstaller(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("with cargo available", func(t *testing.T) {
+		mock := commander.NewMock()
+		mock.Commands["cargo"] = true
+
+		installer := NewCargoInstaller(mock)
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package]\nname = \"api\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{"opentelemetry@0.27.1", "opentelemetry_sdk"}
+		if err := installer.Install(ctx, dir, deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(mock.RecordedCalls) != 1 {
+			t.Fatalf("Expected a single cargo add call, got %v", mock.RecordedCalls)
+		}
+		call := mock.RecordedCalls[0]
+		if call.Name != "cargo" || strings.Join(call.Args, " ") != "add opentelemetry@0.27.1 opentelemetry_sdk@0.27" {
+			t.Errorf("Expected 'cargo add' with all crates, got %s %v", call.Name, call.Args)
+		}
+	})
+
+	t.Run("without cargo - edit Cargo.toml", func(t *testing.T) {
+		mock := commander.NewMock()
+		// cargo not available
+
+		installer := NewCargoInstaller(mock)
+
+		dir := t.TempDir()
+		manifest := filepath.Join(dir, "Cargo.toml")
+		original := `[package]
+name = "api"
+
+[dependencies]
+opentelemetry-otlp = "0.27"
+
+[dev-dependencies]
+pretty_assertions = "1"
+`
+		if err := os.WriteFile(manifest, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{"opentelemetry@0.27.1", "opentelemetry_otlp@0.27.0", "opentelemetry_sdk"}
+		if err := installer.Install(ctx, dir, deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `[package]
+name = "api"
+
+[dependencies]
+opentelemetry-otlp = "0.27"
+opentelemetry = "0.27.1"
+opentelemetry_sdk = "0.27"
+
+[dev-dependencies]
+pretty_assertions = "1"
+`
+		if string(content) != expected {
+			t.Errorf("Unexpected Cargo.toml:\n%s", content)
+		}
+	})
+
+	t.Run("versions follow the required opentelemetry release", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			manifest string
+			want     string
+		}{
+			{"version string", "[dependencies]\nopentelemetry = \"0.26.0\"\n", "add opentelemetry_sdk@0.26 tracing-opentelemetry@0.27 serde"},
+			{"inline table", "[dependencies]\nopentelemetry = { version = \"^0.26\", features = [\"trace\"] }\n", "add opentelemetry_sdk@0.26 tracing-opentelemetry@0.27 serde"},
+			{"no opentelemetry", "[dependencies]\nopentelemetry-otlp = \"0.26\"\n", "add opentelemetry_sdk@0.28 tracing-opentelemetry@0.29 serde"},
+		}
+		for _, c := range cases {
+			mock := commander.NewMock()
+			mock.Commands["cargo"] = true
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte("[package]\nname = \"api\"\n\n"+c.manifest), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := NewCargoInstaller(mock).Install(ctx, dir, []string{"opentelemetry_sdk", "tracing-opentelemetry", "serde"}, false); err != nil {
+				t.Fatal(err)
+			}
+			if len(mock.RecordedCalls) != 1 || strings.Join(mock.RecordedCalls[0].Args, " ") != c.want {
+				t.Errorf("%s: expected 'cargo %s', got %v", c.name, c.want, mock.RecordedCalls)
+			}
+		}
+	})
+
+	t.Run("without cargo - create dependencies table", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewCargoInstaller(mock)
+
+		dir := t.TempDir()
+		manifest := filepath.Join(dir, "Cargo.toml")
+		if err := os.WriteFile(manifest, []byte("[package]\nname = \"api\""), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := installer.Install(ctx, dir, []string{"opentelemetry@0.27.1"}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != "[package]\nname = \"api\"\n\n[dependencies]\nopentelemetry = \"0.27.1\"\n" {
+			t.Errorf("Unexpected Cargo.toml:\n%s", content)
+		}
+	})
+}
+
+func TestMixInstaller(t *testing.T) {
+	ctx := context.Background()
+	original := `defmodule Orders.MixProject do
+  use Mix.Project
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      {:jason, "~> 1.2"}
+    ]
+  end
+end
+`
+
+	t.Run("with mix available", func(t *testing.T) {
+		mock := commander.NewMock()
+		mock.Commands["mix"] = true
+
+		installer := NewMixInstaller(mock)
+
+		dir := t.TempDir()
+		manifest := filepath.Join(dir, "mix.exs")
+		if err := os.WriteFile(manifest, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{"opentelemetry@1.5.0", "opentelemetry_exporter", "phoenix@1.7.14"}
+		if err := installer.Install(ctx, dir, deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `defmodule Orders.MixProject do
+  use Mix.Project
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      {:jason, "~> 1.2"},
+      {:opentelemetry, "~> 1.5"},
+      {:opentelemetry_exporter, ">= 0.0.0"}
+    ]
+  end
+end
+`
+		if string(content) != expected {
+			t.Errorf("Unexpected mix.exs:\n%s", content)
+		}
+
+		if len(mock.RecordedCalls) != 1 {
+			t.Fatalf("Expected a single mix deps.get call, got %v", mock.RecordedCalls)
+		}
+		call := mock.RecordedCalls[0]
+		if call.Name != "mix" || strings.Join(call.Args, " ") != "deps.get" || call.Dir != dir {
+			t.Errorf("Expected 'mix deps.get' in the project, got %s %v in %s", call.Name, call.Args, call.Dir)
+		}
+	})
+
+	t.Run("without mix - edit only", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMixInstaller(mock)
+
+		dir := t.TempDir()
+		manifest := filepath.Join(dir, "mix.exs")
+		if err := os.WriteFile(manifest, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := installer.Install(ctx, dir, []string{"opentelemetry_api@1.4.0"}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(manifest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), "      {:jason, \"~> 1.2\"},\n      {:opentelemetry_api, \"~> 1.4\"}\n    ]") {
+			t.Errorf("Unexpected mix.exs:\n%s", content)
+		}
+		if len(mock.RecordedCalls) != 0 {
+			t.Errorf("Expected no commands without mix, got %v", mock.RecordedCalls)
+		}
+	})
 }
 
 func TestInstallerErrors(t *testing.T) {
@@ -421,3 +805,85 @@ func TestInstallerErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestCppInstaller(t *testing.T) {
+	ctx := context.Background()
+	cmake := `cmake_minimum_required(VERSION 3.20)
+project(checkout LANGUAGES CXX)
+
+find_package(fmt CONFIG REQUIRED)
+
+add_executable(checkout src/main.cc)
+target_link_libraries(checkout PRIVATE fmt::fmt)
+`
+	expectedCMake := `cmake_minimum_required(VERSION 3.20)
+project(checkout LANGUAGES CXX)
+
+find_package(fmt CONFIG REQUIRED)
+find_package(opentelemetry-cpp CONFIG REQUIRED)
+
+add_executable(checkout src/main.cc)
+target_link_libraries(checkout PRIVATE fmt::fmt opentelemetry-cpp::trace opentelemetry-cpp::otlp_http_exporter opentelemetry-cpp::ostream_span_exporter)
+`
+
+	t.Run("vcpkg manifest", func(t *testing.T) {
+		dir := t.TempDir()
+		files := map[string]string{
+			"CMakeLists.txt": cmake,
+			"vcpkg.json":     "{\n  \"name\": \"checkout\",\n  \"dependencies\": [\"fmt\"]\n}\n",
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		installer := NewCppInstaller()
+		if err := installer.Install(ctx, dir, []string{"opentelemetry-cpp@1.16.1"}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "vcpkg.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "{\n  \"name\": \"checkout\",\n  \"dependencies\": [\"fmt\",\n    {\n      \"name\": \"opentelemetry-cpp\",\n      \"features\": [\n        \"otlp-http\"\n      ]\n    }\n  ]\n}\n"
+		if string(content) != expected {
+			t.Errorf("Unexpected vcpkg.json:\n%s", content)
+		}
+		content, err = os.ReadFile(filepath.Join(dir, "CMakeLists.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != expectedCMake {
+			t.Errorf("Unexpected CMakeLists.txt:\n%s", content)
+		}
+	})
+
+	t.Run("conanfile", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "conanfile.txt"), []byte("[requires]\nfmt/10.2.1\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		installer := NewCppInstaller()
+		if err := installer.Install(ctx, dir, []string{"opentelemetry-cpp@1.16.1"}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "conanfile.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := "[requires]\nfmt/10.2.1\nopentelemetry-cpp/1.16.1\n\n[options]\nopentelemetry-cpp/*:with_otlp_http=True\n"
+		if string(content) != expected {
+			t.Errorf("Unexpected conanfile.txt:\n%s", content)
+		}
+	})
+
+	t.Run("no manifest", func(t *testing.T) {
+		if err := NewCppInstaller().Install(ctx, t.TempDir(), []string{"opentelemetry-cpp"}, false); err == nil {
+			t.Error("Expected an error without C++ manifests")
+		}
+	})
+}
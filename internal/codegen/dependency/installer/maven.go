@@ -7,9 +7,13 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/dependency/types"
+	"github.com/getlawrence/cli/internal/gradle"
+	"github.com/getlawrence/cli/internal/maven"
+	"github.com/getlawrence/cli/internal/semver"
 )
 
 // MavenInstaller installs Java dependencies using Maven
@@ -34,16 +38,23 @@ func (i *MavenInstaller) Install(ctx context.Context, projectPath string, depend
 		hasPom = true
 	}
 
-	// Check for Gradle
-	hasGradle := false
-	gradlePath := ""
-	if _, err := os.Stat(filepath.Join(projectPath, "build.gradle")); err == nil {
-		hasGradle = true
-		gradlePath = filepath.Join(projectPath, "build.gradle")
-	} else if _, err := os.Stat(filepath.Join(projectPath, "build.gradle.kts")); err == nil {
-		hasGradle = true
-		gradlePath = filepath.Join(projectPath, "build.gradle.kts")
+	// Check for Gradle: the project's build script, or a new one for a subproject
+	// included by the settings script without one
+	gradlePath := gradle.BuildFile(projectPath)
+	var build *gradle.Build
+	if !hasPom {
+		var err error
+		if build, err = gradle.FindBuild(projectPath); err != nil {
+			return fmt.Errorf("failed to read the Gradle build: %w", err)
+		}
+		if gradlePath == "" && build.Includes(projectPath) {
+			gradlePath = filepath.Join(projectPath, "build.gradle")
+			if build.KotlinDSL() {
+				gradlePath += ".kts"
+			}
+		}
 	}
+	hasGradle := gradlePath != ""
 
 	if !hasPom && !hasGradle {
 		return fmt.Errorf("no pom.xml or build.gradle found in %s", projectPath)
@@ -75,7 +86,18 @@ func (i *MavenInstaller) Install(ctx context.Context, projectPath string, depend
 
 	// Auto-edit pom.xml or build.gradle files
 	if hasPom {
-		if err := i.addDependenciesToPom(pomPath, resolved); err != nil {
+		project, err := maven.Load(pomPath)
+		if err != nil {
+			return fmt.Errorf("failed to read pom.xml: %w", err)
+		}
+		deps, boms := i.manageVersions(project, resolved)
+		// BOMs go to the outermost local parent so every module shares them
+		if len(boms) > 0 {
+			if err := i.addBOMDependencies(project.RootPath(), boms); err != nil {
+				return fmt.Errorf("failed to add BOM dependencies: %w", err)
+			}
+		}
+		if err := i.addDependenciesToPom(pomPath, deps); err != nil {
 			return fmt.Errorf("failed to add dependencies to pom.xml: %w", err)
 		}
 		// Add Maven Shade plugin if OpenTelemetry dependencies are being added
@@ -86,7 +108,7 @@ func (i *MavenInstaller) Install(ctx context.Context, projectPath string, depend
 		}
 		fmt.Printf("Added %d dependencies to pom.xml\n", len(resolved))
 	} else if hasGradle {
-		if err := i.addDependenciesToGradle(gradlePath, resolved); err != nil {
+		if err := i.addDependenciesToGradle(gradlePath, build, resolved); err != nil {
 			return fmt.Errorf("failed to add dependencies to build.gradle: %w", err)
 		}
 		fmt.Printf("Added %d dependencies to %s\n", len(resolved), filepath.Base(gradlePath))
@@ -126,31 +148,7 @@ func (i *MavenInstaller) addDependenciesToPom(pomPath string, dependencies []str
 	pruneHTTPExporter := regexp.MustCompile(`(?s)\n\s*<dependency>\s*<groupId>io\.opentelemetry</groupId>\s*<artifactId>opentelemetry-exporter-otlp-http</artifactId>[\s\S]*?</dependency>\s*`)
 	content = pruneHTTPExporter.ReplaceAll(content, []byte("\n"))
 
-	// Separate BOM dependencies from regular dependencies first
-	var bomDeps []string
-	var regularDeps []string
-	for _, dep := range dependencies {
-		if strings.Contains(dep, "-bom:") || strings.Contains(dep, "opentelemetry-bom") {
-			bomDeps = append(bomDeps, dep)
-		} else {
-			regularDeps = append(regularDeps, dep)
-		}
-	}
-
-	// Handle BOM dependencies first (add to dependencyManagement)
-	if len(bomDeps) > 0 {
-		if err := i.addBOMDependencies(pomPath, bomDeps); err != nil {
-			return fmt.Errorf("failed to add BOM dependencies: %w", err)
-		}
-		// Re-read content after BOM modification
-		content, err = os.ReadFile(pomPath)
-		if err != nil {
-			return err
-		}
-	}
-
-	// Now handle regular dependencies
-	if len(regularDeps) == 0 {
+	if len(dependencies) == 0 {
 		return nil
 	}
 
@@ -182,14 +180,13 @@ func (i *MavenInstaller) addDependenciesToPom(pomPath string, dependencies []str
 
 		var newDeps strings.Builder
 		newDeps.WriteString("\n  <dependencies>\n")
-		for _, dep := range regularDeps {
+		for _, dep := range dependencies {
 			parts := strings.Split(dep, ":")
 			if len(parts) >= 2 {
 				newDeps.WriteString("    <dependency>\n")
 				newDeps.WriteString(fmt.Sprintf("      <groupId>%s</groupId>\n", parts[0]))
 				newDeps.WriteString(fmt.Sprintf("      <artifactId>%s</artifactId>\n", parts[1]))
-				// Only add version if no BOM is present
-				if len(parts) >= 3 && parts[2] != "LATEST" && len(bomDeps) == 0 {
+				if len(parts) >= 3 && parts[2] != "LATEST" {
 					newDeps.WriteString(fmt.Sprintf("      <version>%s</version>\n", parts[2]))
 				}
 				newDeps.WriteString("    </dependency>\n")
@@ -234,7 +231,7 @@ func (i *MavenInstaller) addDependenciesToPom(pomPath string, dependencies []str
 
 	// Check if regular dependencies already exist to avoid duplicates
 	var newDepsToAdd []string
-	for _, dep := range regularDeps {
+	for _, dep := range dependencies {
 		parts := strings.Split(dep, ":")
 		if len(parts) >= 2 {
 			groupID := parts[0]
@@ -251,8 +248,7 @@ func (i *MavenInstaller) addDependenciesToPom(pomPath string, dependencies []str
 				// Prepare new dependency XML
 				newDep := fmt.Sprintf("    <dependency>\n      <groupId>%s</groupId>\n      <artifactId>%s</artifactId>\n",
 					groupID, artifactID)
-				// Only add version if no BOM is present (BOM manages versions)
-				if version != "" && len(bomDeps) == 0 {
+				if version != "" {
 					newDep += fmt.Sprintf("      <version>%s</version>\n", version)
 				}
 				newDep += "    </dependency>\n"
@@ -280,50 +276,68 @@ func (i *MavenInstaller) addDependenciesToPom(pomPath string, dependencies []str
 	return os.WriteFile(pomPath, newContent, 0644)
 }
 
-// addDependenciesToGradle adds dependencies to build.gradle file
-func (i *MavenInstaller) addDependenciesToGradle(gradlePath string, dependencies []string) error {
-	content, err := os.ReadFile(gradlePath)
-	if err != nil {
-		return err
+// manageVersions leaves dependency versions to <dependencyManagement> and BOMs. It splits
+// the requested BOMs from the dependencies, then drops the version of the dependencies the
+// project already manages or whose groupId gets a BOM. When several stable artifacts of a
+// group with a known OpenTelemetry BOM are pinned, that BOM is imported at their highest
+// version instead of pinning each artifact. It returns the dependencies and the BOMs to add.
+func (i *MavenInstaller) manageVersions(project *maven.Project, dependencies []string) ([]string, []string) {
+	var boms, regular []string
+	bomGroups := make(map[string]bool)
+	for _, dep := range dependencies {
+		parts := strings.Split(dep, ":")
+		if strings.HasSuffix(parts[1], "-bom") || strings.Contains(parts[1], "-bom-") {
+			boms = append(boms, dep)
+			bomGroups[parts[0]] = true
+		} else {
+			regular = append(regular, dep)
+		}
 	}
 
-	// Find the dependencies block
-	depsPattern := regexp.MustCompile(`(dependencies\s*\{)(.*?)(\})`)
-	matches := depsPattern.FindSubmatch(content)
-
-	if len(matches) == 0 {
-		// No dependencies block found, create one at the end of the file
-		var newDeps strings.Builder
-		newDeps.WriteString("\ndependencies {\n")
-		for _, dep := range dependencies {
-			newDeps.WriteString(fmt.Sprintf("    implementation '%s'\n", dep))
+	// Stable pinned versions of the groups that have a known BOM
+	pinned := make(map[string][]string)
+	for _, dep := range regular {
+		parts := strings.Split(dep, ":")
+		if bomGroups[parts[0]] || project.Manages(parts[0], parts[1]) || maven.KnownBOM(parts[0]) == "" {
+			continue
+		}
+		if v, err := semver.Parse(parts[2]); err == nil && !v.IsPrerelease() {
+			pinned[parts[0]] = append(pinned[parts[0]], parts[2])
 		}
-		newDeps.WriteString("}\n")
-
-		// Append to the end of the file
-		newContent := append(content, []byte(newDeps.String())...)
-		return os.WriteFile(gradlePath, newContent, 0644)
 	}
-
-	// Dependencies block exists, add to it
-	depsStart := matches[1]
-	depsContent := matches[2]
-	depsEnd := matches[3]
-
-	// Check if dependencies already exist to avoid duplicates
-	for _, dep := range dependencies {
-		// Check if this dependency already exists
-		depPattern := regexp.MustCompile(fmt.Sprintf(`implementation\s+['"]%s['"]`, regexp.QuoteMeta(dep)))
-		if !depPattern.Match(depsContent) {
-			// Add new dependency
-			newDep := fmt.Sprintf("    implementation '%s'\n", dep)
-			depsContent = append(depsContent, []byte(newDep)...)
+	groups := make([]string, 0, len(pinned))
+	for group := range pinned {
+		groups = append(groups, group)
+	}
+	slices.Sort(groups)
+	for _, group := range groups {
+		versions := pinned[group]
+		if len(versions) < 2 {
+			continue
 		}
+		highest := versions[0]
+		for _, version := range versions[1:] {
+			if c, err := semver.Compare(version, highest); err == nil && c > 0 {
+				highest = version
+			}
+		}
+		boms = append(boms, group+":"+maven.KnownBOM(group)+":"+highest)
+		bomGroups[group] = true
 	}
 
-	// Reconstruct the file
-	newContent := bytes.Replace(content, matches[0], append(append(depsStart, depsContent...), depsEnd...), 1)
-	return os.WriteFile(gradlePath, newContent, 0644)
+	var deps []string
+	for _, dep := range regular {
+		parts := strings.Split(dep, ":")
+		// BOMs only manage the stable artifacts, alpha ones keep their version
+		v, err := semver.Parse(parts[2])
+		stable := err != nil || !v.IsPrerelease()
+		_, managed := project.Managed[parts[0]+":"+parts[1]]
+		if managed || (stable && (bomGroups[parts[0]] || project.Manages(parts[0], parts[1]))) {
+			dep = parts[0] + ":" + parts[1]
+		}
+		deps = append(deps, dep)
+	}
+	return deps, boms
 }
 
 // addBOMDependencies adds BOM dependencies to the dependencyManagement section
@@ -649,4 +649,321 @@ func TestMavenInstaller(t *testing.T) {
 			t.Error("Expected existing maven-jar-plugin to remain")
 		}
 	})
+
+	t.Run("omits versions managed by a BOM imported in the parent POM", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMavenInstaller(mock)
+
+		dir := t.TempDir()
+		parentPom := `<project>
+  <groupId>com.example</groupId>
+  <artifactId>parent</artifactId>
+  <version>1.0.0</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>api</module>
+  </modules>
+  <properties>
+    <otel.version>1.42.1</otel.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>io.opentelemetry</groupId>
+        <artifactId>opentelemetry-bom</artifactId>
+        <version>${otel.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`
+		childPom := `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <artifactId>api</artifactId>
+</project>`
+		if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(parentPom), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "api"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "api", "pom.xml"), []byte(childPom), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{"io.opentelemetry:opentelemetry-api:1.42.1", "io.opentelemetry:opentelemetry-sdk:1.42.1"}
+		if err := installer.Install(ctx, filepath.Join(dir, "api"), deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, "api", "pom.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), "<artifactId>opentelemetry-sdk</artifactId>") || strings.Contains(string(content), "<version>1.42.1</version>") {
+			t.Errorf("expected version-less dependencies, got:\n%s", content)
+		}
+		parent, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(parent) != parentPom {
+			t.Errorf("expected the parent POM to be left alone, got:\n%s", parent)
+		}
+	})
+
+	t.Run("imports the OpenTelemetry BOM into the parent instead of pinning each artifact", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMavenInstaller(mock)
+
+		dir := t.TempDir()
+		parentPom := `<project>
+  <groupId>com.example</groupId>
+  <artifactId>parent</artifactId>
+  <version>1.0.0</version>
+  <packaging>pom</packaging>
+</project>`
+		childPom := `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>parent</artifactId>
+    <version>1.0.0</version>
+  </parent>
+  <artifactId>api</artifactId>
+</project>`
+		if err := os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(parentPom), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Join(dir, "api"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "api", "pom.xml"), []byte(childPom), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{
+			"io.opentelemetry:opentelemetry-api:1.41.0",
+			"io.opentelemetry:opentelemetry-sdk:1.42.1",
+			"io.opentelemetry.semconv:opentelemetry-semconv:1.26.0-alpha",
+		}
+		if err := installer.Install(ctx, filepath.Join(dir, "api"), deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		parent, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(parent), "<artifactId>opentelemetry-bom</artifactId>\n        <version>1.42.1</version>") {
+			t.Errorf("expected opentelemetry-bom 1.42.1 in the parent, got:\n%s", parent)
+		}
+		content, err := os.ReadFile(filepath.Join(dir, "api", "pom.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := string(content)
+		if strings.Contains(got, "<version>1.4") || !strings.Contains(got, "<version>1.26.0-alpha</version>") {
+			t.Errorf("expected BOM-managed artifacts without versions and semconv pinned, got:\n%s", got)
+		}
+	})
+
+	t.Run("adds Kotlin DSL entries to the top-level dependencies block", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMavenInstaller(mock)
+
+		dir := t.TempDir()
+		gradlePath := filepath.Join(dir, "build.gradle.kts")
+		initial := `buildscript {
+    dependencies {
+        classpath("com.example:plugin:1.0")
+    }
+}
+
+plugins {
+    kotlin("jvm") version "2.0.21"
+}
+
+dependencies {
+    // "dependencies {" in a comment
+    implementation("io.ktor:ktor-server-netty:2.3.12")
+    implementation("io.opentelemetry:opentelemetry-api:1.42.1")
+}
+`
+		if err := os.WriteFile(gradlePath, []byte(initial), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{
+			"io.opentelemetry:opentelemetry-bom:1.42.1",
+			"io.opentelemetry:opentelemetry-api",
+			"io.opentelemetry:opentelemetry-sdk",
+		}
+		if err := installer.Install(ctx, dir, deps, false); err != nil {
+			t.Fatal(err)
+		}
+		// A second run must not duplicate entries
+		if err := installer.Install(ctx, dir, deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(gradlePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `buildscript {
+    dependencies {
+        classpath("com.example:plugin:1.0")
+    }
+}
+
+plugins {
+    kotlin("jvm") version "2.0.21"
+}
+
+dependencies {
+    // "dependencies {" in a comment
+    implementation("io.ktor:ktor-server-netty:2.3.12")
+    implementation("io.opentelemetry:opentelemetry-api:1.42.1")
+    implementation(platform("io.opentelemetry:opentelemetry-bom:1.42.1"))
+    implementation("io.opentelemetry:opentelemetry-sdk")
+}
+`
+		if string(content) != want {
+			t.Fatalf("unexpected build.gradle.kts:\n%s", content)
+		}
+	})
+
+	t.Run("uses Groovy syntax and creates the dependencies block in build.gradle", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMavenInstaller(mock)
+
+		dir := t.TempDir()
+		gradlePath := filepath.Join(dir, "build.gradle")
+		if err := os.WriteFile(gradlePath, []byte("plugins {\n    id 'java'\n}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := installer.Install(ctx, dir, []string{"io.opentelemetry:opentelemetry-api:1.42.1", "io.opentelemetry:opentelemetry-sdk"}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := os.ReadFile(gradlePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "plugins {\n    id 'java'\n}\n\ndependencies {\n    implementation 'io.opentelemetry:opentelemetry-api:1.42.1'\n    implementation 'io.opentelemetry:opentelemetry-sdk:latest.release'\n}\n"
+		if string(content) != want {
+			t.Fatalf("unexpected build.gradle:\n%s", content)
+		}
+	})
+
+	t.Run("adds version catalog entries and references them from the subproject", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMavenInstaller(mock)
+
+		dir := t.TempDir()
+		files := map[string]string{
+			"settings.gradle.kts":       "rootProject.name = \"shop\"\ninclude(\"app\")\n",
+			"gradle/libs.versions.toml": "[versions]\nkotlin = \"2.0.21\"\n\n[libraries]\nktor-server-netty = { module = \"io.ktor:ktor-server-netty\", version = \"2.3.12\" }\n",
+			"app/build.gradle.kts":      "dependencies {\n    implementation(libs.ktor.server.netty)\n}\n",
+		}
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		deps := []string{"io.opentelemetry:opentelemetry-api:1.42.1", "io.opentelemetry:opentelemetry-sdk:1.42.1", "io.ktor:ktor-server-netty:2.3.12"}
+		if err := installer.Install(ctx, filepath.Join(dir, "app"), deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		catalog, err := os.ReadFile(filepath.Join(dir, "gradle", "libs.versions.toml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantCatalog := `[versions]
+kotlin = "2.0.21"
+opentelemetry = "1.42.1"
+
+[libraries]
+ktor-server-netty = { module = "io.ktor:ktor-server-netty", version = "2.3.12" }
+opentelemetry-api = { module = "io.opentelemetry:opentelemetry-api", version.ref = "opentelemetry" }
+opentelemetry-sdk = { module = "io.opentelemetry:opentelemetry-sdk", version.ref = "opentelemetry" }
+
+[bundles]
+opentelemetry = ["opentelemetry-api", "opentelemetry-sdk"]
+`
+		if string(catalog) != wantCatalog {
+			t.Errorf("unexpected catalog:\n%s", catalog)
+		}
+		script, err := os.ReadFile(filepath.Join(dir, "app", "build.gradle.kts"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "dependencies {\n    implementation(libs.ktor.server.netty)\n    implementation(libs.bundles.opentelemetry)\n}\n"; string(script) != want {
+			t.Errorf("unexpected build.gradle.kts:\n%s", script)
+		}
+	})
+
+	t.Run("creates the build script of an included subproject with catalog references", func(t *testing.T) {
+		mock := commander.NewMock()
+		installer := NewMavenInstaller(mock)
+
+		dir := t.TempDir()
+		files := map[string]string{
+			"settings.gradle":           "include ':services:worker'\n",
+			"gradle/libs.versions.toml": "[libraries]\nopentelemetry-sdk-trace = { module = \"io.opentelemetry:opentelemetry-sdk-trace\" }\n",
+		}
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		worker := filepath.Join(dir, "services", "worker")
+		if err := os.MkdirAll(worker, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		deps := []string{"io.opentelemetry:opentelemetry-bom:1.42.1", "io.opentelemetry:opentelemetry-sdk"}
+		if err := installer.Install(ctx, worker, deps, false); err != nil {
+			t.Fatal(err)
+		}
+
+		catalog, err := os.ReadFile(filepath.Join(dir, "gradle", "libs.versions.toml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantCatalog := `[versions]
+opentelemetry = "1.42.1"
+
+[libraries]
+opentelemetry-sdk-trace = { module = "io.opentelemetry:opentelemetry-sdk-trace" }
+opentelemetry-bom = { module = "io.opentelemetry:opentelemetry-bom", version.ref = "opentelemetry" }
+opentelemetry-sdk = { module = "io.opentelemetry:opentelemetry-sdk" }
+`
+		if string(catalog) != wantCatalog {
+			t.Errorf("unexpected catalog:\n%s", catalog)
+		}
+		script, err := os.ReadFile(filepath.Join(worker, "build.gradle"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "dependencies {\n    implementation platform(libs.opentelemetry.bom)\n    implementation libs.opentelemetry.sdk.asProvider()\n}\n"; string(script) != want {
+			t.Errorf("unexpected build.gradle:\n%s", script)
+		}
+	})
 }
@@ -0,0 +1,76 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/dependency/types"
+	"github.com/getlawrence/cli/internal/mix"
+)
+
+// MixInstaller adds Elixir packages to the deps function of mix.exs and fetches them with mix
+type MixInstaller struct {
+	commander types.Commander
+}
+
+// NewMixInstaller creates a new mix installer
+func NewMixInstaller(commander types.Commander) Installer {
+	return &MixInstaller{commander: commander}
+}
+
+// Install installs Elixir dependencies. Mix has no command to add a dependency, so
+// mix.exs is always edited; `mix deps.get` then fetches them when mix is available.
+func (i *MixInstaller) Install(ctx context.Context, projectPath string, dependencies []string, dryRun bool) error {
+	if len(dependencies) == 0 {
+		return nil
+	}
+
+	manifestPath := filepath.Join(projectPath, mix.FileName)
+	content, err := os.ReadFile(manifestPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("mix.exs not found in %s", projectPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	deps := make([]mix.Dependency, 0, len(dependencies))
+	for _, dep := range dependencies {
+		name, version := parseMixSpec(dep)
+		deps = append(deps, mix.Dependency{Name: name, Requirement: mix.Requirement(version)})
+	}
+
+	updated, added, err := mix.AddDeps(content, deps)
+	if err != nil {
+		return fmt.Errorf("failed to edit %s: %w", manifestPath, err)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := os.WriteFile(manifestPath, updated, 0644); err != nil {
+		return err
+	}
+
+	if _, err := i.commander.LookPath("mix"); err == nil {
+		if out, err := i.commander.Run(ctx, "mix", []string{"deps.get"}, projectPath); err != nil {
+			return fmt.Errorf("mix deps.get failed: %w\nOutput: %s", err, out)
+		}
+	}
+	return nil
+}
+
+// parseMixSpec splits package@version (or package:version) into name and version
+func parseMixSpec(dep string) (string, string) {
+	dep = strings.TrimPrefix(strings.TrimSpace(dep), ":")
+	if idx := strings.IndexAny(dep, "@:"); idx > 0 {
+		return dep[:idx], dep[idx+1:]
+	}
+	return dep, ""
+}
@@ -2,14 +2,26 @@ package installer
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/dependency/types"
+	"github.com/getlawrence/cli/internal/python"
 )
 
-// PipInstaller installs Python packages using pip or edits requirements.txt
+// toolAddCommands are the subcommands that add dependencies to the manifest and lockfile of
+// each Python package manager besides pip
+var toolAddCommands = map[python.Tool]string{
+	python.ToolPoetry: "add",
+	python.ToolUv:     "add",
+	python.ToolPipenv: "install",
+}
+
+// PipInstaller installs Python packages using pip, Poetry, uv or Pipenv, depending on the
+// project's lockfile, or edits the manifest when the tool is not installed
 type PipInstaller struct {
 	commander types.Commander
 }
@@ -37,6 +49,10 @@ func (i *PipInstaller) Install(ctx context.Context, projectPath string, dependen
 		return nil
 	}
 
+	if tool := python.DetectTool(projectPath); tool != python.ToolPip {
+		return i.installWithTool(ctx, projectPath, tool, resolved)
+	}
+
 	// Check if pip is available
 	pipCmd := "pip"
 	if _, err := i.commander.LookPath(pipCmd); err != nil {
@@ -47,8 +63,8 @@ func (i *PipInstaller) Install(ctx context.Context, projectPath string, dependen
 			// Try python -m pip
 			pipCmd = "python"
 		} else {
-			// Fallback to editing requirements.txt
-			return i.editRequirements(reqPath, resolved)
+			// Fallback to editing the manifest
+			return i.declareDependencies(projectPath, reqPath, resolved)
 		}
 	}
 
@@ -62,12 +78,72 @@ func (i *PipInstaller) Install(ctx context.Context, projectPath string, dependen
 		}
 
 		if _, err := i.commander.Run(ctx, pipCmd, args, projectPath); err != nil {
-			return i.updateRequirements(reqPath, resolved)
+			return i.declareDependencies(projectPath, reqPath, resolved)
+		}
+	}
+
+	// Record the installed packages in the manifest
+	return i.declareDependencies(projectPath, reqPath, resolved)
+}
+
+// installWithTool adds dependencies with Poetry, uv or Pipenv, which update the manifest
+// and the lockfile together. Without the tool on PATH the manifest is edited and the
+// lockfile is left for the next lock.
+func (i *PipInstaller) installWithTool(ctx context.Context, projectPath string, tool python.Tool, dependencies []string) error {
+	command := string(tool)
+	if _, err := i.commander.LookPath(command); err == nil {
+		args := append([]string{toolAddCommands[tool]}, dependencies...)
+		if out, err := i.commander.Run(ctx, command, args, projectPath); err != nil {
+			return fmt.Errorf("%s %s failed: %w\nOutput: %s", command, toolAddCommands[tool], err, out)
+		}
+		return nil
+	}
+
+	reqs := parseRequirements(dependencies)
+	if tool == python.ToolPipenv {
+		return editFile(filepath.Join(projectPath, python.PipfileName), func(content []byte) ([]byte, error) {
+			updated, _, err := python.AddTableDependencies(content, "packages", reqs)
+			return updated, err
+		})
+	}
+	// uv, and Poetry 2 projects, declare dependencies in the PEP 621 [project] table;
+	// older Poetry projects only have [tool.poetry.dependencies]
+	return editFile(filepath.Join(projectPath, python.PyprojectFileName), func(content []byte) ([]byte, error) {
+		updated, _, err := python.AddProjectDependencies(content, reqs)
+		if errors.Is(err, python.ErrNoProjectTable) && tool == python.ToolPoetry {
+			updated, _, err = python.AddTableDependencies(content, "tool.poetry.dependencies", reqs)
+		}
+		return updated, err
+	})
+}
+
+// declareDependencies records pip-installed dependencies in requirements.txt, or in the
+// PEP 621 dependencies of pyproject.toml when that is the project's only manifest
+func (i *PipInstaller) declareDependencies(projectPath, reqPath string, dependencies []string) error {
+	if _, err := os.Stat(reqPath); os.IsNotExist(err) {
+		pyprojectPath := filepath.Join(projectPath, python.PyprojectFileName)
+		if content, err := os.ReadFile(pyprojectPath); err == nil {
+			if project, err := python.ParsePyproject(content); err == nil && project.HasProjectTable {
+				reqs := parseRequirements(dependencies)
+				return editFile(pyprojectPath, func(content []byte) ([]byte, error) {
+					updated, _, err := python.AddProjectDependencies(content, reqs)
+					return updated, err
+				})
+			}
 		}
 	}
+	return i.updateRequirements(reqPath, dependencies)
+}
 
-	// Update requirements.txt
-	return i.updateRequirements(reqPath, resolved)
+// parseRequirements parses dependency specs such as opentelemetry-sdk==1.27.0
+func parseRequirements(dependencies []string) []python.Requirement {
+	var reqs []python.Requirement
+	for _, dep := range dependencies {
+		if req, ok := python.ParseRequirement(dep); ok {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
 }
 
 // resolveVersions adds versions to dependencies
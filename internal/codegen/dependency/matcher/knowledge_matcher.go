@@ -132,6 +132,15 @@ func (m *KnowledgeEnhancedMatcher) formatPackageWithVersion(packageName, version
 	case "ruby":
 		// gem format: package:version
 		return packageName + ":" + version
+	case "rust":
+		// cargo add format: crate@version
+		return packageName + "@" + version
+	case "elixir":
+		// the mix installer turns package@version into a requirement
+		return packageName + "@" + version
+	case "cpp":
+		// the C++ installer writes the version into vcpkg.json or the conanfile
+		return packageName + "@" + version
 	default:
 		// Default to @ separator
 		return packageName + "@" + version
@@ -26,6 +26,9 @@ func New(commander types.Commander) *Registry {
 			"java":       scanner.NewMavenScanner(),
 			"csharp":     scanner.NewCsprojScanner(),
 			"dotnet":     scanner.NewCsprojScanner(),
+			"rust":       scanner.NewCargoScanner(),
+			"elixir":     scanner.NewMixScanner(),
+			"cpp":        scanner.NewCppScanner(),
 		},
 		installers: map[string]installer.Installer{
 			"go":         installer.NewGoInstaller(commander),
@@ -36,6 +39,9 @@ func New(commander types.Commander) *Registry {
 			"java":       installer.NewMavenInstaller(commander),
 			"csharp":     installer.NewDotNetInstaller(commander),
 			"dotnet":     installer.NewDotNetInstaller(commander),
+			"rust":       installer.NewCargoInstaller(commander),
+			"elixir":     installer.NewMixInstaller(commander),
+			"cpp":        installer.NewCppInstaller(),
 		},
 	}
 }
@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// cargoDepsSectionRe matches dependency tables: [dependencies], [dev-dependencies],
+	// [target.'cfg(unix)'.dependencies], [workspace.dependencies], ...
+	cargoDepsSectionRe = regexp.MustCompile(`^\[(?:workspace\.|target\..+\.)?(?:dev-|build-)?dependencies\]$`)
+	// cargoDepTableRe matches a dependency declared as its own table, e.g. [dependencies.serde]
+	cargoDepTableRe = regexp.MustCompile(`^\[(?:workspace\.|target\..+\.)?(?:dev-|build-)?dependencies\.([A-Za-z0-9_-]+)\]$`)
+	cargoDepEntryRe = regexp.MustCompile(`^([A-Za-z0-9_-]+)(?:\.[A-Za-z-]+)?\s*=`)
+	cargoPackageRe  = regexp.MustCompile(`\bpackage\s*=\s*"([^"]+)"`)
+)
+
+// CargoScanner scans Cargo.toml for Rust dependencies
+type CargoScanner struct{}
+
+// NewCargoScanner creates a new Cargo.toml scanner
+func NewCargoScanner() Scanner {
+	return &CargoScanner{}
+}
+
+// Detect checks for Cargo.toml
+func (s *CargoScanner) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "Cargo.toml"))
+	return err == nil
+}
+
+// Scan reads Cargo.toml and returns crate names. Renamed dependencies
+// (`alias = { package = "crate" }`) are reported by their registry name.
+func (s *CargoScanner) Scan(projectPath string) ([]string, error) {
+	file, err := os.Open(filepath.Join(projectPath, "Cargo.toml"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var deps []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			deps = append(deps, name)
+		}
+	}
+
+	inSection := false
+	tableDep := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if tableDep != "" {
+				add(tableDep)
+			}
+			tableDep = ""
+			inSection = cargoDepsSectionRe.MatchString(line)
+			if m := cargoDepTableRe.FindStringSubmatch(line); m != nil {
+				tableDep = m[1]
+			}
+			continue
+		}
+		if tableDep != "" {
+			if m := cargoPackageRe.FindStringSubmatch(line); m != nil && strings.HasPrefix(line, "package") {
+				tableDep = m[1]
+			}
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		if m := cargoDepEntryRe.FindStringSubmatch(line); m != nil {
+			name := m[1]
+			if p := cargoPackageRe.FindStringSubmatch(line); p != nil {
+				name = p[1]
+			}
+			add(name)
+		}
+	}
+	if tableDep != "" {
+		add(tableDep)
+	}
+
+	return deps, scanner.Err()
+}
@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/getlawrence/cli/internal/cpp"
+)
+
+// cppManifests are the C++ manifests in the order packages are read from them
+var cppManifests = []string{cpp.VcpkgFileName, cpp.ConanfileTxt, cpp.ConanfilePy, cpp.CMakeFileName}
+
+// CppScanner scans vcpkg.json, conanfiles and CMakeLists.txt for C++ dependencies
+type CppScanner struct{}
+
+// NewCppScanner creates a new C++ manifest scanner
+func NewCppScanner() Scanner {
+	return &CppScanner{}
+}
+
+// Detect checks for any C++ manifest
+func (s *CppScanner) Detect(projectPath string) bool {
+	for _, name := range cppManifests {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan returns the packages declared by the package manager manifests and imported
+// with find_package in CMakeLists.txt
+func (s *CppScanner) Scan(projectPath string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range cppManifests {
+		content, err := os.ReadFile(filepath.Join(projectPath, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var packages []cpp.Package
+		switch name {
+		case cpp.VcpkgFileName:
+			if packages, err = cpp.VcpkgDependencies(content); err != nil {
+				return nil, err
+			}
+		case cpp.ConanfileTxt:
+			packages = cpp.ConanfileTxtRequires(content)
+		case cpp.ConanfilePy:
+			packages = cpp.ConanfilePyRequires(content)
+		case cpp.CMakeFileName:
+			packages = cpp.FindPackages(content)
+		}
+		for _, pkg := range packages {
+			if !seen[pkg.Name] {
+				seen[pkg.Name] = true
+				names = append(names, pkg.Name)
+			}
+		}
+	}
+	return names, nil
+}
@@ -1,13 +1,12 @@
 package scanner
 
 import (
-	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
+	"github.com/getlawrence/cli/internal/dotnet"
 )
 
-// CsprojScanner scans .csproj files for .NET dependencies
+// CsprojScanner scans .NET project files (.csproj, .fsproj, .vbproj) for package references,
+// including those inherited from Directory.Build.props and Directory.Packages.props and the
+// packages.config of legacy projects
 type CsprojScanner struct{}
 
 // NewCsprojScanner creates a new csproj scanner
@@ -15,56 +14,26 @@ func NewCsprojScanner() Scanner {
 	return &CsprojScanner{}
 }
 
-// Detect checks for .csproj files
+// Detect checks for a project file, or a solution listing one
 func (s *CsprojScanner) Detect(projectPath string) bool {
-	entries, err := os.ReadDir(projectPath)
-	if err != nil {
-		return false
-	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".csproj") {
-			return true
-		}
-	}
-	return false
+	_, err := dotnet.FindProjectFile(projectPath)
+	return err == nil
 }
 
-// Scan reads .csproj and returns package references
+// Scan reads the project and returns its package references
 func (s *CsprojScanner) Scan(projectPath string) ([]string, error) {
-	// Find first .csproj file
-	entries, err := os.ReadDir(projectPath)
+	projectFile, err := dotnet.FindProjectFile(projectPath)
 	if err != nil {
-		return nil, err
-	}
-
-	var csprojPath string
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".csproj") {
-			csprojPath = filepath.Join(projectPath, entry.Name())
-			break
-		}
-	}
-
-	if csprojPath == "" {
 		return nil, nil
 	}
-
-	content, err := os.ReadFile(csprojPath)
+	project, err := dotnet.LoadProject(projectFile)
 	if err != nil {
 		return nil, err
 	}
 
-	// Extract PackageReference elements
-	var deps []string
-	re := regexp.MustCompile(`<PackageReference\s+Include="([^"]+)"`)
-	matches := re.FindAllStringSubmatch(string(content), -1)
-
-	for _, match := range matches {
-		if len(match) > 1 {
-			deps = append(deps, match[1])
-		}
+	deps := make([]string, 0, len(project.Packages))
+	for _, pkg := range project.Packages {
+		deps = append(deps, pkg.Name)
 	}
-
 	return deps, nil
 }
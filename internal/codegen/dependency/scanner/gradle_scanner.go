@@ -0,0 +1,45 @@
+package scanner
+
+import (
+	"os"
+
+	"github.com/getlawrence/cli/internal/gradle"
+)
+
+// GradleScanner scans Gradle build scripts (Groovy or Kotlin DSL) for JVM dependencies
+type GradleScanner struct{}
+
+// NewGradleScanner creates a new Gradle scanner
+func NewGradleScanner() Scanner {
+	return &GradleScanner{}
+}
+
+// Detect checks for build.gradle or build.gradle.kts
+func (s *GradleScanner) Detect(projectPath string) bool {
+	return gradle.BuildFile(projectPath) != ""
+}
+
+// Scan reads the build script and returns group:artifact coordinates, resolving
+// references to the build's version catalog (libs.*)
+func (s *GradleScanner) Scan(projectPath string) ([]string, error) {
+	content, err := os.ReadFile(gradle.BuildFile(projectPath))
+	if err != nil {
+		return nil, err
+	}
+	build, err := gradle.FindBuild(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []string
+	seen := make(map[string]bool)
+	for _, dep := range gradle.ParseBuildScript(content, build.Catalog) {
+		coord := dep.Coordinate()
+		if !seen[coord] {
+			seen[coord] = true
+			deps = append(deps, coord)
+		}
+	}
+
+	return deps, nil
+}
@@ -1,51 +1,53 @@
 package scanner
 
 import (
-	"encoding/xml"
 	"os"
 	"path/filepath"
+
+	"github.com/getlawrence/cli/internal/maven"
 )
 
-// MavenScanner scans pom.xml for Java dependencies
-type MavenScanner struct{}
+// MavenScanner scans pom.xml for Java dependencies. Like MavenInstaller, it falls
+// back to the Gradle build script when the project has no pom.xml.
+type MavenScanner struct {
+	gradle Scanner
+}
 
 // NewMavenScanner creates a new Maven scanner
 func NewMavenScanner() Scanner {
-	return &MavenScanner{}
+	return &MavenScanner{gradle: NewGradleScanner()}
 }
 
-// Detect checks for pom.xml
+// Detect checks for pom.xml or a Gradle build script
 func (s *MavenScanner) Detect(projectPath string) bool {
-	_, err := os.Stat(filepath.Join(projectPath, "pom.xml"))
-	return err == nil
+	return s.hasPom(projectPath) || s.gradle.Detect(projectPath)
 }
 
-// Scan reads pom.xml and returns dependency coordinates
+// Scan reads the effective dependencies of pom.xml, including those inherited from local
+// parent POMs (or the Gradle build script), and returns dependency coordinates
 func (s *MavenScanner) Scan(projectPath string) ([]string, error) {
-	file, err := os.ReadFile(filepath.Join(projectPath, "pom.xml"))
-	if err != nil {
-		return nil, err
+	if !s.hasPom(projectPath) && s.gradle.Detect(projectPath) {
+		return s.gradle.Scan(projectPath)
 	}
 
-	var pom struct {
-		Dependencies struct {
-			Dependency []struct {
-				GroupId    string `xml:"groupId"`
-				ArtifactId string `xml:"artifactId"`
-			} `xml:"dependency"`
-		} `xml:"dependencies"`
-	}
-
-	if err := xml.Unmarshal(file, &pom); err != nil {
+	project, err := maven.Load(filepath.Join(projectPath, maven.POMFileName))
+	if err != nil {
 		return nil, err
 	}
 
+	// Inherited dependencies count too: they are already on the classpath
 	var deps []string
-	for _, dep := range pom.Dependencies.Dependency {
-		if dep.GroupId != "" && dep.ArtifactId != "" {
-			deps = append(deps, dep.GroupId+":"+dep.ArtifactId)
+	for _, dep := range project.Dependencies {
+		if dep.GroupID != "" && dep.ArtifactID != "" {
+			deps = append(deps, dep.Coordinate())
 		}
 	}
 
 	return deps, nil
 }
+
+// hasPom checks for pom.xml
+func (s *MavenScanner) hasPom(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, "pom.xml"))
+	return err == nil
+}
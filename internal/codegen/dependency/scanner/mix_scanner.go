@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/getlawrence/cli/internal/mix"
+)
+
+// MixScanner scans mix.exs for Elixir dependencies
+type MixScanner struct{}
+
+// NewMixScanner creates a new mix.exs scanner
+func NewMixScanner() Scanner {
+	return &MixScanner{}
+}
+
+// Detect checks for mix.exs
+func (s *MixScanner) Detect(projectPath string) bool {
+	_, err := os.Stat(filepath.Join(projectPath, mix.FileName))
+	return err == nil
+}
+
+// Scan reads the deps function of mix.exs and returns package names
+func (s *MixScanner) Scan(projectPath string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(projectPath, mix.FileName))
+	if err != nil {
+		return nil, err
+	}
+
+	deps, err := mix.ParseDeps(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, dep := range deps {
+		names = append(names, dep.Name)
+	}
+	return names, nil
+}
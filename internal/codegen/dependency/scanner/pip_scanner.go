@@ -5,9 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/getlawrence/cli/internal/python"
 )
 
-// PipScanner scans requirements.txt for Python dependencies
+// pipManifests are the files declaring Python dependencies
+var pipManifests = []string{"requirements.txt", python.PyprojectFileName, python.PipfileName, python.SetupCfgFileName}
+
+// PipScanner scans requirements.txt, pyproject.toml, Pipfile and setup.cfg for Python dependencies
 type PipScanner struct{}
 
 // NewPipScanner creates a new pip scanner
@@ -15,15 +20,65 @@ func NewPipScanner() Scanner {
 	return &PipScanner{}
 }
 
-// Detect checks for requirements.txt
+// Detect checks for any Python dependency manifest
 func (s *PipScanner) Detect(projectPath string) bool {
-	_, err := os.Stat(filepath.Join(projectPath, "requirements.txt"))
-	return err == nil
+	for _, name := range pipManifests {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			return true
+		}
+	}
+	return false
 }
 
-// Scan reads requirements.txt and returns package names
+// Scan returns the package names declared across the project's manifests
 func (s *PipScanner) Scan(projectPath string) ([]string, error) {
-	file, err := os.Open(filepath.Join(projectPath, "requirements.txt"))
+	var deps []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if key := python.NormalizeName(name); !seen[key] {
+			seen[key] = true
+			deps = append(deps, name)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(projectPath, "requirements.txt")); err == nil {
+		names, err := s.scanRequirements(filepath.Join(projectPath, "requirements.txt"))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			add(name)
+		}
+	}
+
+	var reqs []python.Requirement
+	if content, err := os.ReadFile(filepath.Join(projectPath, python.PyprojectFileName)); err == nil {
+		project, err := python.ParsePyproject(content)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, project.Dependencies...)
+	}
+	if content, err := os.ReadFile(filepath.Join(projectPath, python.PipfileName)); err == nil {
+		pipfile, err := python.ParsePipfile(content)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, pipfile...)
+	}
+	if content, err := os.ReadFile(filepath.Join(projectPath, python.SetupCfgFileName)); err == nil {
+		reqs = append(reqs, python.ParseSetupCfg(content).Dependencies...)
+	}
+	for _, req := range reqs {
+		add(req.Name)
+	}
+
+	return deps, nil
+}
+
+// scanRequirements reads requirements.txt and returns package names
+func (s *PipScanner) scanRequirements(path string) ([]string, error) {
+	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
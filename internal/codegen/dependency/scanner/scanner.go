@@ -5,7 +5,8 @@ import (
     "fmt"
     "os"
     "path/filepath"
-    "strings"
+
+    "github.com/getlawrence/cli/internal/gomod"
 )
 
 // Scanner detects and enumerates project dependencies for a given language/ecosystem
@@ -16,45 +17,32 @@ type Scanner interface {
     Scan(projectPath string) ([]string, error)
 }
 
-// GoModScanner scans go.mod files for module requirements
+// GoModScanner scans the go.mod owning a project path for module requirements. The
+// path may be a package directory below the module root, e.g. of a go.work workspace.
 type GoModScanner struct{}
 
 func NewGoModScanner() *GoModScanner { return &GoModScanner{} }
 
 func (s *GoModScanner) Detect(projectPath string) bool {
-    if _, err := os.Stat(filepath.Join(projectPath, "go.mod")); err == nil {
-        return true
-    }
-    return false
+    _, ok, err := gomod.FindOwner(projectPath)
+    return ok && err == nil
 }
 
 func (s *GoModScanner) Scan(projectPath string) ([]string, error) {
-    b, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
+    owner, ok, err := gomod.FindOwner(projectPath)
     if err != nil {
         return nil, err
     }
-    lines := strings.Split(string(b), "\n")
-    deps := make([]string, 0, 32)
-    inBlock := false
-    for _, ln := range lines {
-        t := strings.TrimSpace(ln)
-        if strings.HasPrefix(t, "require (") {
-            inBlock = true
-            continue
-        }
-        if inBlock && t == ")" {
-            inBlock = false
-            continue
-        }
-        if strings.HasPrefix(t, "require ") {
-            t = strings.TrimSpace(strings.TrimPrefix(t, "require "))
-        }
-        if inBlock || strings.HasPrefix(ln, "require ") {
-            fields := strings.Fields(t)
-            if len(fields) >= 1 && !strings.HasPrefix(fields[0], "//") {
-                deps = append(deps, fields[0])
-            }
-        }
+    if !ok {
+        return nil, fmt.Errorf("go.mod not found for %s", projectPath)
+    }
+    mod, err := owner.ReadModule()
+    if err != nil {
+        return nil, err
+    }
+    deps := make([]string, 0, len(mod.Requires))
+    for _, req := range mod.Requires {
+        deps = append(deps, req.Path)
     }
     return deps, nil
 }
@@ -271,3 +271,176 @@ pkg7!=7.0.0`,
 		}
 	})
 }
+
+func TestCargoScanner(t *testing.T) {
+	scanner := NewCargoScanner()
+
+	dir := t.TempDir()
+	if scanner.Detect(dir) {
+		t.Error("Should not detect Cargo.toml in empty directory")
+	}
+
+	content := `[package]
+name = "api"
+version = "0.1.0"
+
+[dependencies]
+opentelemetry = "0.27"
+tokio = { version = "1", features = ["full"] }
+json = { package = "serde_json", version = "1.0" }
+shared.workspace = true
+
+[dependencies.opentelemetry_sdk]
+version = "0.27"
+features = ["rt-tokio"]
+
+[dev-dependencies]
+# test helpers
+pretty_assertions = "1"
+`
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !scanner.Detect(dir) {
+		t.Fatal("Expected to detect Cargo.toml")
+	}
+
+	deps, err := scanner.Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"opentelemetry", "tokio", "serde_json", "shared", "opentelemetry_sdk", "pretty_assertions"}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, deps)
+	}
+	for i, exp := range expected {
+		if deps[i] != exp {
+			t.Errorf("Expected dependency %s at %d, got %s", exp, i, deps[i])
+		}
+	}
+}
+
+func TestMavenScanner_FallsBackToGradleKts(t *testing.T) {
+	scanner := NewMavenScanner()
+
+	dir := t.TempDir()
+	if scanner.Detect(dir) {
+		t.Error("Should not detect a JVM build in empty directory")
+	}
+
+	content := `plugins {
+    kotlin("jvm") version "2.0.21"
+}
+
+dependencies {
+    implementation(platform("io.opentelemetry:opentelemetry-bom:1.42.1"))
+    implementation("io.opentelemetry:opentelemetry-api")
+    implementation(group = "io.ktor", name = "ktor-server-netty", version = "2.3.12")
+    testImplementation(kotlin("test"))
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "build.gradle.kts"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !scanner.Detect(dir) {
+		t.Fatal("Expected to detect build.gradle.kts")
+	}
+
+	deps, err := scanner.Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"io.opentelemetry:opentelemetry-bom", "io.opentelemetry:opentelemetry-api", "io.ktor:ktor-server-netty"}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, deps)
+	}
+	for i, exp := range expected {
+		if deps[i] != exp {
+			t.Errorf("Expected dependency %s at %d, got %s", exp, i, deps[i])
+		}
+	}
+}
+
+func TestMixScanner(t *testing.T) {
+	scanner := NewMixScanner()
+
+	dir := t.TempDir()
+	if scanner.Detect(dir) {
+		t.Error("Should not detect mix.exs in empty directory")
+	}
+
+	content := `defmodule Orders.MixProject do
+  use Mix.Project
+
+  def project do
+    [app: :orders, version: "0.1.0", deps: deps()]
+  end
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      # {:commented, "~> 1.0"},
+      {:opentelemetry_api, "~> 1.4"},
+      {:heroicons, github: "tailwindlabs/heroicons", tag: "v2.1.1", app: false}
+    ]
+  end
+end
+`
+	if err := os.WriteFile(filepath.Join(dir, "mix.exs"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !scanner.Detect(dir) {
+		t.Fatal("Expected to detect mix.exs")
+	}
+
+	deps, err := scanner.Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"phoenix", "opentelemetry_api", "heroicons"}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, deps)
+	}
+	for i, exp := range expected {
+		if deps[i] != exp {
+			t.Errorf("Expected dependency %s at %d, got %s", exp, i, deps[i])
+		}
+	}
+}
+
+func TestCppScanner(t *testing.T) {
+	scanner := NewCppScanner()
+
+	dir := t.TempDir()
+	if scanner.Detect(dir) {
+		t.Error("Should not detect C++ manifests in empty directory")
+	}
+
+	files := map[string]string{
+		"vcpkg.json":     `{"name": "checkout", "dependencies": ["fmt", {"name": "opentelemetry-cpp", "features": ["otlp-http"]}]}`,
+		"conanfile.txt":  "[requires]\nspdlog/1.13.0\n",
+		"CMakeLists.txt": "project(checkout)\nfind_package(Threads REQUIRED)\nfind_package(fmt CONFIG REQUIRED)\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !scanner.Detect(dir) {
+		t.Fatal("Expected to detect C++ manifests")
+	}
+
+	deps, err := scanner.Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"fmt", "opentelemetry-cpp", "spdlog", "Threads"}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, deps)
+	}
+	for i, exp := range expected {
+		if deps[i] != exp {
+			t.Errorf("Expected dependency %s at %d, got %s", exp, i, deps[i])
+		}
+	}
+}
@@ -23,10 +23,12 @@ type AIGenerationStrategy struct {
 	logger         logger.Logger
 
 	// Cached context from analysis to enrich agent prompts
-	projectLanguages   []string
-	existingLibraries  []string
-	existingPackages   []string
+	projectLanguages  []string
+	existingLibraries []string
+	existingPackages  []string
+	// Per-directory maps are keyed by detector.AnalysisKey so a directory can hold several languages
 	directoryLanguages map[string]string
+	directoryPaths     map[string]string
 	rootDirectory      string
 	directoryLibraries map[string][]string
 	directoryPackages  map[string][]string
@@ -50,23 +52,25 @@ func (s *AIGenerationStrategy) SetAnalysisContext(analysis *detector.Analysis) {
 	libSet := make(map[string]bool)
 	pkgSet := make(map[string]bool)
 	s.directoryLanguages = make(map[string]string)
+	s.directoryPaths = make(map[string]string)
 	s.directoryLibraries = make(map[string][]string)
 	s.directoryPackages = make(map[string][]string)
-	for directory, dir := range analysis.DirectoryAnalyses {
+	for key, dir := range analysis.DirectoryAnalyses {
 		if dir.Language != "" {
 			langSet[dir.Language] = true
-			s.directoryLanguages[directory] = dir.Language
+			s.directoryLanguages[key] = dir.Language
+			s.directoryPaths[key] = dir.Directory
 		}
 		for _, lib := range dir.Libraries {
 			if lib.Name != "" {
 				libSet[lib.Name] = true
-				s.directoryLibraries[directory] = append(s.directoryLibraries[directory], lib.Name)
+				s.directoryLibraries[key] = append(s.directoryLibraries[key], lib.Name)
 			}
 		}
 		for _, pkg := range dir.Packages {
 			if pkg.Name != "" {
 				pkgSet[pkg.Name] = true
-				s.directoryPackages[directory] = append(s.directoryPackages[directory], pkg.Name)
+				s.directoryPackages[key] = append(s.directoryPackages[key], pkg.Name)
 			}
 		}
 	}
@@ -201,7 +205,8 @@ func (s *AIGenerationStrategy) sendInstructionsToAgent(allInstructions []string,
 
 	// Build per-directory plans for prompt organization
 	var directoryPlans []templates.DirectoryPlan
-	for directory, lang := range s.directoryLanguages {
+	for key, lang := range s.directoryLanguages {
+		directory := s.directoryPaths[key]
 		plan := templates.DirectoryPlan{
 			Directory:         directory,
 			Language:          lang,
@@ -209,11 +214,11 @@ func (s *AIGenerationStrategy) sendInstructionsToAgent(allInstructions []string,
 			RemoveComponents:  make(map[string][]string),
 		}
 
-		if libs := s.directoryLibraries[directory]; len(libs) > 0 {
+		if libs := s.directoryLibraries[key]; len(libs) > 0 {
 			plan.Libraries = libs
 			sort.Strings(plan.Libraries)
 		}
-		if pkgs := s.directoryPackages[directory]; len(pkgs) > 0 {
+		if pkgs := s.directoryPackages[key]; len(pkgs) > 0 {
 			plan.Packages = pkgs
 			sort.Strings(plan.Packages)
 		}
@@ -12,6 +12,7 @@ import (
 	"github.com/getlawrence/cli/internal/codegen/injector"
 	"github.com/getlawrence/cli/internal/codegen/types"
 	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/detector/issues"
 	"github.com/getlawrence/cli/internal/domain"
 	"github.com/getlawrence/cli/internal/logger"
 	"github.com/getlawrence/cli/internal/templates"
@@ -22,6 +23,7 @@ type Generator struct {
 	detector        *detector.CodebaseAnalyzer
 	templateEngine  *templates.TemplateEngine
 	agentDetector   *agents.Detector
+	injector        *injector.CodeInjector
 	strategies      map[types.GenerationMode]types.CodeGenerationStrategy
 	defaultStrategy types.GenerationMode
 	logger          logger.Logger
@@ -39,6 +41,8 @@ func NewGenerator(codebaseAnalyzer *detector.CodebaseAnalyzer, logger logger.Log
 		return nil, fmt.Errorf("failed to initialize agent detector: %w", err)
 	}
 
+	codeInjector := injector.NewCodeInjector(logger)
+
 	// Initialize strategies
 	strategies := make(map[types.GenerationMode]types.CodeGenerationStrategy)
 	strategies[types.AgentMode] = agent.NewAIGenerationStrategy(agentDetector, templateEngine, logger)
@@ -47,7 +51,7 @@ func NewGenerator(codebaseAnalyzer *detector.CodebaseAnalyzer, logger logger.Log
 	strategies[types.TemplateMode] = NewOrchestratedTemplateStrategy(
 		pureTemplate,
 		dependency.NewDependencyWriter(logger),
-		injector.NewCodeInjector(logger),
+		codeInjector,
 		logger,
 	)
 	defaultStrategy := types.TemplateMode
@@ -56,6 +60,7 @@ func NewGenerator(codebaseAnalyzer *detector.CodebaseAnalyzer, logger logger.Log
 		detector:        codebaseAnalyzer,
 		templateEngine:  templateEngine,
 		agentDetector:   agentDetector,
+		injector:        codeInjector,
 		strategies:      strategies,
 		defaultStrategy: defaultStrategy,
 		logger:          logger,
@@ -171,6 +176,21 @@ func (g *Generator) convertIssuesToOpportunities(analysis *detector.Analysis) []
 		addedInstallForDir := false
 		opportunities = append(opportunities, g.createOpportunitiesFromInstrumentations(dirAnalysis)...)
 		for _, issue := range dirAnalysis.Issues {
+			if issue.DetectorID == issues.MissingShutdownDetectorID {
+				// The provider exists; only its shutdown is added, in the languages
+				// whose injector can add it
+				if !g.injector.SupportsCleanup(issue.Language) {
+					continue
+				}
+				opportunities = append(opportunities, domain.Opportunity{
+					Type:       domain.OpportunityAddCleanup,
+					Language:   issue.Language,
+					FilePath:   dirAnalysis.Directory,
+					Suggestion: fmt.Sprintf("Shut down the OpenTelemetry provider constructed at %s:%d when the program exits", issue.File, issue.Line),
+					Issue:      &issue,
+				})
+				continue
+			}
 			switch issue.Category {
 			case domain.CategoryMissingOtel:
 				if !addedInstallForDir {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/getlawrence/cli/internal/codegen/types"
 	det "github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/detector/issues"
 	"github.com/getlawrence/cli/internal/domain"
 	"github.com/getlawrence/cli/internal/logger"
 )
@@ -73,4 +74,61 @@ func TestGenerator_ConvertIssuesToOpportunities(t *testing.T) {
 	if len(filtered) != 1 {
 		t.Fatalf("expected 1 filtered opportunity, got %d", len(filtered))
 	}
+
+	// Providers that are never shut down only get their cleanup
+	analysis = &det.Analysis{DirectoryAnalyses: map[string]*det.DirectoryAnalysis{
+		"svc": {Directory: "svc", Language: "python", Issues: []domain.Issue{{
+			DetectorID: issues.MissingShutdownDetectorID,
+			Category:   domain.CategoryConfiguration,
+			Language:   "python",
+			File:       "/repo/svc/app.py",
+			Line:       5,
+		}}},
+	}}
+	opps = g.convertIssuesToOpportunities(analysis)
+	if len(opps) != 1 || opps[0].Type != domain.OpportunityAddCleanup || opps[0].FilePath != "svc" || opps[0].Issue.Line != 5 {
+		t.Fatalf("expected a cleanup opportunity, got %+v", opps)
+	}
+
+	// Providers of languages without a cleanup injector are left to the report
+	analysis = &det.Analysis{DirectoryAnalyses: map[string]*det.DirectoryAnalysis{
+		"svc": {Directory: "svc", Language: "java", Issues: []domain.Issue{{
+			DetectorID: issues.MissingShutdownDetectorID,
+			Category:   domain.CategoryConfiguration,
+			Language:   "java",
+			File:       "/repo/svc/App.java",
+			Line:       5,
+		}}},
+	}}
+	if opps = g.convertIssuesToOpportunities(analysis); len(opps) != 0 {
+		t.Fatalf("expected no opportunities for java, got %+v", opps)
+	}
+}
+
+// recordingInjector records the providers passed to InjectCleanup
+type recordingInjector struct {
+	EntryPointInjector
+	cleanups map[string][]types.InsertionPoint
+}
+
+func (r *recordingInjector) InjectCleanup(ctx context.Context, filePath string, language string, providers []types.InsertionPoint, req types.GenerationRequest) ([]string, error) {
+	r.cleanups[filePath] = append(r.cleanups[filePath], providers...)
+	return nil, nil
+}
+
+func TestOrchestratedTemplate_AddCleanupsDeduplicatesProviders(t *testing.T) {
+	inj := &recordingInjector{cleanups: make(map[string][]types.InsertionPoint)}
+	s := NewOrchestratedTemplateStrategy(nil, nil, inj, &logger.StdoutLogger{})
+
+	issue := domain.Issue{File: "/repo/pkg/worker.py", Line: 4, Column: 12}
+	other := domain.Issue{File: "/repo/pkg/worker.py", Line: 9, Column: 12}
+	s.addCleanups(context.Background(), []domain.Opportunity{
+		{Type: domain.OpportunityAddCleanup, Language: "python", Issue: &issue},
+		{Type: domain.OpportunityAddCleanup, Language: "python", Issue: &issue},
+		{Type: domain.OpportunityAddCleanup, Language: "python", Issue: &other},
+	}, types.GenerationRequest{})
+
+	if providers := inj.cleanups["/repo/pkg/worker.py"]; len(providers) != 2 {
+		t.Fatalf("expected 2 providers, got %+v", providers)
+	}
 }
@@ -2,13 +2,13 @@ package generator
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/dependency"
 	dependencyTypes "github.com/getlawrence/cli/internal/codegen/dependency/types"
 	"github.com/getlawrence/cli/internal/codegen/types"
+	"github.com/getlawrence/cli/internal/detector"
 	"github.com/getlawrence/cli/internal/domain"
 	"github.com/getlawrence/cli/internal/logger"
 )
@@ -23,8 +23,9 @@ type DependencyManager interface {
 }
 
 type EntryPointInjector interface {
-	DetectEntryPoints(projectPath string, language string) ([]domain.EntryPoint, error)
+	DetectEntryPoints(ctx context.Context, projectPath string, language string) ([]domain.EntryPoint, error)
 	InjectOtelInitialization(ctx context.Context, entryPoint *domain.EntryPoint, operationsData *types.OperationsData, req types.GenerationRequest) ([]string, error)
+	InjectCleanup(ctx context.Context, filePath string, language string, providers []types.InsertionPoint, req types.GenerationRequest) ([]string, error)
 }
 
 type OrchestratedTemplateStrategy struct {
@@ -43,10 +44,25 @@ func (s *OrchestratedTemplateStrategy) IsAvailable() bool          { return true
 func (s *OrchestratedTemplateStrategy) GetRequiredFlags() []string { return []string{} }
 
 func (s *OrchestratedTemplateStrategy) GenerateCode(ctx context.Context, opportunities []domain.Opportunity, req types.GenerationRequest) error {
+	// Cleanups change existing setup code in place and need neither dependencies nor
+	// generated code
+	var cleanups []domain.Opportunity
+	opportunities, cleanups = splitCleanups(opportunities)
+	s.addCleanups(ctx, cleanups, req)
+	if len(opportunities) == 0 {
+		return nil
+	}
+
+	// Projects decide where dependencies are installed and where entry points are searched
+	projects, err := detector.DetectProjects(ctx, req.CodebasePath)
+	if err != nil {
+		s.logger.Logf("Warning: failed to detect projects: %v\n", err)
+	}
+
 	// Compute operations by dir/lang to orchestrate deps/injection
 	dirOpps := groupByDirectory(opportunities)
 	// Apply fallback discovery similar to template strategy so we orchestrate matching work
-	s.addFallbackLanguageOpportunities(req.CodebasePath, dirOpps)
+	s.addFallbackLanguageOpportunities(projects, dirOpps)
 
 	for dir, opps := range dirOpps {
 		byLang := groupByLanguage(opps)
@@ -54,14 +70,19 @@ func (s *OrchestratedTemplateStrategy) GenerateCode(ctx context.Context, opportu
 			normalized := normalizeLanguage(lang)
 			ops := analyze(langOpps)
 
+			// Work happens in the owning project; directories without one are handled in place
+			projectPath := req.CodebasePath
+			if dir != "root" {
+				projectPath = filepath.Join(req.CodebasePath, dir)
+			}
+			projectCtx := ctx
+			if project := owningProject(projects, dir, normalized); project != nil {
+				projectPath = project.Path
+				projectCtx = detector.WithProjectScope(ctx, projects, project)
+			}
+
 			// Dependencies
 			if ops.InstallOTEL || len(ops.InstallInstrumentations) > 0 || len(ops.InstallComponents) > 0 {
-				projectPath := req.CodebasePath
-				// For most languages, dependencies are managed at the project root
-				// Only use subdirectory for languages that support nested dependency management
-				if dir != "root" && !isProjectRootDependencyLanguage(normalized) {
-					projectPath = filepath.Join(req.CodebasePath, dir)
-				}
 				if req.Config.DryRun {
 					// Try to get enhanced dependencies with versions first
 					if enhancedDeps, err := s.deps.GetEnhancedDependencies(normalized, ops); err == nil && len(enhancedDeps) > 0 {
@@ -92,11 +113,7 @@ func (s *OrchestratedTemplateStrategy) GenerateCode(ctx context.Context, opportu
 
 			// Inject OTEL initialization into entry point when planned
 			if ops.InstallOTEL || len(ops.InstallInstrumentations) > 0 || len(ops.InstallComponents) > 0 {
-				dirPath := req.CodebasePath
-				if dir != "root" {
-					dirPath = filepath.Join(req.CodebasePath, dir)
-				}
-				eps, _ := s.inj.DetectEntryPoints(dirPath, normalized)
+				eps, _ := s.inj.DetectEntryPoints(projectCtx, projectPath, normalized)
 				if len(eps) > 0 {
 					// Choose best by confidence
 					best := eps[0]
@@ -117,8 +134,65 @@ func (s *OrchestratedTemplateStrategy) GenerateCode(ctx context.Context, opportu
 	return s.tmpl.GenerateCode(ctx, opportunities, req)
 }
 
+// addCleanups shuts down the providers located by the cleanup opportunities' issues,
+// one file at a time so that line numbers stay valid
+func (s *OrchestratedTemplateStrategy) addCleanups(ctx context.Context, cleanups []domain.Opportunity, req types.GenerationRequest) {
+	type fileCleanup struct {
+		language  string
+		providers []types.InsertionPoint
+	}
+	byFile := make(map[string]*fileCleanup)
+	var files []string
+	// The same provider may be reported by several directory analyses
+	type provider struct {
+		file         string
+		line, column int
+	}
+	seen := make(map[provider]bool)
+	for _, opp := range cleanups {
+		if opp.Issue == nil || opp.Issue.File == "" {
+			continue
+		}
+		key := provider{opp.Issue.File, opp.Issue.Line, opp.Issue.Column}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		file, ok := byFile[opp.Issue.File]
+		if !ok {
+			file = &fileCleanup{language: normalizeLanguage(opp.Language)}
+			byFile[opp.Issue.File] = file
+			files = append(files, opp.Issue.File)
+		}
+		file.providers = append(file.providers, types.InsertionPoint{
+			LineNumber: uint32(opp.Issue.Line),
+			Column:     uint32(opp.Issue.Column),
+			Context:    opp.Issue.Title,
+		})
+	}
+
+	for _, path := range files {
+		file := byFile[path]
+		if _, err := s.inj.InjectCleanup(ctx, path, file.language, file.providers, req); err != nil {
+			s.logger.Logf("Warning: failed to add provider shutdown for %s: %v\n", file.language, err)
+		}
+	}
+}
+
 // Helpers (duplicated minimal logic from template for orchestration)
 
+// splitCleanups separates cleanup opportunities from the others
+func splitCleanups(opps []domain.Opportunity) (rest, cleanups []domain.Opportunity) {
+	for _, o := range opps {
+		if o.Type == domain.OpportunityAddCleanup {
+			cleanups = append(cleanups, o)
+		} else {
+			rest = append(rest, o)
+		}
+	}
+	return rest, cleanups
+}
+
 func groupByDirectory(opps []domain.Opportunity) map[string][]domain.Opportunity {
 	grouped := make(map[string][]domain.Opportunity)
 	for _, o := range opps {
@@ -143,6 +217,8 @@ func normalizeLanguage(language string) string {
 	switch strings.ToLower(language) {
 	case "js", "node", "nodejs":
 		return "javascript"
+	case "ts":
+		return "typescript"
 	case "csharp":
 		return "dotnet"
 	default:
@@ -150,14 +226,18 @@ func normalizeLanguage(language string) string {
 	}
 }
 
-// isProjectRootDependencyLanguage returns true for languages where dependencies are managed at project root
-func isProjectRootDependencyLanguage(language string) bool {
-	switch language {
-	case "java", "csharp", "dotnet", "go", "php", "ruby":
-		return true
-	default:
-		return false
+// owningProject returns the project of the normalized language that owns dir
+func owningProject(projects []*detector.Project, dir, language string) *detector.Project {
+	var matching []*detector.Project
+	for _, p := range projects {
+		if normalizeLanguage(p.Language) == language {
+			matching = append(matching, p)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
 	}
+	return detector.OwningProject(matching, dir, matching[0].Language)
 }
 
 func analyze(opps []domain.Opportunity) *types.OperationsData {
@@ -184,59 +264,20 @@ func analyze(opps []domain.Opportunity) *types.OperationsData {
 	return data
 }
 
-// addFallbackLanguageOpportunities mirrors the template strategy's fallback to discover language dirs
-func (s *OrchestratedTemplateStrategy) addFallbackLanguageOpportunities(root string, dirOpps map[string][]domain.Opportunity) {
-	entries, err := os.ReadDir(root)
-	if err != nil {
-		return
-	}
-	langByDir := map[string]string{"python": "python", "php": "php", "ruby": "ruby", "go": "go", "js": "javascript", "javascript": "javascript", "csharp": "dotnet", "dotnet": "dotnet", "java": "java"}
-	for _, e := range entries {
-		if !e.IsDir() {
-			continue
-		}
-		name := strings.ToLower(e.Name())
+// addFallbackLanguageOpportunities mirrors the template strategy's fallback to discover language dirs:
+// top-level directories named after a language that hold a project of that language
+func (s *OrchestratedTemplateStrategy) addFallbackLanguageOpportunities(projects []*detector.Project, dirOpps map[string][]domain.Opportunity) {
+	langByDir := map[string]string{"python": "python", "php": "php", "ruby": "ruby", "go": "go", "js": "javascript", "javascript": "javascript", "ts": "typescript", "typescript": "typescript", "csharp": "dotnet", "dotnet": "dotnet", "java": "java", "kotlin": "kotlin", "rust": "rust", "elixir": "elixir", "cpp": "cpp"}
+	for _, p := range projects {
+		name := strings.ToLower(p.Directory)
 		lang, ok := langByDir[name]
-		if !ok {
-			continue
-		}
-		if _, exists := dirOpps[name]; exists {
+		if !ok || normalizeLanguage(p.Language) != lang {
 			continue
 		}
-		subdir := filepath.Join(root, name)
-		if !seemsLikeProjectDir(subdir, lang) {
+		if _, exists := dirOpps[p.Directory]; exists {
 			continue
 		}
-		opp := domain.Opportunity{Type: domain.OpportunityInstallOTEL, Language: lang, FilePath: name}
-		dirOpps[name] = []domain.Opportunity{opp}
-	}
-}
-
-func seemsLikeProjectDir(dir string, lang string) bool {
-	lang = strings.ToLower(lang)
-	checks := map[string][]string{
-		"python":     {"requirements.txt", "app.py", "main.py"},
-		"php":        {"composer.json", "index.php"},
-		"ruby":       {"Gemfile", "app.rb"},
-		"go":         {"go.mod", "main.go"},
-		"javascript": {"package.json", "index.js"},
-		"dotnet":     {"*.csproj", "Program.cs"},
-		"java":       {"pom.xml", "build.gradle", "build.gradle.kts"},
-	}
-	markers := checks[lang]
-	if len(markers) == 0 {
-		return false
-	}
-	for _, m := range markers {
-		if strings.Contains(m, "*") {
-			if matches, _ := filepath.Glob(filepath.Join(dir, m)); len(matches) > 0 {
-				return true
-			}
-			continue
-		}
-		if _, err := os.Stat(filepath.Join(dir, m)); err == nil {
-			return true
-		}
+		opp := domain.Opportunity{Type: domain.OpportunityInstallOTEL, Language: lang, FilePath: p.Directory}
+		dirOpps[p.Directory] = []domain.Opportunity{opp}
 	}
-	return false
 }
@@ -8,22 +8,36 @@ var supportedLanguageExtensions = map[string]string{
 	"python":     "py",
 	"go":         "go",
 	"javascript": "js",
+	"typescript": "ts",
 	"java":       "java",
+	"kotlin":     "kt",
 	"csharp":     "cs",
 	"dotnet":     "cs",
 	"ruby":       "rb",
 	"php":        "php",
+	"rust":       "rs",
+	"elixir":     "exs",
+	"cpp":        "cc",
 }
 
 // getOutputFilenameForLanguage returns the output filename for a given language.
 // Most languages use the convention "otel.{ext}".
 // For languages with identifier constraints (e.g., Java, C#), we use "Otel.{ext}".
+// Elixir is configured rather than bootstrapped in code, so it targets config/runtime.exs.
+// C++ gets an otel_init.cc/.h pair; see cppHeaderFilename.
 func getOutputFilenameForLanguage(language string) string {
 	switch language {
 	case "java":
 		return "telemetry/Otel.java"
+	case "kotlin":
+		return "telemetry/Otel.kt"
 	case "dotnet", "csharp":
 		return "Otel.cs"
+	case "elixir":
+		// Mix evaluates runtime configuration when the release or application boots
+		return "config/runtime.exs"
+	case "cpp":
+		return "otel_init.cc"
 	default:
 		if ext, ok := supportedLanguageExtensions[language]; ok {
 			return "otel." + ext
@@ -32,6 +46,10 @@ func getOutputFilenameForLanguage(language string) string {
 	}
 }
 
+// cppHeaderFilename is the header declaring the functions of otel_init.cc; it is generated
+// next to it from the cpp_header template
+const cppHeaderFilename = "otel_init.h"
+
 // getSupportedLanguages returns all supported language identifiers in a stable order.
 func getSupportedLanguages() []string {
 	languages := make([]string, 0, len(supportedLanguageExtensions))
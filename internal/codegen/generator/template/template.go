@@ -11,6 +11,7 @@ import (
 	"github.com/getlawrence/cli/internal/domain"
 	"github.com/getlawrence/cli/internal/logger"
 	"github.com/getlawrence/cli/internal/templates"
+	"github.com/getlawrence/cli/internal/tsconfig"
 )
 
 const (
@@ -168,34 +169,68 @@ func (s *TemplateGenerationStrategy) generateCodeWithLanguage(
 		}
 	}
 
-	// Generate code using template
-	code, err := s.templateEngine.GenerateInstructions(language, data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate %s code: %w", language, err)
-	}
-
 	// Determine output directory and filename
 	var outputDir string
-	if language == "java" {
+	switch language {
+	case "java":
 		outputDir = s.determineJavaOutputDirectory(req, directory)
-	} else {
+	case "kotlin":
+		outputDir = s.determineKotlinOutputDirectory(req, directory)
+	case "typescript":
+		outputDir = s.determineTypeScriptOutputDirectory(req, directory, &data)
+	case "rust":
+		outputDir = s.determineRustOutputDirectory(req, directory)
+	case "cpp":
+		outputDir = s.determineCppOutputDirectory(req, directory)
+	default:
 		outputDir = s.determineOutputDirectory(req, directory)
 	}
 	filename := getOutputFilenameForLanguage(language)
 	outputPath := filepath.Join(outputDir, filename)
 
+	// Generate code using template
+	code, err := s.templateEngine.GenerateInstructions(language, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s code: %w", language, err)
+	}
+
+	if language == "elixir" {
+		var changed bool
+		if code, changed = s.mergeElixirRuntimeConfig(outputPath, code); !changed {
+			s.logger.Logf("OpenTelemetry exporter is already configured in %s\n", outputPath)
+			return []string{}, nil
+		}
+	}
+
+	outputs := map[string]string{outputPath: code}
+	paths := []string{outputPath}
+	if language == "cpp" {
+		// main includes the header declaring InitTelemetry
+		header, err := s.templateEngine.GenerateInstructions("cpp_header", data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s header: %w", language, err)
+		}
+		headerPath := filepath.Join(outputDir, cppHeaderFilename)
+		outputs[headerPath] = header
+		paths = append(paths, headerPath)
+	}
+
 	if req.Config.DryRun {
 		s.logger.Logf("Generated %s instrumentation code (dry run):\n", language)
-		s.logger.Logf(dryRunOutputFormat, outputPath)
-		s.logger.Logf(dryRunContentFormat, code)
-		return []string{outputPath}, nil
+		for _, path := range paths {
+			s.logger.Logf(dryRunOutputFormat, path)
+			s.logger.Logf(dryRunContentFormat, outputs[path])
+		}
+		return paths, nil
 	}
 
-	if err := s.writeCodeToFile(outputPath, code); err != nil {
-		return nil, fmt.Errorf("failed to write %s code to %s: %w", language, outputPath, err)
+	for _, path := range paths {
+		if err := s.writeCodeToFile(path, outputs[path]); err != nil {
+			return nil, fmt.Errorf("failed to write %s code to %s: %w", language, path, err)
+		}
 	}
 
-	return []string{outputPath}, nil
+	return paths, nil
 }
 
 // determineServiceName extracts service name from codebase path
@@ -241,6 +276,87 @@ func (s *TemplateGenerationStrategy) determineJavaOutputDirectory(req types.Gene
 	return baseDir
 }
 
+// determineKotlinOutputDirectory prefers src/main/kotlin and falls back to src/main/java,
+// which Kotlin JVM projects may also use for .kt sources
+func (s *TemplateGenerationStrategy) determineKotlinOutputDirectory(req types.GenerationRequest, directory string) string {
+	baseDir := s.determineOutputDirectory(req, directory)
+
+	for _, sourceDir := range []string{"kotlin", "java"} {
+		kotlinSourceDir := filepath.Join(baseDir, "src", "main", sourceDir)
+		if _, err := os.Stat(kotlinSourceDir); err == nil {
+			return kotlinSourceDir
+		}
+	}
+
+	return baseDir
+}
+
+// determineRustOutputDirectory places otel.rs next to main.rs so `mod otel;` resolves
+func (s *TemplateGenerationStrategy) determineRustOutputDirectory(req types.GenerationRequest, directory string) string {
+	baseDir := s.determineOutputDirectory(req, directory)
+
+	// Cargo crates keep their sources under src/
+	rustSourceDir := filepath.Join(baseDir, "src")
+	if _, err := os.Stat(rustSourceDir); err == nil {
+		return rustSourceDir
+	}
+
+	return baseDir
+}
+
+// determineCppOutputDirectory places the otel_init.cc/.h pair in src/ when the project keeps
+// its sources there, so `#include "otel_init.h"` resolves from main's directory
+func (s *TemplateGenerationStrategy) determineCppOutputDirectory(req types.GenerationRequest, directory string) string {
+	baseDir := s.determineOutputDirectory(req, directory)
+
+	cppSourceDir := filepath.Join(baseDir, "src")
+	if _, err := os.Stat(cppSourceDir); err == nil {
+		return cppSourceDir
+	}
+
+	return baseDir
+}
+
+// determineTypeScriptOutputDirectory places otel.ts in the tsconfig rootDir so tsc compiles it
+// with the other sources, and records how node should preload the emitted JavaScript
+func (s *TemplateGenerationStrategy) determineTypeScriptOutputDirectory(req types.GenerationRequest, directory string, data *templates.TemplateData) string {
+	baseDir := s.determineOutputDirectory(req, directory)
+	root := req.CodebasePath
+	if req.Config.OutputDirectory != "" {
+		root = req.Config.OutputDirectory
+	}
+
+	cfg, err := tsconfig.Find(baseDir, root)
+	if err != nil {
+		s.logger.Logf("Warning: %v\n", err)
+	}
+	if cfg == nil {
+		return baseDir
+	}
+
+	outputDir := cfg.SourceDir()
+	data.PreloadFlag = cfg.PreloadFlag()
+	data.PreloadPath = cfg.EmittedPath(filepath.Join(outputDir, getOutputFilenameForLanguage("typescript")))
+	s.logger.Logf("Load the compiled bootstrap with: node %s %s\n", data.PreloadFlag, data.PreloadPath)
+	return outputDir
+}
+
+// mergeElixirRuntimeConfig appends the generated OpenTelemetry configuration to an existing
+// config/runtime.exs, which already imports Config. It returns false when the file configures
+// :opentelemetry_exporter already.
+func (s *TemplateGenerationStrategy) mergeElixirRuntimeConfig(path, code string) (string, bool) {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return code, true
+	}
+	if strings.Contains(string(existing), ":opentelemetry_exporter") {
+		return "", false
+	}
+	code = strings.TrimLeft(strings.TrimPrefix(code, "import Config"), "\n")
+	merged := strings.TrimRight(string(existing), "\n") + "\n\n" + code
+	return merged, true
+}
+
 func (s *TemplateGenerationStrategy) writeCodeToFile(filePath, content string) error {
 	// Ensure directory exists
 	dir := filepath.Dir(filePath)
@@ -359,9 +475,15 @@ func (s *TemplateGenerationStrategy) addFallbackLanguageOpportunities(root strin
 		"go":         "go",
 		"js":         "javascript",
 		"javascript": "javascript",
+		"ts":         "typescript",
+		"typescript": "typescript",
 		"csharp":     "dotnet",
 		"dotnet":     "dotnet",
 		"java":       "java",
+		"kotlin":     "kotlin",
+		"rust":       "rust",
+		"elixir":     "elixir",
+		"cpp":        "cpp",
 	}
 	for _, e := range entries {
 		if !e.IsDir() {
@@ -396,8 +518,13 @@ func (s *TemplateGenerationStrategy) seemsLikeProjectDir(dir string, lang string
 		"ruby":       {"Gemfile", "app.rb"},
 		"go":         {"go.mod", "main.go"},
 		"javascript": {"package.json", "index.js"},
+		"typescript": {"tsconfig.json", "index.ts"},
 		"dotnet":     {"*.csproj", "Program.cs"},
 		"java":       {"pom.xml", "build.gradle", "build.gradle.kts"},
+		"kotlin":     {"build.gradle.kts", "build.gradle", "pom.xml"},
+		"rust":       {"Cargo.toml"},
+		"elixir":     {"mix.exs"},
+		"cpp":        {"CMakeLists.txt", "vcpkg.json", "conanfile.txt", "conanfile.py"},
 	}
 	markers := checks[lang]
 	if len(markers) == 0 {
@@ -423,6 +550,16 @@ func normalizeLanguageForGeneration(language string) string {
 	switch strings.ToLower(language) {
 	case "js", "node", "nodejs":
 		return "javascript"
+	case "ts":
+		return "typescript"
+	case "kt":
+		return "kotlin"
+	case "rs":
+		return "rust"
+	case "ex", "exs":
+		return "elixir"
+	case "c++", "cxx", "cc":
+		return "cpp"
 	case "csharp":
 		return "dotnet"
 	default:
@@ -457,12 +594,17 @@ func (s *TemplateGenerationStrategy) findLanguageProjectDir(root, language strin
 	keyFiles := map[string][]string{
 		"ruby":       {"Gemfile"},
 		"php":        {"composer.json"},
-		"python":     {"requirements.txt", "pyproject.toml"},
+		"python":     {"requirements.txt", "pyproject.toml", "Pipfile", "setup.cfg"},
 		"go":         {"go.mod"},
 		"java":       {"pom.xml", "build.gradle", "build.gradle.kts"},
+		"kotlin":     {"build.gradle.kts", "build.gradle", "pom.xml"},
 		"dotnet":     {".csproj"},
 		"csharp":     {".csproj"},
 		"javascript": {"package.json"},
+		"typescript": {"tsconfig.json"},
+		"rust":       {"Cargo.toml"},
+		"elixir":     {"mix.exs"},
+		"cpp":        {"CMakeLists.txt", "vcpkg.json", "conanfile.txt", "conanfile.py"},
 	}
 	wanted := keyFiles[language]
 	if len(wanted) == 0 {
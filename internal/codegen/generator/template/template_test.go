@@ -164,3 +164,185 @@ func TestGenerateCode_FallbackLanguageDirectories_DryRun(t *testing.T) {
 		t.Fatalf("expected fallback to trigger python generation, got calls: %+v", fte.calls)
 	}
 }
+
+func TestGenerateCode_TypeScriptFollowsTsconfig(t *testing.T) {
+	flog := &fakeLogger{}
+	fte := &fakeTemplateEngine{}
+	strat := &TemplateGenerationStrategy{logger: flog, templateEngine: fte}
+
+	root := t.TempDir()
+	webDir := filepath.Join(root, "web")
+	if err := os.MkdirAll(filepath.Join(webDir, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tsconfig := `{
+  // compiled to ES modules
+  "compilerOptions": { "module": "ESNext", "rootDir": "./src", "outDir": "./dist" },
+}`
+	if err := os.WriteFile(filepath.Join(webDir, "tsconfig.json"), []byte(tsconfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opps := []domain.Opportunity{{Type: domain.OpportunityInstallOTEL, Language: "TypeScript", FilePath: "web"}}
+	req := types.GenerationRequest{CodebasePath: root, Config: types.StrategyConfig{DryRun: true}}
+	if err := strat.GenerateCode(context.Background(), opps, req); err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+
+	if len(fte.calls) != 1 || fte.calls[0].lang != "typescript" {
+		t.Fatalf("expected one typescript render, got %+v", fte.calls)
+	}
+	data := fte.calls[0].data
+	if data.PreloadFlag != "--import" || data.PreloadPath != "./dist/otel.js" {
+		t.Fatalf("unexpected preload hints: %q %q", data.PreloadFlag, data.PreloadPath)
+	}
+	joined := strings.Join(flog.logs, "\n")
+	if want := filepath.Join(webDir, "src", "otel.ts"); !strings.Contains(joined, want) {
+		t.Fatalf("expected otel.ts in the tsconfig rootDir %s, logs: %s", want, joined)
+	}
+}
+
+func TestGenerateCode_RustWritesNextToMain(t *testing.T) {
+	flog := &fakeLogger{}
+	fte := &fakeTemplateEngine{}
+	strat := &TemplateGenerationStrategy{logger: flog, templateEngine: fte}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "engine", "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	opps := []domain.Opportunity{{Type: domain.OpportunityInstallOTEL, Language: "Rust", FilePath: "engine"}}
+	req := types.GenerationRequest{CodebasePath: root, Config: types.StrategyConfig{DryRun: true}}
+	if err := strat.GenerateCode(context.Background(), opps, req); err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+
+	if len(fte.calls) != 1 || fte.calls[0].lang != "rust" {
+		t.Fatalf("expected one rust render, got %+v", fte.calls)
+	}
+	joined := strings.Join(flog.logs, "\n")
+	if want := filepath.Join(root, "engine", "src", "otel.rs"); !strings.Contains(joined, want) {
+		t.Fatalf("expected otel.rs in the crate's src directory %s, logs: %s", want, joined)
+	}
+}
+
+func TestGenerateCode_KotlinWritesToKotlinSourceRoot(t *testing.T) {
+	flog := &fakeLogger{}
+	fte := &fakeTemplateEngine{}
+	strat := &TemplateGenerationStrategy{logger: flog, templateEngine: fte}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "orders", "src", "main", "kotlin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	opps := []domain.Opportunity{{Type: domain.OpportunityInstallOTEL, Language: "Kotlin", FilePath: "orders"}}
+	req := types.GenerationRequest{CodebasePath: root, Config: types.StrategyConfig{DryRun: true}}
+	if err := strat.GenerateCode(context.Background(), opps, req); err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+
+	if len(fte.calls) != 1 || fte.calls[0].lang != "kotlin" {
+		t.Fatalf("expected one kotlin render, got %+v", fte.calls)
+	}
+	joined := strings.Join(flog.logs, "\n")
+	if want := filepath.Join(root, "orders", "src", "main", "kotlin", "telemetry", "Otel.kt"); !strings.Contains(joined, want) {
+		t.Fatalf("expected Otel.kt under src/main/kotlin %s, logs: %s", want, joined)
+	}
+}
+
+func TestGenerateCode_ElixirMergesRuntimeConfig(t *testing.T) {
+	engine, err := templates.NewTemplateEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	strat := &TemplateGenerationStrategy{logger: &fakeLogger{}, templateEngine: engine}
+
+	root := t.TempDir()
+	runtime := filepath.Join(root, "orders", "config", "runtime.exs")
+	if err := os.MkdirAll(filepath.Dir(runtime), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existing := "import Config\n\nif config_env() == :prod do\n  config :orders, Orders.Repo, url: System.get_env(\"DATABASE_URL\")\nend\n"
+	if err := os.WriteFile(runtime, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opps := []domain.Opportunity{{Type: domain.OpportunityInstallOTEL, Language: "elixir", FilePath: "orders"}}
+	req := types.GenerationRequest{CodebasePath: root}
+	if err := strat.GenerateCode(context.Background(), opps, req); err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+
+	out, err := os.ReadFile(runtime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(out)
+	if !strings.HasPrefix(content, existing+"\n# OpenTelemetry configuration") || strings.Count(content, "import Config") != 1 {
+		t.Fatalf("expected the configuration appended to runtime.exs, got:\n%s", content)
+	}
+	for _, want := range []string{"traces_exporter: :otlp", "config :opentelemetry_exporter,", "otlp_protocol: :http_protobuf"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in runtime.exs, got:\n%s", want, content)
+		}
+	}
+
+	// Generating again leaves a configured exporter alone
+	if err := strat.GenerateCode(context.Background(), opps, req); err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+	again, _ := os.ReadFile(runtime)
+	if string(again) != content {
+		t.Fatalf("expected runtime.exs to be unchanged, got:\n%s", again)
+	}
+}
+
+func TestGenerateCode_CppWritesSourceAndHeader(t *testing.T) {
+	engine, err := templates.NewTemplateEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	strat := &TemplateGenerationStrategy{logger: &fakeLogger{}, templateEngine: engine}
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "checkout", "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	opps := []domain.Opportunity{{Type: domain.OpportunityInstallOTEL, Language: "cpp", FilePath: "checkout"}}
+	otel := &types.OTELConfig{}
+	otel.Exporters.Traces.Type = "otlp"
+	otel.Exporters.Traces.Endpoint = "http://collector:4318"
+	otel.Exporters.Traces.Headers = map[string]string{"x-api-key": "secret"}
+	req := types.GenerationRequest{CodebasePath: root, OTEL: otel}
+	if err := strat.GenerateCode(context.Background(), opps, req); err != nil {
+		t.Fatalf("GenerateCode error: %v", err)
+	}
+
+	source, err := os.ReadFile(filepath.Join(root, "checkout", "src", "otel_init.cc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`#include "otel_init.h"`,
+		"OtlpHttpExporterFactory::Create(options)",
+		`std::string endpoint = "http://collector:4318";`,
+		`options.http_headers.insert(std::make_pair("x-api-key", "secret"));`,
+		"BatchSpanProcessorFactory::Create(std::move(exporter), batch_options)",
+		"trace_api::Provider::SetTracerProvider(api_provider);",
+		"new trace_api::propagation::HttpTraceContext()",
+	} {
+		if !strings.Contains(string(source), want) {
+			t.Fatalf("expected %q in otel_init.cc, got:\n%s", want, source)
+		}
+	}
+	header, err := os.ReadFile(filepath.Join(root, "checkout", "src", "otel_init.h"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(header), "void InitTelemetry();") || !strings.Contains(string(header), "void ShutdownTelemetry();") {
+		t.Fatalf("expected the otel_init declarations, got:\n%s", header)
+	}
+}
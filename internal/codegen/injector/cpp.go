@@ -0,0 +1,222 @@
+package injector
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/types"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/cpp"
+)
+
+// cppMainIndentRe matches the indentation of the first statement in main
+var cppMainIndentRe = regexp.MustCompile(`\bmain\s*\([^)]*\)[^{;]*\{[ \t]*\r?\n(?:[ \t]*\r?\n)*([ \t]+)\S`)
+
+// CppInjector implements LanguageInjector for C++. The generated otel_init.cc/.h pair is
+// included by the file defining int main, which initializes telemetry before anything else.
+type CppInjector struct {
+	config *types.LanguageConfig
+}
+
+// NewCppInjector creates a new C++ language handler
+func NewCppInjector() *CppInjector {
+	return &CppInjector{
+		config: &types.LanguageConfig{
+			Language:       "cpp",
+			FileExtensions: []string{".cc", ".cpp", ".cxx", ".c++"},
+			ImportQueries: map[string]string{
+				// Only top-level includes: includes under #ifdef are platform specific
+				"existing_imports": `
+                (translation_unit (preproc_include path: (_) @include_path) @include_location)
+            `,
+			},
+			FunctionQueries: map[string]string{
+				"main_function": `
+                (function_definition
+                  declarator: (function_declarator
+                    declarator: (identifier) @fn_name)
+                  body: (compound_statement) @main_body
+                  (#eq? @fn_name "main"))
+            `,
+			},
+			InsertionQueries: map[string]string{
+				"optimal_insertion": `
+                (compound_statement) @function_start
+            `,
+			},
+			ImportTemplate:         `#include "%s"`,
+			InitializationTemplate: `    otel_init::InitTelemetry();`,
+			CleanupTemplate:        `otel_init::ShutdownTelemetry();`,
+		},
+	}
+}
+
+// ForFile returns a handler whose initialization matches the indentation of main's body
+func (h *CppInjector) ForFile(filePath string) LanguageInjector {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return h
+	}
+	m := cppMainIndentRe.FindSubmatch(content)
+	if m == nil {
+		return h
+	}
+	config := *h.config
+	config.InitializationTemplate = string(m[1]) + strings.TrimLeft(h.config.InitializationTemplate, " ")
+	return &CppInjector{config: &config}
+}
+
+// GetLanguage returns the tree-sitter language parser for C++
+func (h *CppInjector) GetLanguage() *sitter.Language { return cpp.GetLanguage() }
+
+// GetConfig returns the language configuration
+func (h *CppInjector) GetConfig() *types.LanguageConfig { return h.config }
+
+// GetRequiredImports returns the headers the entry point must include: the generated otel_init.h
+func (h *CppInjector) GetRequiredImports() []string {
+	return []string{"otel_init.h"}
+}
+
+// GetFrameworkImports returns framework-specific imports based on detected frameworks
+func (h *CppInjector) GetFrameworkImports(content []byte) []string {
+	// Instrumentation is set up by the generated otel_init.cc
+	return []string{}
+}
+
+// FormatFrameworkImports formats framework-specific import statements for C++
+func (h *CppInjector) FormatFrameworkImports(imports []string) string {
+	return ""
+}
+
+// GenerateFrameworkModifications generates framework-specific instrumentation modifications for C++
+func (h *CppInjector) GenerateFrameworkModifications(content []byte, operationsData *types.OperationsData) []types.CodeModification {
+	return []types.CodeModification{}
+}
+
+// FormatImports formats C++ include directives
+func (h *CppInjector) FormatImports(imports []string, hasExistingImports bool) string {
+	if len(imports) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, imp := range imports {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(h.FormatSingleImport(imp))
+	}
+	return b.String()
+}
+
+// FormatSingleImport formats a single C++ include directive
+func (h *CppInjector) FormatSingleImport(importPath string) string {
+	return fmt.Sprintf(h.config.ImportTemplate, importPath)
+}
+
+// AnalyzeImportCapture records included headers. The import location is kept at the last
+// top-level include so otel_init.h joins the existing includes.
+func (h *CppInjector) AnalyzeImportCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis) {
+	switch captureName {
+	case "include_path":
+		path := strings.Trim(node.Content(content), `"<>`)
+		analysis.ExistingImports[path] = true
+		if strings.HasPrefix(path, "opentelemetry/") {
+			analysis.HasOTELImports = true
+		}
+	case "include_location":
+		// preproc_include ends at the start of the next line, so anchor on its first line
+		point := types.InsertionPoint{
+			LineNumber: node.StartPoint().Row + 1,
+			Column:     1,
+			Context:    strings.TrimSpace(node.Content(content)),
+			Priority:   2,
+		}
+		if len(analysis.ImportLocations) == 0 {
+			analysis.ImportLocations = append(analysis.ImportLocations, point)
+		} else if point.LineNumber > analysis.ImportLocations[0].LineNumber {
+			analysis.ImportLocations[0] = point
+		}
+	}
+}
+
+// AnalyzeFunctionCapture records int main
+func (h *CppInjector) AnalyzeFunctionCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis, config *types.LanguageConfig) {
+	if captureName != "main_body" {
+		return
+	}
+	analysis.EntryPoints = append(analysis.EntryPoints, types.EntryPointInfo{
+		Name:       "main",
+		LineNumber: node.StartPoint().Row + 1,
+		Column:     node.StartPoint().Column + 1,
+		// Initialize on the line after the opening brace
+		BodyStart: types.InsertionPoint{
+			LineNumber: node.StartPoint().Row + 1,
+			Column:     node.StartPoint().Column + 1,
+			Priority:   h.GetInsertionPointPriority("function_start"),
+		},
+		BodyEnd: types.InsertionPoint{
+			LineNumber: node.EndPoint().Row + 1,
+			Column:     node.EndPoint().Column + 1,
+		},
+		HasOTELSetup: h.detectExistingOTELSetup(node, content),
+	})
+}
+
+// GetInsertionPointPriority returns priority for C++ insertion point types
+func (h *CppInjector) GetInsertionPointPriority(captureName string) int {
+	switch captureName {
+	case "function_start":
+		return 100
+	default:
+		return 1
+	}
+}
+
+// detectExistingOTELSetup checks if main already initializes OpenTelemetry
+func (h *CppInjector) detectExistingOTELSetup(node *sitter.Node, content []byte) bool {
+	body := node.Content(content)
+	return strings.Contains(body, "InitTelemetry(") ||
+		strings.Contains(body, "TracerProviderFactory") ||
+		strings.Contains(body, "SetTracerProvider")
+}
+
+// FallbackAnalyzeImports places the include after the leading comment block (license
+// headers) when the file has no includes yet
+func (h *CppInjector) FallbackAnalyzeImports(content []byte, analysis *types.FileAnalysis) {
+	lines := strings.Split(string(content), "\n")
+	after := 0
+	inBlock := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inBlock:
+			inBlock = !strings.Contains(trimmed, "*/")
+		case strings.HasPrefix(trimmed, "/*"):
+			inBlock = !strings.Contains(trimmed, "*/")
+		case strings.HasPrefix(trimmed, "//"):
+		default:
+			analysis.ImportLocations = append(analysis.ImportLocations, types.InsertionPoint{
+				LineNumber: uint32(after),
+				Column:     1,
+				Priority:   1,
+			})
+			return
+		}
+		after = i + 1
+	}
+	analysis.ImportLocations = append(analysis.ImportLocations, types.InsertionPoint{
+		LineNumber: uint32(after),
+		Column:     1,
+		Priority:   1,
+	})
+}
+
+// FallbackAnalyzeEntryPoints: no-op for C++; only int main is an entry point
+func (h *CppInjector) FallbackAnalyzeEntryPoints(content []byte, analysis *types.FileAnalysis) {}
+
+// GenerateImportModifications generates modifications to fix import statements
+func (h *CppInjector) GenerateImportModifications(content []byte, analysis *types.FileAnalysis) []types.CodeModification {
+	return []types.CodeModification{}
+}
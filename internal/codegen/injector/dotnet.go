@@ -47,7 +47,8 @@ func NewDotNetInjector() *DotNetInjector {
     // Initialize OpenTelemetry via generated bootstrap
     Otel.Configure(builder.Services);
 `,
-			CleanupTemplate: `// no-op`,
+			// Prefixed to the declaration of a provider so it is disposed when the program ends
+			CleanupTemplate: `using `,
 		},
 	}
 }
@@ -204,3 +205,42 @@ func (h *DotNetInjector) GenerateImportModifications(content []byte, analysis *t
 	// No special import handling needed for C#
 	return []types.CodeModification{}
 }
+
+// GetCleanupImports returns no imports; using declarations need none
+func (h *DotNetInjector) GetCleanupImports() []string { return nil }
+
+// GenerateCleanupModifications declares a provider built in Main or in top-level
+// statements with using, so it is disposed when the program ends. Providers declared
+// elsewhere would be disposed too early and are left alone.
+func (h *DotNetInjector) GenerateCleanupModifications(node *sitter.Node, content []byte) []types.CodeModification {
+	for ; node != nil; node = node.Parent() {
+		if node.Type() != "local_declaration_statement" {
+			continue
+		}
+		if strings.HasPrefix(node.Content(content), "using") || !h.endsWithProgram(node, content) {
+			return nil
+		}
+		return []types.CodeModification{{
+			Type:       types.ModificationAddCleanup,
+			Language:   h.config.Language,
+			LineNumber: node.StartPoint().Row + 1,
+			Column:     node.StartPoint().Column + 1,
+			Content:    h.config.CleanupTemplate,
+		}}
+	}
+	return nil
+}
+
+// endsWithProgram reports whether statement is a top-level statement or directly in the
+// body of Main
+func (h *DotNetInjector) endsWithProgram(statement *sitter.Node, content []byte) bool {
+	parent := statement.Parent()
+	if parent != nil && parent.Type() == "global_statement" {
+		return true
+	}
+	if parent == nil || parent.Type() != "block" || parent.Parent() == nil || parent.Parent().Type() != "method_declaration" {
+		return false
+	}
+	name := parent.Parent().ChildByFieldName("name")
+	return name != nil && name.Content(content) == "Main"
+}
@@ -0,0 +1,237 @@
+package injector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/types"
+	"github.com/getlawrence/cli/internal/mix"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/elixir"
+)
+
+var (
+	// elixirStartIndentRe matches the indentation of the Application start/2 callback
+	elixirStartIndentRe = regexp.MustCompile(`(?m)^([ \t]*)def[ \t]+start\(`)
+	// elixirRepoRe matches an Ecto repo module such as Orders.Repo
+	elixirRepoRe = regexp.MustCompile(`\b((?:[A-Z][A-Za-z0-9]*\.)+)Repo\b`)
+	// elixirUseApplicationRe matches the Application behaviour in a module body
+	elixirUseApplicationRe = regexp.MustCompile(`(?m)^[ \t]*use[ \t]+Application\b`)
+)
+
+// ElixirInjector implements LanguageInjector for Elixir. OpenTelemetry itself is configured
+// in config/runtime.exs by the generated code; the injector adds the instrumentation setup
+// calls (OpentelemetryPhoenix.setup/1, OpentelemetryEcto.setup/1) to the Application
+// start/2 callback for the libraries declared in mix.exs. See ForFile.
+type ElixirInjector struct {
+	config *types.LanguageConfig
+}
+
+// NewElixirInjector creates a new Elixir language handler
+func NewElixirInjector() *ElixirInjector {
+	return &ElixirInjector{
+		config: &types.LanguageConfig{
+			Language:       "Elixir",
+			FileExtensions: []string{".ex", ".exs"},
+			FunctionQueries: map[string]string{
+				"main_function": `
+                (call
+                  target: (identifier) @def
+                  (arguments
+                    (call
+                      target: (identifier) @fn_name
+                      (arguments (_) (_))))
+                  (do_block) @start_body
+                  (#eq? @def "def")
+                  (#eq? @fn_name "start"))
+            `,
+			},
+			// Set per file by ForFile, depending on the instrumentation libraries in mix.exs
+			InitializationTemplate: "",
+			CleanupTemplate:        `# no-op cleanup: the :opentelemetry application flushes spans on shutdown`,
+		},
+	}
+}
+
+// ForFile returns a handler whose initialization adds the setup calls for the OpenTelemetry
+// instrumentation libraries declared in the nearest mix.exs that filePath does not call yet
+func (h *ElixirInjector) ForFile(filePath string) LanguageInjector {
+	config := *h.config
+	config.InitializationTemplate = ""
+
+	projectDir := mix.FindProject(filepath.Dir(filePath))
+	if projectDir == "" {
+		return &ElixirInjector{config: &config}
+	}
+	manifest, err := os.ReadFile(filepath.Join(projectDir, mix.FileName))
+	if err != nil {
+		return &ElixirInjector{config: &config}
+	}
+	source, err := os.ReadFile(filePath)
+	if err != nil {
+		return &ElixirInjector{config: &config}
+	}
+	deps, _ := mix.ParseDeps(manifest)
+	declared := make(map[string]bool)
+	for _, dep := range deps {
+		declared[dep.Name] = true
+	}
+
+	indent := "    "
+	if m := elixirStartIndentRe.FindSubmatch(source); m != nil {
+		indent = string(m[1]) + "  "
+	}
+
+	var lines []string
+	if declared["opentelemetry_phoenix"] && !strings.Contains(string(source), "OpentelemetryPhoenix.setup") {
+		lines = append(lines, indent+"OpentelemetryPhoenix.setup("+phoenixSetupOptions(declared)+")")
+	}
+	if declared["opentelemetry_ecto"] && !strings.Contains(string(source), "OpentelemetryEcto.setup") {
+		lines = append(lines, indent+"OpentelemetryEcto.setup("+ectoEventPrefix(source, manifest)+")")
+	}
+	if len(lines) > 0 {
+		config.InitializationTemplate = indent + "# Initialize OpenTelemetry instrumentation\n" + strings.Join(lines, "\n")
+	}
+	return &ElixirInjector{config: &config}
+}
+
+// phoenixSetupOptions returns the OpentelemetryPhoenix.setup/1 options for the HTTP
+// server adapter declared in mix.exs
+func phoenixSetupOptions(declared map[string]bool) string {
+	switch {
+	case declared["bandit"]:
+		return "adapter: :bandit"
+	case declared["plug_cowboy"]:
+		return "adapter: :cowboy2"
+	default:
+		return ""
+	}
+}
+
+// ectoEventPrefix returns the telemetry event prefix of the application's Ecto repo:
+// Orders.Repo emits [:orders, :repo]. Without a repo reference the Mix app name is used.
+func ectoEventPrefix(source, manifest []byte) string {
+	var segments []string
+	if m := elixirRepoRe.FindSubmatch(source); m != nil {
+		for _, part := range strings.Split(strings.TrimSuffix(string(m[1]), "."), ".") {
+			segments = append(segments, ":"+macroUnderscore(part))
+		}
+	} else if app := mix.AppName(manifest); app != "" {
+		segments = append(segments, ":"+app)
+	}
+	segments = append(segments, ":repo")
+	return "[" + strings.Join(segments, ", ") + "]"
+}
+
+// macroUnderscore converts a module alias to its underscored form like Macro.underscore/1:
+// MyApp becomes my_app
+func macroUnderscore(alias string) string {
+	var b strings.Builder
+	for i, r := range alias {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && !(alias[i-1] >= 'A' && alias[i-1] <= 'Z') {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// GetLanguage returns the tree-sitter language parser for Elixir
+func (h *ElixirInjector) GetLanguage() *sitter.Language { return elixir.GetLanguage() }
+
+// GetConfig returns the language configuration for Elixir
+func (h *ElixirInjector) GetConfig() *types.LanguageConfig { return h.config }
+
+// GetRequiredImports returns the list of imports needed for OTEL in Elixir
+func (h *ElixirInjector) GetRequiredImports() []string {
+	// Modules are referenced by their full names; nothing needs to be aliased or required
+	return []string{}
+}
+
+// GetFrameworkImports returns framework-specific imports based on detected frameworks
+func (h *ElixirInjector) GetFrameworkImports(content []byte) []string {
+	return []string{}
+}
+
+// FormatFrameworkImports formats framework-specific import statements for Elixir
+func (h *ElixirInjector) FormatFrameworkImports(imports []string) string {
+	return ""
+}
+
+// GenerateFrameworkModifications generates framework-specific instrumentation modifications for Elixir
+func (h *ElixirInjector) GenerateFrameworkModifications(content []byte, operationsData *types.OperationsData) []types.CodeModification {
+	return []types.CodeModification{}
+}
+
+// FormatImports formats Elixir import statements; Elixir needs none
+func (h *ElixirInjector) FormatImports(imports []string, hasExistingImports bool) string {
+	return ""
+}
+
+// FormatSingleImport formats a single Elixir alias
+func (h *ElixirInjector) FormatSingleImport(importPath string) string {
+	return "alias " + importPath + "\n"
+}
+
+// AnalyzeImportCapture is a no-op for Elixir
+func (h *ElixirInjector) AnalyzeImportCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis) {
+}
+
+// AnalyzeFunctionCapture records the start/2 callback of modules that use Application
+func (h *ElixirInjector) AnalyzeFunctionCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis, config *types.LanguageConfig) {
+	if captureName != "start_body" || !h.inApplicationModule(node, content) {
+		return
+	}
+	analysis.EntryPoints = append(analysis.EntryPoints, types.EntryPointInfo{
+		Name:       "start",
+		LineNumber: node.StartPoint().Row + 1,
+		Column:     node.StartPoint().Column + 1,
+		// Set up instrumentation on the line after `do`, before the supervision tree starts
+		BodyStart: types.InsertionPoint{
+			LineNumber: node.StartPoint().Row + 1,
+			Column:     node.StartPoint().Column + 1,
+			Priority:   h.GetInsertionPointPriority("function_start"),
+		},
+		BodyEnd:      types.InsertionPoint{LineNumber: node.EndPoint().Row + 1, Column: node.EndPoint().Column + 1},
+		HasOTELSetup: h.detectExistingOTELSetup(node, content),
+	})
+}
+
+// inApplicationModule reports whether the start/2 body belongs to a module with `use Application`
+func (h *ElixirInjector) inApplicationModule(node *sitter.Node, content []byte) bool {
+	for n := node.Parent(); n != nil; n = n.Parent() {
+		if n.Type() == "do_block" {
+			return elixirUseApplicationRe.MatchString(n.Content(content))
+		}
+	}
+	return false
+}
+
+// GetInsertionPointPriority returns priority for Elixir insertion point types
+func (h *ElixirInjector) GetInsertionPointPriority(captureName string) int {
+	if captureName == "function_start" {
+		return 100
+	}
+	return 1
+}
+
+// detectExistingOTELSetup reports setup as done when ForFile found no setup call to add
+func (h *ElixirInjector) detectExistingOTELSetup(node *sitter.Node, content []byte) bool {
+	return h.config.InitializationTemplate == ""
+}
+
+// FallbackAnalyzeImports is a no-op for Elixir
+func (h *ElixirInjector) FallbackAnalyzeImports(content []byte, analysis *types.FileAnalysis) {}
+
+// FallbackAnalyzeEntryPoints is a no-op for Elixir; only Application modules are entry points
+func (h *ElixirInjector) FallbackAnalyzeEntryPoints(content []byte, analysis *types.FileAnalysis) {}
+
+// GenerateImportModifications generates modifications to fix import statements
+func (h *ElixirInjector) GenerateImportModifications(content []byte, analysis *types.FileAnalysis) []types.CodeModification {
+	return []types.CodeModification{}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/types"
+	"github.com/getlawrence/cli/internal/textedit"
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/golang"
 )
@@ -84,7 +85,7 @@ func NewGoInjector() *GoInjector {
 		}
 	}()
 `,
-			CleanupTemplate: `tp.Shutdown(context.Background())`,
+			CleanupTemplate: `defer %s.Shutdown(context.Background())`,
 		},
 	}
 }
@@ -408,3 +409,51 @@ func (h *GoInjector) GenerateImportModifications(content []byte, analysis *types
 	// No special import handling needed for Go
 	return []types.CodeModification{}
 }
+
+// GetCleanupImports returns the imports needed by the deferred Shutdown
+func (h *GoInjector) GetCleanupImports() []string {
+	return []string{"context"}
+}
+
+// GenerateCleanupModifications defers the Shutdown of a provider assigned to a variable in
+// main. A provider constructed in another function would be shut down when that function
+// returns, so it is left alone.
+func (h *GoInjector) GenerateCleanupModifications(node *sitter.Node, content []byte) []types.CodeModification {
+	for ; node != nil; node = node.Parent() {
+		var name *sitter.Node
+		switch node.Type() {
+		case "short_var_declaration", "assignment_statement":
+			if left := node.ChildByFieldName("left"); left != nil && left.NamedChildCount() > 0 {
+				name = left.NamedChild(0)
+			}
+		case "var_declaration":
+			if spec := node.NamedChild(0); spec != nil && spec.Type() == "var_spec" {
+				name = spec.ChildByFieldName("name")
+			}
+		default:
+			continue
+		}
+
+		if name == nil || name.Type() != "identifier" || name.Content(content) == "_" || !h.inMain(node, content) {
+			return nil
+		}
+		return []types.CodeModification{{
+			Type:        types.ModificationAddCleanup,
+			Language:    h.config.Language,
+			LineNumber:  node.EndPoint().Row + 1,
+			InsertAfter: true,
+			Content:     textedit.LineIndent(string(content), int(node.StartByte())) + fmt.Sprintf(h.config.CleanupTemplate, name.Content(content)),
+		}}
+	}
+	return nil
+}
+
+// inMain reports whether statement is directly in the body of func main
+func (h *GoInjector) inMain(statement *sitter.Node, content []byte) bool {
+	body := statement.Parent()
+	if body == nil || body.Type() != "block" || body.Parent() == nil || body.Parent().Type() != "function_declaration" {
+		return false
+	}
+	name := body.Parent().ChildByFieldName("name")
+	return name != nil && name.Content(content) == "main"
+}
@@ -6,10 +6,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/types"
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
 	"github.com/getlawrence/cli/internal/logger"
 	sitter "github.com/smacker/go-tree-sitter"
 )
@@ -25,19 +27,25 @@ func NewCodeInjector(logger logger.Logger) *CodeInjector {
 		handlers: map[string]LanguageInjector{
 			"go":         NewGoInjector(),
 			"javascript": NewJavaScriptInjector(),
+			"typescript": NewTypeScriptInjector(),
 			"python":     NewPythonInjector(),
 			"java":       NewJavaInjector(),
+			"kotlin":     NewKotlinInjector(),
 			"csharp":     NewDotNetInjector(),
 			"dotnet":     NewDotNetInjector(),
 			"ruby":       NewRubyInjector(),
 			"php":        NewPHPInjector(),
+			"rust":       NewRustInjector(),
+			"elixir":     NewElixirInjector(),
+			"cpp":        NewCppInjector(),
 		},
 	}
 }
 
 // DetectEntryPoints scans a project directory for entry points using language handlers.
+// Paths excluded by the ignore matcher in ctx are skipped.
 // Returns at most one best entry point per directory.
-func (ci *CodeInjector) DetectEntryPoints(projectPath string, language string) ([]domain.EntryPoint, error) {
+func (ci *CodeInjector) DetectEntryPoints(ctx context.Context, projectPath string, language string) ([]domain.EntryPoint, error) {
 	handler, ok := ci.handlers[strings.ToLower(language)]
 	if !ok {
 		return nil, fmt.Errorf("unsupported language: %s", language)
@@ -57,31 +65,9 @@ func (ci *CodeInjector) DetectEntryPoints(projectPath string, language string) (
 			return err
 		}
 		if d.IsDir() {
-			// Skip hidden and common vendor/build directories
-			name := d.Name()
-			if strings.HasPrefix(name, ".") {
-				return filepath.SkipDir
-			}
-			switch name {
-			case "node_modules", "vendor", ".git", "__pycache__", ".venv", "venv", "dist", "build", "target", "out":
-				return filepath.SkipDir
-			}
 			return nil
 		}
 
-		// Defensive: if file resides under ignored directories, skip it
-		if rel, relErr := filepath.Rel(projectPath, path); relErr == nil {
-			parts := strings.Split(rel, string(filepath.Separator))
-			for _, part := range parts {
-				if strings.HasPrefix(part, ".") {
-					return nil
-				}
-				switch part {
-				case "node_modules", "vendor", ".git", "__pycache__", ".venv", "venv", "dist", "build", "target", "out":
-					return nil
-				}
-			}
-		}
 		ext := strings.ToLower(filepath.Ext(path))
 		if !validExts[ext] {
 			return nil
@@ -122,7 +108,7 @@ func (ci *CodeInjector) DetectEntryPoints(projectPath string, language string) (
 		return nil
 	}
 
-	if err := filepath.WalkDir(projectPath, walkFn); err != nil {
+	if err := ignore.FromContext(ctx, projectPath).Walk(projectPath, walkFn); err != nil {
 		return nil, err
 	}
 
@@ -143,6 +129,8 @@ func (ci *CodeInjector) InjectOtelInitialization(ctx context.Context,
 		return nil, fmt.Errorf("unsupported language for modification: %s", entryPoint.Language)
 	}
 
+	handler = injectorForFile(handler, entryPoint.FilePath)
+
 	// Analyze the current file
 	analysis, err := ci.analyzeFile(entryPoint.FilePath, handler)
 	if err != nil {
@@ -163,6 +151,88 @@ func (ci *CodeInjector) InjectOtelInitialization(ctx context.Context,
 	return []string{entryPoint.FilePath}, nil
 }
 
+// SupportsCleanup reports whether InjectCleanup can shut down the providers of language
+func (ci *CodeInjector) SupportsCleanup(language string) bool {
+	_, ok := ci.handlers[strings.ToLower(language)].(CleanupInjector)
+	return ok
+}
+
+// InjectCleanup shuts down the providers constructed at the given positions of filePath
+// when the program exits, through the language's CleanupInjector. Providers without a
+// safe place for the cleanup are reported in the error and left alone.
+func (ci *CodeInjector) InjectCleanup(ctx context.Context,
+	filePath string,
+	language string,
+	providers []types.InsertionPoint,
+	req types.GenerationRequest) ([]string, error) {
+
+	handler, exists := ci.handlers[strings.ToLower(language)]
+	if !exists {
+		return nil, fmt.Errorf("unsupported language for modification: %s", language)
+	}
+
+	handler = injectorForFile(handler, filePath)
+	cleanup, ok := handler.(CleanupInjector)
+	if !ok {
+		return nil, fmt.Errorf("adding provider cleanup is not supported for %s", language)
+	}
+
+	analysis, err := ci.analyzeFile(filePath, handler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze file %s: %w", filePath, err)
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	parser := sitter.NewParser()
+	parser.SetLanguage(handler.GetLanguage())
+	tree, err := parser.ParseCtx(ctx, nil, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	defer tree.Close()
+
+	var cleanupMods []types.CodeModification
+	var skipped []string
+	for _, provider := range providers {
+		point := sitter.Point{Row: provider.LineNumber - 1, Column: provider.Column - 1}
+		node := tree.RootNode().NamedDescendantForPointRange(point, point)
+		mods := cleanup.GenerateCleanupModifications(node, content)
+		if len(mods) == 0 {
+			skipped = append(skipped, fmt.Sprintf("line %d", provider.LineNumber))
+			continue
+		}
+		cleanupMods = append(cleanupMods, mods...)
+	}
+
+	if len(cleanupMods) > 0 {
+		// Modifications are applied bottom-up and must be ordered by line
+		sort.SliceStable(cleanupMods, func(i, j int) bool { return cleanupMods[i].LineNumber < cleanupMods[j].LineNumber })
+		for i := range cleanupMods {
+			cleanupMods[i].FilePath = filePath
+		}
+		modifications := append(ci.importModifications(analysis, handler, cleanup.GetCleanupImports()), cleanupMods...)
+		if err := ci.applyModifications(filePath, modifications, req.Config.DryRun); err != nil {
+			return nil, fmt.Errorf("failed to apply modifications: %w", err)
+		}
+	}
+
+	if len(skipped) > 0 {
+		return nil, fmt.Errorf("no safe place to shut down the provider at %s %s; shut it down before the program exits",
+			filePath, strings.Join(skipped, ", "))
+	}
+	return []string{filePath}, nil
+}
+
+// injectorForFile returns the file-specific injector when handler provides one
+func injectorForFile(handler LanguageInjector, filePath string) LanguageInjector {
+	if fileHandler, ok := handler.(FileLanguageInjector); ok {
+		return fileHandler.ForFile(filePath)
+	}
+	return handler
+}
+
 // analyzeFile analyzes a source file to understand its structure
 func (ci *CodeInjector) analyzeFile(filePath string, handler LanguageInjector) (*types.FileAnalysis, error) {
 	content, err := os.ReadFile(filePath)
@@ -170,6 +240,7 @@ func (ci *CodeInjector) analyzeFile(filePath string, handler LanguageInjector) (
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	handler = injectorForFile(handler, filePath)
 	lang := handler.GetLanguage()
 	config := handler.GetConfig()
 	parser := sitter.NewParser()
@@ -260,6 +331,8 @@ func (ci *CodeInjector) analyzeEntryPoints(tree *sitter.Tree, content []byte, ha
 			if !ok {
 				break
 			}
+			// Apply #eq?/#match? predicates, which the cursor does not evaluate itself
+			match = cursor.FilterPredicates(match, content)
 
 			for _, capture := range match.Captures {
 				captureName := query.CaptureNameForId(capture.Index)
@@ -347,15 +420,22 @@ func (ci *CodeInjector) generateImportModifications(
 	operationsData *types.OperationsData,
 	handler LanguageInjector,
 ) []types.CodeModification {
-	var modifications []types.CodeModification
-
 	// Only import language-required paths. Instrumentations are handled via
 	// generated bootstrap files (e.g., otel.js) rather than direct imports here.
-	requiredImports := handler.GetRequiredImports()
+	return ci.importModifications(analysis, handler, handler.GetRequiredImports())
+}
+
+// importModifications creates the modification adding the imports missing from a file
+func (ci *CodeInjector) importModifications(
+	analysis *types.FileAnalysis,
+	handler LanguageInjector,
+	imports []string,
+) []types.CodeModification {
+	var modifications []types.CodeModification
 	newImports := make([]string, 0)
 
 	// Collect imports that need to be added
-	for _, importPath := range requiredImports {
+	for _, importPath := range imports {
 		if !analysis.ExistingImports[importPath] {
 			newImports = append(newImports, importPath)
 		}
@@ -538,7 +618,7 @@ func (ci *CodeInjector) applyModifications(filePath string, modifications []type
 				newLines = append(newLines, lines[mod.LineNumber:]...)
 				lines = newLines
 			}
-		case types.ModificationAddImport, types.ModificationAddInit, types.ModificationAddFramework:
+		case types.ModificationAddImport, types.ModificationAddInit, types.ModificationAddFramework, types.ModificationAddCleanup:
 			// Insert the modification content
 			if mod.InsertAfter {
 				// Insert after the specified line
@@ -558,6 +638,14 @@ func (ci *CodeInjector) applyModifications(filePath string, modifications []type
 					copy(newLines[mod.LineNumber:], lines[mod.LineNumber-1:])
 					lines = newLines
 				}
+			} else if mod.Type == types.ModificationAddCleanup && mod.Column > 0 {
+				// Insert into the line at the column, e.g. a keyword before a declaration
+				line := lines[mod.LineNumber-1]
+				column := int(mod.Column) - 1
+				if column > len(line) {
+					column = len(line)
+				}
+				lines[mod.LineNumber-1] = line[:column] + mod.Content + line[column:]
 			}
 		}
 	}
@@ -587,3 +675,14 @@ func (ci *CodeInjector) applyModifications(filePath string, modifications []type
 	ci.logger.Logf("Successfully modified: %s (backup: %s)\n", filePath, backupPath)
 	return nil
 }
+
+// indentLines prefixes every line of code with indent
+func indentLines(code, indent string) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
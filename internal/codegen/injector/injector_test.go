@@ -54,6 +54,17 @@ console.log('hi');
 			expectInitSub:   "require('./otel')",
 			expectImportSub: "",
 		},
+		{
+			name:     "TypeScript",
+			language: "typescript",
+			filename: "index.ts",
+			source: `import express from 'express';
+const app = express();
+app.listen(3000);
+`,
+			expectInitSub:   "import './otel';",
+			expectImportSub: "",
+		},
 		{
 			name:     "Python",
 			language: "python",
@@ -116,6 +127,38 @@ echo "hi";
 			expectInitSub:   "setup_otel();",
 			expectImportSub: "require_once './otel.php';",
 		},
+		{
+			name:     "Kotlin",
+			language: "kotlin",
+			filename: "Application.kt",
+			source: `package com.acme.orders
+
+import org.springframework.boot.autoconfigure.SpringBootApplication
+import org.springframework.boot.runApplication
+
+@SpringBootApplication
+class Application
+
+fun main(args: Array<String>) {
+    runApplication<Application>(*args)
+}
+`,
+			expectInitSub:   "fun main(args: Array<String>) {\n    // Initialize OpenTelemetry\n    telemetry.Otel.start()\n    runApplication",
+			expectImportSub: "import org.springframework.boot.runApplication\nimport telemetry.Otel",
+		},
+		{
+			name:     "Rust",
+			language: "rust",
+			filename: "main.rs",
+			source: `use std::env;
+
+fn main() {
+    println!("hi");
+}
+`,
+			expectInitSub:   "fn main() {\n    let _otel_guard = otel::init_tracer_provider();",
+			expectImportSub: "use std::env;\nmod otel;",
+		},
 	}
 
 	for _, tc := range cases {
@@ -173,3 +216,480 @@ echo "hi";
 		})
 	}
 }
+
+func TestTypeScriptInjector_TSXWithESMTsconfig(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"package.json":  `{"name": "web", "type": "module"}`,
+		"tsconfig.json": `{"compilerOptions": {"module": "NodeNext", "rootDir": "src", "outDir": "dist"}}`,
+		"src/main.tsx": `import { render } from 'react-dom';
+const root = document.getElementById('root');
+render(<App />, root);
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	injector := NewCodeInjector(&logger.StdoutLogger{})
+	eps, err := injector.DetectEntryPoints(context.Background(), root, "typescript")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eps) != 1 || filepath.Base(eps[0].FilePath) != "main.tsx" {
+		t.Fatalf("expected main.tsx entry point, got %+v", eps)
+	}
+
+	ops := &types.OperationsData{InstallOTEL: true, InstallComponents: map[string][]string{}}
+	req := types.GenerationRequest{CodebasePath: root}
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	out, err := os.ReadFile(eps[0].FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(out), "import './otel.js';") {
+		t.Fatalf("expected ESM bootstrap import with extension at the top, got:\n%s", out)
+	}
+
+	// A second run recognizes the existing bootstrap import
+	eps, _ = injector.DetectEntryPoints(context.Background(), root, "typescript")
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	again, _ := os.ReadFile(eps[0].FilePath)
+	if strings.Count(string(again), "otel.js") != 1 {
+		t.Fatalf("expected injection to be idempotent, got:\n%s", again)
+	}
+}
+
+func TestKotlinInjector_KtorEmbeddedServer(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "src", "main", "kotlin", "Server.kt")
+	source := `package com.acme
+
+import io.ktor.server.engine.*
+import io.ktor.server.netty.*
+
+fun start() {
+    val port = 8080
+    embeddedServer(Netty, port = port) {
+        routing {}
+    }.start(wait = true)
+}
+`
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	injector := NewCodeInjector(&logger.StdoutLogger{})
+	eps, err := injector.DetectEntryPoints(context.Background(), root, "kotlin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eps) != 1 || eps[0].FunctionName != "embeddedServer" {
+		t.Fatalf("expected a single embeddedServer entry point, got %+v", eps)
+	}
+
+	ops := &types.OperationsData{InstallOTEL: true, InstallComponents: map[string][]string{}}
+	req := types.GenerationRequest{CodebasePath: root}
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `package com.acme
+
+import io.ktor.server.engine.*
+import io.ktor.server.netty.*
+import telemetry.Otel
+
+fun start() {
+    val port = 8080
+    // Initialize OpenTelemetry
+    telemetry.Otel.start()
+    embeddedServer(Netty, port = port) {
+        routing {}
+    }.start(wait = true)
+}
+`
+	if string(out) != want {
+		t.Fatalf("unexpected injection result:\n%s", out)
+	}
+
+	// A second run recognizes the existing setup and import
+	eps, _ = injector.DetectEntryPoints(context.Background(), root, "kotlin")
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	again, _ := os.ReadFile(path)
+	if string(again) != want {
+		t.Fatalf("expected injection to be idempotent, got:\n%s", again)
+	}
+}
+
+func TestRustInjector_TokioMainAfterInnerAttributes(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "src", "main.rs")
+	source := `#![deny(unsafe_code)]
+//! API server
+
+#[tokio::main]
+async fn main() {
+    serve().await;
+}
+
+async fn serve() {}
+`
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	injector := NewCodeInjector(&logger.StdoutLogger{})
+	eps, err := injector.DetectEntryPoints(context.Background(), root, "rust")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eps) != 1 || eps[0].FunctionName != "tokio::main" {
+		t.Fatalf("expected a single tokio::main entry point, got %+v", eps)
+	}
+
+	ops := &types.OperationsData{InstallOTEL: true, InstallComponents: map[string][]string{}}
+	req := types.GenerationRequest{CodebasePath: root}
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `#![deny(unsafe_code)]
+//! API server
+mod otel;
+
+#[tokio::main]
+async fn main() {
+    let _otel_guard = otel::init_tracer_provider();
+    serve().await;
+}
+
+async fn serve() {}
+`
+	if string(out) != want {
+		t.Fatalf("unexpected injection result:\n%s", out)
+	}
+
+	// A second run recognizes the existing setup and module declaration
+	eps, _ = injector.DetectEntryPoints(context.Background(), root, "rust")
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	again, _ := os.ReadFile(path)
+	if string(again) != want {
+		t.Fatalf("expected injection to be idempotent, got:\n%s", again)
+	}
+}
+
+func TestElixirInjector_PhoenixAndEctoSetup(t *testing.T) {
+	root := t.TempDir()
+	manifest := `defmodule Orders.MixProject do
+  use Mix.Project
+
+  def project, do: [app: :orders, deps: deps()]
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      {:bandit, "~> 1.5"},
+      {:opentelemetry_phoenix, "~> 2.0"},
+      {:opentelemetry_ecto, "~> 1.2"}
+    ]
+  end
+end
+`
+	path := filepath.Join(root, "lib", "orders", "application.ex")
+	source := `defmodule Orders.Application do
+  use Application
+
+  @impl true
+  def start(_type, _args) do
+    children = [OrdersWeb.Repo, OrdersWeb.Endpoint]
+    Supervisor.start_link(children, strategy: :one_for_one)
+  end
+end
+`
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "mix.exs"), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Modules without `use Application` are not entry points
+	worker := "defmodule Orders.Worker do\n  def start(_type, _args) do\n    :ok\n  end\nend\n"
+	if err := os.WriteFile(filepath.Join(root, "lib", "orders", "worker.ex"), []byte(worker), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	injector := NewCodeInjector(&logger.StdoutLogger{})
+	eps, err := injector.DetectEntryPoints(context.Background(), root, "elixir")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eps) != 1 || eps[0].FilePath != path {
+		t.Fatalf("expected the Application module as the only entry point, got %+v", eps)
+	}
+
+	ops := &types.OperationsData{InstallOTEL: true, InstallComponents: map[string][]string{}}
+	req := types.GenerationRequest{CodebasePath: root}
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `defmodule Orders.Application do
+  use Application
+
+  @impl true
+  def start(_type, _args) do
+    # Initialize OpenTelemetry instrumentation
+    OpentelemetryPhoenix.setup(adapter: :bandit)
+    OpentelemetryEcto.setup([:orders_web, :repo])
+    children = [OrdersWeb.Repo, OrdersWeb.Endpoint]
+    Supervisor.start_link(children, strategy: :one_for_one)
+  end
+end
+`
+	if string(out) != want {
+		t.Fatalf("unexpected injection result:\n%s", out)
+	}
+
+	// A second run finds both setup calls and leaves the file alone
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	again, _ := os.ReadFile(path)
+	if string(again) != want {
+		t.Fatalf("expected injection to be idempotent, got:\n%s", again)
+	}
+}
+
+func TestCppInjector_IncludeAndInitInMain(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "src", "main.cc")
+	source := `// Copyright Acme
+#include <iostream>
+#include "server.h"
+
+namespace {
+int helper() { return 1; }
+}  // namespace
+
+int main(int argc, char** argv) {
+  Server server(argc, argv);
+  return server.Run();
+}
+`
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Sources without main are not entry points
+	if err := os.WriteFile(filepath.Join(root, "src", "server.cc"), []byte("#include \"server.h\"\n\nint Server::Run() { return 0; }\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	injector := NewCodeInjector(&logger.StdoutLogger{})
+	eps, err := injector.DetectEntryPoints(context.Background(), root, "cpp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eps) != 1 || eps[0].FilePath != path {
+		t.Fatalf("expected main.cc as the only entry point, got %+v", eps)
+	}
+
+	ops := &types.OperationsData{InstallOTEL: true, InstallComponents: map[string][]string{}}
+	req := types.GenerationRequest{CodebasePath: root}
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `// Copyright Acme
+#include <iostream>
+#include "server.h"
+#include "otel_init.h"
+
+namespace {
+int helper() { return 1; }
+}  // namespace
+
+int main(int argc, char** argv) {
+  otel_init::InitTelemetry();
+  Server server(argc, argv);
+  return server.Run();
+}
+`
+	if string(out) != want {
+		t.Fatalf("unexpected injection result:\n%s", out)
+	}
+
+	// A second run finds the include and the initialization and leaves the file alone
+	if _, err := injector.InjectOtelInitialization(context.Background(), &eps[0], ops, req); err != nil {
+		t.Fatalf("injection failed: %v", err)
+	}
+	again, _ := os.ReadFile(path)
+	if string(again) != want {
+		t.Fatalf("expected injection to be idempotent, got:\n%s", again)
+	}
+}
+
+func TestInjectCleanup_PerLanguage(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		language     string
+		filename     string
+		source       string
+		providers    []types.InsertionPoint
+		expect       string
+		expectImport string
+	}{
+		{
+			name:     "Go",
+			language: "go",
+			filename: "main.go",
+			source: `package main
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func main() {
+	tp := sdktrace.NewTracerProvider()
+	run(tp)
+}
+`,
+			providers:    []types.InsertionPoint{{LineNumber: 8, Column: 8}},
+			expect:       "\ttp := sdktrace.NewTracerProvider()\n\tdefer tp.Shutdown(context.Background())\n\trun(tp)",
+			expectImport: "\t\"context\"",
+		},
+		{
+			name:     "Python",
+			language: "python",
+			filename: "app.py",
+			source: `from opentelemetry.sdk.trace import TracerProvider
+
+def setup():
+    provider = TracerProvider(shutdown_on_exit=False)
+    return provider
+`,
+			providers:    []types.InsertionPoint{{LineNumber: 4, Column: 16}},
+			expect:       "    provider = TracerProvider(shutdown_on_exit=False)\n    atexit.register(provider.shutdown)\n",
+			expectImport: "import atexit",
+		},
+		{
+			name:     "JavaScript",
+			language: "javascript",
+			filename: "tracing.js",
+			source: `const { NodeSDK } = require('@opentelemetry/sdk-node');
+const sdk = new NodeSDK({});
+sdk.start();
+`,
+			providers: []types.InsertionPoint{{LineNumber: 2, Column: 13}},
+			expect:    "const sdk = new NodeSDK({});\nfor (const signal of ['SIGTERM', 'SIGINT']) {\n  process.once(signal, () => {\n    sdk.shutdown().finally(() => process.exit(0));",
+		},
+		{
+			name:     "CSharp",
+			language: "csharp",
+			filename: "Program.cs",
+			source: `using OpenTelemetry;
+
+var tracerProvider = Sdk.CreateTracerProviderBuilder().Build();
+app.Run();
+`,
+			providers: []types.InsertionPoint{{LineNumber: 3, Column: 22}},
+			expect:    "\nusing var tracerProvider = Sdk.CreateTracerProviderBuilder().Build();\n",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filePath := filepath.Join(t.TempDir(), tc.filename)
+			if err := os.WriteFile(filePath, []byte(tc.source), 0o644); err != nil {
+				t.Fatalf("failed writing temp source: %v", err)
+			}
+			injector := NewCodeInjector(&logger.StdoutLogger{})
+			if _, err := injector.InjectCleanup(context.Background(), filePath, tc.language, tc.providers, types.GenerationRequest{}); err != nil {
+				t.Fatalf("cleanup injection failed for %s: %v", tc.name, err)
+			}
+
+			out, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("failed reading modified file: %v", err)
+			}
+			if !strings.Contains(string(out), tc.expect) {
+				t.Fatalf("expected cleanup for %s to contain %q; got:\n%s", tc.name, tc.expect, out)
+			}
+			if !strings.Contains(string(out), tc.expectImport) {
+				t.Fatalf("expected imports for %s to contain %q; got:\n%s", tc.name, tc.expectImport, out)
+			}
+		})
+	}
+}
+
+func TestInjectCleanup_ProviderOutsideMain(t *testing.T) {
+	t.Parallel()
+
+	source := `package main
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newProvider() *sdktrace.TracerProvider {
+	tp := sdktrace.NewTracerProvider()
+	return tp
+}
+`
+	filePath := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(filePath, []byte(source), 0o644); err != nil {
+		t.Fatalf("failed writing temp source: %v", err)
+	}
+	injector := NewCodeInjector(&logger.StdoutLogger{})
+	providers := []types.InsertionPoint{{LineNumber: 8, Column: 8}}
+	if _, err := injector.InjectCleanup(context.Background(), filePath, "go", providers, types.GenerationRequest{}); err == nil {
+		t.Fatalf("expected an error for a provider returned from a helper")
+	}
+	out, _ := os.ReadFile(filePath)
+	if string(out) != source {
+		t.Fatalf("expected the file to be left alone; got:\n%s", out)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/types"
+	"github.com/getlawrence/cli/internal/textedit"
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/javascript"
 )
@@ -52,7 +53,11 @@ func NewJavaScriptInjector() *JavaScriptInjector {
 			},
 			ImportTemplate:         `const { %s } = require("%s")`,
 			InitializationTemplate: `require('./otel');`,
-			CleanupTemplate:        `await sdk.shutdown()`,
+			CleanupTemplate: `for (const signal of ['SIGTERM', 'SIGINT']) {
+  process.once(signal, () => {
+    %s.shutdown().finally(() => process.exit(0));
+  });
+}`,
 		},
 	}
 }
@@ -214,3 +219,35 @@ func (h *JavaScriptInjector) GenerateImportModifications(content []byte, analysi
 	// No special import handling needed for JavaScript
 	return []types.CodeModification{}
 }
+
+// GetCleanupImports returns no imports; process is a global
+func (h *JavaScriptInjector) GetCleanupImports() []string { return nil }
+
+// GenerateCleanupModifications shuts down an SDK or provider assigned to a variable when
+// the process receives a termination signal
+func (h *JavaScriptInjector) GenerateCleanupModifications(node *sitter.Node, content []byte) []types.CodeModification {
+	return signalCleanupModifications(node, content, h.config)
+}
+
+// signalCleanupModifications adds the signal hooks of config.CleanupTemplate after the
+// declaration of the variable holding the SDK or provider at node
+func signalCleanupModifications(node *sitter.Node, content []byte, config *types.LanguageConfig) []types.CodeModification {
+	for ; node != nil; node = node.Parent() {
+		if node.Type() != "variable_declarator" {
+			continue
+		}
+		name := node.ChildByFieldName("name")
+		declaration := node.Parent()
+		if name == nil || name.Type() != "identifier" || declaration == nil {
+			return nil
+		}
+		return []types.CodeModification{{
+			Type:        types.ModificationAddCleanup,
+			Language:    config.Language,
+			LineNumber:  declaration.EndPoint().Row + 1,
+			InsertAfter: true,
+			Content:     indentLines(fmt.Sprintf(config.CleanupTemplate, name.Content(content)), textedit.LineIndent(string(content), int(declaration.StartByte()))),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package injector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/types"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/kotlin"
+)
+
+// KotlinInjector implements LanguageInjector for Kotlin. It initializes the generated
+// telemetry.Otel object in fun main (top-level or in a companion object, which covers
+// @SpringBootApplication apps) or right before a Ktor embeddedServer(...) call.
+type KotlinInjector struct {
+	config *types.LanguageConfig
+}
+
+// NewKotlinInjector creates a new Kotlin language handler
+func NewKotlinInjector() *KotlinInjector {
+	return &KotlinInjector{
+		config: &types.LanguageConfig{
+			Language:       "Kotlin",
+			FileExtensions: []string{".kt"},
+			ImportQueries: map[string]string{
+				"existing_imports": `
+                (import_header (identifier) @import_path) @import_location
+                (package_header) @package_location
+            `,
+			},
+			FunctionQueries: map[string]string{
+				// The Kotlin grammar has no field names, so match children by type
+				"main_function": `
+                (function_declaration
+                  (simple_identifier) @fn_name
+                  (function_body) @main_body
+                  (#eq? @fn_name "main"))
+
+                (call_expression
+                  (simple_identifier) @call_name
+                  (#eq? @call_name "embeddedServer")) @server_call
+            `,
+			},
+			InsertionQueries: map[string]string{
+				"optimal_insertion": `
+                (function_body) @function_start
+            `,
+			},
+			ImportTemplate: `import %s`,
+			InitializationTemplate: `    // Initialize OpenTelemetry
+    telemetry.Otel.start()`,
+			CleanupTemplate: `// no-op cleanup for basic setup`,
+		},
+	}
+}
+
+// GetLanguage returns the tree-sitter language parser for Kotlin
+func (h *KotlinInjector) GetLanguage() *sitter.Language { return kotlin.GetLanguage() }
+
+// GetConfig returns the language configuration for Kotlin
+func (h *KotlinInjector) GetConfig() *types.LanguageConfig { return h.config }
+
+// GetRequiredImports returns the list of imports needed for OTEL in Kotlin
+func (h *KotlinInjector) GetRequiredImports() []string {
+	// Only import our helper; it encapsulates all OTEL deps
+	return []string{"telemetry.Otel"}
+}
+
+// GetFrameworkImports returns framework-specific imports based on detected frameworks
+func (h *KotlinInjector) GetFrameworkImports(content []byte) []string {
+	// Instrumentation is configured by the generated Otel object
+	return []string{}
+}
+
+// FormatFrameworkImports formats framework-specific import statements for Kotlin
+func (h *KotlinInjector) FormatFrameworkImports(imports []string) string {
+	return ""
+}
+
+// GenerateFrameworkModifications generates framework-specific instrumentation modifications for Kotlin
+func (h *KotlinInjector) GenerateFrameworkModifications(content []byte, operationsData *types.OperationsData) []types.CodeModification {
+	return []types.CodeModification{}
+}
+
+// FormatImports formats Kotlin import statements
+func (h *KotlinInjector) FormatImports(imports []string, hasExistingImports bool) string {
+	if len(imports) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, imp := range imports {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(h.FormatSingleImport(imp))
+	}
+	return b.String()
+}
+
+// FormatSingleImport formats a single Kotlin import statement
+func (h *KotlinInjector) FormatSingleImport(importPath string) string {
+	return fmt.Sprintf(h.config.ImportTemplate, importPath)
+}
+
+// AnalyzeImportCapture processes an import capture from tree-sitter query for Kotlin.
+// New imports go after the last import, or after the package header when there are none.
+func (h *KotlinInjector) AnalyzeImportCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis) {
+	switch captureName {
+	case "import_path":
+		path := strings.TrimSpace(node.Content(content))
+		analysis.ExistingImports[path] = true
+		// Only our helper counts: the injected telemetry.Otel.start() needs nothing else
+		if path == "telemetry.Otel" {
+			analysis.HasOTELImports = true
+		}
+	case "import_location", "package_location":
+		priority := 2
+		if captureName == "package_location" {
+			priority = 1
+		}
+		point := types.InsertionPoint{
+			LineNumber: node.EndPoint().Row + 1,
+			Column:     node.EndPoint().Column + 1,
+			Context:    node.Content(content),
+			Priority:   priority,
+		}
+		for i, existing := range analysis.ImportLocations {
+			if existing.Priority == priority {
+				if point.LineNumber > existing.LineNumber {
+					analysis.ImportLocations[i] = point
+				}
+				return
+			}
+		}
+		analysis.ImportLocations = append(analysis.ImportLocations, point)
+	}
+}
+
+// AnalyzeFunctionCapture records block-bodied fun main declarations and Ktor
+// embeddedServer(...) calls made outside of main
+func (h *KotlinInjector) AnalyzeFunctionCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis, config *types.LanguageConfig) {
+	switch captureName {
+	case "main_body":
+		// Expression bodies (fun main() = ...) have no block to insert into
+		if !strings.HasPrefix(node.Content(content), "{") {
+			return
+		}
+		entryPoint := types.EntryPointInfo{
+			Name:       "main",
+			LineNumber: node.StartPoint().Row + 1,
+			Column:     node.StartPoint().Column + 1,
+			// Initialize on the line after the opening brace
+			BodyStart: types.InsertionPoint{
+				LineNumber: node.StartPoint().Row + 1,
+				Column:     node.StartPoint().Column + 1,
+				Priority:   h.GetInsertionPointPriority("function_start"),
+			},
+			BodyEnd:      types.InsertionPoint{LineNumber: node.EndPoint().Row + 1, Column: node.EndPoint().Column + 1},
+			HasOTELSetup: h.detectExistingOTELSetup(node, content),
+		}
+		// Prefer main over server calls found earlier in the file
+		idx := 0
+		for idx < len(analysis.EntryPoints) && analysis.EntryPoints[idx].Name == "main" {
+			idx++
+		}
+		analysis.EntryPoints = append(analysis.EntryPoints[:idx], append([]types.EntryPointInfo{entryPoint}, analysis.EntryPoints[idx:]...)...)
+	case "server_call":
+		statement := h.enclosingStatement(node, content)
+		if statement == nil {
+			return
+		}
+		block := statement.Parent()
+		entryPoint := types.EntryPointInfo{
+			Name:       "embeddedServer",
+			LineNumber: statement.StartPoint().Row + 1,
+			Column:     statement.StartPoint().Column + 1,
+			// Initialize on the line before the server is created
+			BodyStart: types.InsertionPoint{
+				LineNumber: statement.StartPoint().Row,
+				Column:     1,
+				Priority:   h.GetInsertionPointPriority("before_server"),
+			},
+			BodyEnd:      types.InsertionPoint{LineNumber: block.EndPoint().Row + 1, Column: block.EndPoint().Column + 1},
+			HasOTELSetup: h.detectExistingOTELSetup(block, content),
+		}
+		analysis.EntryPoints = append(analysis.EntryPoints, entryPoint)
+	}
+}
+
+// enclosingStatement returns the statement containing an embeddedServer call, or nil when
+// the call is inside fun main (handled as its own entry point) or not in a statement block
+func (h *KotlinInjector) enclosingStatement(call *sitter.Node, content []byte) *sitter.Node {
+	var statement *sitter.Node
+	for n := call; n.Parent() != nil; n = n.Parent() {
+		parent := n.Parent()
+		if statement == nil && parent.Type() == "statements" {
+			statement = n
+		}
+		if parent.Type() == "function_declaration" {
+			for i := 0; i < int(parent.NamedChildCount()); i++ {
+				child := parent.NamedChild(i)
+				if child.Type() == "simple_identifier" {
+					if child.Content(content) == "main" {
+						return nil
+					}
+					break
+				}
+			}
+		}
+	}
+	return statement
+}
+
+// GetInsertionPointPriority returns priority for Kotlin insertion point types
+func (h *KotlinInjector) GetInsertionPointPriority(captureName string) int {
+	switch captureName {
+	case "function_start":
+		return 100
+	case "before_server":
+		return 90
+	default:
+		return 1
+	}
+}
+
+func (h *KotlinInjector) detectExistingOTELSetup(node *sitter.Node, content []byte) bool {
+	body := node.Content(content)
+	return strings.Contains(body, "Otel.start()") ||
+		strings.Contains(body, "OpenTelemetrySdk") ||
+		strings.Contains(body, "GlobalOpenTelemetry") ||
+		strings.Contains(body, "SdkTracerProvider")
+}
+
+// FallbackAnalyzeImports places imports at the top of files without a package header
+func (h *KotlinInjector) FallbackAnalyzeImports(content []byte, analysis *types.FileAnalysis) {
+	analysis.ImportLocations = append(analysis.ImportLocations, types.InsertionPoint{
+		LineNumber: 0,
+		Column:     1,
+		Priority:   1,
+	})
+}
+
+// FallbackAnalyzeEntryPoints: no-op for Kotlin; main and embeddedServer captures should be sufficient
+func (h *KotlinInjector) FallbackAnalyzeEntryPoints(content []byte, analysis *types.FileAnalysis) {}
+
+// GenerateImportModifications generates modifications to fix import statements
+func (h *KotlinInjector) GenerateImportModifications(content []byte, analysis *types.FileAnalysis) []types.CodeModification {
+	return []types.CodeModification{}
+}
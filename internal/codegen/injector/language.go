@@ -49,3 +49,21 @@ type LanguageInjector interface {
 	// GenerateImportModifications generates modifications to fix import statements
 	GenerateImportModifications(content []byte, analysis *types.FileAnalysis) []types.CodeModification
 }
+
+// FileLanguageInjector is implemented by injectors whose grammar or templates depend on
+// the file being modified, e.g. TypeScript parses .tsx files with the TSX grammar
+type FileLanguageInjector interface {
+	// ForFile returns the injector to use for filePath
+	ForFile(filePath string) LanguageInjector
+}
+
+// CleanupInjector is implemented by injectors that can shut down a provider the
+// application already constructs, so the telemetry it buffers is exported on exit
+type CleanupInjector interface {
+	// GetCleanupImports returns the imports needed by the cleanup code
+	GetCleanupImports() []string
+
+	// GenerateCleanupModifications returns the modifications shutting down the provider
+	// constructed at node when the program exits, or nil when there is no safe place
+	GenerateCleanupModifications(node *sitter.Node, content []byte) []types.CodeModification
+}
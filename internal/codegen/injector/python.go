@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/getlawrence/cli/internal/codegen/types"
+	"github.com/getlawrence/cli/internal/textedit"
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/python"
 )
@@ -56,7 +57,7 @@ func NewPythonInjector() *PythonInjector {
 			},
 			ImportTemplate:         `from opentelemetry import %s`,
 			InitializationTemplate: `init_tracer()`,
-			CleanupTemplate:        `tp.shutdown()`,
+			CleanupTemplate:        `atexit.register(%s.shutdown)`,
 			FrameworkTemplates: map[string]string{
 				"flask": `
 # Instrument Flask application
@@ -651,3 +652,32 @@ func (h *PythonInjector) FallbackAnalyzeImports(content []byte, analysis *types.
 func (h *PythonInjector) FallbackAnalyzeEntryPoints(content []byte, analysis *types.FileAnalysis) {
 	h.findMainBlockWithRegex(content, analysis)
 }
+
+// GetCleanupImports returns the imports needed to shut down at exit
+func (h *PythonInjector) GetCleanupImports() []string {
+	return []string{"atexit"}
+}
+
+// GenerateCleanupModifications registers the shutdown of a provider assigned to a name or
+// an attribute to run at exit
+func (h *PythonInjector) GenerateCleanupModifications(node *sitter.Node, content []byte) []types.CodeModification {
+	for ; node != nil; node = node.Parent() {
+		if node.Type() != "assignment" {
+			continue
+		}
+		statement := node.Parent()
+		left := node.ChildByFieldName("left")
+		if statement == nil || statement.Type() != "expression_statement" || left == nil ||
+			(left.Type() != "identifier" && left.Type() != "attribute") {
+			return nil
+		}
+		return []types.CodeModification{{
+			Type:        types.ModificationAddCleanup,
+			Language:    h.config.Language,
+			LineNumber:  statement.EndPoint().Row + 1,
+			InsertAfter: true,
+			Content:     textedit.LineIndent(string(content), int(statement.StartByte())) + fmt.Sprintf(h.config.CleanupTemplate, left.Content(content)),
+		}}
+	}
+	return nil
+}
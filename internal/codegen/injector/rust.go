@@ -0,0 +1,233 @@
+package injector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/types"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/rust"
+)
+
+// RustInjector implements LanguageInjector for Rust. The generated otel.rs is
+// declared as a module of the binary crate and initialized first thing in main.
+type RustInjector struct {
+	config *types.LanguageConfig
+}
+
+// NewRustInjector creates a new Rust language handler
+func NewRustInjector() *RustInjector {
+	return &RustInjector{
+		config: &types.LanguageConfig{
+			Language:       "Rust",
+			FileExtensions: []string{".rs"},
+			ImportQueries: map[string]string{
+				// Only crate-level items: modules declared inside functions or mod blocks
+				// are not valid places for `mod otel;`
+				"existing_imports": `
+                (source_file (use_declaration argument: (_) @import_path) @import_location)
+                (source_file (mod_item name: (identifier) @mod_name) @import_location)
+                (source_file (extern_crate_declaration name: (identifier) @import_path) @import_location)
+            `,
+			},
+			FunctionQueries: map[string]string{
+				"main_function": `
+                (source_file
+                  (attribute_item
+                    (attribute
+                      (scoped_identifier
+                        path: (identifier) @runtime
+                        name: (identifier) @attribute)))
+                  .
+                  (function_item
+                    name: (identifier) @fn_name
+                    body: (block) @tokio_main_body)
+                  (#eq? @runtime "tokio")
+                  (#eq? @attribute "main")
+                  (#eq? @fn_name "main"))
+
+                (source_file
+                  (function_item
+                    name: (identifier) @fn_name
+                    body: (block) @main_body)
+                  (#eq? @fn_name "main"))
+            `,
+			},
+			InsertionQueries: map[string]string{
+				"optimal_insertion": `
+                (block) @function_start
+            `,
+			},
+			ImportTemplate:         `mod %s;`,
+			InitializationTemplate: `    let _otel_guard = otel::init_tracer_provider();`,
+			CleanupTemplate:        `drop(_otel_guard);`,
+		},
+	}
+}
+
+// GetLanguage returns the tree-sitter language parser for Rust
+func (h *RustInjector) GetLanguage() *sitter.Language { return rust.GetLanguage() }
+
+// GetConfig returns the language configuration
+func (h *RustInjector) GetConfig() *types.LanguageConfig { return h.config }
+
+// GetRequiredImports returns the modules the entry point must declare: the generated otel.rs
+func (h *RustInjector) GetRequiredImports() []string {
+	return []string{"otel"}
+}
+
+// GetFrameworkImports returns framework-specific imports based on detected frameworks
+func (h *RustInjector) GetFrameworkImports(content []byte) []string {
+	// Instrumentation crates are wired up by the generated otel.rs
+	return []string{}
+}
+
+// FormatFrameworkImports formats framework-specific import statements for Rust
+func (h *RustInjector) FormatFrameworkImports(imports []string) string {
+	return ""
+}
+
+// GenerateFrameworkModifications generates framework-specific instrumentation modifications for Rust
+func (h *RustInjector) GenerateFrameworkModifications(content []byte, operationsData *types.OperationsData) []types.CodeModification {
+	return []types.CodeModification{}
+}
+
+// FormatImports formats Rust module declarations
+func (h *RustInjector) FormatImports(imports []string, hasExistingImports bool) string {
+	if len(imports) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, imp := range imports {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(h.FormatSingleImport(imp))
+	}
+	return b.String()
+}
+
+// FormatSingleImport formats a single Rust module declaration
+func (h *RustInjector) FormatSingleImport(importPath string) string {
+	return fmt.Sprintf(h.config.ImportTemplate, importPath)
+}
+
+// AnalyzeImportCapture records used crates and declared modules. The import location is
+// kept at the last crate-level use/mod item so `mod otel;` joins the existing declarations.
+func (h *RustInjector) AnalyzeImportCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis) {
+	switch captureName {
+	case "import_path":
+		path := strings.TrimPrefix(node.Content(content), "::")
+		root := strings.SplitN(path, "::", 2)[0]
+		analysis.ExistingImports[root] = true
+		if strings.HasPrefix(root, "opentelemetry") {
+			analysis.HasOTELImports = true
+		}
+	case "mod_name":
+		analysis.ExistingImports[node.Content(content)] = true
+	case "import_location":
+		point := types.InsertionPoint{
+			LineNumber: node.EndPoint().Row + 1,
+			Column:     node.EndPoint().Column + 1,
+			Context:    node.Content(content),
+			Priority:   2,
+		}
+		if len(analysis.ImportLocations) == 0 {
+			analysis.ImportLocations = append(analysis.ImportLocations, point)
+		} else if point.LineNumber > analysis.ImportLocations[0].LineNumber {
+			analysis.ImportLocations[0] = point
+		}
+	}
+}
+
+// AnalyzeFunctionCapture records fn main, noting whether it runs on the tokio runtime
+func (h *RustInjector) AnalyzeFunctionCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis, config *types.LanguageConfig) {
+	var name string
+	switch captureName {
+	case "tokio_main_body":
+		name = "tokio::main"
+	case "main_body":
+		name = "main"
+	default:
+		return
+	}
+
+	entryPoint := types.EntryPointInfo{
+		Name:       name,
+		LineNumber: node.StartPoint().Row + 1,
+		Column:     node.StartPoint().Column + 1,
+		// Initialize on the line after the opening brace
+		BodyStart: types.InsertionPoint{
+			LineNumber: node.StartPoint().Row + 1,
+			Column:     node.StartPoint().Column + 1,
+			Priority:   h.GetInsertionPointPriority("function_start"),
+		},
+		BodyEnd: types.InsertionPoint{
+			LineNumber: node.EndPoint().Row + 1,
+			Column:     node.EndPoint().Column + 1,
+		},
+		HasOTELSetup: h.detectExistingOTELSetup(node, content),
+	}
+
+	// A #[tokio::main] function also matches the plain main pattern; keep one entry
+	// and prefer the more specific name
+	for i, existing := range analysis.EntryPoints {
+		if existing.LineNumber == entryPoint.LineNumber {
+			if name == "tokio::main" {
+				analysis.EntryPoints[i].Name = name
+			}
+			return
+		}
+	}
+	analysis.EntryPoints = append(analysis.EntryPoints, entryPoint)
+}
+
+// GetInsertionPointPriority returns priority for Rust insertion point types
+func (h *RustInjector) GetInsertionPointPriority(captureName string) int {
+	switch captureName {
+	case "function_start":
+		return 100
+	default:
+		return 1
+	}
+}
+
+// detectExistingOTELSetup checks if main already initializes OpenTelemetry
+func (h *RustInjector) detectExistingOTELSetup(node *sitter.Node, content []byte) bool {
+	body := node.Content(content)
+	return strings.Contains(body, "init_tracer_provider") ||
+		strings.Contains(body, "SdkTracerProvider") ||
+		strings.Contains(body, "TracerProvider::builder") ||
+		strings.Contains(body, "set_tracer_provider") ||
+		strings.Contains(body, "opentelemetry_otlp::")
+}
+
+// FallbackAnalyzeImports places module declarations after any leading inner attributes
+// (#![...]) and inner doc comments (//!), which must precede all items
+func (h *RustInjector) FallbackAnalyzeImports(content []byte, analysis *types.FileAnalysis) {
+	lines := strings.Split(string(content), "\n")
+	after := 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#![") || strings.HasPrefix(trimmed, "//!") {
+			after = i + 1
+			continue
+		}
+		if trimmed != "" {
+			break
+		}
+	}
+	analysis.ImportLocations = append(analysis.ImportLocations, types.InsertionPoint{
+		LineNumber: uint32(after),
+		Column:     1,
+		Priority:   1,
+	})
+}
+
+// FallbackAnalyzeEntryPoints: no-op for Rust; only fn main is an entry point
+func (h *RustInjector) FallbackAnalyzeEntryPoints(content []byte, analysis *types.FileAnalysis) {}
+
+// GenerateImportModifications generates modifications to fix import statements
+func (h *RustInjector) GenerateImportModifications(content []byte, analysis *types.FileAnalysis) []types.CodeModification {
+	return []types.CodeModification{}
+}
@@ -0,0 +1,245 @@
+package injector
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/codegen/types"
+	"github.com/getlawrence/cli/internal/tsconfig"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// otelBootstrapImportRe matches a relative import or require of the generated otel bootstrap
+var otelBootstrapImportRe = regexp.MustCompile(`['"](?:\.{1,2}/)+(?:[\w.-]+/)*otel(?:\.js)?['"]`)
+
+// TypeScriptInjector implements LanguageInjector for TypeScript. Files ending in .tsx are
+// parsed with the TSX grammar; see ForFile.
+type TypeScriptInjector struct {
+	config *types.LanguageConfig
+	tsx    bool
+}
+
+// NewTypeScriptInjector creates a new handler
+func NewTypeScriptInjector() *TypeScriptInjector {
+	return &TypeScriptInjector{
+		config: &types.LanguageConfig{
+			Language:       "TypeScript",
+			FileExtensions: []string{".ts", ".tsx", ".mts", ".cts"},
+			InitAtTop:      true,
+			ImportQueries: map[string]string{
+				"existing_imports": `
+                (import_statement source: (string) @import_path) @import_location
+                (call_expression
+                  function: (identifier) @require_ident
+                  arguments: (arguments (string) @require_path)
+                ) @import_location
+            `,
+			},
+			FunctionQueries: map[string]string{
+				"main_function": `
+                (call_expression
+                  function: (member_expression
+                    object: (identifier)
+                    property: (property_identifier) @method
+                  )
+                  (#eq? @method "listen")
+                ) @server_listen
+
+                (program) @main_block
+            `,
+			},
+			InsertionQueries: map[string]string{
+				"optimal_insertion": `
+                (program (lexical_declaration) @after_variables)
+                (program (expression_statement (call_expression)) @before_function_calls)
+                (program) @function_start
+            `,
+			},
+			ImportTemplate:         `import { %s } from "%s";`,
+			InitializationTemplate: `import './otel';`,
+			CleanupTemplate: `for (const signal of ['SIGTERM', 'SIGINT']) {
+  process.once(signal, () => {
+    %s.shutdown().finally(() => process.exit(0));
+  });
+}`,
+		},
+	}
+}
+
+// ForFile returns a handler for filePath: the TSX grammar for .tsx files and an
+// initialization import whose specifier follows the nearest tsconfig.json
+func (h *TypeScriptInjector) ForFile(filePath string) LanguageInjector {
+	config := *h.config
+	dir := filepath.Dir(filePath)
+	if cfg, err := tsconfig.Find(dir, filepath.VolumeName(dir)+string(filepath.Separator)); err == nil && cfg != nil {
+		bootstrap := filepath.Join(cfg.SourceDir(), "otel")
+		config.InitializationTemplate = fmt.Sprintf("import '%s';", cfg.ImportSpecifier(dir, bootstrap))
+	}
+	return &TypeScriptInjector{
+		config: &config,
+		tsx:    strings.EqualFold(filepath.Ext(filePath), ".tsx"),
+	}
+}
+
+// GetLanguage returns the tree-sitter language for TypeScript or TSX
+func (h *TypeScriptInjector) GetLanguage() *sitter.Language {
+	if h.tsx {
+		return tsx.GetLanguage()
+	}
+	return typescript.GetLanguage()
+}
+
+// GetConfig returns the language configuration
+func (h *TypeScriptInjector) GetConfig() *types.LanguageConfig { return h.config }
+
+// GetRequiredImports returns the list of imports needed for OTEL in TypeScript
+func (h *TypeScriptInjector) GetRequiredImports() []string {
+	// The generated otel.ts bootstrap owns all OTEL imports
+	return []string{}
+}
+
+// GetFrameworkImports returns framework-specific imports based on detected frameworks
+func (h *TypeScriptInjector) GetFrameworkImports(content []byte) []string {
+	// Instrumentations are registered by the bootstrap, not imported by user files
+	return []string{}
+}
+
+// FormatFrameworkImports formats framework-specific import statements for TypeScript
+func (h *TypeScriptInjector) FormatFrameworkImports(imports []string) string {
+	return ""
+}
+
+// GenerateFrameworkModifications generates framework-specific instrumentation modifications for TypeScript
+func (h *TypeScriptInjector) GenerateFrameworkModifications(content []byte, operationsData *types.OperationsData) []types.CodeModification {
+	return []types.CodeModification{}
+}
+
+// FormatImports formats TypeScript side-effect import statements
+func (h *TypeScriptInjector) FormatImports(imports []string, hasExisting bool) string {
+	if len(imports) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, imp := range imports {
+		b.WriteString(h.FormatSingleImport(imp))
+	}
+	return b.String()
+}
+
+// FormatSingleImport formats a single TypeScript import
+func (h *TypeScriptInjector) FormatSingleImport(importPath string) string {
+	return fmt.Sprintf("import \"%s\";\n", importPath)
+}
+
+// AnalyzeImportCapture records imports
+func (h *TypeScriptInjector) AnalyzeImportCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis) {
+	switch captureName {
+	case "import_path", "require_path":
+		path := strings.Trim(node.Content(content), "\"'")
+		analysis.ExistingImports[path] = true
+		if strings.Contains(path, "@opentelemetry/") {
+			analysis.HasOTELImports = true
+		}
+	case "import_location":
+		analysis.ImportLocations = append(analysis.ImportLocations, types.InsertionPoint{
+			LineNumber: node.EndPoint().Row + 1,
+			Column:     node.EndPoint().Column + 1,
+			Context:    node.Content(content),
+			Priority:   2,
+		})
+	}
+}
+
+// AnalyzeFunctionCapture finds entry blocks
+func (h *TypeScriptInjector) AnalyzeFunctionCapture(captureName string, node *sitter.Node, content []byte, analysis *types.FileAnalysis, config *types.LanguageConfig) {
+	switch captureName {
+	case "server_listen", "main_block":
+		insertionPoint := h.findBestInsertionPoint(node, content, config)
+		entryPoint := types.EntryPointInfo{
+			Name:         "main",
+			LineNumber:   node.StartPoint().Row + 1,
+			Column:       node.StartPoint().Column + 1,
+			BodyStart:    insertionPoint,
+			BodyEnd:      types.InsertionPoint{LineNumber: node.EndPoint().Row + 1, Column: node.EndPoint().Column + 1},
+			HasOTELSetup: h.detectExistingOTELSetup(node, content),
+		}
+		analysis.EntryPoints = append(analysis.EntryPoints, entryPoint)
+	}
+}
+
+// GetInsertionPointPriority for TypeScript
+func (h *TypeScriptInjector) GetInsertionPointPriority(captureName string) int {
+	switch captureName {
+	case "after_variables":
+		return 3
+	case "before_function_calls":
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (h *TypeScriptInjector) findBestInsertionPoint(node *sitter.Node, content []byte, config *types.LanguageConfig) types.InsertionPoint {
+	defaultPoint := types.InsertionPoint{LineNumber: node.StartPoint().Row + 1, Column: node.StartPoint().Column + 1, Priority: 1}
+	insertQuery, ok := config.InsertionQueries["optimal_insertion"]
+	if !ok {
+		return defaultPoint
+	}
+	q, err := sitter.NewQuery([]byte(insertQuery), h.GetLanguage())
+	if err != nil {
+		return defaultPoint
+	}
+	defer q.Close()
+	cur := sitter.NewQueryCursor()
+	defer cur.Close()
+	cur.Exec(q, node)
+	best := defaultPoint
+	for {
+		m, ok := cur.NextMatch()
+		if !ok {
+			break
+		}
+		for _, c := range m.Captures {
+			p := h.GetInsertionPointPriority(q.CaptureNameForId(c.Index))
+			if p > best.Priority {
+				n := c.Node
+				best = types.InsertionPoint{LineNumber: n.EndPoint().Row + 1, Column: n.EndPoint().Column + 1, Context: n.Content(content), Priority: p}
+			}
+		}
+	}
+	return best
+}
+
+func (h *TypeScriptInjector) detectExistingOTELSetup(node *sitter.Node, content []byte) bool {
+	body := node.Content(content)
+	if strings.Contains(body, "@opentelemetry/sdk-node") || strings.Contains(body, "NodeSDK(") {
+		return true
+	}
+	// The bootstrap may be imported with or without the emitted .js extension
+	return otelBootstrapImportRe.MatchString(body) || strings.Contains(body, "setupOTel(")
+}
+
+// FallbackAnalyzeImports: no-op for TypeScript
+func (h *TypeScriptInjector) FallbackAnalyzeImports(content []byte, analysis *types.FileAnalysis) {}
+
+// FallbackAnalyzeEntryPoints: no-op for TypeScript; the program node is always captured
+func (h *TypeScriptInjector) FallbackAnalyzeEntryPoints(content []byte, analysis *types.FileAnalysis) {
+}
+
+// GenerateImportModifications generates modifications to fix import statements
+func (h *TypeScriptInjector) GenerateImportModifications(content []byte, analysis *types.FileAnalysis) []types.CodeModification {
+	return []types.CodeModification{}
+}
+
+// GetCleanupImports returns no imports; process is a global
+func (h *TypeScriptInjector) GetCleanupImports() []string { return nil }
+
+// GenerateCleanupModifications shuts down an SDK or provider assigned to a variable when
+// the process receives a termination signal
+func (h *TypeScriptInjector) GenerateCleanupModifications(node *sitter.Node, content []byte) []types.CodeModification {
+	return signalCleanupModifications(node, content, h.config)
+}
@@ -0,0 +1,307 @@
+package cpp
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/textedit"
+)
+
+// CMakeFileName is the CMake project file
+const CMakeFileName = "CMakeLists.txt"
+
+// ErrNoExecutable is returned when CMakeLists.txt defines no executable target to link
+var ErrNoExecutable = errors.New("CMakeLists.txt has no add_executable target")
+
+// Package is a C++ dependency declared in CMakeLists.txt, vcpkg.json or a conanfile
+type Package struct {
+	Name string
+	// Version is the declared version or requirement; empty when unpinned
+	Version string
+}
+
+// command is a CMake command invocation such as find_package(fmt CONFIG REQUIRED)
+type command struct {
+	// Name is the lower-cased command name
+	Name string
+	Args []string
+	// start is the offset of the command name and end the offset just past its closing parenthesis
+	start, end int
+}
+
+// FindPackages returns the packages imported with find_package in CMakeLists.txt
+func FindPackages(content []byte) []Package {
+	var packages []Package
+	for _, cmd := range parseCommands(string(content)) {
+		if cmd.Name != "find_package" || len(cmd.Args) == 0 {
+			continue
+		}
+		pkg := Package{Name: cmd.Args[0]}
+		if len(cmd.Args) > 1 && isCMakeVersion(cmd.Args[1]) {
+			pkg.Version = cmd.Args[1]
+		}
+		packages = append(packages, pkg)
+	}
+	return packages
+}
+
+// ProjectName returns the name given to project() in CMakeLists.txt, or ""
+func ProjectName(content []byte) string {
+	for _, cmd := range parseCommands(string(content)) {
+		if cmd.Name == "project" && len(cmd.Args) > 0 {
+			return cmd.Args[0]
+		}
+	}
+	return ""
+}
+
+// DeclaresCXX reports whether CMakeLists.txt defines a project that builds C++: project()
+// without languages enables C and C++ by default
+func DeclaresCXX(content []byte) bool {
+	for _, cmd := range parseCommands(string(content)) {
+		if cmd.Name != "project" || len(cmd.Args) == 0 {
+			continue
+		}
+		languages := projectLanguages(cmd.Args[1:])
+		return len(languages) == 0 || languages["CXX"]
+	}
+	return false
+}
+
+// cmakeLanguages are the language names project() accepts
+var cmakeLanguages = map[string]bool{
+	"C": true, "CXX": true, "CUDA": true, "OBJC": true, "OBJCXX": true, "Fortran": true,
+	"HIP": true, "ISPC": true, "Swift": true, "CSharp": true, "ASM": true, "NONE": true,
+}
+
+// projectLanguages returns the languages enabled by the project() arguments after the
+// name, given after LANGUAGES or in the short form project(name C CXX)
+func projectLanguages(args []string) map[string]bool {
+	languages := make(map[string]bool)
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "VERSION", "DESCRIPTION", "HOMEPAGE_URL":
+			// Skip the keyword's value
+			i++
+		default:
+			if cmakeLanguages[args[i]] {
+				languages[args[i]] = true
+			}
+		}
+	}
+	return languages
+}
+
+// AddFindPackage adds find_package(name CONFIG REQUIRED) after the last find_package call,
+// or after project(), unless the package is already imported. It reports whether it changed
+// the content.
+func AddFindPackage(content []byte, name string) ([]byte, bool) {
+	text := string(content)
+	lastFind, project, minimum := -1, -1, -1
+	for _, cmd := range parseCommands(text) {
+		switch cmd.Name {
+		case "find_package":
+			if len(cmd.Args) > 0 && strings.EqualFold(cmd.Args[0], name) {
+				return content, false
+			}
+			lastFind = cmd.end
+		case "project":
+			if project < 0 {
+				project = cmd.end
+			}
+		case "cmake_minimum_required":
+			minimum = cmd.end
+		}
+	}
+	anchor := lastFind
+	if anchor < 0 {
+		anchor = max(project, minimum)
+	}
+
+	line := "find_package(" + name + " CONFIG REQUIRED)"
+	if anchor < 0 {
+		return []byte(line + "\n" + text), true
+	}
+	eol := strings.IndexByte(text[anchor:], '\n')
+	if eol < 0 {
+		return []byte(text + "\n" + line + "\n"), true
+	}
+	eol += anchor
+	return []byte(text[:eol+1] + textedit.LineIndent(text, anchor) + line + "\n" + text[eol+1:]), true
+}
+
+// LinkLibraries links the first executable target against targets, appending to its
+// target_link_libraries call or adding one after add_executable. It returns the updated
+// content and the targets that were added.
+func LinkLibraries(content []byte, targets []string) ([]byte, []string, error) {
+	text := string(content)
+	commands := parseCommands(text)
+
+	var executable *command
+	for i := range commands {
+		if commands[i].Name == "add_executable" && len(commands[i].Args) > 0 && !isCMakeAlias(commands[i].Args) {
+			executable = &commands[i]
+			break
+		}
+	}
+	if executable == nil {
+		return nil, nil, ErrNoExecutable
+	}
+	target := executable.Args[0]
+
+	var link *command
+	linked := make(map[string]bool)
+	for i := range commands {
+		cmd := &commands[i]
+		if cmd.Name != "target_link_libraries" || len(cmd.Args) == 0 || cmd.Args[0] != target {
+			continue
+		}
+		for _, arg := range cmd.Args[1:] {
+			linked[arg] = true
+		}
+		link = cmd
+	}
+
+	var added []string
+	for _, t := range targets {
+		if !linked[t] {
+			linked[t] = true
+			added = append(added, t)
+		}
+	}
+	if len(added) == 0 {
+		return content, nil, nil
+	}
+
+	if link != nil {
+		// Keep the call's layout: one target per line when it already spans several lines
+		separator := " "
+		if call := text[link.start:link.end]; strings.Contains(call, "\n") {
+			last := strings.LastIndexByte(call, '\n')
+			if strings.TrimSpace(call[last:len(call)-1]) == "" {
+				// The closing parenthesis is on its own line
+				insertAt := link.start + last
+				indent := textedit.LineIndent(text, link.start) + "  "
+				return []byte(text[:insertAt] + "\n" + indent + strings.Join(added, "\n"+indent) + text[insertAt:]), added, nil
+			}
+			separator = "\n" + textedit.LineIndent(text, link.start) + "  "
+		}
+		closing := link.end - 1
+		return []byte(text[:closing] + separator + strings.Join(added, separator) + text[closing:]), added, nil
+	}
+
+	line := "target_link_libraries(" + target + " PRIVATE " + strings.Join(added, " ") + ")"
+	eol := strings.IndexByte(text[executable.end:], '\n')
+	if eol < 0 {
+		return []byte(text + "\n" + line + "\n"), added, nil
+	}
+	eol += executable.end
+	return []byte(text[:eol+1] + textedit.LineIndent(text, executable.start) + line + "\n" + text[eol+1:]), added, nil
+}
+
+// isCMakeAlias reports whether add_executable declares an imported or alias target
+func isCMakeAlias(args []string) bool {
+	return len(args) > 1 && (args[1] == "IMPORTED" || args[1] == "ALIAS")
+}
+
+// isCMakeVersion reports whether a find_package argument is a version such as 1.16 or 1.2...<2
+func isCMakeVersion(arg string) bool {
+	return arg != "" && arg[0] >= '0' && arg[0] <= '9'
+}
+
+// parseCommands splits a CMake script into command invocations, skipping comments and
+// keeping quoted arguments whole
+func parseCommands(text string) []command {
+	var commands []command
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '#':
+			i = skipCMakeComment(text, i)
+		case isIdentStart(c) && (i == 0 || !isIdentChar(text[i-1])):
+			start := i
+			for i < len(text) && isIdentChar(text[i]) {
+				i++
+			}
+			name := strings.ToLower(text[start:i])
+			j := i
+			for j < len(text) && (text[j] == ' ' || text[j] == '\t') {
+				j++
+			}
+			if j >= len(text) || text[j] != '(' {
+				continue
+			}
+			args, end := parseArguments(text, j)
+			commands = append(commands, command{Name: name, Args: args, start: start, end: end})
+			i = end
+		default:
+			i++
+		}
+	}
+	return commands
+}
+
+// parseArguments reads the arguments of the command whose opening parenthesis is at open.
+// It returns the arguments and the offset just past the closing parenthesis.
+func parseArguments(text string, open int) ([]string, int) {
+	var args []string
+	depth := 0
+	for i := open; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+			if depth == 0 {
+				return args, i
+			}
+		case c == '#':
+			i = skipCMakeComment(text, i)
+		case c == '"':
+			j := i + 1
+			for j < len(text) && text[j] != '"' {
+				if text[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			args = append(args, text[i+1:min(j, len(text))])
+			i = j + 1
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		default:
+			j := i
+			for j < len(text) && !strings.ContainsRune(" \t\r\n()#\"", rune(text[j])) {
+				j++
+			}
+			args = append(args, text[i:j])
+			i = j
+		}
+	}
+	return args, len(text)
+}
+
+// skipCMakeComment returns the offset after the line or bracket comment starting at i
+func skipCMakeComment(text string, i int) int {
+	if strings.HasPrefix(text[i:], "#[[") {
+		if end := strings.Index(text[i:], "]]"); end >= 0 {
+			return i + end + 2
+		}
+		return len(text)
+	}
+	for i < len(text) && text[i] != '\n' {
+		i++
+	}
+	return i
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
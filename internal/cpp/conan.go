@@ -0,0 +1,330 @@
+package cpp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/textedit"
+)
+
+// Conan recipe file names
+const (
+	ConanfileTxt = "conanfile.txt"
+	ConanfilePy  = "conanfile.py"
+)
+
+// ErrNoConanFileClass is returned when conanfile.py has no ConanFile subclass to add requirements to
+var ErrNoConanFileClass = errors.New("conanfile.py has no ConanFile class")
+
+var (
+	// conanReferenceRe matches a package reference such as fmt/10.2.1@user/channel#rev
+	conanReferenceRe = regexp.MustCompile(`^([A-Za-z0-9_][A-Za-z0-9_.+-]*)/([^@#\s]+)`)
+	// conanSelfRequiresRe matches self.requires("ref") calls in the requirements method
+	conanSelfRequiresRe = regexp.MustCompile(`(?m)^([ \t]*)self\.requires\(\s*["']([^"']+)["']`)
+	// conanRequiresAttrRe matches the requires class attribute
+	conanRequiresAttrRe = regexp.MustCompile(`(?m)^([ \t]*)requires[ \t]*=[ \t]*`)
+	// conanStringRe matches a quoted reference
+	conanStringRe = regexp.MustCompile(`["']([^"']+)["']`)
+	// conanRequirementsMethodRe matches the head of the requirements method
+	conanRequirementsMethodRe = regexp.MustCompile(`(?m)^([ \t]*)def[ \t]+requirements\(\s*self\s*\)[^:\n]*:[ \t]*(?:#.*)?$`)
+	// conanClassRe matches the recipe class declaration
+	conanClassRe = regexp.MustCompile(`(?m)^class[ \t]+\w+\([^)]*ConanFile[^)]*\)[ \t]*:[ \t]*(?:#.*)?$`)
+)
+
+// ConanReference renders a package reference; unpinned packages accept any version
+func ConanReference(pkg Package) string {
+	version := pkg.Version
+	if version == "" {
+		version = "[*]"
+	}
+	return pkg.Name + "/" + version
+}
+
+// parseConanReference splits a reference into its package name and version
+func parseConanReference(ref string) (Package, bool) {
+	m := conanReferenceRe.FindStringSubmatch(strings.TrimSpace(ref))
+	if m == nil {
+		return Package{}, false
+	}
+	return Package{Name: m[1], Version: m[2]}, true
+}
+
+// ConanfileTxtRequires returns the packages listed in the [requires] section of conanfile.txt
+func ConanfileTxtRequires(content []byte) []Package {
+	var packages []Package
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		if section != "requires" {
+			continue
+		}
+		if pkg, ok := parseConanReference(line); ok {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// AddConanfileTxtRequires adds packages to the [requires] section and options to the
+// [options] section of conanfile.txt, creating the sections when missing. Packages and
+// options already present are skipped. It returns the updated content and added packages.
+func AddConanfileTxtRequires(content []byte, packages []Package, options []string) ([]byte, []string) {
+	listed := make(map[string]bool)
+	for _, pkg := range ConanfileTxtRequires(content) {
+		listed[pkg.Name] = true
+	}
+	var refs, added []string
+	for _, pkg := range packages {
+		if pkg.Name == "" || listed[pkg.Name] {
+			continue
+		}
+		listed[pkg.Name] = true
+		refs = append(refs, ConanReference(pkg))
+		added = append(added, pkg.Name)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	lines = appendToConanSection(lines, "requires", refs, func(string) bool { return false })
+	lines = appendToConanSection(lines, "options", options, func(option string) bool {
+		key := strings.TrimSpace(strings.SplitN(option, "=", 2)[0])
+		return conanSectionHasKey(lines, "options", key)
+	})
+	return []byte(strings.Join(lines, "\n")), added
+}
+
+// appendToConanSection appends entries after the last entry of a conanfile.txt section,
+// skipping the ones present reports as existing. A missing section is added at the end.
+func appendToConanSection(lines []string, section string, entries []string, present func(string) bool) []string {
+	var missing []string
+	for _, e := range entries {
+		if !present(e) {
+			missing = append(missing, e)
+		}
+	}
+	if len(missing) == 0 {
+		return lines
+	}
+
+	header, last := -1, -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			if header >= 0 {
+				break
+			}
+			if strings.Trim(trimmed, "[]") == section {
+				header, last = i, i
+			}
+			continue
+		}
+		if header >= 0 && trimmed != "" {
+			last = i
+		}
+	}
+
+	if header < 0 {
+		// Keep a single trailing newline after the new section
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+section+"]")
+		lines = append(lines, missing...)
+		return append(lines, "")
+	}
+
+	result := make([]string, 0, len(lines)+len(missing))
+	result = append(result, lines[:last+1]...)
+	result = append(result, missing...)
+	return append(result, lines[last+1:]...)
+}
+
+// conanSectionHasKey reports whether a conanfile.txt section sets key (e.g. an option)
+func conanSectionHasKey(lines []string, section, key string) bool {
+	current := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			current = strings.Trim(trimmed, "[]")
+			continue
+		}
+		if current == section && strings.TrimSpace(strings.SplitN(trimmed, "=", 2)[0]) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ConanfilePyRequires returns the packages required by conanfile.py, either through the
+// requires attribute or self.requires() calls
+func ConanfilePyRequires(content []byte) []Package {
+	text := string(content)
+	var packages []Package
+	if loc := conanRequiresAttrRe.FindStringIndex(text); loc != nil {
+		start, end := pythonValueSpan(text, loc[1])
+		for _, m := range conanStringRe.FindAllStringSubmatch(text[start:end], -1) {
+			if pkg, ok := parseConanReference(m[1]); ok {
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	for _, m := range conanSelfRequiresRe.FindAllStringSubmatch(text, -1) {
+		if pkg, ok := parseConanReference(m[2]); ok {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// AddConanfilePyRequires adds packages to conanfile.py: as self.requires() calls in the
+// requirements method when the recipe has one, otherwise to the requires attribute, which
+// is created when missing. It returns the updated content and the added packages.
+func AddConanfilePyRequires(content []byte, packages []Package) ([]byte, []string, error) {
+	listed := make(map[string]bool)
+	for _, pkg := range ConanfilePyRequires(content) {
+		listed[pkg.Name] = true
+	}
+	var refs, added []string
+	for _, pkg := range packages {
+		if pkg.Name == "" || listed[pkg.Name] {
+			continue
+		}
+		listed[pkg.Name] = true
+		refs = append(refs, ConanReference(pkg))
+		added = append(added, pkg.Name)
+	}
+	if len(refs) == 0 {
+		return content, nil, nil
+	}
+	text := string(content)
+
+	if method := conanRequirementsMethodRe.FindStringSubmatchIndex(text); method != nil {
+		// After the last self.requires() call, or first thing in the method
+		indent := text[method[2]:method[3]] + "    "
+		anchor := method[1]
+		for _, m := range conanSelfRequiresRe.FindAllStringSubmatchIndex(text, -1) {
+			if m[0] > method[1] {
+				indent = text[m[2]:m[3]]
+				anchor = m[0] + strings.IndexByte(text[m[0]:], '\n')
+				if anchor < m[0] {
+					anchor = len(text)
+				}
+			}
+		}
+		var b strings.Builder
+		for _, ref := range refs {
+			b.WriteString("\n" + indent + "self.requires(" + strconv.Quote(ref) + ")")
+		}
+		return []byte(text[:anchor] + b.String() + text[anchor:]), added, nil
+	}
+
+	quoted := make([]string, len(refs))
+	for i, ref := range refs {
+		quoted[i] = strconv.Quote(ref)
+	}
+
+	if attr := conanRequiresAttrRe.FindStringIndex(text); attr != nil {
+		start, end := pythonValueSpan(text, attr[1])
+		value := strings.TrimSpace(text[start:end])
+		if value == "" {
+			return nil, nil, ErrNoConanFileClass
+		}
+		if value[0] != '(' && value[0] != '[' {
+			// A single reference string becomes a tuple
+			replacement := "(" + value + ", " + strings.Join(quoted, ", ") + ")"
+			return []byte(text[:start] + replacement + text[start+len(value):]), added, nil
+		}
+
+		closing := start + len(value) - 1
+		lastCode := start + len(strings.TrimRight(text[start:closing], " \t\r\n"))
+		empty := lastCode == start+1
+		trailingComma := text[lastCode-1] == ','
+		var insert string
+		if strings.Contains(value, "\n") {
+			// One entry per line, before the line holding the closing bracket
+			indent := textedit.LineIndent(text, closing) + "    "
+			if !empty && !trailingComma {
+				insert = ","
+			}
+			insert += "\n" + indent + strings.Join(quoted, ",\n"+indent)
+			if trailingComma {
+				insert += ","
+			}
+		} else {
+			insert = strings.Join(quoted, ", ")
+			switch {
+			case trailingComma:
+				insert = " " + insert
+			case !empty:
+				insert = ", " + insert
+			}
+		}
+		return []byte(text[:lastCode] + insert + text[lastCode:]), added, nil
+	}
+
+	class := conanClassRe.FindStringIndex(text)
+	if class == nil {
+		return nil, nil, ErrNoConanFileClass
+	}
+	indent := "    "
+	if rest := text[class[1]:]; strings.HasPrefix(rest, "\n") {
+		for _, line := range strings.Split(rest[1:], "\n") {
+			if strings.TrimSpace(line) != "" {
+				indent = textedit.LineIndent(line, 0)
+				break
+			}
+		}
+	}
+	value := quoted[0]
+	if len(quoted) > 1 {
+		value = "(" + strings.Join(quoted, ", ") + ")"
+	}
+	return []byte(text[:class[1]] + "\n" + indent + "requires = " + value + text[class[1]:]), added, nil
+}
+
+// pythonValueSpan returns the span of the Python expression starting at start: a string,
+// or a bracketed tuple or list that may span several lines
+func pythonValueSpan(text string, start int) (int, int) {
+	depth := 0
+	for i := start; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '"', '\'':
+			for i++; i < len(text) && text[i] != c; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth--
+			if depth == 0 {
+				return start, i + 1
+			}
+		case '#', '\n':
+			if depth == 0 {
+				return start, i
+			}
+			if c == '#' {
+				for i < len(text) && text[i] != '\n' {
+					i++
+				}
+			}
+		}
+	}
+	return start, len(text)
+}
@@ -0,0 +1,252 @@
+package cpp
+
+import (
+	"reflect"
+	"testing"
+)
+
+const serviceCMake = `cmake_minimum_required(VERSION 3.20)
+project(checkout VERSION 1.2.0 LANGUAGES CXX)
+
+# find_package(Boost) is vendored
+find_package(fmt 10.1 CONFIG REQUIRED)
+
+add_executable(checkout src/main.cc)
+target_link_libraries(checkout
+  PRIVATE
+    fmt::fmt
+)
+`
+
+func TestCMakeParsing(t *testing.T) {
+	content := []byte(serviceCMake)
+	if got := FindPackages(content); !reflect.DeepEqual(got, []Package{{Name: "fmt", Version: "10.1"}}) {
+		t.Errorf("unexpected packages: %+v", got)
+	}
+	if got := ProjectName(content); got != "checkout" {
+		t.Errorf("expected project checkout, got %q", got)
+	}
+	if !DeclaresCXX(content) {
+		t.Error("expected a C++ project")
+	}
+	if DeclaresCXX([]byte("project(firmware VERSION 2.0 LANGUAGES C ASM)")) {
+		t.Error("C-only project should not be reported as C++")
+	}
+	if !DeclaresCXX([]byte("project(tool)")) {
+		t.Error("project() without languages enables C++")
+	}
+}
+
+func TestCMakeEdits(t *testing.T) {
+	out, changed := AddFindPackage([]byte(serviceCMake), "opentelemetry-cpp")
+	if !changed {
+		t.Fatal("expected find_package to be added")
+	}
+	out, added, err := LinkLibraries(out, []string{"fmt::fmt", "opentelemetry-cpp::trace", "opentelemetry-cpp::otlp_http_exporter"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(added) != 2 {
+		t.Fatalf("expected two targets added, got %v", added)
+	}
+	want := `cmake_minimum_required(VERSION 3.20)
+project(checkout VERSION 1.2.0 LANGUAGES CXX)
+
+# find_package(Boost) is vendored
+find_package(fmt 10.1 CONFIG REQUIRED)
+find_package(opentelemetry-cpp CONFIG REQUIRED)
+
+add_executable(checkout src/main.cc)
+target_link_libraries(checkout
+  PRIVATE
+    fmt::fmt
+  opentelemetry-cpp::trace
+  opentelemetry-cpp::otlp_http_exporter
+)
+`
+	if string(out) != want {
+		t.Fatalf("unexpected CMakeLists.txt:\n%s", out)
+	}
+
+	// Running again changes nothing
+	if _, changed := AddFindPackage(out, "opentelemetry-cpp"); changed {
+		t.Error("find_package added twice")
+	}
+	if _, added, _ := LinkLibraries(out, []string{"opentelemetry-cpp::trace"}); len(added) != 0 {
+		t.Errorf("targets linked twice: %v", added)
+	}
+
+	minimal := "project(tool)\nadd_executable(tool main.cpp)\n"
+	out, _, err = LinkLibraries([]byte(minimal), []string{"opentelemetry-cpp::trace"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "project(tool)\nadd_executable(tool main.cpp)\ntarget_link_libraries(tool PRIVATE opentelemetry-cpp::trace)\n"; string(out) != want {
+		t.Errorf("unexpected CMakeLists.txt:\n%s", out)
+	}
+	if _, _, err := LinkLibraries([]byte("project(lib)\nadd_library(lib lib.cc)\n"), []string{"x"}); err != ErrNoExecutable {
+		t.Errorf("expected ErrNoExecutable, got %v", err)
+	}
+}
+
+func TestAddVcpkgPorts(t *testing.T) {
+	manifest := `{
+  "name": "checkout",
+  "version-string": "1.2.0",
+  "dependencies": [
+    "fmt"
+  ]
+}
+`
+	out, added, err := AddVcpkgPorts([]byte(manifest), []VcpkgPort{
+		{Name: "fmt"},
+		{Name: "opentelemetry-cpp", Version: "1.16.1", Features: []string{"otlp-http"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(added, []string{"opentelemetry-cpp"}) {
+		t.Fatalf("unexpected added ports: %v", added)
+	}
+	// Without a builtin-baseline the minimum version is dropped
+	want := `{
+  "name": "checkout",
+  "version-string": "1.2.0",
+  "dependencies": [
+    "fmt",
+    {
+      "name": "opentelemetry-cpp",
+      "features": [
+        "otlp-http"
+      ]
+    }
+  ]
+}
+`
+	if string(out) != want {
+		t.Fatalf("unexpected vcpkg.json:\n%s", out)
+	}
+
+	out, _, err = AddVcpkgPorts([]byte(`{"name": "tool", "builtin-baseline": "abc"}`), []VcpkgPort{{Name: "opentelemetry-cpp", Version: "1.16.1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deps, err := VcpkgDependencies(out)
+	if err != nil {
+		t.Fatalf("updated manifest is not valid JSON: %v\n%s", err, out)
+	}
+	if !reflect.DeepEqual(deps, []Package{{Name: "opentelemetry-cpp", Version: "1.16.1"}}) {
+		t.Errorf("unexpected dependencies: %+v", deps)
+	}
+}
+
+func TestConanfileTxt(t *testing.T) {
+	conanfile := `[requires]
+fmt/10.2.1
+zlib/1.3@user/stable#rev1
+
+[generators]
+CMakeDeps
+CMakeToolchain
+`
+	if got := ConanfileTxtRequires([]byte(conanfile)); !reflect.DeepEqual(got, []Package{{Name: "fmt", Version: "10.2.1"}, {Name: "zlib", Version: "1.3"}}) {
+		t.Errorf("unexpected requires: %+v", got)
+	}
+
+	out, added := AddConanfileTxtRequires([]byte(conanfile), []Package{{Name: "opentelemetry-cpp", Version: "1.16.1"}, {Name: "fmt"}}, []string{"opentelemetry-cpp/*:with_otlp_http=True"})
+	if !reflect.DeepEqual(added, []string{"opentelemetry-cpp"}) {
+		t.Fatalf("unexpected added packages: %v", added)
+	}
+	want := `[requires]
+fmt/10.2.1
+zlib/1.3@user/stable#rev1
+opentelemetry-cpp/1.16.1
+
+[generators]
+CMakeDeps
+CMakeToolchain
+
+[options]
+opentelemetry-cpp/*:with_otlp_http=True
+`
+	if string(out) != want {
+		t.Fatalf("unexpected conanfile.txt:\n%s", out)
+	}
+	if again, added := AddConanfileTxtRequires(out, []Package{{Name: "opentelemetry-cpp"}}, []string{"opentelemetry-cpp/*:with_otlp_http=True"}); len(added) != 0 || string(again) != want {
+		t.Errorf("second run changed conanfile.txt:\n%s", again)
+	}
+}
+
+func TestConanfilePy(t *testing.T) {
+	otel := []Package{{Name: "opentelemetry-cpp", Version: "1.16.1"}}
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "requirements method",
+			in: `class Checkout(ConanFile):
+    def requirements(self):
+        self.requires("fmt/10.2.1")
+        self.tool_requires("cmake/3.27.0")
+`,
+			want: `class Checkout(ConanFile):
+    def requirements(self):
+        self.requires("fmt/10.2.1")
+        self.requires("opentelemetry-cpp/1.16.1")
+        self.tool_requires("cmake/3.27.0")
+`,
+		},
+		{
+			name: "requires string",
+			in: `class Checkout(ConanFile):
+    requires = "fmt/10.2.1"
+`,
+			want: `class Checkout(ConanFile):
+    requires = ("fmt/10.2.1", "opentelemetry-cpp/1.16.1")
+`,
+		},
+		{
+			name: "multi-line requires tuple",
+			in: `class Checkout(ConanFile):
+    requires = (
+        "fmt/10.2.1",
+    )
+`,
+			want: `class Checkout(ConanFile):
+    requires = (
+        "fmt/10.2.1",
+        "opentelemetry-cpp/1.16.1",
+    )
+`,
+		},
+		{
+			name: "no requires",
+			in: `class Checkout(ConanFile):
+    settings = "os", "compiler", "build_type", "arch"
+`,
+			want: `class Checkout(ConanFile):
+    requires = "opentelemetry-cpp/1.16.1"
+    settings = "os", "compiler", "build_type", "arch"
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, added, err := AddConanfilePyRequires([]byte(tt.in), otel)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(added) != 1 {
+				t.Fatalf("expected one added package, got %v", added)
+			}
+			if string(out) != tt.want {
+				t.Fatalf("unexpected conanfile.py:\n%s", out)
+			}
+			if _, added, _ := AddConanfilePyRequires(out, otel); len(added) != 0 {
+				t.Errorf("package added twice: %v", added)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package cpp
+
+// OpenTelemetryPackage is the name of opentelemetry-cpp in CMake, vcpkg and Conan
+const OpenTelemetryPackage = "opentelemetry-cpp"
+
+// LinkTargets returns the CMake targets a package's find_package() import provides for
+// linking. Packages without a known layout follow the name::name convention.
+func LinkTargets(name string) []string {
+	if name == OpenTelemetryPackage {
+		return []string{
+			"opentelemetry-cpp::trace",
+			"opentelemetry-cpp::otlp_http_exporter",
+			"opentelemetry-cpp::ostream_span_exporter",
+		}
+	}
+	return []string{name + "::" + name}
+}
+
+// VcpkgFeatures returns the port features needed by the generated setup
+func VcpkgFeatures(name string) []string {
+	if name == OpenTelemetryPackage {
+		return []string{"otlp-http"}
+	}
+	return nil
+}
+
+// ConanOptions returns the conanfile.txt options needed by the generated setup
+func ConanOptions(name string) []string {
+	if name == OpenTelemetryPackage {
+		return []string{"opentelemetry-cpp/*:with_otlp_http=True"}
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package cpp
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/textedit"
+)
+
+// VcpkgFileName is the vcpkg manifest
+const VcpkgFileName = "vcpkg.json"
+
+// ErrInvalidVcpkgManifest is returned when vcpkg.json is not a JSON object
+var ErrInvalidVcpkgManifest = errors.New("vcpkg.json is not a JSON object")
+
+// VcpkgPort is a dependency entry of vcpkg.json
+type VcpkgPort struct {
+	Name string `json:"name"`
+	// Version is the minimum version; vcpkg only honors it with a builtin-baseline
+	Version  string   `json:"version>=,omitempty"`
+	Features []string `json:"features,omitempty"`
+}
+
+// VcpkgDependencies returns the ports listed in vcpkg.json. Versions come from the
+// version>= constraint or, when pinned, from the overrides.
+func VcpkgDependencies(content []byte) ([]Package, error) {
+	var manifest struct {
+		Dependencies []json.RawMessage `json:"dependencies"`
+		Overrides    []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"overrides"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[string]string)
+	for _, o := range manifest.Overrides {
+		pinned[o.Name] = o.Version
+	}
+
+	var packages []Package
+	for _, raw := range manifest.Dependencies {
+		var port VcpkgPort
+		if err := json.Unmarshal(raw, &port.Name); err != nil {
+			if err := json.Unmarshal(raw, &port); err != nil {
+				continue
+			}
+		}
+		if port.Name == "" {
+			continue
+		}
+		version := port.Version
+		if v, ok := pinned[port.Name]; ok {
+			version = v
+		}
+		packages = append(packages, Package{Name: port.Name, Version: version})
+	}
+	return packages, nil
+}
+
+// AddVcpkgPorts appends ports to the dependencies array of vcpkg.json, creating the array
+// when missing and skipping ports already listed. Minimum versions are kept only when the
+// manifest has a builtin-baseline. It returns the updated content and the added port names.
+func AddVcpkgPorts(content []byte, ports []VcpkgPort) ([]byte, []string, error) {
+	existing, err := VcpkgDependencies(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	listed := make(map[string]bool)
+	for _, p := range existing {
+		listed[p.Name] = true
+	}
+
+	text := string(content)
+	object, ok := scanJSONObject(text)
+	if !ok {
+		return nil, nil, ErrInvalidVcpkgManifest
+	}
+	versioned := object.has("builtin-baseline")
+
+	var added []string
+	var entries []VcpkgPort
+	for _, port := range ports {
+		if port.Name == "" || listed[port.Name] {
+			continue
+		}
+		listed[port.Name] = true
+		if !versioned {
+			port.Version = ""
+		}
+		entries = append(entries, port)
+		added = append(added, port.Name)
+	}
+	if len(entries) == 0 {
+		return content, nil, nil
+	}
+
+	unit := object.indentUnit(text)
+	if deps, ok := object.members["dependencies"]; ok && text[deps.value] == '[' {
+		array, _ := scanJSONArray(text, deps.value)
+		indent := textedit.LineIndent(text, deps.key) + unit
+		if len(array.elements) > 0 && strings.Contains(text[array.open:array.elements[0]], "\n") {
+			indent = textedit.LineIndent(text, array.elements[0])
+		}
+		rendered := renderVcpkgPorts(entries, indent, unit)
+
+		var b strings.Builder
+		b.WriteString(text[:array.lastCode+1])
+		if len(array.elements) > 0 {
+			b.WriteString(",")
+		}
+		if eol := strings.IndexByte(text[array.lastCode:array.close], '\n'); eol >= 0 {
+			// The closing bracket is on its own line
+			b.WriteString("\n" + rendered)
+			b.WriteString(text[array.lastCode+eol:])
+		} else {
+			b.WriteString("\n" + rendered + "\n" + textedit.LineIndent(text, deps.key))
+			b.WriteString(text[array.close:])
+		}
+		return []byte(b.String()), added, nil
+	}
+
+	// No dependencies array yet: add one as the last member
+	keyIndent := unit
+	if object.lastKey >= 0 {
+		keyIndent = textedit.LineIndent(text, object.lastKey)
+	}
+	var b strings.Builder
+	b.WriteString(text[:object.lastCode+1])
+	if object.lastKey >= 0 {
+		b.WriteString(",")
+	}
+	b.WriteString("\n" + keyIndent + `"dependencies": [` + "\n")
+	b.WriteString(renderVcpkgPorts(entries, keyIndent+unit, unit))
+	b.WriteString("\n" + keyIndent + "]\n")
+	b.WriteString(text[object.close:])
+	return []byte(b.String()), added, nil
+}
+
+// renderVcpkgPorts renders ports as array elements in the layout `vcpkg format-manifest`
+// uses: bare names, or objects with one key per line
+func renderVcpkgPorts(ports []VcpkgPort, indent, unit string) string {
+	var lines []string
+	for _, port := range ports {
+		if port.Version == "" && len(port.Features) == 0 {
+			name, _ := json.Marshal(port.Name)
+			lines = append(lines, indent+string(name))
+			continue
+		}
+		entry, _ := json.MarshalIndent(port, indent, unit)
+		lines = append(lines, indent+string(entry))
+	}
+	return strings.Join(lines, ",\n")
+}
+
+// jsonMember locates a member of a JSON object by the offsets of its key and value
+type jsonMember struct {
+	key, value int
+}
+
+// jsonObject locates the members of the top-level object of a JSON document
+type jsonObject struct {
+	open, close int
+	// lastCode is the offset of the last character of code before the closing brace
+	lastCode int
+	// lastKey is the offset of the last member's key, or -1 for an empty object
+	lastKey int
+	members map[string]jsonMember
+}
+
+// has reports whether the object has a member named key
+func (o jsonObject) has(key string) bool {
+	_, ok := o.members[key]
+	return ok
+}
+
+// indentUnit returns the indentation of the object's members, two spaces by default
+func (o jsonObject) indentUnit(text string) string {
+	if o.lastKey >= 0 {
+		if indent := textedit.LineIndent(text, o.lastKey); indent != "" && strings.Contains(text[o.open:o.lastKey], "\n") {
+			return indent
+		}
+	}
+	return "  "
+}
+
+// jsonArray locates the elements of a JSON array
+type jsonArray struct {
+	open, close int
+	lastCode    int
+	// elements are the offsets of the first character of each element
+	elements []int
+}
+
+// scanJSONObject scans the top-level object of a JSON document
+func scanJSONObject(text string) (jsonObject, bool) {
+	object := jsonObject{lastKey: -1, members: make(map[string]jsonMember)}
+	i := skipJSONSpace(text, 0)
+	if i >= len(text) || text[i] != '{' {
+		return object, false
+	}
+	object.open, object.lastCode = i, i
+	for i++; i < len(text); {
+		i = skipJSONSpace(text, i)
+		if i >= len(text) {
+			break
+		}
+		switch text[i] {
+		case '}':
+			object.close = i
+			return object, true
+		case ',':
+			object.lastCode = i
+			i++
+		case '"':
+			keyStart := i
+			keyEnd := skipJSONString(text, i)
+			var key string
+			_ = json.Unmarshal([]byte(text[keyStart:keyEnd]), &key)
+			i = skipJSONSpace(text, keyEnd)
+			if i >= len(text) || text[i] != ':' {
+				return object, false
+			}
+			value := skipJSONSpace(text, i+1)
+			object.members[key] = jsonMember{key: keyStart, value: value}
+			object.lastKey = keyStart
+			i = skipJSONValue(text, value)
+			object.lastCode = i - 1
+		default:
+			return object, false
+		}
+	}
+	return object, false
+}
+
+// scanJSONArray scans the array whose opening bracket is at open
+func scanJSONArray(text string, open int) (jsonArray, bool) {
+	array := jsonArray{open: open, lastCode: open}
+	for i := open + 1; i < len(text); {
+		i = skipJSONSpace(text, i)
+		if i >= len(text) {
+			break
+		}
+		switch text[i] {
+		case ']':
+			array.close = i
+			return array, true
+		case ',':
+			array.lastCode = i
+			i++
+		default:
+			array.elements = append(array.elements, i)
+			i = skipJSONValue(text, i)
+			array.lastCode = i - 1
+		}
+	}
+	return array, false
+}
+
+// skipJSONValue returns the offset just past the value starting at i
+func skipJSONValue(text string, i int) int {
+	if i >= len(text) {
+		return i
+	}
+	switch text[i] {
+	case '"':
+		return skipJSONString(text, i)
+	case '{', '[':
+		depth := 0
+		for ; i < len(text); i++ {
+			switch text[i] {
+			case '"':
+				i = skipJSONString(text, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return i
+	default:
+		for i < len(text) && !strings.ContainsRune(",}] \t\r\n", rune(text[i])) {
+			i++
+		}
+		return i
+	}
+}
+
+// skipJSONString returns the offset just past the string starting at i
+func skipJSONString(text string, i int) int {
+	for i++; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return i
+}
+
+// skipJSONSpace returns the offset of the next non-whitespace character
+func skipJSONSpace(text string, i int) int {
+	for i < len(text) && strings.ContainsRune(" \t\r\n", rune(text[i])) {
+		i++
+	}
+	return i
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/getlawrence/cli/internal/codegen/injector"
 	"github.com/getlawrence/cli/internal/domain"
@@ -41,18 +44,39 @@ type IssueDetector interface {
 
 // Analysis contains the results of language detection and library discovery
 type Analysis struct {
-	RootPath          string                        `json:"root_path"`
+	RootPath string `json:"root_path"`
+	// DirectoryAnalyses holds one entry per (directory, language) pair, keyed by AnalysisKey
 	DirectoryAnalyses map[string]*DirectoryAnalysis `json:"directory_analyses"`
+	// DirectoryLanguages lists the languages detected in each directory, most common first
+	DirectoryLanguages map[string][]string `json:"directory_languages,omitempty"`
+	// Projects lists the manifest-defined projects found under the root
+	Projects []*Project `json:"projects,omitempty"`
+	// Errors holds per-analysis failures keyed by AnalysisKey; other analyses still complete
+	Errors map[string]string `json:"errors,omitempty"`
 }
 
-// DirectoryAnalysis contains analysis results for a specific directory
+// AnalysisKey returns the DirectoryAnalyses key for a directory and language
+func AnalysisKey(directory, language string) string {
+	return directory + ":" + strings.ToLower(language)
+}
+
+// DirectoryAnalysis contains analysis results for a specific directory, or for a whole
+// project when the directory is a project root
 type DirectoryAnalysis struct {
 	Directory                 string                       `json:"directory"`
 	Language                  string                       `json:"language"`
+	Project                   *Project                     `json:"project,omitempty"`
 	Libraries                 []domain.Library             `json:"libraries"`
 	Packages                  []domain.Package             `json:"packages"`
 	AvailableInstrumentations []domain.InstrumentationInfo `json:"available_instrumentations"`
 	Issues                    []domain.Issue               `json:"issues"`
+	SuppressedIssues          []domain.Issue               `json:"suppressed_issues,omitempty"`
+	// Path is the absolute path of the directory, or of the project root, for detectors
+	// that read source files
+	Path string `json:"-"`
+	// Root is the absolute path of the analyzed codebase; detectors report files
+	// relative to it
+	Root string `json:"-"`
 }
 
 // CodebaseAnalyzer coordinates the detection process
@@ -61,6 +85,7 @@ type CodebaseAnalyzer struct {
 	languageDetectors map[string]Language
 	codeInjector      *injector.CodeInjector
 	knowledgeService  *KnowledgeBasedInstrumentationService
+	concurrency       int
 }
 
 // NewCodebaseAnalyzer creates a new analysis engine
@@ -78,18 +103,28 @@ func NewCodebaseAnalyzer(detectors []IssueDetector, languages map[string]Languag
 		languageDetectors: languages,
 		codeInjector:      injector.NewCodeInjector(logger),
 		knowledgeService:  knowledgeService,
+		concurrency:       runtime.GOMAXPROCS(0),
+	}
+}
+
+// SetConcurrency sets how many directories are analyzed in parallel (values < 1 mean 1)
+func (ca *CodebaseAnalyzer) SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
 	}
+	ca.concurrency = n
 }
 
 // AnalyzeCodebase performs the full analysis
 func (ca *CodebaseAnalyzer) AnalyzeCodebase(ctx context.Context, rootPath string) (*Analysis, error) {
 	analysis := &Analysis{
-		RootPath:          rootPath,
-		DirectoryAnalyses: make(map[string]*DirectoryAnalysis),
+		RootPath:           rootPath,
+		DirectoryAnalyses:  make(map[string]*DirectoryAnalysis),
+		DirectoryLanguages: make(map[string][]string),
 	}
 
 	// Use the enhanced language detection to get directory-specific languages
-	directoryLanguages, err := DetectLanguages(rootPath)
+	directoryLanguages, err := DetectLanguages(ctx, rootPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to detect languages: %w", err)
 	}
@@ -99,25 +134,121 @@ func (ca *CodebaseAnalyzer) AnalyzeCodebase(ctx context.Context, rootPath string
 		return nil, fmt.Errorf("no languages detected in the codebase at %s", rootPath)
 	}
 
-	seenLanguages := make(map[string]bool)
+	projects, err := DetectProjects(ctx, rootPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect projects: %w", err)
+	}
 
-	for directory, language := range directoryLanguages {
-		languageDetector := ca.findLanguageDetector(language)
-		if languageDetector == nil {
-			// Skip if we don't have a detector for this language
-			continue
+	// Resolve work items up front in a stable order so results don't depend on scheduling
+	directories := make([]string, 0, len(directoryLanguages))
+	for directory := range directoryLanguages {
+		directories = append(directories, directory)
+	}
+	sort.Strings(directories)
+
+	type job struct {
+		key       string
+		directory string
+		dirPath   string
+		language  string
+		detector  Language
+		project   *Project
+		ctx       context.Context
+	}
+	var jobs []job
+	jobIndex := make(map[string]int)
+	for _, directory := range directories {
+		analysis.DirectoryLanguages[directory] = directoryLanguages[directory]
+		for _, language := range directoryLanguages[directory] {
+			languageDetector := ca.findLanguageDetector(language)
+			if languageDetector == nil {
+				// Skip if we don't have a detector for this language
+				continue
+			}
+
+			// Directories owned by a project are analyzed once, as part of that project
+			unit := job{
+				directory: directory,
+				dirPath:   ca.calculateDirectoryPath(rootPath, directory),
+				language:  language,
+				detector:  languageDetector,
+				ctx:       ctx,
+			}
+			if project := OwningProject(projects, directory, language); project != nil {
+				project.Directories = append(project.Directories, directory)
+				unit.directory = project.Directory
+				unit.dirPath = project.Path
+				unit.project = project
+			}
+			unit.key = AnalysisKey(unit.directory, language)
+			if _, exists := jobIndex[unit.key]; exists {
+				continue
+			}
+			if unit.project != nil {
+				// Nested projects of the same language are analyzed on their own
+				unit.ctx = WithProjectScope(ctx, projects, unit.project)
+			}
+			jobIndex[unit.key] = len(jobs)
+			jobs = append(jobs, unit)
 		}
+	}
+	analysis.Projects = projects
 
-		// Calculate the full path for this directory
-		dirPath := ca.calculateDirectoryPath(rootPath, directory)
-		seenLanguages[language] = true
+	results := make([]*DirectoryAnalysis, len(jobs))
+	errs := make([]error, len(jobs))
 
-		// Process each directory individually
-		dirAnalysis, err := ca.processDirectory(ctx, directory, dirPath, language, languageDetector)
-		if err != nil {
-			return nil, fmt.Errorf("failed to process directory %s: %w", directory, err)
+	workers := ca.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				j := jobs[i]
+				results[i], errs[i] = ca.processDirectory(j.ctx, rootPath, j.directory, j.dirPath, j.language, j.detector, j.project)
+			}
+		}()
+	}
+
+dispatch:
+	for i := range jobs {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case indexes <- i:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("analysis cancelled: %w", err)
+	}
+
+	var failures []string
+	for i, j := range jobs {
+		if errs[i] != nil {
+			if analysis.Errors == nil {
+				analysis.Errors = make(map[string]string)
+			}
+			analysis.Errors[j.key] = errs[i].Error()
+			failures = append(failures, fmt.Sprintf("%s: %v", j.key, errs[i]))
+			continue
 		}
-		analysis.DirectoryAnalyses[directory] = dirAnalysis
+		analysis.DirectoryAnalyses[j.key] = results[i]
+	}
+
+	// Partial results are still useful; only fail when nothing could be analyzed
+	if len(jobs) > 0 && len(failures) == len(jobs) {
+		return nil, fmt.Errorf("failed to process directories: %s", strings.Join(failures, "; "))
 	}
 	return analysis, nil
 }
@@ -137,7 +268,11 @@ func (ca *CodebaseAnalyzer) calculateDirectoryPath(rootPath, directory string) s
 }
 
 // processDirectory handles the complete analysis pipeline for a single directory
-func (ca *CodebaseAnalyzer) processDirectory(ctx context.Context, directory, dirPath, language string, languageDetector Language) (*DirectoryAnalysis, error) {
+func (ca *CodebaseAnalyzer) processDirectory(ctx context.Context, rootPath, directory, dirPath, language string, languageDetector Language, project *Project) (*DirectoryAnalysis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Step 1: Collect libraries and packages
 	libs, packages, err := ca.collectLibrariesAndPackagesForDirectory(ctx, dirPath, language, languageDetector)
 	if err != nil {
@@ -146,7 +281,10 @@ func (ca *CodebaseAnalyzer) processDirectory(ctx context.Context, directory, dir
 
 	dirAnalysis := &DirectoryAnalysis{
 		Directory: directory,
+		Path:      dirPath,
+		Root:      rootPath,
 		Language:  language,
+		Project:   project,
 		Libraries: libs,
 		Packages:  packages,
 	}
@@ -251,6 +389,9 @@ func (ca *CodebaseAnalyzer) populateInstrumentationsWithKnowledge(ctx context.Co
 func (ca *CodebaseAnalyzer) runIssueDetectorsForDirectory(ctx context.Context, dirAnalysis *DirectoryAnalysis) ([]domain.Issue, error) {
 	var issues []domain.Issue
 
+	if ca.knowledgeService != nil {
+		ctx = WithKnowledge(ctx, ca.knowledgeService)
+	}
 	for _, detector := range ca.detectors {
 		if !ca.detectorAppliesForLanguage(detector, dirAnalysis.Language) {
 			continue
@@ -260,6 +401,12 @@ func (ca *CodebaseAnalyzer) runIssueDetectorsForDirectory(ctx context.Context, d
 		if err != nil {
 			return nil, fmt.Errorf("detector %s failed for directory %s: %w", detector.ID(), dirAnalysis.Directory, err)
 		}
+		// Record which detector produced each issue so reporters can map findings back to rules
+		for i := range detectorIssues {
+			if detectorIssues[i].DetectorID == "" {
+				detectorIssues[i].DetectorID = detector.ID()
+			}
+		}
 		issues = append(issues, detectorIssues...)
 	}
 
@@ -277,7 +424,7 @@ func (ca *CodebaseAnalyzer) detectorAppliesForLanguage(detector IssueDetector, l
 
 	// Check if the language matches any detector language
 	for _, detectorLang := range detectorLanguages {
-		if language == detectorLang {
+		if strings.EqualFold(language, detectorLang) {
 			return true
 		}
 	}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
 	"github.com/getlawrence/cli/internal/logger"
 )
 
@@ -71,8 +72,174 @@ func TestProcessDirectory_ErrorFromPackages(t *testing.T) {
 	_ = os.WriteFile(filepath.Join(dir, "main.fake"), []byte(""), 0o644)
 
 	ca := NewCodebaseAnalyzer(nil, map[string]Language{"fake": &errorLanguage{}}, &logger.StdoutLogger{})
-	_, err := ca.processDirectory(context.Background(), "root", dir, "fake", &errorLanguage{})
+	_, err := ca.processDirectory(context.Background(), dir, "root", dir, "fake", &errorLanguage{}, nil)
 	if err == nil {
 		t.Fatalf("expected error from package collection")
 	}
 }
+
+// pathLanguage reports one library named after each directory and fails for directories named "bad"
+type pathLanguage struct{ fakeLanguage }
+
+func (p *pathLanguage) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
+	if filepath.Base(rootPath) == "bad" {
+		return nil, errors.New("boom")
+	}
+	return []domain.Library{{Name: filepath.Base(rootPath)}}, nil
+}
+
+func writeGoDirs(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, d := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, d, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAnalyzeCodebase_ConcurrentResultsAreDeterministic(t *testing.T) {
+	root := t.TempDir()
+	writeGoDirs(t, root, "a", "b", "c", "d", "e")
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"go": &pathLanguage{}}, &logger.StdoutLogger{})
+	ca.SetConcurrency(4)
+	analysis, err := ca.AnalyzeCodebase(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(analysis.DirectoryAnalyses) != 5 {
+		t.Fatalf("expected 5 directories, got %d", len(analysis.DirectoryAnalyses))
+	}
+	for key, da := range analysis.DirectoryAnalyses {
+		if key != AnalysisKey(da.Directory, "go") || len(da.Libraries) != 1 || da.Libraries[0].Name != da.Directory {
+			t.Fatalf("analysis %s got results for %+v", key, da.Libraries)
+		}
+	}
+}
+
+func TestAnalyzeCodebase_CollectsPerDirectoryErrors(t *testing.T) {
+	root := t.TempDir()
+	writeGoDirs(t, root, "good", "bad")
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"go": &pathLanguage{}}, &logger.StdoutLogger{})
+	ca.SetConcurrency(2)
+	analysis, err := ca.AnalyzeCodebase(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := analysis.DirectoryAnalyses[AnalysisKey("good", "go")]; !ok {
+		t.Fatalf("expected good directory to be analyzed")
+	}
+	if _, ok := analysis.Errors[AnalysisKey("bad", "go")]; !ok {
+		t.Fatalf("expected error recorded for bad directory, got %v", analysis.Errors)
+	}
+}
+
+func TestAnalyzeCodebase_AllDirectoriesFail(t *testing.T) {
+	root := t.TempDir()
+	writeGoDirs(t, root, "bad")
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"go": &pathLanguage{}}, &logger.StdoutLogger{})
+	if _, err := ca.AnalyzeCodebase(context.Background(), root); err == nil {
+		t.Fatalf("expected error when every directory fails")
+	}
+}
+
+func TestAnalyzeCodebase_Cancelled(t *testing.T) {
+	root := t.TempDir()
+	writeGoDirs(t, root, "a", "b")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"go": &pathLanguage{}}, &logger.StdoutLogger{})
+	_, err := ca.AnalyzeCodebase(ctx, root)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestAnalyzeCodebase_HonorsIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	writeGoDirs(t, root, "svc", "generated", "fixtures/app")
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("generated/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ignore.FileName), []byte("fixtures/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"go": &pathLanguage{}}, &logger.StdoutLogger{})
+	analysis, err := ca.AnalyzeCodebase(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(analysis.DirectoryAnalyses) != 1 || analysis.DirectoryAnalyses[AnalysisKey("svc", "go")] == nil {
+		t.Fatalf("expected only svc to be analyzed, got %v", analysis.DirectoryAnalyses)
+	}
+}
+
+func TestDetectLanguages_MultipleLanguagesPerDirectory(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"app/package.json":         "{}",
+		"app/build.js":             "console.log(1)\n",
+		"api/stray.js":             "console.log(1)\n",
+		"java/pom.xml":             "<project/>\n",
+		"web/package.json":         "{}",
+		"web/app.tsx":              "export const App = () => <div />;\n",
+		"ktor/build.gradle.kts":    "plugins {\n    kotlin(\"jvm\") version \"2.0.21\"\n}\n",
+		"ktor/settings.gradle.kts": "rootProject.name = \"ktor\"\n",
+		"jobs/build.gradle.kts":    "plugins {\n    alias(libs.plugins.kotlin.jvm)\n}\n",
+	}
+	for i := 0; i < 12; i++ {
+		files[filepath.Join("app", "mod"+string(rune('a'+i))+".py")] = "print(1)\n"
+		files[filepath.Join("api", "mod"+string(rune('a'+i))+".py")] = "print(1)\n"
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	langs, err := DetectLanguages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := langs["app"]; len(got) != 2 || got[0] != "Python" || got[1] != "JavaScript" {
+		t.Fatalf("expected app to have Python and JavaScript (manifest), got %v", got)
+	}
+	if got := langs["api"]; len(got) != 1 || got[0] != "Python" {
+		t.Fatalf("expected a single stray script below the thresholds to be ignored, got %v", got)
+	}
+	if got := langs["java"]; len(got) != 1 || got[0] != "Java" {
+		t.Fatalf("expected a manifest-only directory to be detected, got %v", got)
+	}
+	if got := langs["web"]; len(got) != 1 || got[0] != "TypeScript" {
+		t.Fatalf("expected package.json next to TypeScript sources to declare TypeScript only, got %v", got)
+	}
+	if got := langs["ktor"]; len(got) != 1 || got[0] != "Kotlin" {
+		t.Fatalf("expected a Gradle build applying the Kotlin plugin to declare Kotlin only, got %v", got)
+	}
+	if got := langs["jobs"]; len(got) != 1 || got[0] != "Kotlin" {
+		t.Fatalf("expected a Kotlin plugin applied through the version catalog to declare Kotlin, got %v", got)
+	}
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"python": &fakeLanguage{}, "javascript": &fakeLanguage{}}, &logger.StdoutLogger{})
+	analysis, err := ca.AnalyzeCodebase(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, key := range []string{AnalysisKey("app", "Python"), AnalysisKey("app", "JavaScript"), AnalysisKey("api", "Python")} {
+		if analysis.DirectoryAnalyses[key] == nil {
+			t.Fatalf("expected analysis %s, got %v", key, analysis.DirectoryAnalyses)
+		}
+	}
+}
@@ -137,6 +137,11 @@ func (s *InstrumentationRegistryService) RegistryLanguage(language string) strin
 	switch lang {
 	case "javascript", "typescript":
 		return "js"
+	case "kotlin":
+		return "java"
+	case "elixir":
+		// Elixir packages are listed with the Erlang ones
+		return "erlang"
 	default:
 		return lang
 	}
@@ -148,6 +153,8 @@ func (s *InstrumentationRegistryService) CanonicalLanguage(language string) stri
 	switch lang {
 	case "js", "node", "nodejs":
 		return "javascript"
+	case "erlang":
+		return "elixir"
 	default:
 		return lang
 	}
@@ -0,0 +1,323 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/semver"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+const (
+	// jaegerMigrationGuide explains how to move from the Jaeger exporters to OTLP
+	jaegerMigrationGuide = "https://opentelemetry.io/blog/2023/jaeger-exporter-collector-migration/"
+	jaegerReason         = "The Jaeger exporters are deprecated and no longer maintained; Jaeger ingests OTLP natively since v1.35."
+	// jsUpgradeGuide lists the packages renamed in OpenTelemetry JS
+	jsUpgradeGuide  = "https://github.com/open-telemetry/opentelemetry-js/blob/main/doc/upgrade-guide.md"
+	jsRenamedReason = "The package was renamed before the 1.0 release and is no longer published."
+)
+
+// deprecation describes a deprecated component and what replaces it
+type deprecation struct {
+	language string
+	// names are package names and import path prefixes of the component
+	names       []string
+	replacement string
+	reason      string
+	guide       string
+}
+
+// knownDeprecations lists deprecated components that are still common, with their
+// replacements. The knowledge base flags deprecations too but doesn't record
+// replacements.
+var knownDeprecations = []deprecation{
+	{
+		language:    "go",
+		names:       []string{"go.opentelemetry.io/otel/exporters/jaeger"},
+		replacement: "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc",
+		reason:      jaegerReason,
+		guide:       jaegerMigrationGuide,
+	},
+	{
+		language:    "python",
+		names:       []string{"opentelemetry-exporter-jaeger", "opentelemetry-exporter-jaeger-thrift", "opentelemetry-exporter-jaeger-proto-grpc", "opentelemetry.exporter.jaeger"},
+		replacement: "opentelemetry-exporter-otlp",
+		reason:      jaegerReason,
+		guide:       jaegerMigrationGuide,
+	},
+	{
+		language:    "javascript",
+		names:       []string{"@opentelemetry/exporter-jaeger"},
+		replacement: "@opentelemetry/exporter-trace-otlp-proto",
+		reason:      jaegerReason,
+		guide:       jaegerMigrationGuide,
+	},
+	{
+		language:    "javascript",
+		names:       []string{"@opentelemetry/exporter-collector"},
+		replacement: "@opentelemetry/exporter-trace-otlp-http",
+		reason:      "The collector exporters were renamed to the OTLP exporters and are no longer published.",
+		guide:       jsUpgradeGuide,
+	},
+	{
+		language:    "javascript",
+		names:       []string{"@opentelemetry/node"},
+		replacement: "@opentelemetry/sdk-trace-node",
+		reason:      jsRenamedReason,
+		guide:       jsUpgradeGuide,
+	},
+	{
+		language:    "javascript",
+		names:       []string{"@opentelemetry/tracing"},
+		replacement: "@opentelemetry/sdk-trace-base",
+		reason:      jsRenamedReason,
+		guide:       jsUpgradeGuide,
+	},
+	{
+		language:    "java",
+		names:       []string{"io.opentelemetry:opentelemetry-exporter-jaeger", "io.opentelemetry:opentelemetry-exporter-jaeger-thrift", "io.opentelemetry.exporter.jaeger"},
+		replacement: "io.opentelemetry:opentelemetry-exporter-otlp",
+		reason:      jaegerReason,
+		guide:       jaegerMigrationGuide,
+	},
+	{
+		language:    "csharp",
+		names:       []string{"OpenTelemetry.Exporter.Jaeger"},
+		replacement: "OpenTelemetry.Exporter.OpenTelemetryProtocol",
+		reason:      jaegerReason,
+		guide:       jaegerMigrationGuide,
+	},
+	{
+		language:    "ruby",
+		names:       []string{"opentelemetry-exporter-jaeger"},
+		replacement: "opentelemetry-exporter-otlp",
+		reason:      jaegerReason,
+		guide:       jaegerMigrationGuide,
+	},
+}
+
+// DeprecatedComponentDetector detects deprecated OpenTelemetry components and versions,
+// and installed versions with known breaking changes
+type DeprecatedComponentDetector struct{}
+
+// NewDeprecatedComponentDetector creates a new deprecated component detector
+func NewDeprecatedComponentDetector() *DeprecatedComponentDetector {
+	return &DeprecatedComponentDetector{}
+}
+
+// ID returns the detector identifier
+func (d *DeprecatedComponentDetector) ID() string {
+	return "deprecated_components"
+}
+
+// Name returns the detector name
+func (d *DeprecatedComponentDetector) Name() string {
+	return "Deprecated OpenTelemetry Components"
+}
+
+// Description returns what this detector looks for
+func (d *DeprecatedComponentDetector) Description() string {
+	return "Detects deprecated or end-of-life OpenTelemetry exporters, instrumentations and versions"
+}
+
+// Category returns the issue category
+func (d *DeprecatedComponentDetector) Category() domain.Category {
+	return domain.CategoryDeprecated
+}
+
+// Languages returns which languages this detector applies to
+func (d *DeprecatedComponentDetector) Languages() []string {
+	return []string{} // Applies to all languages
+}
+
+// Detect finds deprecated components. Well-known deprecations are reported without a
+// knowledge base; deprecated versions and breaking changes need one in the context.
+func (d *DeprecatedComponentDetector) Detect(ctx context.Context, directory *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	knowledge := detector.KnowledgeFromContext(ctx)
+
+	var issues []domain.Issue
+	index := make(map[string]int)
+	add := func(issue domain.Issue) {
+		// A library is often found both in the manifest and in imports; keep one finding
+		// and prefer the manifest as its location
+		if i, ok := index[issue.ID]; ok {
+			if issues[i].File == "" {
+				issues[i].File = issue.File
+			}
+			return
+		}
+		index[issue.ID] = len(issues)
+		issues = append(issues, issue)
+	}
+
+	for _, lib := range directory.Libraries {
+		if lib.Language == "" {
+			lib.Language = directory.Language
+		}
+		if known, ok := findDeprecation(lib); ok {
+			add(d.deprecatedIssue(lib, known.names[0], known.reason, known.replacement, known.guide))
+			continue
+		}
+		if knowledge == nil {
+			continue
+		}
+
+		component, err := knowledge.GetComponent(ctx, libraryPackage(lib))
+		if err != nil {
+			return nil, err
+		}
+		if component == nil {
+			continue
+		}
+		if component.Status == types.ComponentStatusDeprecated {
+			reason := fmt.Sprintf("%s is marked deprecated in the OpenTelemetry registry.", component.Name)
+			add(d.deprecatedIssue(lib, component.Name, reason, "", component.MigrationGuideURL))
+			continue
+		}
+		if lib.Version == "" {
+			continue
+		}
+		found, err := d.versionIssues(ctx, knowledge, lib, component)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range found {
+			add(issue)
+		}
+	}
+	return issues, nil
+}
+
+// versionIssues reports a deprecated installed version and the breaking changes it
+// introduced
+func (d *DeprecatedComponentDetector) versionIssues(ctx context.Context, knowledge detector.Knowledge, lib domain.Library, component *types.Component) ([]domain.Issue, error) {
+	var issues []domain.Issue
+	pkg := libraryPackage(lib)
+
+	version, err := knowledge.GetVersion(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	if version != nil && (version.Deprecated || version.Status == types.VersionStatusDeprecated) {
+		target, err := findRelease(ctx, knowledge, lib, func(*types.Version) bool { return true })
+		if err != nil {
+			return nil, err
+		}
+		issue := domain.Issue{
+			ID:          fmt.Sprintf("deprecated_version_%s_%s", lib.Language, lib.Name),
+			Title:       fmt.Sprintf("%s %s is a deprecated release", lib.Name, lib.Version),
+			Description: fmt.Sprintf("Version %s of %s is deprecated and no longer receives fixes.", lib.Version, lib.Name),
+			Severity:    domain.SeverityWarning,
+			Category:    d.Category(),
+			Language:    lib.Language,
+			File:        lib.PackageFile,
+			Suggestion:  fmt.Sprintf("Upgrade %s to a supported release", lib.Name),
+		}
+		if target != nil {
+			issue.Suggestion = upgradeSuggestion(lib, target.Name)
+		}
+		issue.References = appendReference(issue.References, component.MigrationGuideURL)
+		issue.References = appendReference(issue.References, version.ChangelogURL)
+		issues = append(issues, issue)
+	}
+
+	changes, err := knowledge.GetBreakingChanges(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	installed, err := semver.Parse(lib.Version)
+	if err != nil {
+		return issues, nil
+	}
+	var lines, references []string
+	for _, change := range changes {
+		if v, err := semver.Parse(change.Version); err != nil || v.Compare(installed) != 0 {
+			continue
+		}
+		line := "- " + change.Description
+		if len(change.AffectedFeatures) > 0 {
+			line += fmt.Sprintf(" (affects %s)", strings.Join(change.AffectedFeatures, ", "))
+		}
+		lines = append(lines, line)
+		references = appendReference(references, change.MigrationGuideURL)
+	}
+	if len(lines) > 0 {
+		references = appendReference(references, component.MigrationGuideURL)
+		issues = append(issues, domain.Issue{
+			ID:    fmt.Sprintf("breaking_changes_%s_%s", lib.Language, lib.Name),
+			Title: fmt.Sprintf("%s %s has known breaking changes", lib.Name, lib.Version),
+			Description: fmt.Sprintf("Version %s of %s introduced breaking changes; code written against earlier releases may need to be migrated:\n%s",
+				lib.Version, lib.Name, strings.Join(lines, "\n")),
+			Severity:   domain.SeverityInfo,
+			Category:   d.Category(),
+			Language:   lib.Language,
+			File:       lib.PackageFile,
+			Suggestion: "Review the migration guide and update the affected APIs",
+			References: references,
+		})
+	}
+	return issues, nil
+}
+
+// deprecatedIssue reports a deprecated component; replacement and guide may be empty
+func (d *DeprecatedComponentDetector) deprecatedIssue(lib domain.Library, name, reason, replacement, guide string) domain.Issue {
+	issue := domain.Issue{
+		ID:          fmt.Sprintf("deprecated_component_%s_%s", lib.Language, name),
+		Title:       fmt.Sprintf("%s is deprecated", name),
+		Description: reason,
+		Severity:    domain.SeverityWarning,
+		Category:    d.Category(),
+		Language:    lib.Language,
+		File:        lib.PackageFile,
+		Suggestion:  fmt.Sprintf("Remove %s and migrate to a supported component", name),
+		References:  appendReference(nil, guide),
+	}
+	if replacement != "" {
+		issue.Description += fmt.Sprintf(" Replace it with %s.", replacement)
+		issue.Suggestion = fmt.Sprintf("Replace %s with %s", name, replacement)
+	}
+	if guide != "" {
+		issue.Suggestion += fmt.Sprintf("\nSee the migration guide: %s", guide)
+	}
+	return issue
+}
+
+// findDeprecation returns the known deprecation matching a library by package name or
+// import path
+func findDeprecation(lib domain.Library) (deprecation, bool) {
+	// TypeScript and Kotlin share the packages of the JavaScript and Java ecosystems
+	language := strings.ToLower(lib.Language)
+	switch language {
+	case "typescript":
+		language = "javascript"
+	case "kotlin":
+		language = "java"
+	}
+	for _, known := range knownDeprecations {
+		if known.language != language {
+			continue
+		}
+		for _, name := range known.names {
+			libName, name := strings.ToLower(lib.Name), strings.ToLower(name)
+			if libName == name || strings.HasPrefix(libName, name+"/") || strings.HasPrefix(libName, name+".") {
+				return known, true
+			}
+		}
+	}
+	return deprecation{}, false
+}
+
+func appendReference(references []string, url string) []string {
+	if url == "" {
+		return references
+	}
+	for _, existing := range references {
+		if existing == url {
+			return references
+		}
+	}
+	return append(references, url)
+}
@@ -0,0 +1,108 @@
+package issues
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+func TestDeprecatedComponentDetector_KnownDeprecations(t *testing.T) {
+	dir := &detector.DirectoryAnalysis{
+		Language: "go",
+		Libraries: []domain.Library{
+			{Name: "go.opentelemetry.io/otel/exporters/jaeger", Language: "go"},
+			{Name: "go.opentelemetry.io/otel/exporters/jaeger", Version: "v1.17.0", Language: "go", PackageFile: "go.mod"},
+			{Name: "go.opentelemetry.io/otel/sdk", Version: "v1.28.0", Language: "go", PackageFile: "go.mod"},
+		},
+	}
+	issues, err := NewDeprecatedComponentDetector().Detect(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	issue := issues[0]
+	if issue.Category != domain.CategoryDeprecated || issue.File != "go.mod" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	if !strings.Contains(issue.Suggestion, "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc") {
+		t.Errorf("expected the replacement in the suggestion, got %q", issue.Suggestion)
+	}
+	if len(issue.References) != 1 || issue.References[0] != jaegerMigrationGuide {
+		t.Errorf("expected the migration guide, got %v", issue.References)
+	}
+
+	// Imports match by prefix
+	dir = &detector.DirectoryAnalysis{
+		Language:  "python",
+		Libraries: []domain.Library{{Name: "opentelemetry.exporter.jaeger.thrift"}},
+	}
+	issues, _ = NewDeprecatedComponentDetector().Detect(context.Background(), dir)
+	if len(issues) != 1 || !strings.Contains(issues[0].Suggestion, "opentelemetry-exporter-otlp") {
+		t.Errorf("expected the Python Jaeger exporter to be flagged, got %+v", issues)
+	}
+
+	// Kotlin projects use the Java artifacts
+	dir = &detector.DirectoryAnalysis{
+		Language: "kotlin",
+		Libraries: []domain.Library{
+			{Name: "io.opentelemetry:opentelemetry-exporter-jaeger", Version: "1.34.1", Language: "kotlin", PackageFile: "build.gradle.kts"},
+		},
+	}
+	issues, _ = NewDeprecatedComponentDetector().Detect(context.Background(), dir)
+	if len(issues) != 1 || !strings.Contains(issues[0].Suggestion, "io.opentelemetry:opentelemetry-exporter-otlp") {
+		t.Errorf("expected the Kotlin Jaeger exporter to be flagged, got %+v", issues)
+	}
+}
+
+func TestDeprecatedComponentDetector_Knowledge(t *testing.T) {
+	knowledge := fakeKnowledge{
+		"@opentelemetry/instrumentation-fastify": {
+			Name:              "@opentelemetry/instrumentation-fastify",
+			Status:            types.ComponentStatusDeprecated,
+			MigrationGuideURL: "https://example.com/fastify-migration",
+		},
+		"@opentelemetry/sdk-node": {Versions: []types.Version{
+			{Name: "0.40.0", Deprecated: true},
+			{Name: "0.41.0"},
+		}},
+		"@opentelemetry/sdk-metrics": {MigrationGuideURL: "https://example.com/metrics-migration", Versions: []types.Version{
+			{Name: "1.0.0"},
+			{Name: "2.0.0", BreakingChanges: []types.BreakingChange{
+				{Version: "2.0.0", Description: "MeterProvider.addMetricReader was removed", AffectedFeatures: []string{"metrics"}},
+			}},
+		}},
+	}
+	dir := &detector.DirectoryAnalysis{
+		Language: "javascript",
+		Libraries: []domain.Library{
+			{Name: "@opentelemetry/instrumentation-fastify", Version: "0.38.0"},
+			{Name: "@opentelemetry/sdk-node", Version: "0.40.0"},
+			{Name: "@opentelemetry/sdk-metrics", Version: "2.0.0"},
+		},
+	}
+
+	issues, err := NewDeprecatedComponentDetector().Detect(detector.WithKnowledge(context.Background(), knowledge), dir)
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d: %+v", len(issues), issues)
+	}
+	if issues[0].ID != "deprecated_component_javascript_@opentelemetry/instrumentation-fastify" ||
+		issues[0].References[0] != "https://example.com/fastify-migration" {
+		t.Errorf("unexpected deprecated component issue %+v", issues[0])
+	}
+	if issues[1].Suggestion != "Run: npm install @opentelemetry/sdk-node@0.41.0" {
+		t.Errorf("unexpected deprecated version suggestion %q", issues[1].Suggestion)
+	}
+	if !strings.Contains(issues[2].Description, "addMetricReader was removed (affects metrics)") ||
+		issues[2].References[0] != "https://example.com/metrics-migration" {
+		t.Errorf("unexpected breaking changes issue %+v", issues[2])
+	}
+}
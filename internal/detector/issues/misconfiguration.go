@@ -0,0 +1,347 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+const (
+	sdkConfigurationDocs = "https://opentelemetry.io/docs/specs/otel/configuration/sdk-environment-variables/"
+	samplingDocs         = "https://opentelemetry.io/docs/concepts/sampling/"
+	spanProcessorDocs    = "https://opentelemetry.io/docs/specs/otel/trace/sdk/#built-in-span-processors"
+)
+
+var (
+	// exporterCallee matches calls constructing or configuring an exporter
+	exporterCallee = regexp.MustCompile(`(?i)exporter|otlp|jaeger|zipkin`)
+	// endpointPattern matches a URL or host:port; a scheme or a port is required so that
+	// other strings aren't mistaken for hosts
+	endpointPattern = regexp.MustCompile(`^(?:([a-zA-Z][a-zA-Z0-9+.-]*)://)?(\[[0-9a-fA-F:]+\]|[a-zA-Z0-9.-]+)(?::(\d{2,5}))?(/\S*)?$`)
+	// envLookup matches the callee of an environment lookup taking a default value, e.g.
+	// os.environ.get, System.getenv().getOrDefault or env::var("X").unwrap_or
+	envLookup = regexp.MustCompile(`(?i)(getenv\w*|get_env|environ\.get|getOrDefault|GetEnvironmentVariable|ENV\.fetch|env::var\(.*\)\.unwrap_or)$`)
+	// envReference matches an expression reading an environment variable
+	envReference = regexp.MustCompile(`(?i)getenv|get_env|environ|process\.env|GetEnvironmentVariable|\b_?ENV\b`)
+	// fallbackOperators return their right operand when the left one is unset
+	fallbackOperators = map[string]bool{"||": true, "??": true, "or": true, "?:": true}
+	// optionContainers are the node types holding the options passed next to an exporter
+	optionContainers = nodeTypes("argument_list", "arguments", "expression_list", "literal_value", "literal_element",
+		"keyed_element", "array", "list", "object", "pair", "initializer_expression", "argument")
+)
+
+// alwaysOnSamplers are the identifiers of samplers recording every trace
+var alwaysOnSamplers = map[string]bool{
+	"AlwaysSample": true, "AlwaysOnSampler": true, "ALWAYS_ON": true, "alwaysOn": true,
+	"AlwaysOn": true, "AlwaysOnSamplerFactory": true, ":always_on": true,
+}
+
+// syncProcessors are the identifiers of span processors exporting on the calling thread
+var syncProcessors = map[string]bool{
+	"NewSimpleSpanProcessor": true, "WithSyncer": true, "SimpleSpanProcessor": true, "SimpleActivityExportProcessor": true,
+	"SimpleSpanProcessorFactory": true, "with_simple_exporter": true,
+}
+
+// serviceNameIdentifiers are the semantic convention constants and builder methods
+// setting service.name
+var serviceNameIdentifiers = map[string]bool{
+	"ServiceName": true, "ServiceNameKey": true, "SERVICE_NAME": true, "ATTR_SERVICE_NAME": true,
+	"SEMRESATTRS_SERVICE_NAME": true, "serviceName": true, "AddService": true,
+	"service_name": true, "with_service_name": true, "kServiceName": true,
+}
+
+// MisconfigurationDetector detects misconfigured OpenTelemetry setup code in services
+// that are already instrumented
+type MisconfigurationDetector struct{}
+
+// NewMisconfigurationDetector creates a new misconfiguration detector
+func NewMisconfigurationDetector() *MisconfigurationDetector {
+	return &MisconfigurationDetector{}
+}
+
+// ID returns the detector identifier
+func (m *MisconfigurationDetector) ID() string {
+	return "otel_misconfiguration"
+}
+
+// Name returns the detector name
+func (m *MisconfigurationDetector) Name() string {
+	return "OpenTelemetry Misconfiguration"
+}
+
+// Description returns what this detector looks for
+func (m *MisconfigurationDetector) Description() string {
+	return "Detects hard-coded or plaintext exporter endpoints, AlwaysOn samplers, synchronous span processors, missing service.name and unregistered tracer providers"
+}
+
+// Category returns the issue category
+func (m *MisconfigurationDetector) Category() domain.Category {
+	return domain.CategoryConfiguration
+}
+
+// Languages returns which languages this detector applies to
+func (m *MisconfigurationDetector) Languages() []string {
+	return []string{"go", "python", "javascript", "typescript", "java", "kotlin", "csharp", "ruby", "php", "rust", "elixir", "cpp"}
+}
+
+// providerSite is a place where a tracer provider is constructed
+type providerSite struct {
+	file *sourceFile
+	line int
+	col  int
+	// sdk is set when the SDK registers the provider itself
+	sdk bool
+}
+
+// setupScan collects what the setup code of a directory does across its files
+type setupScan struct {
+	issues      []domain.Issue
+	providers   []providerSite
+	serviceName bool
+	registered  bool
+}
+
+// Detect parses the source files of an instrumented directory and reports each finding
+// at its location
+func (m *MisconfigurationDetector) Detect(ctx context.Context, directory *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	language := strings.ToLower(directory.Language)
+	syntax, ok := setupSyntaxes[language]
+	if !ok || directory.Path == "" || len(directory.Libraries) == 0 {
+		return nil, nil
+	}
+
+	scan := &setupScan{}
+	err := walkSetupFiles(ctx, directory, syntax, func(f *sourceFile) error {
+		if !f.test {
+			m.scanFile(f, syntax, language, scan)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// service.name and registration may live in another file than the provider
+	for _, site := range scan.providers {
+		if !scan.serviceName {
+			scan.issues = append(scan.issues, m.issue("missing_service_name", site.file, site.line, site.col, language,
+				domain.SeverityWarning, domain.CategoryConfiguration,
+				"Tracer provider has no service.name",
+				"The provider's resource doesn't set service.name, so backends show the telemetry as unknown_service and can't tell services apart.",
+				"Set service.name on the provider's resource, or set OTEL_SERVICE_NAME where the service is deployed",
+				sdkConfigurationDocs))
+		}
+		if syntax.register != nil && !scan.registered && !site.sdk {
+			scan.issues = append(scan.issues, m.issue("unregistered_provider", site.file, site.line, site.col, language,
+				domain.SeverityWarning, domain.CategoryConfiguration,
+				"Tracer provider is never registered globally",
+				"The tracer provider is created but never installed as the global provider. Instrumentation libraries get their tracers from the global provider, so their spans are dropped.",
+				fmt.Sprintf("Register the provider after creating it: %s", syntax.registerHint),
+				""))
+		}
+	}
+	return scan.issues, nil
+}
+
+// scanFile reports the findings local to a file and records providers, service.name
+// and registrations in scan
+func (m *MisconfigurationDetector) scanFile(f *sourceFile, syntax *setupSyntax, language string, scan *setupScan) {
+	// exporterEnd is the end of the exporter call being checked; exporter calls nested in
+	// it were checked along with it
+	var exporterEnd uint32
+	visitNodes(f.tree.RootNode(), syntax, false, func(n *sitter.Node, inImport bool) bool {
+		typ := n.Type()
+		switch {
+		case syntax.stringTypes[typ]:
+			value := f.stringValue(n)
+			if strings.Contains(value, "service.name") || strings.Contains(value, "OTEL_SERVICE_NAME") {
+				scan.serviceName = true
+			}
+			return false
+
+		case n.NamedChildCount() == 0 && (strings.HasSuffix(typ, "identifier") || syntax.identifierTypes[typ]):
+			name := f.text(n)
+			if serviceNameIdentifiers[name] {
+				scan.serviceName = true
+			}
+			if inImport {
+				return false
+			}
+			line, col := position(n)
+			if alwaysOnSamplers[name] {
+				scan.issues = append(scan.issues, m.issue("always_on_sampler", f, line, col, language,
+					domain.SeverityInfo, domain.CategoryPerformance,
+					"AlwaysOn sampler records every trace",
+					fmt.Sprintf("%s samples every trace. At production traffic this exports all spans, which costs CPU, network and storage in the backend.", name),
+					"Use a parent-based trace ID ratio sampler, or configure sampling with OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG",
+					samplingDocs))
+			}
+			if syncProcessors[name] {
+				scan.issues = append(scan.issues, m.issue("simple_span_processor", f, line, col, language,
+					domain.SeverityWarning, domain.CategoryPerformance,
+					fmt.Sprintf("Spans are exported synchronously with %s", name),
+					fmt.Sprintf("%s exports each span as it ends, on the thread ending it. Every span then waits for a network round trip to the backend.", name),
+					"Use a batch span processor outside of tests",
+					spanProcessorDocs))
+			}
+			return false
+
+		case syntax.endpointOption != nil && typ == "assignment_expression":
+			if left := n.ChildByFieldName("left"); left != nil && n.StartByte() >= exporterEnd && syntax.endpointOption.MatchString(f.text(left)) {
+				scan.issues = append(scan.issues, m.checkExporter(f, syntax, language, n)...)
+			}
+
+		case syntax.callTypes[typ]:
+			callee := f.callee(n)
+			if syntax.configCall != nil && syntax.configCall.MatchString(callee) {
+				if args := f.arguments(n); args != nil && args.NamedChildCount() > 0 {
+					callee += f.text(args.NamedChild(0))
+				}
+			}
+			if (syntax.provider != nil && syntax.provider.MatchString(callee)) || (syntax.sdk != nil && syntax.sdk.MatchString(callee)) {
+				line, col := position(n)
+				scan.providers = append(scan.providers, providerSite{
+					file: f, line: line, col: col,
+					sdk: syntax.sdk != nil && syntax.sdk.MatchString(callee),
+				})
+			}
+			if syntax.register != nil && syntax.register.MatchString(callee) {
+				scan.registered = true
+			}
+			if n.StartByte() >= exporterEnd && exporterCallee.MatchString(callee) {
+				exporterEnd = n.EndByte()
+				scan.issues = append(scan.issues, m.checkExporter(f, syntax, language, n)...)
+			}
+		}
+		return true
+	})
+}
+
+// checkExporter reports the endpoints hard-coded in an exporter call that point at the
+// local host, or that are sent to over plaintext
+func (m *MisconfigurationDetector) checkExporter(f *sourceFile, syntax *setupSyntax, language string, call *sitter.Node) []domain.Issue {
+	// Options disabling TLS are often passed next to the endpoint rather than inside
+	// the exporter call
+	scope := call
+	for parent := scope.Parent(); parent != nil && optionContainers[parent.Type()]; parent = parent.Parent() {
+		scope = parent
+	}
+	insecure := syntax.insecure != nil && syntax.insecure.MatchString(f.text(scope))
+
+	var issues []domain.Issue
+	visitNodes(call, syntax, false, func(n *sitter.Node, _ bool) bool {
+		if !syntax.stringTypes[n.Type()] {
+			return true
+		}
+		if isEnvDefault(f, syntax, n) {
+			return false
+		}
+		value := f.stringValue(n)
+		match := endpointPattern.FindStringSubmatch(value)
+		if match == nil || (match[1] == "" && match[3] == "") {
+			return false
+		}
+		scheme, host := strings.ToLower(match[1]), strings.ToLower(match[2])
+		line, col := position(n)
+		switch {
+		case isLocalHost(host):
+			issues = append(issues, m.issue("localhost_endpoint", f, line, col, language,
+				domain.SeverityWarning, domain.CategoryConfiguration,
+				fmt.Sprintf("Exporter endpoint is hard-coded to %s", value),
+				fmt.Sprintf("The exporter sends telemetry to %s, which only works where a collector runs next to the process. Deployed elsewhere, the telemetry is dropped.", value),
+				"Leave the endpoint to OTEL_EXPORTER_OTLP_ENDPOINT, or read it from the service's configuration",
+				sdkConfigurationDocs))
+		case insecure || scheme == "http":
+			issues = append(issues, m.issue("plaintext_exporter", f, line, col, language,
+				domain.SeverityWarning, domain.CategorySecurity,
+				fmt.Sprintf("Exporter sends telemetry in plaintext to %s", host),
+				fmt.Sprintf("TLS is disabled for the exporter, but %s is not a local address, so spans and their attributes cross the network unencrypted.", host),
+				"Enable TLS for the exporter, or export to a collector agent on the local host",
+				sdkConfigurationDocs))
+		}
+		return false
+	})
+	return issues
+}
+
+// isEnvDefault reports whether a string literal is the fallback of an environment
+// lookup, which only applies where the variable isn't set
+func isEnvDefault(f *sourceFile, syntax *setupSyntax, literal *sitter.Node) bool {
+	n, parent := literal, literal.Parent()
+	// C#, PHP and Kotlin wrap each argument in a node, and Rust converts literals with
+	// a method call, e.g. "...".to_string()
+	for parent != nil && (argumentWrappers[parent.Type()] || isReceiverOf(parent, n)) {
+		n, parent = parent, parent.Parent()
+	}
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "argument_list", "arguments", "value_arguments":
+		call := parent.Parent()
+		if call != nil && call.Type() == "call_suffix" {
+			call = call.Parent()
+		}
+		return call != nil && syntax.callTypes[call.Type()] && envLookup.MatchString(f.callee(call))
+	case "binary_expression", "boolean_operator", "binary":
+		left, operator, right := parent.ChildByFieldName("left"), parent.ChildByFieldName("operator"), parent.ChildByFieldName("right")
+		return left != nil && operator != nil && right != nil && right.Equal(n) &&
+			fallbackOperators[f.text(operator)] && envReference.MatchString(f.text(left))
+	case "elvis_expression":
+		return parent.NamedChildCount() == 2 && parent.NamedChild(1).Equal(n) && envReference.MatchString(f.text(parent.NamedChild(0)))
+	case "conditional_expression":
+		// PHP's short ternary, getenv('X') ?: 'default'
+		condition, alternative := parent.ChildByFieldName("condition"), parent.ChildByFieldName("alternative")
+		return parent.ChildByFieldName("body") == nil && condition != nil && alternative != nil && alternative.Equal(n) &&
+			envReference.MatchString(f.text(condition))
+	}
+	return false
+}
+
+// argumentWrappers are the node types wrapping a single argument of a call
+var argumentWrappers = nodeTypes("argument", "value_argument")
+
+// isReceiverOf reports whether parent calls a method on n or accesses one of its
+// fields, as in "...".to_string()
+func isReceiverOf(parent, n *sitter.Node) bool {
+	switch parent.Type() {
+	case "field_expression":
+		value := parent.ChildByFieldName("value")
+		return value != nil && value.Equal(n)
+	case "call_expression":
+		function := parent.ChildByFieldName("function")
+		return function != nil && function.Equal(n) && n.Type() == "field_expression"
+	}
+	return false
+}
+
+// isLocalHost reports whether host is a loopback or unspecified address
+func isLocalHost(host string) bool {
+	switch host {
+	case "localhost", "0.0.0.0", "[::1]", "[::]":
+		return true
+	}
+	return strings.HasPrefix(host, "127.")
+}
+
+// issue builds a finding at a position of a source file; reference may be empty
+func (m *MisconfigurationDetector) issue(kind string, f *sourceFile, line, col int, language string, severity domain.Severity, category domain.Category, title, description, suggestion, reference string) domain.Issue {
+	return domain.Issue{
+		ID:          fmt.Sprintf("%s_%s:%d", kind, f.rel, line),
+		Title:       title,
+		Description: description,
+		Severity:    severity,
+		Category:    category,
+		Language:    language,
+		File:        f.path,
+		Line:        line,
+		Column:      col,
+		Suggestion:  suggestion,
+		References:  appendReference(nil, reference),
+	}
+}
@@ -0,0 +1,369 @@
+package issues
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func detectMisconfigurations(t *testing.T, language, root, directory string, project *detector.Project) map[string]domain.Issue {
+	t.Helper()
+	dir := &detector.DirectoryAnalysis{
+		Language:  language,
+		Project:   project,
+		Path:      filepath.Join(root, directory),
+		Root:      root,
+		Libraries: []domain.Library{{Name: "opentelemetry"}},
+	}
+	issues, err := NewMisconfigurationDetector().Detect(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	byID := make(map[string]domain.Issue)
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	return byID
+}
+
+func TestMisconfigurationDetector_Go(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"main.go": `package main
+
+import (
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func setup(ctx context.Context) {
+	exp, _ := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint("collector.prod:4317"),
+		otlptracegrpc.WithInsecure(),
+	)
+	local, _ := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint("localhost:4317"))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSyncer(exp),
+		sdktrace.WithBatcher(local),
+	)
+	_ = tp
+}
+`,
+		"main_test.go": `package main
+
+import sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+var tp = sdktrace.NewTracerProvider(sdktrace.WithSyncer(nil))
+`,
+	})
+
+	issues := detectMisconfigurations(t, "go", root, "", nil)
+	want := map[string]domain.Category{
+		"plaintext_exporter_main.go:10":    domain.CategorySecurity,
+		"localhost_endpoint_main.go:13":    domain.CategoryConfiguration,
+		"always_on_sampler_main.go:15":     domain.CategoryPerformance,
+		"simple_span_processor_main.go:16": domain.CategoryPerformance,
+		"missing_service_name_main.go:14":  domain.CategoryConfiguration,
+		"unregistered_provider_main.go:14": domain.CategoryConfiguration,
+	}
+	if len(issues) != len(want) {
+		t.Errorf("expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+	for id, category := range want {
+		issue, ok := issues[id]
+		if !ok {
+			t.Errorf("expected issue %s", id)
+			continue
+		}
+		if issue.Category != category || issue.File != filepath.Join(root, "main.go") || issue.Column == 0 {
+			t.Errorf("unexpected issue %+v", issue)
+		}
+	}
+	if col := issues["localhost_endpoint_main.go:13"].Column; col != 64 {
+		t.Errorf("expected the endpoint literal at column 64, got %d", col)
+	}
+}
+
+func TestMisconfigurationDetector_Python(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"app/telemetry.py": `from opentelemetry import trace
+from opentelemetry.sdk.resources import Resource, SERVICE_NAME
+from opentelemetry.sdk.trace import TracerProvider
+from opentelemetry.sdk.trace.export import BatchSpanProcessor
+from opentelemetry.exporter.otlp.proto.grpc.trace_exporter import OTLPSpanExporter
+
+provider = TracerProvider(resource=Resource.create({SERVICE_NAME: "checkout"}))
+provider.add_span_processor(BatchSpanProcessor(OTLPSpanExporter(endpoint="http://127.0.0.1:4317", insecure=True)))
+trace.set_tracer_provider(provider)
+`,
+	})
+
+	issues := detectMisconfigurations(t, "python", root, "app", nil)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	if _, ok := issues["localhost_endpoint_app/telemetry.py:8"]; !ok {
+		t.Errorf("expected the localhost endpoint, got %+v", issues)
+	}
+}
+
+func TestMisconfigurationDetector_JavaScript(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"tracing.js": `const { NodeSDK } = require('@opentelemetry/sdk-node');
+const { OTLPTraceExporter } = require('@opentelemetry/exporter-trace-otlp-http');
+
+const sdk = new NodeSDK({
+  traceExporter: new OTLPTraceExporter({ url: 'http://otel.example.com:4318/v1/traces' }),
+});
+sdk.start();
+`,
+	})
+
+	issues := detectMisconfigurations(t, "javascript", root, "", nil)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	if _, ok := issues["plaintext_exporter_tracing.js:5"]; !ok {
+		t.Errorf("expected the plaintext exporter, got %+v", issues)
+	}
+	// NodeSDK registers its provider, but still needs a service name
+	if _, ok := issues["missing_service_name_tracing.js:4"]; !ok {
+		t.Errorf("expected the missing service name, got %+v", issues)
+	}
+}
+
+func TestMisconfigurationDetector_OwnedFiles(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"app.py": `from opentelemetry.sdk.trace.sampling import ALWAYS_ON
+sampler = ALWAYS_ON
+`,
+		"pkg/worker.py": `from opentelemetry.sdk.trace.sampling import ALWAYS_ON
+
+sampler = ALWAYS_ON
+`,
+	})
+
+	// Without a project, subdirectories are analyzed on their own
+	issues := detectMisconfigurations(t, "python", root, "", nil)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	if _, ok := issues["always_on_sampler_app.py:2"]; !ok {
+		t.Errorf("expected the sampler in app.py, got %+v", issues)
+	}
+	issues = detectMisconfigurations(t, "python", root, "pkg", nil)
+	if _, ok := issues["always_on_sampler_pkg/worker.py:3"]; !ok || len(issues) != 1 {
+		t.Errorf("expected the sampler in pkg/worker.py keyed from the root, got %+v", issues)
+	}
+
+	// A project owns the files of its subdirectories
+	issues = detectMisconfigurations(t, "python", root, "", &detector.Project{Path: root, Language: "python"})
+	if len(issues) != 2 {
+		t.Errorf("expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+}
+
+func TestMisconfigurationDetector_EnvironmentDefaults(t *testing.T) {
+	cases := []struct {
+		language string
+		file     string
+		source   string
+	}{
+		{"java", "Otel.java", `import io.opentelemetry.exporter.otlp.trace.OtlpGrpcSpanExporter;
+
+class Otel {
+    static OtlpGrpcSpanExporter exporter() {
+        return OtlpGrpcSpanExporter.builder()
+            .setEndpoint(System.getenv().getOrDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317"))
+            .build();
+    }
+}
+`},
+		{"python", "telemetry.py", `import os
+from opentelemetry.exporter.otlp.proto.grpc.trace_exporter import OTLPSpanExporter
+
+exporter = OTLPSpanExporter(endpoint=os.environ.get("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4317"))
+fallback = OTLPSpanExporter(endpoint=os.getenv("OTEL_EXPORTER_OTLP_ENDPOINT") or "localhost:4317")
+`},
+		{"javascript", "tracing.js", `const { OTLPTraceExporter } = require('@opentelemetry/exporter-trace-otlp-http');
+
+const exporter = new OTLPTraceExporter({ url: process.env.OTEL_EXPORTER_OTLP_ENDPOINT || 'http://localhost:4318/v1/traces' });
+const other = new OTLPTraceExporter({ url: process.env.COLLECTOR_URL ?? 'http://localhost:4318/v1/traces' });
+`},
+		{"csharp", "Program.cs", `using OpenTelemetry.Exporter;
+
+var exporter = new OtlpExporterOptions { Endpoint = new Uri(Environment.GetEnvironmentVariable("OTEL_EXPORTER_OTLP_ENDPOINT") ?? "http://localhost:4318") };
+`},
+		{"kotlin", "Otel.kt", `import io.opentelemetry.exporter.otlp.trace.OtlpGrpcSpanExporter
+
+val exporter = OtlpGrpcSpanExporter.builder().setEndpoint(System.getenv("OTEL_EXPORTER_OTLP_ENDPOINT") ?: "http://localhost:4317").build()
+`},
+		{"ruby", "otel.rb", `require 'opentelemetry/exporter/otlp'
+
+exporter = OpenTelemetry::Exporter::OTLP::Exporter.new(endpoint: ENV.fetch('OTEL_EXPORTER_OTLP_ENDPOINT', 'http://localhost:4318/v1/traces'))
+other = OpenTelemetry::Exporter::OTLP::Exporter.new(endpoint: ENV['COLLECTOR_URL'] || 'http://localhost:4318/v1/traces')
+`},
+		{"php", "otel.php", `<?php
+use OpenTelemetry\Contrib\Otlp\OtlpHttpTransportFactory;
+
+$transport = (new OtlpHttpTransportFactory())->create(getenv('COLLECTOR_URL') ?: 'http://localhost:4318/v1/traces', 'application/json');
+$other = (new OtlpHttpTransportFactory())->create($_ENV['COLLECTOR_URL'] ?? 'http://localhost:4318/v1/traces', 'application/json');
+`},
+		{"rust", "otel.rs", `use opentelemetry_otlp::{SpanExporter, WithExportConfig};
+
+fn exporter() -> SpanExporter {
+    SpanExporter::builder().with_tonic().with_endpoint(std::env::var("COLLECTOR_URL").unwrap_or("http://localhost:4317".to_string())).build().unwrap()
+}
+`},
+		{"elixir", "runtime.exs", `import Config
+
+config :opentelemetry_exporter,
+  otlp_endpoint: System.get_env("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.language, func(t *testing.T) {
+			root := t.TempDir()
+			testutil.WriteFiles(t, root, map[string]string{tc.file: tc.source})
+			issues := detectMisconfigurations(t, tc.language, root, "", nil)
+			for id, issue := range issues {
+				if strings.HasPrefix(id, "localhost_endpoint") || strings.HasPrefix(id, "plaintext_exporter") {
+					t.Errorf("expected no endpoint findings for environment defaults, got %+v", issue)
+				}
+			}
+		})
+	}
+}
+
+func TestMisconfigurationDetector_OtherLanguages(t *testing.T) {
+	cases := []struct {
+		language string
+		file     string
+		source   string
+		want     []string
+	}{
+		{
+			language: "kotlin",
+			file:     "Otel.kt",
+			source: `import io.opentelemetry.sdk.trace.SdkTracerProvider
+
+val tracerProvider = SdkTracerProvider.builder()
+    .addSpanProcessor(SimpleSpanProcessor.create(OtlpGrpcSpanExporter.builder().setEndpoint("http://collector.prod:4317").build()))
+    .setSampler(Sampler.alwaysOn())
+    .build()
+`,
+			want: []string{"simple_span_processor_Otel.kt:4", "plaintext_exporter_Otel.kt:4", "always_on_sampler_Otel.kt:5",
+				"missing_service_name_Otel.kt:3", "unregistered_provider_Otel.kt:3"},
+		},
+		{
+			language: "ruby",
+			file:     "otel.rb",
+			source: `require 'opentelemetry/sdk'
+
+OpenTelemetry::SDK.configure do |c|
+  c.add_span_processor(
+    OpenTelemetry::SDK::Trace::Export::SimpleSpanProcessor.new(
+      OpenTelemetry::Exporter::OTLP::Exporter.new(endpoint: 'http://localhost:4318/v1/traces')
+    )
+  )
+end
+`,
+			want: []string{"simple_span_processor_otel.rb:5", "localhost_endpoint_otel.rb:6", "missing_service_name_otel.rb:3"},
+		},
+		{
+			language: "php",
+			file:     "otel.php",
+			source: `<?php
+use OpenTelemetry\SDK\Trace\TracerProvider;
+use OpenTelemetry\SDK\Resource\ResourceInfo;
+
+$transport = (new OtlpHttpTransportFactory())->create('http://collector.prod:4318/v1/traces', 'application/json');
+$tracerProvider = new TracerProvider(new BatchSpanProcessor(new SpanExporter($transport)), new AlwaysOnSampler(), ResourceInfo::create(Attributes::create(['service.name' => 'checkout'])));
+Sdk::builder()->setTracerProvider($tracerProvider)->buildAndRegisterGlobal();
+`,
+			want: []string{"plaintext_exporter_otel.php:5", "always_on_sampler_otel.php:6"},
+		},
+		{
+			language: "rust",
+			file:     "otel.rs",
+			source: `use opentelemetry_sdk::trace::{Sampler, SdkTracerProvider};
+
+fn init() -> SdkTracerProvider {
+    let exporter = SpanExporter::builder().with_http().with_endpoint("http://localhost:4318/v1/traces").build().unwrap();
+    SdkTracerProvider::builder()
+        .with_simple_exporter(exporter)
+        .with_sampler(Sampler::AlwaysOn)
+        .with_resource(Resource::builder().with_service_name("checkout").build())
+        .build()
+}
+`,
+			want: []string{"localhost_endpoint_otel.rs:4", "simple_span_processor_otel.rs:6", "always_on_sampler_otel.rs:7",
+				"unregistered_provider_otel.rs:5"},
+		},
+		{
+			language: "elixir",
+			file:     "runtime.exs",
+			source: `import Config
+
+config :opentelemetry,
+  sampler: {:parent_based, %{root: :always_on}}
+
+config :opentelemetry_exporter,
+  otlp_protocol: :http_protobuf,
+  otlp_endpoint: "http://collector.prod:4318"
+`,
+			want: []string{"always_on_sampler_runtime.exs:4", "plaintext_exporter_runtime.exs:8"},
+		},
+		{
+			language: "cpp",
+			file:     "otel.cc",
+			source: `#include "opentelemetry/sdk/trace/tracer_provider_factory.h"
+
+void InitTracer() {
+  otlp::OtlpHttpExporterOptions opts;
+  opts.url = "http://localhost:4318/v1/traces";
+  auto exporter = otlp::OtlpHttpExporterFactory::Create(opts);
+  auto processor = trace_sdk::SimpleSpanProcessorFactory::Create(std::move(exporter));
+  std::shared_ptr<opentelemetry::trace::TracerProvider> provider =
+      trace_sdk::TracerProviderFactory::Create(std::move(processor), resource::Resource::Create({{"service.name", "checkout"}}));
+  opentelemetry::trace::Provider::SetTracerProvider(provider);
+}
+`,
+			want: []string{"localhost_endpoint_otel.cc:5", "simple_span_processor_otel.cc:7"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.language, func(t *testing.T) {
+			root := t.TempDir()
+			testutil.WriteFiles(t, root, map[string]string{tc.file: tc.source})
+			issues := detectMisconfigurations(t, tc.language, root, "", nil)
+			for _, id := range tc.want {
+				if _, ok := issues[id]; !ok {
+					t.Errorf("expected issue %s", id)
+				}
+			}
+			if len(issues) != len(tc.want) {
+				t.Errorf("expected %d issues, got %d: %v", len(tc.want), len(issues), issueIDs(issues))
+			}
+		})
+	}
+}
+
+func issueIDs(issues map[string]domain.Issue) []string {
+	var ids []string
+	for id := range issues {
+		ids = append(ids, id)
+	}
+	return ids
+}
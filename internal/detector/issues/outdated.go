@@ -0,0 +1,185 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/semver"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+// OutdatedDetector detects OpenTelemetry libraries behind their latest stable release
+type OutdatedDetector struct {
+	// now returns the time release ages are measured against
+	now func() time.Time
+}
+
+// NewOutdatedDetector creates a new outdated library detector
+func NewOutdatedDetector() *OutdatedDetector {
+	return &OutdatedDetector{now: time.Now}
+}
+
+// ID returns the detector identifier
+func (o *OutdatedDetector) ID() string {
+	return "outdated_otel_libraries"
+}
+
+// Name returns the detector name
+func (o *OutdatedDetector) Name() string {
+	return "Outdated OpenTelemetry Libraries"
+}
+
+// Description returns what this detector looks for
+func (o *OutdatedDetector) Description() string {
+	return "Detects OpenTelemetry libraries behind their latest stable release and the breaking changes on the upgrade path"
+}
+
+// Category returns the issue category
+func (o *OutdatedDetector) Category() domain.Category {
+	return domain.CategoryBestPractice
+}
+
+// Languages returns which languages this detector applies to
+func (o *OutdatedDetector) Languages() []string {
+	return []string{} // Applies to all languages
+}
+
+// Detect reports one issue per outdated library. It needs the knowledge base from the
+// context and reports nothing without it.
+func (o *OutdatedDetector) Detect(ctx context.Context, directory *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	knowledge := detector.KnowledgeFromContext(ctx)
+	if knowledge == nil {
+		return nil, nil
+	}
+
+	var issues []domain.Issue
+	seen := make(map[string]bool)
+	for _, lib := range directory.Libraries {
+		if lib.Version == "" || seen[lib.Name] {
+			continue
+		}
+		seen[lib.Name] = true
+		if lib.Language == "" {
+			lib.Language = directory.Language
+		}
+		issue, err := o.checkLibrary(ctx, knowledge, lib)
+		if err != nil {
+			return nil, err
+		}
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	return issues, nil
+}
+
+// checkLibrary returns an issue when lib is older than its latest release, nil otherwise
+func (o *OutdatedDetector) checkLibrary(ctx context.Context, knowledge detector.Knowledge, lib domain.Library) (*domain.Issue, error) {
+	pkg := libraryPackage(lib)
+	latest, err := knowledge.GetLatestVersion(ctx, pkg)
+	if err != nil || latest == nil {
+		return nil, err
+	}
+	installed, err := semver.Parse(lib.Version)
+	if err != nil {
+		return nil, nil
+	}
+	target, err := semver.Parse(latest.Name)
+	if err != nil || installed.Compare(target) >= 0 {
+		return nil, nil
+	}
+
+	// Count the releases between the installed and the latest one, and find the
+	// installed release for its age
+	versions, err := knowledge.GetVersions(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	behind := 0
+	var current *types.Version
+	for i := range versions {
+		v, err := semver.Parse(versions[i].Name)
+		if err != nil {
+			continue
+		}
+		if v.Compare(installed) == 0 {
+			current = &versions[i]
+		}
+		if v.Compare(installed) > 0 && v.Compare(target) <= 0 && (!v.IsPrerelease() || installed.IsPrerelease()) {
+			behind++
+		}
+	}
+	if behind == 0 {
+		// The knowledge base doesn't list the releases in between
+		behind = 1
+	}
+
+	changes, err := knowledge.GetBreakingChanges(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	var breaking, references []string
+	references = appendReference(references, latest.ChangelogURL)
+	for _, change := range changes {
+		v, err := semver.Parse(change.Version)
+		if err != nil || v.Compare(installed) <= 0 || v.Compare(target) > 0 {
+			continue
+		}
+		breaking = append(breaking, fmt.Sprintf("- %s: %s", change.Version, change.Description))
+		references = appendReference(references, change.MigrationGuideURL)
+	}
+
+	releases := "release"
+	if behind > 1 {
+		releases = "releases"
+	}
+	description := fmt.Sprintf("%s %s is %d %s behind the latest release %s.", lib.Name, lib.Version, behind, releases, latest.Name)
+	if current != nil && !current.ReleaseDate.IsZero() {
+		description += fmt.Sprintf(" %s was released on %s, %s ago.",
+			lib.Version, current.ReleaseDate.Format("2006-01-02"), formatAge(o.now().Sub(current.ReleaseDate)))
+	}
+	if !latest.ReleaseDate.IsZero() {
+		description += fmt.Sprintf(" %s was released on %s.", latest.Name, latest.ReleaseDate.Format("2006-01-02"))
+	}
+	suggestion := upgradeSuggestion(lib, latest.Name)
+	if len(breaking) > 0 {
+		description += "\n\nBreaking changes on the upgrade path:\n" + strings.Join(breaking, "\n")
+		suggestion += "\nReview the breaking changes before upgrading."
+	}
+
+	severity := domain.SeverityInfo
+	if target.Major > installed.Major {
+		severity = domain.SeverityWarning
+	}
+	return &domain.Issue{
+		ID:          fmt.Sprintf("outdated_%s_%s", lib.Language, lib.Name),
+		Title:       fmt.Sprintf("%s %s is outdated (latest %s)", lib.Name, lib.Version, latest.Name),
+		Description: description,
+		Severity:    severity,
+		Category:    o.Category(),
+		Language:    lib.Language,
+		File:        lib.PackageFile,
+		Suggestion:  suggestion,
+		References:  references,
+	}, nil
+}
+
+// formatAge renders a duration in days, months or years
+func formatAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	unit, n := "day", days
+	switch {
+	case days >= 365:
+		unit, n = "year", days/365
+	case days >= 30:
+		unit, n = "month", days/30
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
@@ -0,0 +1,70 @@
+package issues
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+func TestOutdatedDetector_Detect(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	knowledge := fakeKnowledge{
+		"opentelemetry-sdk": {Versions: []types.Version{
+			{Name: "1.9.0", ReleaseDate: date("2022-01-10")},
+			{Name: "1.10.0", ReleaseDate: date("2022-02-10")},
+			{Name: "1.20.0rc1", ReleaseDate: date("2023-05-01")},
+			{Name: "1.20.0", ReleaseDate: date("2023-06-01"), BreakingChanges: []types.BreakingChange{
+				{Version: "1.20.0", Description: "Dropped Python 3.7", MigrationGuideURL: "https://example.com/py37"},
+			}},
+			{Name: "1.27.0", ReleaseDate: date("2024-08-28"), Status: types.VersionStatusLatest, ChangelogURL: "https://example.com/changelog"},
+		}},
+		"opentelemetry-api": {Versions: []types.Version{
+			{Name: "1.27.0", Status: types.VersionStatusLatest},
+		}},
+	}
+	dir := &detector.DirectoryAnalysis{
+		Language: "python",
+		Libraries: []domain.Library{
+			{Name: "opentelemetry-sdk", Version: "1.10.0", PackageFile: "requirements.txt"},
+			{Name: "opentelemetry-sdk", Language: "python"},
+			{Name: "opentelemetry-api", Version: "1.27.0"},
+		},
+	}
+
+	det := NewOutdatedDetector()
+	det.now = func() time.Time { return date("2024-09-01") }
+	issues, err := det.Detect(detector.WithKnowledge(context.Background(), knowledge), dir)
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	issue := issues[0]
+	if issue.Category != domain.CategoryBestPractice || issue.Severity != domain.SeverityInfo || issue.File != "requirements.txt" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	for _, want := range []string{
+		"is 2 releases behind the latest release 1.27.0",
+		"1.10.0 was released on 2022-02-10, 2 years ago",
+		"- 1.20.0: Dropped Python 3.7",
+	} {
+		if !strings.Contains(issue.Description, want) {
+			t.Errorf("expected %q in the description, got %q", want, issue.Description)
+		}
+	}
+	if !strings.HasPrefix(issue.Suggestion, "Run: pip install opentelemetry-sdk==1.27.0") {
+		t.Errorf("unexpected suggestion %q", issue.Suggestion)
+	}
+	if len(issue.References) != 2 {
+		t.Errorf("expected the changelog and the migration guide, got %v", issue.References)
+	}
+}
@@ -0,0 +1,437 @@
+package issues
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/ignore"
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
+	"github.com/smacker/go-tree-sitter/elixir"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/php"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+// maxSourceSize skips larger files, which are usually generated or bundled
+const maxSourceSize = 1 << 20
+
+// setupSyntax describes how OpenTelemetry setup code looks in the syntax tree of a
+// language
+type setupSyntax struct {
+	grammar    func(path string) *sitter.Language
+	extensions []string
+	// callTypes are the node types of calls and constructor invocations; their callee is
+	// the text before the arguments
+	callTypes   map[string]bool
+	stringTypes map[string]bool
+	importTypes map[string]bool
+	// identifierTypes are the node types of names that don't end in identifier, e.g.
+	// Ruby constants
+	identifierTypes map[string]bool
+	testFile        *regexp.Regexp
+	// provider matches the callee constructing a tracer provider, nil when the SDK is
+	// configured rather than constructed
+	provider *regexp.Regexp
+	// sdk matches the callee of an SDK setup that creates and registers its own providers
+	sdk *regexp.Regexp
+	// register matches the callee installing a provider globally, nil when the SDK
+	// doesn't need one
+	register *regexp.Regexp
+	// registerHint shows how to register a provider
+	registerHint string
+	// insecure matches exporter options disabling TLS
+	insecure *regexp.Regexp
+	// endpointOption matches the target of an assignment setting an exporter endpoint,
+	// for SDKs configured through option structs, e.g. opts.url in C++
+	endpointOption *regexp.Regexp
+	// configCall matches the callee of calls configuring an application in config files;
+	// the application they configure is appended to their callee, e.g.
+	// config:opentelemetry_exporter in Elixir
+	configCall *regexp.Regexp
+	// owned matches the callee constructing a tracer, meter or logger provider that the
+	// application must shut down
+	owned *regexp.Regexp
+	// shutdown matches the name of the method shutting down or flushing a provider; it
+	// counts for the provider its receiver names
+	shutdown *regexp.Regexp
+	// exitHook matches the callee registering a provider's shutdown method to run at
+	// exit, e.g. atexit.register(provider.shutdown)
+	exitHook *regexp.Regexp
+	// autoShutdown matches the callee of a builder call shutting down at exit the
+	// providers set earlier in its chain, e.g. ->setAutoShutdown(true) in PHP
+	autoShutdown *regexp.Regexp
+	// scoped matches statements disposing what they declare when they end, e.g. using
+	// declarations in C#
+	scoped *regexp.Regexp
+	// exitOptOut is set for SDKs whose providers shut down at exit by default, and
+	// matches the arguments of a provider opting out
+	exitOptOut *regexp.Regexp
+}
+
+func nodeTypes(types ...string) map[string]bool {
+	set := make(map[string]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	return set
+}
+
+func fixedGrammar(language *sitter.Language) func(string) *sitter.Language {
+	return func(string) *sitter.Language { return language }
+}
+
+var javaScriptSetup = setupSyntax{
+	grammar:      fixedGrammar(javascript.GetLanguage()),
+	extensions:   []string{".js", ".mjs", ".cjs", ".jsx"},
+	callTypes:    nodeTypes("call_expression", "new_expression"),
+	stringTypes:  nodeTypes("string", "template_string"),
+	importTypes:  nodeTypes("import_statement"),
+	testFile:     regexp.MustCompile(`\.(test|spec)\.[cm]?[jt]sx?$`),
+	provider:     regexp.MustCompile(`(^|\.)(NodeTracerProvider|BasicTracerProvider|WebTracerProvider)$`),
+	sdk:          regexp.MustCompile(`(^|\.)NodeSDK$`),
+	register:     regexp.MustCompile(`(\.register|(^|\.)setGlobalTracerProvider)$`),
+	registerHint: "provider.register()",
+	insecure:     regexp.MustCompile(`\bcreateInsecure\s*\(`),
+	// Browser providers live as long as the page
+	owned:    regexp.MustCompile(`(^|\.)(NodeSDK|NodeTracerProvider|BasicTracerProvider|MeterProvider|LoggerProvider)$`),
+	shutdown: regexp.MustCompile(`^(shutdown|forceFlush)$`),
+}
+
+// setupSyntaxes holds the languages whose setup code is analyzed, keyed by analysis
+// language
+var setupSyntaxes = map[string]*setupSyntax{
+	"go": {
+		grammar:      fixedGrammar(golang.GetLanguage()),
+		extensions:   []string{".go"},
+		callTypes:    nodeTypes("call_expression"),
+		stringTypes:  nodeTypes("interpreted_string_literal", "raw_string_literal"),
+		importTypes:  nodeTypes("import_declaration"),
+		testFile:     regexp.MustCompile(`_test\.go$`),
+		provider:     regexp.MustCompile(`(^|\.)NewTracerProvider$`),
+		register:     regexp.MustCompile(`(^|\.)SetTracerProvider$`),
+		registerHint: "otel.SetTracerProvider(tp)",
+		insecure:     regexp.MustCompile(`\b(WithInsecure\(\)|insecure\.NewCredentials\(\))`),
+		owned:        regexp.MustCompile(`(^|\.)(NewTracerProvider|NewMeterProvider|NewLoggerProvider)$`),
+		shutdown:     regexp.MustCompile(`^(Shutdown|ForceFlush)$`),
+	},
+	"python": {
+		grammar:      fixedGrammar(python.GetLanguage()),
+		extensions:   []string{".py"},
+		callTypes:    nodeTypes("call"),
+		stringTypes:  nodeTypes("string"),
+		importTypes:  nodeTypes("import_statement", "import_from_statement"),
+		testFile:     regexp.MustCompile(`(^|/)(test_[^/]*|[^/]*_test|conftest)\.py$`),
+		provider:     regexp.MustCompile(`(^|\.)TracerProvider$`),
+		register:     regexp.MustCompile(`(^|\.)set_tracer_provider$`),
+		registerHint: "trace.set_tracer_provider(provider)",
+		insecure:     regexp.MustCompile(`\binsecure\s*=\s*True\b`),
+		owned:        regexp.MustCompile(`(^|\.)(TracerProvider|MeterProvider|LoggerProvider)$`),
+		shutdown:     regexp.MustCompile(`^(shutdown|force_flush)$`),
+		exitHook:     regexp.MustCompile(`^atexit\.register$`),
+		exitOptOut:   regexp.MustCompile(`\bshutdown_on_exit\s*=\s*False\b`),
+	},
+	"javascript": &javaScriptSetup,
+	"typescript": func() *setupSyntax {
+		ts := javaScriptSetup
+		ts.grammar = func(path string) *sitter.Language {
+			if strings.HasSuffix(path, ".tsx") {
+				return tsx.GetLanguage()
+			}
+			return typescript.GetLanguage()
+		}
+		ts.extensions = []string{".ts", ".mts", ".cts", ".tsx"}
+		return &ts
+	}(),
+	"java": {
+		grammar:      fixedGrammar(java.GetLanguage()),
+		extensions:   []string{".java"},
+		callTypes:    nodeTypes("method_invocation", "object_creation_expression"),
+		stringTypes:  nodeTypes("string_literal"),
+		importTypes:  nodeTypes("import_declaration"),
+		testFile:     regexp.MustCompile(`(^|/)src/test/|Tests?\.java$`),
+		provider:     regexp.MustCompile(`(^|\.)SdkTracerProvider\.builder$`),
+		register:     regexp.MustCompile(`(\.buildAndRegisterGlobal|(^|\.)GlobalOpenTelemetry\.set)$`),
+		registerHint: "OpenTelemetrySdk.builder().setTracerProvider(tracerProvider).buildAndRegisterGlobal()",
+		owned:        regexp.MustCompile(`(^|\.)(SdkTracerProvider|SdkMeterProvider|SdkLoggerProvider)\.builder$`),
+		shutdown:     regexp.MustCompile(`^(shutdown|close|forceFlush)$`),
+		exitHook:     regexp.MustCompile(`(^|\.)addShutdownHook$`),
+		scoped:       regexp.MustCompile(`^try\s*\(`),
+	},
+	"kotlin": {
+		grammar:      fixedGrammar(kotlin.GetLanguage()),
+		extensions:   []string{".kt"},
+		callTypes:    nodeTypes("call_expression"),
+		stringTypes:  nodeTypes("string_literal"),
+		importTypes:  nodeTypes("import_header"),
+		testFile:     regexp.MustCompile(`(^|/)src/test/|Tests?\.kt$`),
+		provider:     regexp.MustCompile(`(^|\.)SdkTracerProvider\.builder$`),
+		register:     regexp.MustCompile(`(\.buildAndRegisterGlobal|(^|\.)GlobalOpenTelemetry\.set)$`),
+		registerHint: "OpenTelemetrySdk.builder().setTracerProvider(tracerProvider).buildAndRegisterGlobal()",
+		owned:        regexp.MustCompile(`(^|\.)(SdkTracerProvider|SdkMeterProvider|SdkLoggerProvider)\.builder$`),
+		shutdown:     regexp.MustCompile(`^(shutdown|close|forceFlush)$`),
+		exitHook:     regexp.MustCompile(`(^|\.)addShutdownHook$`),
+	},
+	"csharp": {
+		grammar:     fixedGrammar(csharp.GetLanguage()),
+		extensions:  []string{".cs"},
+		callTypes:   nodeTypes("invocation_expression", "object_creation_expression"),
+		stringTypes: nodeTypes("string_literal", "verbatim_string_literal", "raw_string_literal"),
+		importTypes: nodeTypes("using_directive"),
+		testFile:    regexp.MustCompile(`(^|/)[^/]*\.Tests?/|Tests?\.cs$`),
+		// The .NET SDK listens to ActivitySources directly; there is nothing to register
+		provider: regexp.MustCompile(`((^|\.)Sdk\.CreateTracerProviderBuilder|\.AddOpenTelemetry)$`),
+		// Providers added to a host are disposed with it
+		owned:    regexp.MustCompile(`(^|\.)Sdk\.(CreateTracerProviderBuilder|CreateMeterProviderBuilder|CreateLoggerProviderBuilder)$`),
+		shutdown: regexp.MustCompile(`^(Dispose|Shutdown|ForceFlush)$`),
+		scoped:   regexp.MustCompile(`^using\b`),
+	},
+	"ruby": {
+		grammar:         fixedGrammar(ruby.GetLanguage()),
+		extensions:      []string{".rb"},
+		callTypes:       nodeTypes("call"),
+		stringTypes:     nodeTypes("string"),
+		identifierTypes: nodeTypes("constant"),
+		testFile:        regexp.MustCompile(`_(spec|test)\.rb$`),
+		provider:        regexp.MustCompile(`(^|::)TracerProvider\.new$`),
+		// A provider constructed by hand is installed by assigning
+		// OpenTelemetry.tracer_provider, which isn't a call
+		sdk:      regexp.MustCompile(`(^|::)SDK\.configure$`),
+		owned:    regexp.MustCompile(`(^|::)(TracerProvider|MeterProvider|LoggerProvider)\.new$`),
+		shutdown: regexp.MustCompile(`^(shutdown|force_flush)$`),
+	},
+	"php": {
+		grammar:         fixedGrammar(php.GetLanguage()),
+		extensions:      []string{".php"},
+		callTypes:       nodeTypes("function_call_expression", "member_call_expression", "nullsafe_member_call_expression", "scoped_call_expression", "object_creation_expression"),
+		stringTypes:     nodeTypes("string", "encapsed_string"),
+		importTypes:     nodeTypes("namespace_use_declaration"),
+		identifierTypes: nodeTypes("name"),
+		testFile:        regexp.MustCompile(`Test\.php$`),
+		provider:        regexp.MustCompile(`(^|\\)TracerProvider(::builder)?$`),
+		register:        regexp.MustCompile(`(\.buildAndRegisterGlobal|(^|\\)Globals::registerInitializer)$`),
+		registerHint:    "Sdk::builder()->setTracerProvider($tracerProvider)->buildAndRegisterGlobal()",
+		owned:           regexp.MustCompile(`(^|\\)(TracerProvider|MeterProvider|LoggerProvider)(::builder)?$`),
+		shutdown:        regexp.MustCompile(`^(shutdown|forceFlush)$`),
+		exitHook:        regexp.MustCompile(`^register_shutdown_function$`),
+		autoShutdown:    regexp.MustCompile(`\.setAutoShutdown$`),
+	},
+	"rust": {
+		grammar:      fixedGrammar(rust.GetLanguage()),
+		extensions:   []string{".rs"},
+		callTypes:    nodeTypes("call_expression"),
+		stringTypes:  nodeTypes("string_literal", "raw_string_literal"),
+		importTypes:  nodeTypes("use_declaration"),
+		testFile:     regexp.MustCompile(`(^|/)(tests|benches)/`),
+		provider:     regexp.MustCompile(`(^|::)(Sdk)?TracerProvider::builder$`),
+		register:     regexp.MustCompile(`(^|::)global::set_tracer_provider$`),
+		registerHint: "global::set_tracer_provider(provider.clone())",
+		// Providers are usually shut down when a guard owning them is dropped, which
+		// can't be followed through the syntax tree, so their shutdown isn't checked
+	},
+	"elixir": {
+		grammar:     fixedGrammar(elixir.GetLanguage()),
+		extensions:  []string{".ex", ".exs"},
+		callTypes:   nodeTypes("call"),
+		stringTypes: nodeTypes("string"),
+		// Atoms name samplers and processors in the SDK's configuration
+		identifierTypes: nodeTypes("atom"),
+		testFile:        regexp.MustCompile(`_test\.exs$`),
+		// The SDK is an OTP application configured in config files; it starts and
+		// registers its providers itself and defaults service.name to the release name
+		configCall: regexp.MustCompile(`^config$`),
+		// The application stops its providers when it stops, so their shutdown isn't
+		// checked
+	},
+	"cpp": {
+		grammar:      fixedGrammar(cpp.GetLanguage()),
+		extensions:   []string{".cc", ".cpp", ".cxx", ".h", ".hh", ".hpp"},
+		callTypes:    nodeTypes("call_expression", "new_expression"),
+		stringTypes:  nodeTypes("string_literal", "raw_string_literal"),
+		importTypes:  nodeTypes("preproc_include"),
+		testFile:     regexp.MustCompile(`(_test|_unittest|Test)\.(cc|cpp|cxx)$`),
+		provider:     regexp.MustCompile(`(^|::)TracerProviderFactory::Create$`),
+		register:     regexp.MustCompile(`(^|::)Provider::SetTracerProvider$`),
+		registerHint: "opentelemetry::trace::Provider::SetTracerProvider(provider)",
+		// Exporters are configured through option structs, e.g. opts.url = "..."
+		endpointOption: regexp.MustCompile(`\.(url|endpoint)$`),
+		// Providers are shut down by their destructor when the last shared_ptr to them
+		// is released, so their shutdown isn't checked
+	},
+}
+
+// argumentListTypes are the node types holding the arguments of a call when the
+// grammar has no arguments field
+var argumentListTypes = nodeTypes("arguments", "argument_list", "call_suffix")
+
+// testDirs are directory names holding tests in every language
+var testDirs = map[string]bool{"test": true, "tests": true, "__tests__": true, "testdata": true, "spec": true}
+
+// sourceFile is a parsed source file
+type sourceFile struct {
+	path string
+	// rel is the path relative to the codebase root, which issue IDs are built from
+	rel     string
+	content []byte
+	tree    *sitter.Tree
+	// test is set for test files and files under test directories
+	test bool
+}
+
+// walkSetupFiles parses the source files of a directory that reference OpenTelemetry
+// and calls visit with each. A project's files are walked down to its nested projects,
+// which the ignore matcher in ctx excludes; any other directory only owns its own files,
+// since its subdirectories are analyzed on their own.
+func walkSetupFiles(ctx context.Context, directory *detector.DirectoryAnalysis, syntax *setupSyntax, visit func(*sourceFile) error) error {
+	root := directory.Path
+	base := directory.Root
+	if base == "" {
+		base = root
+	}
+	parser := sitter.NewParser()
+	return ignore.FromContext(ctx, root).Walk(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && directory.Project == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !hasExtension(path, syntax.extensions) || strings.HasSuffix(path, ".d.ts") {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > maxSourceSize {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil || !bytes.Contains(bytes.ToLower(content), []byte("opentelemetry")) {
+			return nil
+		}
+
+		parser.SetLanguage(syntax.grammar(path))
+		tree, err := parser.ParseCtx(ctx, nil, content)
+		if err != nil {
+			return nil
+		}
+		defer tree.Close()
+
+		return visit(&sourceFile{
+			path:    path,
+			rel:     relativePath(base, path),
+			content: content,
+			tree:    tree,
+			test:    isTestFile(relativePath(root, path), syntax),
+		})
+	})
+}
+
+// relativePath returns path relative to base with forward slashes
+func relativePath(base, path string) string {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func hasExtension(path string, extensions []string) bool {
+	for _, ext := range extensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTestFile(rel string, syntax *setupSyntax) bool {
+	if syntax.testFile.MatchString(rel) {
+		return true
+	}
+	parts := strings.Split(rel, "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if testDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
+
+// callee returns the text of a call before its arguments and block without whitespace
+// and without the new keyword, e.g. "otlptracegrpc.New" or
+// "SdkTracerProvider.builder().setSampler(x).build". Member access with -> is written
+// with a dot, so PHP and C++ callees read like the others.
+func (f *sourceFile) callee(call *sitter.Node) string {
+	start, end := call.StartByte(), call.EndByte()
+	for _, field := range []string{"constructor", "type"} {
+		if name := call.ChildByFieldName(field); name != nil {
+			start = name.StartByte()
+			break
+		}
+	}
+	if first := call.Child(0); first != nil && first.Type() == "new" && call.NamedChildCount() > 0 && start == call.StartByte() {
+		start = call.NamedChild(0).StartByte()
+	}
+	if args := f.arguments(call); args != nil {
+		end = args.StartByte()
+	} else if block := call.ChildByFieldName("block"); block != nil {
+		end = block.StartByte()
+	}
+	return strings.ReplaceAll(strings.Join(strings.Fields(string(f.content[start:end])), ""), "->", ".")
+}
+
+// arguments returns the node holding the arguments of a call, nil when it has none
+func (f *sourceFile) arguments(call *sitter.Node) *sitter.Node {
+	if args := call.ChildByFieldName("arguments"); args != nil {
+		return args
+	}
+	for i := 0; i < int(call.NamedChildCount()); i++ {
+		if child := call.NamedChild(i); argumentListTypes[child.Type()] {
+			return child
+		}
+	}
+	return nil
+}
+
+// text returns the source of a node
+func (f *sourceFile) text(n *sitter.Node) string {
+	return n.Content(f.content)
+}
+
+// stringValue returns the contents of a string literal without prefixes and quotes
+func (f *sourceFile) stringValue(n *sitter.Node) string {
+	return strings.Trim(strings.TrimLeft(f.text(n), "@$rRbBuUfF"), "\"'`")
+}
+
+// position returns the 1-based line and column of a node
+func position(n *sitter.Node) (int, int) {
+	p := n.StartPoint()
+	return int(p.Row) + 1, int(p.Column) + 1
+}
+
+// visitNodes calls visit with every node below n, telling whether it is inside an
+// import. visit returns false to skip a node's children.
+func visitNodes(n *sitter.Node, syntax *setupSyntax, inImport bool, visit func(n *sitter.Node, inImport bool) bool) {
+	inImport = inImport || syntax.importTypes[n.Type()]
+	if !visit(n, inImport) {
+		return
+	}
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		visitNodes(n.NamedChild(i), syntax, inImport, visit)
+	}
+}
@@ -0,0 +1,281 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// MissingShutdownDetectorID identifies the missing shutdown detector and its issues
+const MissingShutdownDetectorID = "missing_otel_shutdown"
+
+const providerShutdownDocs = "https://opentelemetry.io/docs/specs/otel/trace/sdk/#shutdown"
+
+// shutdownHints show how each language shuts a provider down on exit
+var shutdownHints = map[string]string{
+	"go":         "Shut the provider down before main returns: defer tp.Shutdown(context.Background())",
+	"python":     "Shut the provider down at exit with atexit.register(provider.shutdown), or keep the default shutdown_on_exit=True",
+	"javascript": "Shut the SDK down on termination signals: process.on('SIGTERM', () => sdk.shutdown())",
+	"typescript": "Shut the SDK down on termination signals: process.on('SIGTERM', () => sdk.shutdown())",
+	"java":       "Close the provider on exit: Runtime.getRuntime().addShutdownHook(new Thread(tracerProvider::close))",
+	"csharp":     "Dispose the provider on exit by declaring it with using: using var tracerProvider = Sdk.CreateTracerProviderBuilder()...Build();",
+	"kotlin":     "Close the provider on exit: Runtime.getRuntime().addShutdownHook(Thread(tracerProvider::close))",
+	"ruby":       "Shut the provider down at exit: at_exit { tracer_provider.shutdown }",
+	"php":        "Shut the provider down at exit with register_shutdown_function([$tracerProvider, 'shutdown']), or build the SDK with ->setAutoShutdown(true)",
+}
+
+// MissingShutdownDetector detects tracer, meter and logger providers that are never shut
+// down, so the telemetry still buffered on exit is lost
+type MissingShutdownDetector struct{}
+
+// NewMissingShutdownDetector creates a new missing shutdown detector
+func NewMissingShutdownDetector() *MissingShutdownDetector {
+	return &MissingShutdownDetector{}
+}
+
+// ID returns the detector identifier
+func (m *MissingShutdownDetector) ID() string {
+	return MissingShutdownDetectorID
+}
+
+// Name returns the detector name
+func (m *MissingShutdownDetector) Name() string {
+	return "Missing OpenTelemetry Shutdown"
+}
+
+// Description returns what this detector looks for
+func (m *MissingShutdownDetector) Description() string {
+	return "Detects OpenTelemetry providers that are constructed but never shut down, flushed or disposed"
+}
+
+// Category returns the issue category
+func (m *MissingShutdownDetector) Category() domain.Category {
+	return domain.CategoryConfiguration
+}
+
+// Languages returns which languages this detector applies to
+func (m *MissingShutdownDetector) Languages() []string {
+	return []string{"go", "python", "javascript", "typescript", "java", "kotlin", "csharp", "ruby", "php"}
+}
+
+// methodReference matches a method referenced or accessed on a receiver, e.g.
+// provider.shutdown, tracerProvider::close or @provider&.shutdown
+var methodReference = regexp.MustCompile(`([\w$@.]+)(?:[?&]?\.|::)(\w+)`)
+
+// callableArray matches a method referenced as a PHP callable, e.g.
+// [$tracerProvider, 'shutdown']
+var callableArray = regexp.MustCompile(`\[\s*([\w$>-]+)\s*,\s*['"](\w+)['"]\s*\]`)
+
+// chainedProvider matches a provider set on a PHP SDK builder, e.g.
+// setTracerProvider($tracerProvider)
+var chainedProvider = regexp.MustCompile(`\bset(?:Tracer|Meter|Logger)Provider\(\s*([\w$.]+)\s*\)`)
+
+// declarationFields are the fields naming what a declaration or assignment node binds,
+// keyed by node type
+var declarationFields = map[string]string{
+	"short_var_declaration":   "left",
+	"assignment_statement":    "left",
+	"var_spec":                "name",
+	"assignment":              "left",
+	"assignment_expression":   "left",
+	"variable_declarator":     "name",
+	"public_field_definition": "name",
+	"operator_assignment":     "left",
+	"property_declaration":    "",
+}
+
+// argumentTypes are the node types holding the arguments of a call
+var argumentTypes = nodeTypes("argument_list", "arguments", "argument", "value_arguments")
+
+// providerConstruction is a provider constructed by the application
+type providerConstruction struct {
+	issue domain.Issue
+	// names are the variables the provider is assigned to
+	names []string
+}
+
+// Detect reports each provider construction that is never shut down. A provider is
+// shut down by a shutdown call on the variable it is assigned to, or by registering
+// that variable's shutdown method as an exit hook. Shutdowns are matched anywhere in
+// the directory's non-test code, since they often live in another function or file
+// than the construction.
+func (m *MissingShutdownDetector) Detect(ctx context.Context, directory *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	language := strings.ToLower(directory.Language)
+	syntax, ok := setupSyntaxes[language]
+	if !ok || syntax.owned == nil || directory.Path == "" || len(directory.Libraries) == 0 {
+		return nil, nil
+	}
+
+	var providers []providerConstruction
+	shutDown := make(map[string]bool)
+	err := walkSetupFiles(ctx, directory, syntax, func(f *sourceFile) error {
+		if f.test {
+			return nil
+		}
+		visitNodes(f.tree.RootNode(), syntax, false, func(n *sitter.Node, _ bool) bool {
+			if !syntax.callTypes[n.Type()] {
+				return true
+			}
+			callee := f.callee(n)
+			if match := methodReference.FindStringSubmatch(callee); match != nil && match[0] == callee && syntax.shutdown.MatchString(match[2]) {
+				shutDown[receiverName(match[1])] = true
+			}
+			if syntax.exitHook != nil && syntax.exitHook.MatchString(callee) {
+				if args := f.arguments(n); args != nil {
+					text := f.text(args)
+					for _, match := range append(methodReference.FindAllStringSubmatch(text, -1), callableArray.FindAllStringSubmatch(text, -1)...) {
+						if syntax.shutdown.MatchString(match[2]) {
+							shutDown[receiverName(match[1])] = true
+						}
+					}
+				}
+			}
+			if syntax.autoShutdown != nil && syntax.autoShutdown.MatchString(callee) {
+				if args := f.arguments(n); args != nil && strings.Contains(f.text(args), "true") {
+					for _, match := range chainedProvider.FindAllStringSubmatch(callee, -1) {
+						shutDown[receiverName(match[1])] = true
+					}
+				}
+			}
+			if syntax.owned.MatchString(callee) && !shutsDownItself(f, syntax, n) {
+				if names, returned := assignedNames(f, syntax, n); !returned {
+					providers = append(providers, providerConstruction{issue: m.issue(f, n, callee, language), names: names})
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []domain.Issue
+	for _, p := range providers {
+		if !anyShutDown(p.names, shutDown) {
+			issues = append(issues, p.issue)
+		}
+	}
+	return issues, nil
+}
+
+// assignedNames returns the variables the value of call is assigned to, and whether it
+// is returned to the caller instead, which then owns its shutdown. A provider passed
+// straight to another call has no name it can be shut down by.
+func assignedNames(f *sourceFile, syntax *setupSyntax, call *sitter.Node) ([]string, bool) {
+	for child, n := call, call.Parent(); n != nil; child, n = n, n.Parent() {
+		typ := n.Type()
+		if field, ok := declarationFields[typ]; ok {
+			target := declarationTarget(n, field)
+			if target == nil {
+				return nil, false
+			}
+			var names []string
+			for _, name := range strings.Split(f.text(target), ",") {
+				if name = receiverName(name); name != "" && name != "_" {
+					names = append(names, name)
+				}
+			}
+			return names, false
+		}
+		switch {
+		case typ == "return_statement", typ == "return", typ == "jump_expression" && strings.HasPrefix(f.text(n), "return"):
+			return nil, true
+		case isImplicitResult(f, n, child):
+			return nil, true
+		case argumentTypes[typ], typ == "block", strings.HasSuffix(typ, "statement"):
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// declarationTarget returns the child of a declaration or assignment naming what it
+// binds. Kotlin's grammar has no fields, so its targets are found by type.
+func declarationTarget(n *sitter.Node, field string) *sitter.Node {
+	if target := n.ChildByFieldName(field); target != nil {
+		return target
+	}
+	for i := 0; i < int(n.NamedChildCount()); i++ {
+		switch child := n.NamedChild(i); child.Type() {
+		case "variable_declaration":
+			return child.NamedChild(0)
+		case "directly_assignable_expression":
+			return child
+		}
+	}
+	return nil
+}
+
+// isImplicitResult reports whether child is the value a function returns without a
+// return statement: the last expression of a Ruby method or a Kotlin expression body
+func isImplicitResult(f *sourceFile, n, child *sitter.Node) bool {
+	switch n.Type() {
+	case "body_statement":
+		parent := n.Parent()
+		return parent != nil && (parent.Type() == "method" || parent.Type() == "singleton_method") &&
+			n.NamedChild(int(n.NamedChildCount())-1).EndByte() == child.EndByte()
+	case "function_body":
+		return strings.HasPrefix(f.text(n), "=")
+	}
+	return false
+}
+
+// receiverName normalizes a variable or receiver so that fields match whether or not
+// they are accessed through this, $this or self
+func receiverName(name string) string {
+	name = strings.ReplaceAll(strings.TrimSuffix(strings.Join(strings.Fields(name), ""), "?"), "->", ".")
+	for _, prefix := range []string{"$this.", "this.", "self."} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
+
+func anyShutDown(names []string, shutDown map[string]bool) bool {
+	for _, name := range names {
+		if shutDown[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// shutsDownItself reports whether the provider constructed by call is disposed by its
+// enclosing statement or shut down by the SDK at exit
+func shutsDownItself(f *sourceFile, syntax *setupSyntax, call *sitter.Node) bool {
+	if syntax.exitOptOut != nil {
+		return !syntax.exitOptOut.MatchString(f.text(call))
+	}
+	if syntax.scoped == nil {
+		return false
+	}
+	for n := call.Parent(); n != nil && n.Type() != "block"; n = n.Parent() {
+		if strings.HasSuffix(n.Type(), "statement") {
+			return syntax.scoped.MatchString(f.text(n))
+		}
+	}
+	return false
+}
+
+func (m *MissingShutdownDetector) issue(f *sourceFile, call *sitter.Node, callee, language string) domain.Issue {
+	line, col := position(call)
+	return domain.Issue{
+		ID:    fmt.Sprintf("missing_shutdown_%s:%d", f.rel, line),
+		Title: "OpenTelemetry provider is never shut down",
+		Description: fmt.Sprintf("%s constructs a provider, but nothing shuts it down, flushes or disposes it. "+
+			"Spans and metrics still buffered when the process exits are lost, usually the ones explaining why it exited.", callee),
+		Severity:   domain.SeverityWarning,
+		Category:   m.Category(),
+		Language:   language,
+		File:       f.path,
+		Line:       line,
+		Column:     col,
+		Suggestion: shutdownHints[language],
+		References: []string{providerShutdownDocs},
+	}
+}
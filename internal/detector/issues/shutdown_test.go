@@ -0,0 +1,300 @@
+package issues
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func TestMissingShutdownDetector_Detect(t *testing.T) {
+	cases := []struct {
+		name     string
+		language string
+		files    map[string]string
+		want     []string
+	}{
+		{
+			name:     "go provider without shutdown",
+			language: "go",
+			files: map[string]string{"main.go": `package main
+
+import sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+func main() {
+	tp := sdktrace.NewTracerProvider()
+	_ = tp
+}
+`},
+			want: []string{"missing_shutdown_main.go:6"},
+		},
+		{
+			name:     "go shutdown in another file",
+			language: "go",
+			files: map[string]string{
+				"main.go": `package main
+
+import sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+var tp = sdktrace.NewTracerProvider()
+`,
+				"shutdown.go": `package main
+
+// opentelemetry provider shutdown
+func stop(ctx context.Context) { tp.Shutdown(ctx) }
+`,
+			},
+		},
+		{
+			name:     "go shutdown of another receiver",
+			language: "go",
+			files: map[string]string{"main.go": `package main
+
+import sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+func main() {
+	tp := sdktrace.NewTracerProvider()
+	_ = tp
+	srv.Shutdown(ctx)
+}
+`},
+			want: []string{"missing_shutdown_main.go:6"},
+		},
+		{
+			name:     "go provider returned to the caller",
+			language: "go",
+			files: map[string]string{"otel.go": `package main
+
+import sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+func newProvider() *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider()
+}
+`},
+		},
+		{
+			name:     "python exit hook",
+			language: "python",
+			files: map[string]string{"app.py": `import atexit
+from opentelemetry.sdk.trace import TracerProvider
+from opentelemetry.sdk.metrics import MeterProvider
+
+provider = TracerProvider(shutdown_on_exit=False)
+meter_provider = MeterProvider(shutdown_on_exit=False)
+atexit.register(provider.shutdown)
+`},
+			want: []string{"missing_shutdown_app.py:6"},
+		},
+		{
+			name:     "python shuts down at exit by default",
+			language: "python",
+			files: map[string]string{"app.py": `from opentelemetry.sdk.trace import TracerProvider
+from opentelemetry.sdk.metrics import MeterProvider
+
+provider = TracerProvider()
+meter_provider = MeterProvider(shutdown_on_exit=False)
+`},
+			want: []string{"missing_shutdown_app.py:5"},
+		},
+		{
+			name:     "node sdk without signal hooks",
+			language: "javascript",
+			files: map[string]string{"tracing.js": `const { NodeSDK } = require('@opentelemetry/sdk-node');
+const sdk = new NodeSDK({});
+sdk.start();
+`},
+			want: []string{"missing_shutdown_tracing.js:2"},
+		},
+		{
+			name:     "csharp using declaration",
+			language: "csharp",
+			files: map[string]string{"Program.cs": `using OpenTelemetry;
+
+using var tracerProvider = Sdk.CreateTracerProviderBuilder().Build();
+var meterProvider = Sdk.CreateMeterProviderBuilder().Build();
+`},
+			want: []string{"missing_shutdown_Program.cs:4"},
+		},
+		{
+			name:     "csharp dispose",
+			language: "csharp",
+			files: map[string]string{"Program.cs": `using OpenTelemetry;
+
+var tracerProvider = Sdk.CreateTracerProviderBuilder().Build();
+tracerProvider?.Dispose();
+`},
+		},
+		{
+			name:     "java close of another receiver",
+			language: "java",
+			files: map[string]string{"App.java": `import io.opentelemetry.sdk.trace.SdkTracerProvider;
+
+class App {
+    public static void main(String[] args) throws Exception {
+        SdkTracerProvider tracerProvider = SdkTracerProvider.builder().build();
+        reader.close();
+    }
+}
+`},
+			want: []string{"missing_shutdown_App.java:5"},
+		},
+		{
+			name:     "java shutdown hook",
+			language: "java",
+			files: map[string]string{"App.java": `import io.opentelemetry.sdk.trace.SdkTracerProvider;
+
+class App {
+    private final SdkTracerProvider tracerProvider = SdkTracerProvider.builder().build();
+
+    void start() {
+        Runtime.getRuntime().addShutdownHook(new Thread(this.tracerProvider::close));
+    }
+}
+`},
+		},
+		{
+			name:     "java try with resources",
+			language: "java",
+			files: map[string]string{"App.java": `import io.opentelemetry.sdk.trace.SdkTracerProvider;
+
+class App {
+    public static void main(String[] args) {
+        try (SdkTracerProvider tp = SdkTracerProvider.builder().build()) {
+            run();
+        }
+    }
+}
+`},
+		},
+		{
+			name:     "kotlin provider without shutdown",
+			language: "kotlin",
+			files: map[string]string{"App.kt": `import io.opentelemetry.sdk.trace.SdkTracerProvider
+
+fun main() {
+    val tracerProvider = SdkTracerProvider.builder().build()
+    run(tracerProvider)
+}
+`},
+			want: []string{"missing_shutdown_App.kt:4"},
+		},
+		{
+			name:     "kotlin shutdown hook",
+			language: "kotlin",
+			files: map[string]string{"App.kt": `import io.opentelemetry.sdk.trace.SdkTracerProvider
+
+class App {
+    private val tracerProvider: SdkTracerProvider = SdkTracerProvider.builder().build()
+
+    fun start() {
+        Runtime.getRuntime().addShutdownHook(Thread(tracerProvider::close))
+    }
+}
+
+fun meterProvider() = SdkMeterProvider.builder().build()
+`},
+		},
+		{
+			name:     "ruby provider without shutdown",
+			language: "ruby",
+			files: map[string]string{"otel.rb": `require 'opentelemetry/sdk'
+
+provider = OpenTelemetry::SDK::Trace::TracerProvider.new
+OpenTelemetry.tracer_provider = provider
+`},
+			want: []string{"missing_shutdown_otel.rb:3"},
+		},
+		{
+			name:     "ruby shutdown at exit",
+			language: "ruby",
+			files: map[string]string{"otel.rb": `require 'opentelemetry/sdk'
+
+class Telemetry
+  def start
+    @provider ||= OpenTelemetry::SDK::Trace::TracerProvider.new
+    at_exit { @provider&.shutdown }
+  end
+
+  def meter_provider
+    OpenTelemetry::SDK::Metrics::MeterProvider.new
+  end
+end
+`},
+		},
+		{
+			name:     "php provider without shutdown",
+			language: "php",
+			files: map[string]string{"otel.php": `<?php
+use OpenTelemetry\SDK\Trace\TracerProvider;
+
+$tracerProvider = new TracerProvider($processor);
+Sdk::builder()->setTracerProvider($tracerProvider)->buildAndRegisterGlobal();
+`},
+			want: []string{"missing_shutdown_otel.php:4"},
+		},
+		{
+			name:     "php shutdown function",
+			language: "php",
+			files: map[string]string{"otel.php": `<?php
+use OpenTelemetry\SDK\Trace\TracerProvider;
+
+class Telemetry
+{
+    public function start(): void
+    {
+        $this->tracerProvider = TracerProvider::builder()->addSpanProcessor($processor)->build();
+        register_shutdown_function([$this->tracerProvider, 'shutdown']);
+    }
+}
+`},
+		},
+		{
+			name:     "php auto shutdown",
+			language: "php",
+			files: map[string]string{"otel.php": `<?php
+use OpenTelemetry\SDK\Trace\TracerProvider;
+
+$tracerProvider = new TracerProvider($processor);
+Sdk::builder()->setTracerProvider($tracerProvider)->setAutoShutdown(true)->buildAndRegisterGlobal();
+`},
+		},
+		{
+			name:     "rust providers are not checked",
+			language: "rust",
+			files: map[string]string{"main.rs": `use opentelemetry_sdk::trace::SdkTracerProvider;
+
+fn main() {
+    let provider = SdkTracerProvider::builder().build();
+}
+`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			testutil.WriteFiles(t, root, tc.files)
+			dir := &detector.DirectoryAnalysis{
+				Language:  tc.language,
+				Path:      root,
+				Root:      root,
+				Libraries: []domain.Library{{Name: "opentelemetry"}},
+			}
+			issues, err := NewMissingShutdownDetector().Detect(context.Background(), dir)
+			if err != nil {
+				t.Fatalf("Detect returned error: %v", err)
+			}
+			if len(issues) != len(tc.want) {
+				t.Fatalf("expected %d issues, got %d: %+v", len(tc.want), len(issues), issues)
+			}
+			for i, id := range tc.want {
+				if issues[i].ID != id || issues[i].Line == 0 || filepath.Dir(issues[i].File) != root {
+					t.Errorf("expected issue %s, got %+v", id, issues[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/semver"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+// VersionSkewDetector detects OpenTelemetry libraries whose versions don't work together:
+// libraries built against different core or experimental releases, and versions outside
+// the ranges another library declares compatible
+type VersionSkewDetector struct{}
+
+// NewVersionSkewDetector creates a new version skew detector
+func NewVersionSkewDetector() *VersionSkewDetector {
+	return &VersionSkewDetector{}
+}
+
+// ID returns the detector identifier
+func (v *VersionSkewDetector) ID() string {
+	return "otel_version_skew"
+}
+
+// Name returns the detector name
+func (v *VersionSkewDetector) Name() string {
+	return "Incompatible OpenTelemetry Versions"
+}
+
+// Description returns what this detector looks for
+func (v *VersionSkewDetector) Description() string {
+	return "Detects OpenTelemetry libraries built against different core releases or outside each other's compatible versions"
+}
+
+// Category returns the issue category
+func (v *VersionSkewDetector) Category() domain.Category {
+	return domain.CategoryConfiguration
+}
+
+// Languages returns which languages this detector applies to
+func (v *VersionSkewDetector) Languages() []string {
+	return []string{} // Applies to all languages
+}
+
+// releaseTrain selects the release of the core repository a library version is built
+// against. Stable and experimental packages are released in separate trains.
+type releaseTrain struct {
+	name    string
+	version func(*types.Version) string
+}
+
+var releaseTrains = []releaseTrain{
+	{"core", func(v *types.Version) string { return v.CoreVersion }},
+	{"experimental", func(v *types.Version) string { return v.ExperimentalVersion }},
+}
+
+// resolvedLibrary is a library together with the knowledge base entry for its version
+type resolvedLibrary struct {
+	lib     domain.Library
+	version *types.Version
+}
+
+// Detect finds libraries to bump. It needs the knowledge base from the context and
+// reports nothing without it.
+func (v *VersionSkewDetector) Detect(ctx context.Context, directory *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	knowledge := detector.KnowledgeFromContext(ctx)
+	if knowledge == nil {
+		return nil, nil
+	}
+
+	var resolved []resolvedLibrary
+	for _, lib := range directory.Libraries {
+		if lib.Version == "" {
+			continue
+		}
+		if lib.Language == "" {
+			lib.Language = directory.Language
+		}
+		version, err := knowledge.GetVersion(ctx, libraryPackage(lib))
+		if err != nil {
+			return nil, err
+		}
+		if version != nil {
+			resolved = append(resolved, resolvedLibrary{lib: lib, version: version})
+		}
+	}
+
+	report := newSkewReport()
+	for _, train := range releaseTrains {
+		if err := v.checkTrain(ctx, knowledge, resolved, train, report); err != nil {
+			return nil, err
+		}
+	}
+	if err := v.checkCompatible(ctx, knowledge, resolved, directory, report); err != nil {
+		return nil, err
+	}
+	return report.issues, nil
+}
+
+// checkTrain flags libraries built against an older release than the newest one in the
+// directory. Releases of a train are compatible within the same minor version.
+func (v *VersionSkewDetector) checkTrain(ctx context.Context, knowledge detector.Knowledge, libs []resolvedLibrary, train releaseTrain, report *skewReport) error {
+	var newest semver.Version
+	var newestLib *resolvedLibrary
+	for i := range libs {
+		release, err := semver.Parse(train.version(libs[i].version))
+		if err != nil {
+			continue
+		}
+		if newestLib == nil || release.Compare(newest) > 0 {
+			newest, newestLib = release, &libs[i]
+		}
+	}
+	if newestLib == nil {
+		return nil
+	}
+
+	for _, r := range libs {
+		release, err := semver.Parse(train.version(r.version))
+		if err != nil || sameMinor(release, newest) {
+			continue
+		}
+		target, err := findRelease(ctx, knowledge, r.lib, func(candidate *types.Version) bool {
+			c, err := semver.Parse(train.version(candidate))
+			return err == nil && sameMinor(c, newest)
+		})
+		if err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("%s %s is built against the %s release %s, but %s %s is built against %s.",
+			r.lib.Name, r.lib.Version, train.name, train.version(r.version),
+			newestLib.lib.Name, newestLib.lib.Version, train.version(newestLib.version))
+		report.add(v, r.lib, target, reason)
+	}
+	return nil
+}
+
+// checkCompatible flags libraries whose version is outside the range another library
+// declares compatible. The library that is too old is bumped; when the other one is too
+// new, it is moved to a release that accepts the installed version.
+func (v *VersionSkewDetector) checkCompatible(ctx context.Context, knowledge detector.Knowledge, libs []resolvedLibrary, directory *detector.DirectoryAnalysis, report *skewReport) error {
+	for _, r := range libs {
+		compatible, err := knowledge.GetCompatibleVersions(ctx, libraryPackage(r.lib))
+		if err != nil {
+			return err
+		}
+		for _, c := range compatible {
+			other, ok := findLibrary(directory.Libraries, c.Name)
+			if !ok || other.Version == "" {
+				continue
+			}
+			if other.Language == "" {
+				other.Language = r.lib.Language
+			}
+			constraint, err := compatibleRange(c.Version)
+			if err != nil {
+				continue
+			}
+			installed, err := semver.Parse(other.Version)
+			if err != nil || constraint.Check(installed) {
+				continue
+			}
+
+			reason := fmt.Sprintf("%s %s requires %s %s, but %s is installed.",
+				r.lib.Name, r.lib.Version, c.Name, c.Version, other.Version)
+			target, err := findRelease(ctx, knowledge, other, func(candidate *types.Version) bool {
+				version, err := semver.Parse(candidate.Name)
+				return err == nil && constraint.Check(version)
+			})
+			if err != nil {
+				return err
+			}
+			if target != nil {
+				report.add(v, other, target, reason)
+				continue
+			}
+			target, err = findRelease(ctx, knowledge, r.lib, func(candidate *types.Version) bool {
+				return accepts(candidate.Compatible, c.Name, installed)
+			})
+			if err != nil {
+				return err
+			}
+			report.add(v, r.lib, target, reason)
+		}
+	}
+	return nil
+}
+
+// findRelease returns the lowest release of lib newer than the installed one that
+// matches, nil when there is none. Prereleases are only considered for libraries that
+// are on a prerelease already.
+func findRelease(ctx context.Context, knowledge detector.Knowledge, lib domain.Library, matches func(*types.Version) bool) (*types.Version, error) {
+	versions, err := knowledge.GetVersions(ctx, libraryPackage(lib))
+	if err != nil {
+		return nil, err
+	}
+	current, err := semver.Parse(lib.Version)
+	if err != nil {
+		return nil, nil
+	}
+
+	var best *types.Version
+	var bestVersion semver.Version
+	for i := range versions {
+		candidate := &versions[i]
+		version, err := semver.Parse(candidate.Name)
+		if err != nil || version.Compare(current) <= 0 || candidate.Deprecated {
+			continue
+		}
+		if version.IsPrerelease() && !current.IsPrerelease() {
+			continue
+		}
+		if !matches(candidate) {
+			continue
+		}
+		if best == nil || version.Compare(bestVersion) < 0 {
+			best, bestVersion = candidate, version
+		}
+	}
+	return best, nil
+}
+
+// compatibleRange parses a compatible version. A bare version means that release or a
+// newer one with the same major version.
+func compatibleRange(version string) (semver.Constraint, error) {
+	version = strings.TrimSpace(version)
+	if version != "" && (version[0] == 'v' || (version[0] >= '0' && version[0] <= '9')) && !strings.ContainsAny(version, " ,") {
+		version = "^" + version
+	}
+	return semver.ParseConstraint(version)
+}
+
+// accepts reports whether a list of compatible components allows version of name. A list
+// that doesn't mention name doesn't restrict it.
+func accepts(compatible []types.CompatibleComponent, name string, version semver.Version) bool {
+	for _, c := range compatible {
+		if !strings.EqualFold(c.Name, name) {
+			continue
+		}
+		constraint, err := compatibleRange(c.Version)
+		return err == nil && constraint.Check(version)
+	}
+	return true
+}
+
+func sameMinor(a, b semver.Version) bool {
+	return a.Major == b.Major && a.Minor == b.Minor
+}
+
+func findLibrary(libraries []domain.Library, name string) (domain.Library, bool) {
+	for _, lib := range libraries {
+		if strings.EqualFold(lib.Name, name) {
+			return lib, true
+		}
+	}
+	return domain.Library{}, false
+}
+
+func libraryPackage(lib domain.Library) domain.Package {
+	return domain.Package{
+		Name:        lib.Name,
+		Version:     lib.Version,
+		Language:    lib.Language,
+		PackageFile: lib.PackageFile,
+	}
+}
+
+// skewReport collects one issue per library, merging the reasons it was flagged for
+type skewReport struct {
+	issues []domain.Issue
+	index  map[string]int
+}
+
+func newSkewReport() *skewReport {
+	return &skewReport{index: make(map[string]int)}
+}
+
+func (r *skewReport) add(v *VersionSkewDetector, lib domain.Library, target *types.Version, reason string) {
+	id := fmt.Sprintf("%s_%s_%s", v.ID(), lib.Language, lib.Name)
+	if i, ok := r.index[id]; ok {
+		if !strings.Contains(r.issues[i].Description, reason) {
+			r.issues[i].Description += "\n" + reason
+		}
+		return
+	}
+
+	issue := domain.Issue{
+		ID:          id,
+		Title:       fmt.Sprintf("%s %s is incompatible with other OpenTelemetry libraries", lib.Name, lib.Version),
+		Description: reason,
+		Severity:    domain.SeverityWarning,
+		Category:    v.Category(),
+		Language:    lib.Language,
+		File:        lib.PackageFile,
+		Suggestion:  fmt.Sprintf("Upgrade %s to a release compatible with the other OpenTelemetry libraries in this directory", lib.Name),
+		References:  []string{"https://opentelemetry.io/docs/specs/otel/versioning-and-stability/"},
+	}
+	if target != nil {
+		issue.Title = fmt.Sprintf("Bump %s from %s to %s", lib.Name, lib.Version, target.Name)
+		issue.Suggestion = upgradeSuggestion(lib, target.Name)
+		if target.ChangelogURL != "" {
+			issue.References = append(issue.References, target.ChangelogURL)
+		}
+	}
+	r.index[id] = len(r.issues)
+	r.issues = append(r.issues, issue)
+}
+
+// upgradeSuggestion returns the command that installs version of lib
+func upgradeSuggestion(lib domain.Library, version string) string {
+	switch strings.ToLower(lib.Language) {
+	case "go":
+		if !strings.HasPrefix(version, "v") {
+			version = "v" + version
+		}
+		return fmt.Sprintf("Run: go get %s@%s", lib.Name, version)
+	case "javascript", "typescript":
+		return fmt.Sprintf("Run: npm install %s@%s", lib.Name, version)
+	case "python":
+		return fmt.Sprintf("Run: pip install %s==%s", lib.Name, version)
+	case "csharp", "dotnet":
+		return fmt.Sprintf("Run: dotnet add package %s --version %s", lib.Name, version)
+	default:
+		if lib.PackageFile != "" {
+			return fmt.Sprintf("Update %s to %s in %s", lib.Name, version, lib.PackageFile)
+		}
+		return fmt.Sprintf("Update %s to %s", lib.Name, version)
+	}
+}
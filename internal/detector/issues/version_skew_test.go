@@ -0,0 +1,152 @@
+package issues
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+// fakeKnowledge serves components keyed by name
+type fakeKnowledge map[string]types.Component
+
+func (f fakeKnowledge) GetComponent(ctx context.Context, pkg domain.Package) (*types.Component, error) {
+	if component, ok := f[pkg.Name]; ok {
+		return &component, nil
+	}
+	return nil, nil
+}
+
+func (f fakeKnowledge) GetVersion(ctx context.Context, pkg domain.Package) (*types.Version, error) {
+	for _, v := range f[pkg.Name].Versions {
+		if v.Name == pkg.Version {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f fakeKnowledge) GetVersions(ctx context.Context, pkg domain.Package) ([]types.Version, error) {
+	return f[pkg.Name].Versions, nil
+}
+
+func (f fakeKnowledge) GetCompatibleVersions(ctx context.Context, pkg domain.Package) ([]types.CompatibleComponent, error) {
+	v, err := f.GetVersion(ctx, pkg)
+	if v == nil {
+		return nil, err
+	}
+	return v.Compatible, nil
+}
+
+func (f fakeKnowledge) GetBreakingChanges(ctx context.Context, pkg domain.Package) ([]types.BreakingChange, error) {
+	var changes []types.BreakingChange
+	for _, v := range f[pkg.Name].Versions {
+		changes = append(changes, v.BreakingChanges...)
+	}
+	return changes, nil
+}
+
+func (f fakeKnowledge) GetLatestVersion(ctx context.Context, pkg domain.Package) (*types.Version, error) {
+	for _, v := range f[pkg.Name].Versions {
+		if v.Status == types.VersionStatusLatest {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestVersionSkewDetector_CoreRelease(t *testing.T) {
+	knowledge := fakeKnowledge{
+		"go.opentelemetry.io/otel": {Versions: []types.Version{
+			{Name: "v1.28.0", CoreVersion: "v1.28.0"},
+		}},
+		"go.opentelemetry.io/otel/sdk": {Versions: []types.Version{
+			{Name: "v1.21.0", CoreVersion: "v1.21.0"},
+			{Name: "v1.28.0", CoreVersion: "v1.28.0"},
+			{Name: "v1.29.0", CoreVersion: "v1.29.0"},
+		}},
+		"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp": {Versions: []types.Version{
+			{Name: "v0.53.0", CoreVersion: "v1.28.0"},
+		}},
+	}
+	dir := &detector.DirectoryAnalysis{
+		Language: "go",
+		Libraries: []domain.Library{
+			{Name: "go.opentelemetry.io/otel", Version: "v1.28.0", Language: "go"},
+			{Name: "go.opentelemetry.io/otel/sdk", Version: "v1.21.0", Language: "go", PackageFile: "go.mod"},
+			{Name: "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp", Version: "v0.53.0", Language: "go"},
+		},
+	}
+
+	issues, err := NewVersionSkewDetector().Detect(detector.WithKnowledge(context.Background(), knowledge), dir)
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+	issue := issues[0]
+	if issue.Title != "Bump go.opentelemetry.io/otel/sdk from v1.21.0 to v1.28.0" || issue.File != "go.mod" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	if issue.Suggestion != "Run: go get go.opentelemetry.io/otel/sdk@v1.28.0" {
+		t.Errorf("unexpected suggestion %q", issue.Suggestion)
+	}
+	if !strings.Contains(issue.Description, "built against the core release v1.21.0") {
+		t.Errorf("expected the reason in the description, got %q", issue.Description)
+	}
+}
+
+func TestVersionSkewDetector_Compatible(t *testing.T) {
+	knowledge := fakeKnowledge{
+		"@opentelemetry/api": {Versions: []types.Version{
+			{Name: "1.4.0"},
+			{Name: "1.9.0"},
+		}},
+		"@opentelemetry/sdk-trace-base": {Versions: []types.Version{
+			{Name: "1.25.0", Compatible: []types.CompatibleComponent{{Name: "@opentelemetry/api", Version: ">=1.9.0 <1.10.0"}}},
+		}},
+		"@opentelemetry/instrumentation-legacy": {Versions: []types.Version{
+			{Name: "0.1.0", Compatible: []types.CompatibleComponent{{Name: "@opentelemetry/sdk-trace-base", Version: "~1.10.0"}}},
+			{Name: "0.2.0", Compatible: []types.CompatibleComponent{{Name: "@opentelemetry/sdk-trace-base", Version: "1.20.0"}}},
+		}},
+	}
+	dir := &detector.DirectoryAnalysis{
+		Language: "javascript",
+		Libraries: []domain.Library{
+			{Name: "@opentelemetry/api", Version: "1.4.0"},
+			{Name: "@opentelemetry/sdk-trace-base", Version: "1.25.0"},
+			{Name: "@opentelemetry/instrumentation-legacy", Version: "0.1.0"},
+		},
+	}
+
+	issues, err := NewVersionSkewDetector().Detect(detector.WithKnowledge(context.Background(), knowledge), dir)
+	if err != nil {
+		t.Fatalf("Detect returned error: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(issues), issues)
+	}
+	// api is too old for the SDK: bump api
+	if issues[0].Suggestion != "Run: npm install @opentelemetry/api@1.9.0" {
+		t.Errorf("unexpected suggestion %q", issues[0].Suggestion)
+	}
+	// The SDK is too new for the instrumentation: bump the instrumentation instead
+	if issues[1].Title != "Bump @opentelemetry/instrumentation-legacy from 0.1.0 to 0.2.0" {
+		t.Errorf("unexpected issue %+v", issues[1])
+	}
+}
+
+func TestVersionSkewDetector_WithoutKnowledge(t *testing.T) {
+	dir := &detector.DirectoryAnalysis{
+		Language:  "go",
+		Libraries: []domain.Library{{Name: "go.opentelemetry.io/otel", Version: "v1.28.0"}},
+	}
+	issues, err := NewVersionSkewDetector().Detect(context.Background(), dir)
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("expected no issues without a knowledge base, got %v, %v", issues, err)
+	}
+}
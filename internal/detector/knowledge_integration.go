@@ -7,10 +7,41 @@ import (
 
 	"github.com/getlawrence/cli/internal/domain"
 	"github.com/getlawrence/cli/internal/logger"
+	"github.com/getlawrence/cli/internal/semver"
 	"github.com/getlawrence/cli/pkg/knowledge/storage"
 	"github.com/getlawrence/cli/pkg/knowledge/types"
 )
 
+// Knowledge is the version data issue detectors read from the knowledge base. Lookups
+// return nil for unknown packages and versions.
+type Knowledge interface {
+	// GetComponent returns the component for pkg
+	GetComponent(ctx context.Context, pkg domain.Package) (*types.Component, error)
+	// GetVersion returns the entry for the version of pkg
+	GetVersion(ctx context.Context, pkg domain.Package) (*types.Version, error)
+	// GetVersions returns every known version of pkg
+	GetVersions(ctx context.Context, pkg domain.Package) ([]types.Version, error)
+	// GetCompatibleVersions returns the components the version of pkg is compatible with
+	GetCompatibleVersions(ctx context.Context, pkg domain.Package) ([]types.CompatibleComponent, error)
+	// GetBreakingChanges returns the breaking changes of every version of pkg
+	GetBreakingChanges(ctx context.Context, pkg domain.Package) ([]types.BreakingChange, error)
+	// GetLatestVersion returns the latest stable version of pkg
+	GetLatestVersion(ctx context.Context, pkg domain.Package) (*types.Version, error)
+}
+
+type knowledgeKey struct{}
+
+// WithKnowledge returns a context carrying k for the issue detectors
+func WithKnowledge(ctx context.Context, k Knowledge) context.Context {
+	return context.WithValue(ctx, knowledgeKey{}, k)
+}
+
+// KnowledgeFromContext returns the knowledge base stored in ctx, nil when there is none
+func KnowledgeFromContext(ctx context.Context) Knowledge {
+	k, _ := ctx.Value(knowledgeKey{}).(Knowledge)
+	return k
+}
+
 // KnowledgeBasedInstrumentationService integrates the new knowledge base with the detector system
 type KnowledgeBasedInstrumentationService struct {
 	storage *storage.Storage
@@ -36,7 +67,7 @@ func (s *KnowledgeBasedInstrumentationService) Close() error {
 // GetInstrumentation finds instrumentation information using the knowledge base
 func (s *KnowledgeBasedInstrumentationService) GetInstrumentation(ctx context.Context, pkg domain.Package) (*domain.InstrumentationInfo, error) {
 	// Try to find the package in the knowledge base
-	component := s.storage.GetComponentByName(pkg.Name)
+	component := s.component(pkg)
 	if component == nil {
 		// Package not found in knowledge base, return nil (no instrumentation available)
 		return nil, nil
@@ -105,26 +136,51 @@ func (s *KnowledgeBasedInstrumentationService) GetRecommendedInstrumentations(ct
 	return recommendations, nil
 }
 
+// GetComponent returns the knowledge base component for a package, nil when it is unknown
+func (s *KnowledgeBasedInstrumentationService) GetComponent(ctx context.Context, pkg domain.Package) (*types.Component, error) {
+	return s.component(pkg), nil
+}
+
+// component looks a package up in its language's ecosystem, since ecosystems reuse
+// names; packages without a language are looked up by name alone
+func (s *KnowledgeBasedInstrumentationService) component(pkg domain.Package) *types.Component {
+	if pkg.Language == "" {
+		return s.storage.GetComponentByName(pkg.Name)
+	}
+	return s.storage.GetComponentByNameAndLanguage(pkg.Name, convertLanguage(pkg.Language))
+}
+
 // GetCompatibleVersions returns compatible versions for a given package
 func (s *KnowledgeBasedInstrumentationService) GetCompatibleVersions(ctx context.Context, pkg domain.Package) ([]types.CompatibleComponent, error) {
-	component := s.storage.GetComponentByName(pkg.Name)
-	if component == nil {
-		return nil, nil
+	version, err := s.GetVersion(ctx, pkg)
+	if err != nil || version == nil {
+		return nil, err
 	}
+	return version.Compatible, nil
+}
 
-	// Find the version that matches our package version
-	for _, version := range component.Versions {
-		if version.Name == pkg.Version {
-			return version.Compatible, nil
-		}
+// GetVersion returns the knowledge base entry for the version of a package, nil when the
+// package or version is unknown
+func (s *KnowledgeBasedInstrumentationService) GetVersion(ctx context.Context, pkg domain.Package) (*types.Version, error) {
+	component := s.component(pkg)
+	if component == nil || pkg.Version == "" {
+		return nil, nil
 	}
+	return findVersion(component.Versions, pkg.Version), nil
+}
 
-	return nil, nil
+// GetVersions returns every known version of a package
+func (s *KnowledgeBasedInstrumentationService) GetVersions(ctx context.Context, pkg domain.Package) ([]types.Version, error) {
+	component := s.component(pkg)
+	if component == nil {
+		return nil, nil
+	}
+	return component.Versions, nil
 }
 
 // GetBreakingChanges returns breaking changes for a given package
 func (s *KnowledgeBasedInstrumentationService) GetBreakingChanges(ctx context.Context, pkg domain.Package) ([]types.BreakingChange, error) {
-	component := s.storage.GetComponentByName(pkg.Name)
+	component := s.component(pkg)
 	if component == nil {
 		return nil, nil
 	}
@@ -139,7 +195,7 @@ func (s *KnowledgeBasedInstrumentationService) GetBreakingChanges(ctx context.Co
 
 // GetLatestVersion returns the latest stable version of a package
 func (s *KnowledgeBasedInstrumentationService) GetLatestVersion(ctx context.Context, pkg domain.Package) (*types.Version, error) {
-	component := s.storage.GetComponentByName(pkg.Name)
+	component := s.component(pkg)
 	if component == nil {
 		return nil, nil
 	}
@@ -151,7 +207,39 @@ func (s *KnowledgeBasedInstrumentationService) GetLatestVersion(ctx context.Cont
 		}
 	}
 
-	return nil, nil
+	// Without a release marked latest, fall back to the highest stable release
+	var latest *types.Version
+	var latestVersion semver.Version
+	for i, version := range component.Versions {
+		v, err := semver.Parse(version.Name)
+		if err != nil || v.IsPrerelease() || version.Deprecated {
+			continue
+		}
+		if latest == nil || v.Compare(latestVersion) > 0 {
+			latest, latestVersion = &component.Versions[i], v
+		}
+	}
+	return latest, nil
+}
+
+// findVersion returns the entry for version, matching "v1.2.3", "1.2.3" and range
+// notations like "^1.2.3" to the same release
+func findVersion(versions []types.Version, version string) *types.Version {
+	for i := range versions {
+		if versions[i].Name == version {
+			return &versions[i]
+		}
+	}
+	want, err := semver.Parse(version)
+	if err != nil {
+		return nil
+	}
+	for i := range versions {
+		if v, err := semver.Parse(versions[i].Name); err == nil && v.Compare(want) == 0 {
+			return &versions[i]
+		}
+	}
+	return nil
 }
 
 // isRelevantInstrumentation checks if an instrumentation is relevant to the given package
@@ -211,7 +299,7 @@ func convertLanguage(lang string) types.ComponentLanguage {
 		return types.ComponentLanguagePython
 	case "go":
 		return types.ComponentLanguageGo
-	case "java":
+	case "java", "kotlin", "kt":
 		return types.ComponentLanguageJava
 	case "csharp", "c#", "dotnet":
 		return types.ComponentLanguageCSharp
@@ -219,6 +307,12 @@ func convertLanguage(lang string) types.ComponentLanguage {
 		return types.ComponentLanguagePHP
 	case "ruby":
 		return types.ComponentLanguageRuby
+	case "rust", "rs":
+		return types.ComponentLanguageRust
+	case "elixir", "ex", "erlang":
+		return types.ComponentLanguageElixir
+	case "cpp", "c++", "cxx":
+		return types.ComponentLanguageCpp
 	default:
 		return types.ComponentLanguageJavaScript // Default fallback
 	}
@@ -0,0 +1,57 @@
+package detector
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/logger"
+	"github.com/getlawrence/cli/pkg/knowledge/storage"
+	"github.com/getlawrence/cli/pkg/knowledge/types"
+)
+
+func TestKnowledgeService_SameNameInDifferentLanguages(t *testing.T) {
+	st, err := storage.NewStorage(filepath.Join(t.TempDir(), "knowledge.db"), &logger.StdoutLogger{})
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer st.Close()
+
+	now := time.Now()
+	component := func(language types.ComponentLanguage, latest string) types.Component {
+		return types.Component{
+			Name:        "opentelemetry",
+			Type:        types.ComponentTypeAPI,
+			Category:    types.ComponentCategoryAPI,
+			Language:    language,
+			Repository:  "https://github.com/open-telemetry",
+			LastUpdated: now,
+			Versions:    []types.Version{{Name: latest, ReleaseDate: now, Status: types.VersionStatusLatest}},
+		}
+	}
+	components := []types.Component{
+		component(types.ComponentLanguageRust, "0.30.0"),
+		component(types.ComponentLanguageElixir, "1.5.0"),
+	}
+	if err := st.SaveKnowledgeBase(components, "test"); err != nil {
+		t.Fatalf("Failed to save knowledge base: %v", err)
+	}
+
+	service := &KnowledgeBasedInstrumentationService{storage: st}
+	for language, want := range map[string]string{"rust": "0.30.0", "elixir": "1.5.0"} {
+		pkg := domain.Package{Name: "opentelemetry", Language: language}
+		latest, err := service.GetLatestVersion(context.Background(), pkg)
+		if err != nil || latest == nil || latest.Name != want {
+			t.Errorf("expected latest %s version %s, got %+v (err %v)", language, want, latest, err)
+		}
+		if c, _ := service.GetComponent(context.Background(), pkg); c == nil || string(c.Language) != language {
+			t.Errorf("expected the %s component, got %+v", language, c)
+		}
+	}
+
+	if c, _ := service.GetComponent(context.Background(), domain.Package{Name: "opentelemetry", Language: "go"}); c != nil {
+		t.Errorf("expected no go component, got %+v", c)
+	}
+}
@@ -1,40 +1,97 @@
 package detector
 
 import (
+	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/getlawrence/cli/internal/cpp"
+	"github.com/getlawrence/cli/internal/ignore"
 	"github.com/go-enry/go-enry/v2"
 )
 
-// DetectLanguages scans a directory and detects the primary programming language of each subdirectory
-func DetectLanguages(rootPath string) (map[string]string, error) {
-	directories, err := collectLanguagesByDirectory(rootPath)
+// Secondary languages must reach both thresholds unless a manifest declares them.
+// The most common language of a directory is always kept.
+const (
+	minSecondaryLanguageFiles = 2
+	minSecondaryLanguageShare = 0.1
+)
+
+// manifestLanguages maps project manifest file names to the language they declare
+var manifestLanguages = map[string]string{
+	"go.mod":           "Go",
+	"package.json":     "JavaScript",
+	"tsconfig.json":    "TypeScript",
+	"requirements.txt": "Python",
+	"pyproject.toml":   "Python",
+	"setup.py":         "Python",
+	"setup.cfg":        "Python",
+	"Pipfile":          "Python",
+	"pom.xml":          "Java",
+	"build.gradle":     "Java",
+	"build.gradle.kts": "Java",
+	"Gemfile":          "Ruby",
+	"composer.json":    "PHP",
+	"Cargo.toml":       "Rust",
+	"mix.exs":          "Elixir",
+	"CMakeLists.txt":   "cpp",
+	"vcpkg.json":       "cpp",
+	"conanfile.txt":    "cpp",
+	"conanfile.py":     "cpp",
+}
+
+// manifestExtensions maps project manifest extensions to the language they declare. F# and
+// Visual Basic projects share the NuGet packages of C# ones and are analyzed as .NET.
+var manifestExtensions = map[string]string{
+	".csproj": "csharp",
+	".fsproj": "csharp",
+	".vbproj": "csharp",
+}
+
+// kotlinBuildRe matches the Kotlin plugin in Gradle build scripts, including version
+// catalog plugin aliases, and Maven POMs
+var kotlinBuildRe = regexp.MustCompile(`\bkotlin\(\s*"(?:jvm|android|multiplatform)"\s*\)|\balias\(\s*libs\.plugins\.kotlin\.(?:jvm|android|multiplatform)\s*\)|org\.jetbrains\.kotlin\.(?:jvm|android|multiplatform|plugin)|(?:id|plugin:)\s*\(?\s*["']kotlin(?:-android|-multiplatform)?["']|<artifactId>kotlin-maven-plugin</artifactId>`)
+
+// supersededLanguages maps a manifest language to a more specific language that takes over
+// the directory when present: a package.json next to TypeScript declares a TypeScript project
+var supersededLanguages = map[string]string{
+	"JavaScript": "TypeScript",
+}
+
+// directoryLanguages holds the per-language evidence collected for one directory
+type directoryLanguages struct {
+	counts    map[string]int
+	manifests map[string]bool
+}
+
+// DetectLanguages scans a directory and detects the programming languages of each subdirectory.
+// A directory can have several languages: the most common one, any language declared by a
+// manifest (go.mod, package.json, ...), and any other language above the file count thresholds.
+// Languages are ordered by file count, most common first.
+// Paths excluded by the ignore matcher in ctx (or rootPath's own ignore files) are skipped.
+func DetectLanguages(ctx context.Context, rootPath string) (map[string][]string, error) {
+	directories, err := collectLanguagesByDirectory(rootPath, ignore.FromContext(ctx, rootPath))
 	if err != nil {
 		return nil, err
 	}
 
-	return determinePrimaryLanguages(directories), nil
+	return determineLanguages(directories), nil
 }
 
-// collectLanguagesByDirectory walks the file tree and counts languages by directory
-func collectLanguagesByDirectory(rootPath string) (map[string]map[string]int, error) {
-	directories := make(map[string]map[string]int) // dir -> language -> count
+// collectLanguagesByDirectory walks the file tree and counts languages and manifests by directory
+func collectLanguagesByDirectory(rootPath string, matcher *ignore.Matcher) (map[string]*directoryLanguages, error) {
+	directories := make(map[string]*directoryLanguages)
 
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	err := matcher.Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if shouldSkipFile(rootPath, path, info) {
-			if info.IsDir() {
-				return filepath.SkipDir
-			}
-			return nil
-		}
-
-		if !info.IsDir() {
+		if !d.IsDir() {
 			processSourceFile(path, rootPath, directories)
 		}
 
@@ -44,8 +101,33 @@ func collectLanguagesByDirectory(rootPath string) (map[string]map[string]int, er
 	return directories, err
 }
 
-// processSourceFile processes a single source file and updates the language count for its directory
-func processSourceFile(filePath, rootPath string, directories map[string]map[string]int) {
+// processSourceFile records a file's language and any manifest it represents for its directory
+func processSourceFile(filePath, rootPath string, directories map[string]*directoryLanguages) {
+	dir := filepath.Dir(filePath)
+	relDir, _ := filepath.Rel(rootPath, dir)
+
+	// Normalize the relative directory path
+	if relDir == "." {
+		relDir = ""
+	}
+
+	entry := func() *directoryLanguages {
+		if directories[relDir] == nil {
+			directories[relDir] = &directoryLanguages{counts: make(map[string]int), manifests: make(map[string]bool)}
+		}
+		return directories[relDir]
+	}
+
+	if lang := manifestLanguage(filePath); lang != "" {
+		entry().manifests[lang] = true
+	}
+
+	// Gradle Kotlin DSL scripts and Conan recipes configure the build; they are not
+	// Kotlin or Python sources
+	if strings.HasSuffix(filePath, ".gradle.kts") || filepath.Base(filePath) == cpp.ConanfilePy {
+		return
+	}
+
 	lang := detectFileLanguage(filePath)
 	if lang == "" || !isProgrammingLanguage(lang) {
 		return
@@ -53,43 +135,91 @@ func processSourceFile(filePath, rootPath string, directories map[string]map[str
 
 	// Normalize language names
 	lang = normalizeLanguageName(lang)
+	entry().counts[lang]++
+}
 
-	dir := filepath.Dir(filePath)
-	relDir, _ := filepath.Rel(rootPath, dir)
-
-	// Normalize the relative directory path
-	if relDir == "." {
-		relDir = ""
+// manifestLanguage returns the language declared by the manifest file at path, if any.
+// JVM builds applying the Kotlin plugin declare Kotlin rather than Java, and only a
+// CMakeLists.txt whose project() enables C++ declares a C++ project.
+func manifestLanguage(path string) string {
+	name := filepath.Base(path)
+	lang, ok := manifestLanguages[name]
+	if !ok {
+		return manifestExtensions[strings.ToLower(filepath.Ext(name))]
 	}
-
-	if directories[relDir] == nil {
-		directories[relDir] = make(map[string]int)
+	switch {
+	case lang == "Java":
+		if content, err := os.ReadFile(path); err == nil && kotlinBuildRe.Match(content) {
+			return "Kotlin"
+		}
+	case name == cpp.CMakeFileName:
+		if content, err := os.ReadFile(path); err != nil || !cpp.DeclaresCXX(content) {
+			return ""
+		}
 	}
-	directories[relDir][lang]++
+	return lang
 }
 
-// determinePrimaryLanguages finds the most common language in each directory
-func determinePrimaryLanguages(directories map[string]map[string]int) map[string]string {
-	dirToLangMap := make(map[string]string)
+// determineLanguages selects the languages of each directory
+func determineLanguages(directories map[string]*directoryLanguages) map[string][]string {
+	dirToLangs := make(map[string][]string)
 
-	for dir, langCounts := range directories {
-		primaryLang := findMostCommonLanguage(langCounts)
-		if primaryLang != "" {
-			dirKey := normalizeDirectoryKey(dir)
-			dirToLangMap[dirKey] = primaryLang
+	for dir, stats := range directories {
+		if langs := selectLanguages(stats); len(langs) > 0 {
+			dirToLangs[normalizeDirectoryKey(dir)] = langs
+		}
+	}
+
+	return dirToLangs
+}
+
+// selectLanguages applies the manifest and threshold rules to one directory's evidence
+func selectLanguages(stats *directoryLanguages) []string {
+	total := 0
+	for _, count := range stats.counts {
+		total += count
+	}
+	primary := findMostCommonLanguage(stats.counts)
+
+	var langs []string
+	for lang, count := range stats.counts {
+		share := float64(count) / float64(total)
+		if lang == primary || stats.manifests[lang] ||
+			(count >= minSecondaryLanguageFiles && share >= minSecondaryLanguageShare) {
+			langs = append(langs, lang)
+		}
+	}
+	// A manifest without sources (e.g. a pom.xml next to src/) still declares a project
+	for lang := range stats.manifests {
+		if stats.counts[lang] == 0 && !isSuperseded(lang, stats) {
+			langs = append(langs, lang)
 		}
 	}
 
-	return dirToLangMap
+	sort.Slice(langs, func(i, j int) bool {
+		ci, cj := stats.counts[langs[i]], stats.counts[langs[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return langs[i] < langs[j]
+	})
+	return langs
+}
+
+// isSuperseded reports whether a manifest-only language gives way to a more specific one
+func isSuperseded(lang string, stats *directoryLanguages) bool {
+	by, ok := supersededLanguages[lang]
+	return ok && (stats.counts[by] > 0 || stats.manifests[by])
 }
 
-// findMostCommonLanguage returns the language with the highest count
+// findMostCommonLanguage returns the language with the highest count, preferring the
+// alphabetically first language on ties so results are deterministic
 func findMostCommonLanguage(langCounts map[string]int) string {
 	primaryLang := ""
 	maxCount := 0
 
 	for lang, count := range langCounts {
-		if count > maxCount {
+		if count > maxCount || (count == maxCount && lang < primaryLang) {
 			maxCount = count
 			primaryLang = lang
 		}
@@ -113,6 +243,10 @@ func normalizeLanguageName(lang string) string {
 		return "Go"
 	case "C#":
 		return "csharp"
+	case "C++":
+		return "cpp"
+	case "TSX":
+		return "TypeScript"
 	default:
 		return lang
 	}
@@ -143,34 +277,6 @@ func isProgrammingLanguage(lang string) bool {
 	return !configLanguages[lang]
 }
 
-// shouldSkipFile determines if a file or directory should be skipped during language detection
-func shouldSkipFile(rootPath, path string, info os.FileInfo) bool {
-	// Skip directories (they will be traversed)
-	if info.IsDir() {
-		return false
-	}
-
-	// Skip hidden files
-	if strings.HasPrefix(info.Name(), ".") {
-		return true
-	}
-
-	// Skip common non-source directories
-	relPath, _ := filepath.Rel(rootPath, path)
-	pathParts := strings.Split(relPath, string(filepath.Separator))
-
-	skipDirs := []string{"node_modules", "vendor", ".git", "__pycache__", ".venv", "venv"}
-	for _, part := range pathParts {
-		for _, skipDir := range skipDirs {
-			if part == skipDir {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
 func detectFileLanguage(path string) string {
 	lang, safe := enry.GetLanguageByExtension(path)
 	if safe && lang != "" {
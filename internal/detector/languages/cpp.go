@@ -0,0 +1,185 @@
+package languages
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/cpp"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
+)
+
+// cppOTelIncludeRe matches includes of opentelemetry-cpp headers, e.g. #include "opentelemetry/trace/provider.h"
+var cppOTelIncludeRe = regexp.MustCompile(`^\s*#\s*include\s*[<"](opentelemetry/[^>"]+)[>"]`)
+
+// cppSourceExtensions are the C++ source and header file extensions
+var cppSourceExtensions = map[string]bool{
+	".cc": true, ".cpp": true, ".cxx": true, ".c++": true,
+	".h": true, ".hh": true, ".hpp": true, ".hxx": true,
+}
+
+// CppDetector detects C++ projects (CMake, vcpkg, Conan) and OpenTelemetry usage
+type CppDetector struct{}
+
+// NewCppDetector creates a new C++ language detector
+func NewCppDetector() *CppDetector { return &CppDetector{} }
+
+// Name returns the language name
+func (c *CppDetector) Name() string { return "cpp" }
+
+// GetOTelLibraries finds OpenTelemetry packages in CMakeLists.txt, vcpkg.json and conanfiles,
+// and opentelemetry-cpp headers included by the sources
+func (c *CppDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
+	var libraries []domain.Library
+
+	for _, pkg := range c.manifestPackages(rootPath) {
+		if !strings.HasPrefix(pkg.Name, "opentelemetry") {
+			continue
+		}
+		libraries = append(libraries, domain.Library{
+			Name:        pkg.Name,
+			Version:     pkg.Version,
+			Language:    "cpp",
+			ImportPath:  pkg.ImportPath,
+			PackageFile: pkg.PackageFile,
+		})
+	}
+
+	files, err := c.findCppFiles(ctx, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		headers, err := c.parseOTelIncludes(file)
+		if err != nil {
+			continue
+		}
+		for _, header := range headers {
+			libraries = append(libraries, domain.Library{
+				Name:       cpp.OpenTelemetryPackage,
+				Language:   "cpp",
+				ImportPath: header,
+			})
+		}
+	}
+
+	return c.deduplicateLibraries(libraries), nil
+}
+
+// GetAllPackages returns the packages declared in vcpkg.json, conanfile.txt, conanfile.py
+// and CMake find_package() calls
+func (c *CppDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	return c.manifestPackages(rootPath), nil
+}
+
+// GetFilePatterns returns patterns for C++ files
+func (c *CppDetector) GetFilePatterns() []string {
+	return []string{"**/*.cc", "**/*.cpp", "**/*.cxx", "**/*.h", "**/*.hpp",
+		cpp.CMakeFileName, cpp.VcpkgFileName, cpp.ConanfileTxt, cpp.ConanfilePy}
+}
+
+// manifestPackages reads the packages from each C++ manifest in rootPath. A package
+// declared in several manifests is reported once, against the package manager manifest
+// rather than CMakeLists.txt, taking the version from whichever manifest pins it.
+func (c *CppDetector) manifestPackages(rootPath string) []domain.Package {
+	readers := []struct {
+		file  string
+		parse func([]byte) []cpp.Package
+	}{
+		{cpp.VcpkgFileName, func(content []byte) []cpp.Package {
+			packages, _ := cpp.VcpkgDependencies(content)
+			return packages
+		}},
+		{cpp.ConanfileTxt, cpp.ConanfileTxtRequires},
+		{cpp.ConanfilePy, cpp.ConanfilePyRequires},
+		{cpp.CMakeFileName, cpp.FindPackages},
+	}
+
+	var packages []domain.Package
+	index := make(map[string]int)
+	for _, r := range readers {
+		path := filepath.Join(rootPath, r.file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, pkg := range r.parse(content) {
+			if i, ok := index[pkg.Name]; ok {
+				if packages[i].Version == "" && pkg.Version != "" {
+					packages[i].Version = pkg.Version
+				}
+				continue
+			}
+			index[pkg.Name] = len(packages)
+			packages = append(packages, domain.Package{
+				Name:        pkg.Name,
+				Version:     pkg.Version,
+				Language:    "cpp",
+				ImportPath:  pkg.Name,
+				PackageFile: path,
+			})
+		}
+	}
+	return packages
+}
+
+// parseOTelIncludes returns the opentelemetry-cpp headers included by a source file
+func (c *CppDetector) parseOTelIncludes(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var headers []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if m := cppOTelIncludeRe.FindStringSubmatch(scanner.Text()); m != nil {
+			headers = append(headers, m[1])
+		}
+	}
+	return headers, scanner.Err()
+}
+
+// findCppFiles recursively finds C++ sources and headers, skipping build trees and
+// installed vcpkg ports
+func (c *CppDetector) findCppFiles(ctx context.Context, rootPath string) ([]string, error) {
+	var files []string
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != rootPath && (name == "build" || name == "vcpkg_installed" || name == "_deps" || strings.HasPrefix(name, "cmake-build-")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if cppSourceExtensions[strings.ToLower(filepath.Ext(path))] {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// deduplicateLibraries removes duplicate library entries
+func (c *CppDetector) deduplicateLibraries(libs []domain.Library) []domain.Library {
+	seen := make(map[string]bool)
+	var res []domain.Library
+	for _, l := range libs {
+		key := fmt.Sprintf("%s:%s", l.Name, l.Version)
+		if !seen[key] {
+			seen[key] = true
+			res = append(res, l)
+		}
+	}
+	return res
+}
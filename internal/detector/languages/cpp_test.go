@@ -0,0 +1,67 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCppDetector_ManifestsAndIncludes(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"CMakeLists.txt": `cmake_minimum_required(VERSION 3.20)
+project(checkout LANGUAGES CXX)
+find_package(fmt CONFIG REQUIRED)
+find_package(opentelemetry-cpp CONFIG REQUIRED)
+add_executable(checkout src/main.cc)
+`,
+		"vcpkg.json": `{
+  "name": "checkout",
+  "dependencies": [
+    "fmt",
+    { "name": "opentelemetry-cpp", "features": ["otlp-http"] }
+  ],
+  "overrides": [{ "name": "opentelemetry-cpp", "version": "1.16.1" }]
+}
+`,
+		"src/main.cc": `#include <fmt/core.h>
+#include "opentelemetry/trace/provider.h"
+
+int main() { return 0; }
+`,
+		"build/_deps/otel/exporter.cc": "#include <opentelemetry/exporters/ignored.h>\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewCppDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(libs) != 2 {
+		t.Fatalf("expected the declared package and the header reference, got %+v", libs)
+	}
+	if libs[0].Name != "opentelemetry-cpp" || libs[0].Version != "1.16.1" || filepath.Base(libs[0].PackageFile) != "vcpkg.json" {
+		t.Errorf("expected opentelemetry-cpp pinned in vcpkg.json, got %+v", libs[0])
+	}
+	if libs[1].ImportPath != "opentelemetry/trace/provider.h" {
+		t.Errorf("expected the included header, got %+v", libs[1])
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pkgs) != 2 || pkgs[0].Name != "fmt" || pkgs[1].Name != "opentelemetry-cpp" {
+		t.Fatalf("expected fmt and opentelemetry-cpp once each, got %+v", pkgs)
+	}
+}
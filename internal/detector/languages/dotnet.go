@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/dotnet"
+	"github.com/getlawrence/cli/internal/ignore"
 )
 
 // DotNetDetector detects .NET/C# projects and OpenTelemetry usage
@@ -26,20 +29,26 @@ func (d *DotNetDetector) Name() string { return "csharp" }
 func (d *DotNetDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
 	var libraries []domain.Library
 
-	// Scan .csproj files for OpenTelemetry packages
-	csprojFiles, err := d.findCSProjFiles(rootPath)
+	// Scan project files for OpenTelemetry packages, with centrally managed versions
+	packages, err := d.projectPackages(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
-	for _, csproj := range csprojFiles {
-		libs, err := d.parseCsProjForOTel(csproj)
-		if err == nil {
-			libraries = append(libraries, libs...)
+	for _, pkg := range packages {
+		if !strings.HasPrefix(strings.ToLower(pkg.Name), "opentelemetry") {
+			continue
 		}
+		libraries = append(libraries, domain.Library{
+			Name:        pkg.Name,
+			Version:     pkg.Version,
+			Language:    "csharp",
+			ImportPath:  pkg.Name,
+			PackageFile: pkg.PackageFile,
+		})
 	}
 
 	// Scan .cs files for using OpenTelemetry.*
-	csFiles, err := d.findCSFiles(rootPath)
+	csFiles, err := d.findCSFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -55,95 +64,101 @@ func (d *DotNetDetector) GetOTelLibraries(ctx context.Context, rootPath string)
 
 // GetAllPackages finds all packages/dependencies used in the project
 func (d *DotNetDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
-	var packages []domain.Package
-
-	csprojFiles, err := d.findCSProjFiles(rootPath)
+	packages, err := d.projectPackages(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
-	for _, csproj := range csprojFiles {
-		pkgs, err := d.parseAllFromCsProj(csproj)
-		if err == nil {
-			packages = append(packages, pkgs...)
-		}
-	}
 
 	return d.deduplicatePackages(packages), nil
 }
 
 // GetFilePatterns returns patterns for .NET projects
 func (d *DotNetDetector) GetFilePatterns() []string {
-	return []string{"**/*.cs", "**/*.csproj"}
+	return []string{"**/*.cs", "**/*.csproj", "**/*.fsproj", "**/*.vbproj", "**/*.sln", dotnet.PackagesPropsFileName, dotnet.BuildPropsFileName, dotnet.PackagesConfigFileName}
 }
 
 // Helpers
-func (d *DotNetDetector) findCSProjFiles(rootPath string) ([]string, error) {
+
+// projectFiles finds the C#, F# and Visual Basic project files under rootPath, and those
+// listed by a solution in rootPath that live elsewhere
+func (d *DotNetDetector) projectFiles(ctx context.Context, rootPath string) ([]string, error) {
 	var files []string
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	seen := make(map[string]bool)
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			switch info.Name() {
-			case ".git", "bin", "obj":
-				return filepath.SkipDir
-			}
+		if entry.IsDir() {
 			return nil
 		}
-		if strings.HasSuffix(path, ".csproj") {
+		if dotnet.IsProjectFile(path) {
+			seen[path] = true
 			files = append(files, path)
 		}
 		return nil
 	})
-	return files, err
-}
+	if err != nil {
+		return nil, err
+	}
 
-func (d *DotNetDetector) findCSFiles(rootPath string) ([]string, error) {
-	var files []string
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	solutions, _ := filepath.Glob(filepath.Join(rootPath, "*"+dotnet.SolutionExtension))
+	for _, solution := range solutions {
+		content, err := os.ReadFile(solution)
 		if err != nil {
-			return err
+			continue
 		}
-		if info.IsDir() {
-			switch info.Name() {
-			case ".git", "bin", "obj":
-				return filepath.SkipDir
+		for _, rel := range dotnet.ParseSolution(content) {
+			path := filepath.Join(rootPath, rel)
+			if _, err := os.Stat(path); err == nil && !seen[path] {
+				seen[path] = true
+				files = append(files, path)
 			}
-			return nil
-		}
-		if strings.HasSuffix(path, ".cs") {
-			files = append(files, path)
 		}
-		return nil
-	})
-	return files, err
+	}
+	return files, nil
 }
 
-func (d *DotNetDetector) parseCsProjForOTel(path string) ([]domain.Library, error) {
-	content, err := os.ReadFile(path)
+// projectPackages returns the packages of every project, with the version each resolves
+// to through Directory.Packages.props, Directory.Build.props or packages.config
+func (d *DotNetDetector) projectPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	files, err := d.projectFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
-	var libraries []domain.Library
-	// Match OpenTelemetry packages in PackageReference Include
-	re := regexp.MustCompile(`(?i)<PackageReference\s+Include=\"(OpenTelemetry(?:\.[^\"]*)?)\"\s*(?:Version=\"([^\"]+)\")?`) // simple
-	for _, m := range re.FindAllStringSubmatch(string(content), -1) {
-		if len(m) >= 2 {
-			name := m[1]
-			version := ""
-			if len(m) >= 3 {
-				version = m[2]
-			}
-			libraries = append(libraries, domain.Library{
-				Name:        name,
-				Version:     version,
+	var packages []domain.Package
+	for _, file := range files {
+		project, err := dotnet.LoadProject(file)
+		if err != nil {
+			continue
+		}
+		for _, pkg := range project.Packages {
+			packages = append(packages, domain.Package{
+				Name:        pkg.Name,
+				Version:     pkg.Version,
 				Language:    "csharp",
-				ImportPath:  name,
-				PackageFile: path,
+				ImportPath:  pkg.Name,
+				PackageFile: pkg.File,
 			})
 		}
 	}
-	return libraries, nil
+	return packages, nil
+}
+
+func (d *DotNetDetector) findCSFiles(ctx context.Context, rootPath string) ([]string, error) {
+	var files []string
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(path, ".cs") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
 }
 
 func (d *DotNetDetector) parseCSForOTelUsings(path string) ([]domain.Library, error) {
@@ -168,33 +183,6 @@ func (d *DotNetDetector) parseCSForOTelUsings(path string) ([]domain.Library, er
 	return libraries, scanner.Err()
 }
 
-func (d *DotNetDetector) parseAllFromCsProj(path string) ([]domain.Package, error) {
-	content, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	var packages []domain.Package
-	re := regexp.MustCompile(`<PackageReference\s+Include=\"([^\"]+)\"\s*(?:Version=\"([^\"]+)\")?`)
-	for _, m := range re.FindAllStringSubmatch(string(content), -1) {
-		if len(m) >= 2 {
-			name := m[1]
-			version := ""
-			if len(m) >= 3 {
-				version = m[2]
-			}
-			// Skip known SDK-style references that aren't external packages
-			packages = append(packages, domain.Package{
-				Name:        name,
-				Version:     version,
-				Language:    "csharp",
-				ImportPath:  name,
-				PackageFile: path,
-			})
-		}
-	}
-	return packages, nil
-}
-
 func (d *DotNetDetector) deduplicateLibraries(libraries []domain.Library) []domain.Library {
 	seen := make(map[string]bool)
 	var res []domain.Library
@@ -0,0 +1,59 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDotNetDetectorCentralPackageVersions(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"Shop.sln": `Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api", "src\Api\Api.csproj", "{1}"
+EndProject
+`,
+		"Directory.Packages.props": `<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageVersion Include="OpenTelemetry.Extensions.Hosting" Version="1.9.0" />
+  </ItemGroup>
+</Project>`,
+		"src/Api/Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="OpenTelemetry.Extensions.Hosting" />
+  </ItemGroup>
+</Project>`,
+		"src/Pricing/Pricing.fsproj": `<Project Sdk="Microsoft.NET.Sdk">
+  <ItemGroup>
+    <PackageReference Include="OpenTelemetry.Api" VersionOverride="1.8.1" />
+  </ItemGroup>
+</Project>`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	libs, err := NewDotNetDetector().GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	versions := make(map[string]string)
+	for _, lib := range libs {
+		versions[lib.Name] = lib.Version + " in " + filepath.Base(lib.PackageFile)
+	}
+	if got := versions["OpenTelemetry.Extensions.Hosting"]; got != "1.9.0 in Directory.Packages.props" {
+		t.Errorf("expected the central version, got %q", got)
+	}
+	if got := versions["OpenTelemetry.Api"]; got != "1.8.1 in Pricing.fsproj" {
+		t.Errorf("expected the fsproj VersionOverride, got %q", got)
+	}
+}
@@ -0,0 +1,190 @@
+package languages
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
+	"github.com/getlawrence/cli/internal/mix"
+)
+
+var (
+	// elixirOTelModuleRe matches references to OpenTelemetry modules, e.g. OpentelemetryPhoenix.setup()
+	// or require OpenTelemetry.Tracer
+	elixirOTelModuleRe = regexp.MustCompile(`\b(Opentelemetry[A-Z][A-Za-z0-9]*|OpenTelemetry(?:\.[A-Z][A-Za-z0-9]*)*)\b`)
+	// elixirCamelRe splits a module name into its words
+	elixirCamelRe = regexp.MustCompile(`[A-Z][a-z0-9]*`)
+)
+
+// ElixirDetector detects Elixir (Mix) projects and OpenTelemetry usage
+type ElixirDetector struct{}
+
+// NewElixirDetector creates a new Elixir language detector
+func NewElixirDetector() *ElixirDetector { return &ElixirDetector{} }
+
+// Name returns the language name
+func (e *ElixirDetector) Name() string { return "elixir" }
+
+// GetOTelLibraries finds OpenTelemetry packages in mix.exs and module references in Elixir sources
+func (e *ElixirDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
+	var libraries []domain.Library
+
+	manifest := filepath.Join(rootPath, mix.FileName)
+	if content, err := os.ReadFile(manifest); err == nil {
+		deps, _ := mix.ParseDeps(content)
+		locked := mix.LockedVersions(rootPath)
+		for _, dep := range deps {
+			if !isOTelMixPackage(dep.Name) {
+				continue
+			}
+			version := dep.Requirement
+			if v, ok := locked[dep.Name]; ok {
+				version = v
+			}
+			libraries = append(libraries, domain.Library{
+				Name:        dep.Name,
+				Version:     version,
+				Language:    "elixir",
+				ImportPath:  dep.Name,
+				PackageFile: manifest,
+			})
+		}
+	}
+
+	files, err := e.findElixirFiles(ctx, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		modules, err := e.parseOTelModules(file)
+		if err != nil {
+			continue
+		}
+		for _, module := range modules {
+			libraries = append(libraries, domain.Library{
+				Name:       elixirModulePackage(module),
+				Language:   "elixir",
+				ImportPath: module,
+			})
+		}
+	}
+
+	return e.deduplicateLibraries(libraries), nil
+}
+
+// GetAllPackages returns the Hex packages declared in mix.exs, with the versions resolved
+// in mix.lock when present. Path and umbrella dependencies are part of the project itself.
+func (e *ElixirDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	var packages []domain.Package
+
+	manifest := filepath.Join(rootPath, mix.FileName)
+	content, err := os.ReadFile(manifest)
+	if err != nil {
+		return packages, nil
+	}
+	deps, _ := mix.ParseDeps(content)
+	locked := mix.LockedVersions(rootPath)
+	for _, dep := range deps {
+		if dep.Local {
+			continue
+		}
+		version := dep.Requirement
+		if v, ok := locked[dep.Name]; ok {
+			version = v
+		}
+		packages = append(packages, domain.Package{
+			Name:        dep.Name,
+			Version:     version,
+			Language:    "elixir",
+			ImportPath:  dep.Name,
+			PackageFile: manifest,
+		})
+	}
+
+	return packages, nil
+}
+
+// GetFilePatterns returns patterns for Elixir files
+func (e *ElixirDetector) GetFilePatterns() []string {
+	return []string{"**/*.ex", "**/*.exs", "mix.exs", "mix.lock"}
+}
+
+// parseOTelModules returns the OpenTelemetry modules referenced in an Elixir source file
+func (e *ElixirDetector) parseOTelModules(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var modules []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, m := range elixirOTelModuleRe.FindAllStringSubmatch(line, -1) {
+			modules = append(modules, m[1])
+		}
+	}
+	return modules, scanner.Err()
+}
+
+// findElixirFiles recursively finds .ex and .exs files, skipping Mix build output and fetched deps
+func (e *ElixirDetector) findElixirFiles(ctx context.Context, rootPath string) ([]string, error) {
+	var files []string
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if (d.Name() == "_build" || d.Name() == "deps") && path != rootPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".ex") || strings.HasSuffix(path, ".exs") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// deduplicateLibraries removes duplicate library entries
+func (e *ElixirDetector) deduplicateLibraries(libs []domain.Library) []domain.Library {
+	seen := make(map[string]bool)
+	var res []domain.Library
+	for _, l := range libs {
+		key := fmt.Sprintf("%s:%s", l.Name, l.Version)
+		if !seen[key] {
+			seen[key] = true
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// isOTelMixPackage reports whether a Hex package is part of OpenTelemetry
+func isOTelMixPackage(name string) bool {
+	return strings.HasPrefix(name, "opentelemetry")
+}
+
+// elixirModulePackage returns the Hex package providing an OpenTelemetry module:
+// OpentelemetryPhoenix is opentelemetry_phoenix, and the OpenTelemetry.* API modules
+// come from opentelemetry_api
+func elixirModulePackage(module string) string {
+	if strings.HasPrefix(module, "OpenTelemetry") {
+		return "opentelemetry_api"
+	}
+	words := elixirCamelRe.FindAllString(module, -1)
+	return strings.ToLower(strings.Join(words, "_"))
+}
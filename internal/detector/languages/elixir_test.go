@@ -0,0 +1,94 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestElixirDetector_MixDepsLockAndModules(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"mix.exs": `defmodule Orders.MixProject do
+  use Mix.Project
+
+  def project, do: [app: :orders, deps: deps()]
+
+  defp deps do
+    [
+      {:phoenix, "~> 1.7"},
+      {:opentelemetry, "~> 1.5"},
+      {:opentelemetry_phoenix, "~> 2.0"},
+      {:shared, in_umbrella: true}
+    ]
+  end
+end
+`,
+		"mix.lock": `%{
+  "opentelemetry": {:hex, :opentelemetry, "1.5.0", "aa", [:rebar3], [], "hexpm", "bb"},
+  "phoenix": {:hex, :phoenix, "1.7.14", "cc", [:mix], [], "hexpm", "dd"},
+}
+`,
+		"lib/orders/application.ex": `defmodule Orders.Application do
+  use Application
+
+  def start(_type, _args) do
+    # OpentelemetryCommented.setup()
+    OpentelemetryPhoenix.setup()
+    OpentelemetryEcto.setup([:orders, :repo])
+    Supervisor.start_link([], strategy: :one_for_one)
+  end
+end
+`,
+		"lib/orders/tracing.ex":                     "defmodule Orders.Tracing do\n  require OpenTelemetry.Tracer\nend\n",
+		"deps/opentelemetry_ignored/lib/ignored.ex": "OpentelemetryIgnored.setup()\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewElixirDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	declared := map[string]string{}
+	referenced := map[string]bool{}
+	for _, l := range libs {
+		if l.PackageFile != "" {
+			declared[l.Name] = l.Version
+		} else {
+			referenced[l.Name] = true
+		}
+	}
+	if declared["opentelemetry"] != "1.5.0" || declared["opentelemetry_phoenix"] != "~> 2.0" {
+		t.Fatalf("expected locked and declared versions, got %v", declared)
+	}
+	for _, want := range []string{"opentelemetry_phoenix", "opentelemetry_ecto", "opentelemetry_api"} {
+		if !referenced[want] {
+			t.Fatalf("expected %s from module references, got %v", want, libs)
+		}
+	}
+	if referenced["opentelemetry_ignored"] || referenced["opentelemetry_commented"] {
+		t.Fatalf("deps/ and comments should not be scanned, got %v", libs)
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	for _, p := range pkgs {
+		got[p.Name] = p.Version
+	}
+	if len(got) != 3 || got["phoenix"] != "1.7.14" {
+		t.Fatalf("expected phoenix, opentelemetry and opentelemetry_phoenix without umbrella deps, got %v", pkgs)
+	}
+}
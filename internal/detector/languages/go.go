@@ -4,12 +4,14 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/gomod"
+	"github.com/getlawrence/cli/internal/ignore"
 )
 
 // GoDetector detects Go projects and OpenTelemetry usage
@@ -29,18 +31,26 @@ func (g *GoDetector) Name() string {
 func (g *GoDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
 	var libraries []domain.Library
 
-	// Check go.mod for OTel dependencies
-	goModPath := filepath.Join(rootPath, "go.mod")
-	if _, err := os.Stat(goModPath); err == nil {
-		libs, err := g.parseGoMod(goModPath)
-		if err != nil {
-			return nil, err
+	// Check the owning go.mod for OTel dependencies, with the versions the build selects
+	for _, pkg := range g.modulePackages(rootPath) {
+		if !strings.HasPrefix(pkg.Name, "go.opentelemetry.io/") {
+			continue
 		}
-		libraries = append(libraries, libs...)
+		version := pkg.ResolvedVersion
+		if version == "" {
+			version = pkg.Version
+		}
+		libraries = append(libraries, domain.Library{
+			Name:        pkg.Name,
+			Version:     version,
+			Language:    "go",
+			ImportPath:  pkg.Name,
+			PackageFile: pkg.PackageFile,
+		})
 	}
 
 	// Check .go files for OTel imports
-	goFiles, err := g.findGoFiles(rootPath)
+	goFiles, err := g.findGoFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -58,25 +68,23 @@ func (g *GoDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]d
 
 // GetFilePatterns returns patterns for Go files
 func (g *GoDetector) GetFilePatterns() []string {
-	return []string{"**/*.go", "go.mod", "go.sum"}
+	return []string{"**/*.go", gomod.ModFileName, gomod.SumFileName, gomod.WorkFileName, gomod.VendorModulesFileName}
 }
 
 // GetAllPackages finds all packages/dependencies used in the Go project
 func (g *GoDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
 	var packages []domain.Package
 
-	// Check go.mod for all dependencies
-	goModPath := filepath.Join(rootPath, "go.mod")
-	if _, err := os.Stat(goModPath); err == nil {
-		pkgs, err := g.parseAllDependencies(goModPath)
-		if err != nil {
-			return nil, err
+	// Check the owning go.mod for all dependencies
+	for _, pkg := range g.modulePackages(rootPath) {
+		// Skip module paths without a domain, e.g. local replacements
+		if strings.Contains(pkg.Name, ".") {
+			packages = append(packages, pkg)
 		}
-		packages = append(packages, pkgs...)
 	}
 
 	// Check .go files for all imports
-	goFiles, err := g.findGoFiles(rootPath)
+	goFiles, err := g.findGoFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -92,37 +100,6 @@ func (g *GoDetector) GetAllPackages(ctx context.Context, rootPath string) ([]dom
 	return g.deduplicatePackages(packages), nil
 }
 
-// parseGoMod extracts OTel dependencies from go.mod
-func (g *GoDetector) parseGoMod(goModPath string) ([]domain.Library, error) {
-	file, err := os.Open(goModPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var libraries []domain.Library
-	scanner := bufio.NewScanner(file)
-
-	// Regex for matching OTel dependencies
-	otelRegex := regexp.MustCompile(`^\s*(go\.opentelemetry\.io/[^\s]+)\s+([^\s]+)`)
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		matches := otelRegex.FindStringSubmatch(line)
-		if len(matches) >= 3 {
-			libraries = append(libraries, domain.Library{
-				Name:        matches[1],
-				Version:     matches[2],
-				Language:    "go",
-				ImportPath:  matches[1],
-				PackageFile: goModPath,
-			})
-		}
-	}
-
-	return libraries, scanner.Err()
-}
-
 // parseGoImports extracts OTel imports from Go source files
 func (g *GoDetector) parseGoImports(filePath string) ([]domain.Library, error) {
 	file, err := os.Open(filePath)
@@ -178,20 +155,16 @@ func (g *GoDetector) parseGoImports(filePath string) ([]domain.Library, error) {
 }
 
 // findGoFiles recursively finds all Go files
-func (g *GoDetector) findGoFiles(rootPath string) ([]string, error) {
+func (g *GoDetector) findGoFiles(ctx context.Context, rootPath string) ([]string, error) {
 	var goFiles []string
 
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	// Ignored paths (vendor, .git, .gitignore entries) are skipped by the matcher
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip vendor and .git directories
-		if info.IsDir() && (info.Name() == "vendor" || info.Name() == ".git") {
-			return filepath.SkipDir
-		}
-
-		if strings.HasSuffix(path, ".go") {
+		if !d.IsDir() && strings.HasSuffix(path, ".go") {
 			goFiles = append(goFiles, path)
 		}
 
@@ -217,62 +190,33 @@ func (g *GoDetector) deduplicateLibraries(libraries []domain.Library) []domain.L
 	return result
 }
 
-// parseAllDependencies extracts all dependencies from go.mod
-func (g *GoDetector) parseAllDependencies(goModPath string) ([]domain.Package, error) {
-	file, err := os.Open(goModPath)
+// modulePackages returns the requirements of the go.mod owning rootPath, which may be a
+// parent directory, with the versions selected for its module or go.work workspace
+func (g *GoDetector) modulePackages(rootPath string) []domain.Package {
+	owner, ok, err := gomod.FindOwner(rootPath)
+	if !ok || err != nil {
+		return nil
+	}
+	mod, err := owner.ReadModule()
 	if err != nil {
-		return nil, err
+		return nil
 	}
-	defer file.Close()
-
-	var packages []domain.Package
-	scanner := bufio.NewScanner(file)
-
-	// Regex for matching dependencies (including version)
-	depRegex := regexp.MustCompile(`^\s*([^\s]+)\s+([^\s]+)`)
-	inRequireBlock := false
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Handle require blocks
-		if strings.HasPrefix(line, "require (") {
-			inRequireBlock = true
-			continue
-		}
-
-		if inRequireBlock && line == ")" {
-			inRequireBlock = false
-			continue
-		}
-
-		// Handle single require line
-		if strings.HasPrefix(line, "require ") {
-			line = strings.TrimPrefix(line, "require ")
-		}
-
-		if inRequireBlock || strings.HasPrefix(scanner.Text(), "require ") {
-			matches := depRegex.FindStringSubmatch(line)
-			if len(matches) >= 3 {
-				// Skip golang.org/x and standard library packages
-				packageName := matches[1]
-				if !strings.HasPrefix(packageName, "golang.org/x/") &&
-					!strings.Contains(packageName, ".") {
-					continue
-				}
-
-				packages = append(packages, domain.Package{
-					Name:        packageName,
-					Version:     strings.TrimSuffix(matches[2], " // indirect"),
-					Language:    "go",
-					ImportPath:  packageName,
-					PackageFile: goModPath,
-				})
-			}
-		}
+	// Selected versions are best effort; the required ones are reported regardless
+	selected, _ := owner.SelectedVersions()
+
+	packages := make([]domain.Package, 0, len(mod.Requires))
+	for _, req := range mod.Requires {
+		packages = append(packages, domain.Package{
+			Name:            req.Path,
+			Version:         req.Version,
+			Language:        "go",
+			ImportPath:      req.Path,
+			PackageFile:     owner.ModFile(),
+			ResolvedVersion: selected[req.Path],
+			Transitive:      req.Indirect,
+		})
 	}
-
-	return packages, scanner.Err()
+	return packages
 }
 
 // parseAllImports extracts all imports from Go source files
@@ -203,7 +203,7 @@ func TestGoDetectorGetFilePatterns(t *testing.T) {
 	detector := NewGoDetector()
 	patterns := detector.GetFilePatterns()
 
-	expectedPatterns := []string{"**/*.go", "go.mod", "go.sum"}
+	expectedPatterns := []string{"**/*.go", "go.mod", "go.sum", "go.work", "vendor/modules.txt"}
 
 	if !reflect.DeepEqual(patterns, expectedPatterns) {
 		t.Errorf("GetFilePatterns() = %v, want %v", patterns, expectedPatterns)
@@ -283,7 +283,7 @@ func TestGoDetectorFindGoFiles(t *testing.T) {
 	}
 
 	// Test findGoFiles
-	goFiles, err := detector.findGoFiles(tempDir)
+	goFiles, err := detector.findGoFiles(context.Background(), tempDir)
 	if err != nil {
 		t.Fatalf("findGoFiles() error = %v", err)
 	}
@@ -324,3 +324,60 @@ func TestGoDetectorFindGoFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestGoDetectorWorkspaceModule(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work": "go 1.23\n\nuse (\n\t./api\n\t./worker\n)\n",
+		"api/go.mod": `module example.com/api
+
+go 1.23
+
+require (
+	go.opentelemetry.io/otel v1.26.0
+	go.opentelemetry.io/otel/metric v1.26.0 // indirect
+)
+`,
+		"api/go.sum":              "go.opentelemetry.io/otel/metric v1.27.0 h1:abc=\n",
+		"api/handlers/handler.go": "package handlers\n",
+		"worker/go.mod":           "module example.com/worker\n\ngo 1.23\n\nrequire go.opentelemetry.io/otel v1.28.0\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A package directory below the module root resolves to the module's go.mod
+	d := NewGoDetector()
+	pkgs, err := d.GetAllPackages(context.Background(), filepath.Join(root, "api", "handlers"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pkgs) != 2 {
+		t.Fatalf("expected the 2 requirements of api/go.mod, got %+v", pkgs)
+	}
+	// The workspace selects the highest version any of its modules requires
+	if pkgs[0].Version != "v1.26.0" || pkgs[0].ResolvedVersion != "v1.28.0" || pkgs[0].PackageFile != filepath.Join(root, "api", "go.mod") {
+		t.Errorf("expected otel v1.26.0 resolved to v1.28.0 by the workspace, got %+v", pkgs[0])
+	}
+	if pkgs[1].ResolvedVersion != "v1.27.0" || !pkgs[1].Transitive {
+		t.Errorf("expected indirect metric resolved to v1.27.0 from go.sum, got %+v", pkgs[1])
+	}
+
+	libs, err := d.GetOTelLibraries(context.Background(), filepath.Join(root, "api"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var versions []string
+	for _, l := range libs {
+		versions = append(versions, l.Name+"@"+l.Version)
+	}
+	if want := []string{"go.opentelemetry.io/otel@v1.28.0", "go.opentelemetry.io/otel/metric@v1.27.0"}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("expected selected versions %v, got %v", want, versions)
+	}
+}
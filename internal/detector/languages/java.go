@@ -3,12 +3,16 @@ package languages
 import (
 	"bufio"
 	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/gradle"
+	"github.com/getlawrence/cli/internal/ignore"
+	"github.com/getlawrence/cli/internal/maven"
 )
 
 // JavaDetector detects Java projects and OpenTelemetry usage
@@ -25,27 +29,20 @@ func (j *JavaDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([
 	var libraries []domain.Library
 
 	// Maven pom.xml
-	pomPath := filepath.Join(rootPath, "pom.xml")
-	if _, err := os.Stat(pomPath); err == nil {
-		libs, err := j.parsePomForOTel(pomPath)
+	if _, err := os.Stat(filepath.Join(rootPath, maven.POMFileName)); err == nil {
+		libs, err := j.pomLibraries(rootPath)
 		if err == nil {
 			libraries = append(libraries, libs...)
 		}
 	}
 
-	// Gradle build files
-	gradlePaths := []string{filepath.Join(rootPath, "build.gradle"), filepath.Join(rootPath, "build.gradle.kts"), filepath.Join(rootPath, "settings.gradle"), filepath.Join(rootPath, "settings.gradle.kts")}
-	for _, p := range gradlePaths {
-		if _, err := os.Stat(p); err == nil {
-			libs, err := j.parseGradleForOTel(p)
-			if err == nil {
-				libraries = append(libraries, libs...)
-			}
-		}
+	// Gradle build script, with the version catalog of its build
+	if libs, err := j.gradleLibraries(rootPath); err == nil {
+		libraries = append(libraries, libs...)
 	}
 
 	// Source imports
-	javaFiles, err := j.findJavaFiles(rootPath)
+	javaFiles, err := j.findJavaFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +58,7 @@ func (j *JavaDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([
 
 // GetFilePatterns returns patterns for Java files
 func (j *JavaDetector) GetFilePatterns() []string {
-	return []string{"**/*.java", "pom.xml", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"}
+	return []string{"**/*.java", "pom.xml", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts", gradle.CatalogFileName}
 }
 
 // GetAllPackages finds all packages/dependencies used in the Java project
@@ -69,27 +66,20 @@ func (j *JavaDetector) GetAllPackages(ctx context.Context, rootPath string) ([]d
 	var packages []domain.Package
 
 	// Maven
-	pomPath := filepath.Join(rootPath, "pom.xml")
-	if _, err := os.Stat(pomPath); err == nil {
-		pkgs, err := j.parseAllFromPom(pomPath)
+	if _, err := os.Stat(filepath.Join(rootPath, maven.POMFileName)); err == nil {
+		pkgs, err := j.pomPackages(rootPath)
 		if err == nil {
 			packages = append(packages, pkgs...)
 		}
 	}
 
 	// Gradle
-	gradlePaths := []string{filepath.Join(rootPath, "build.gradle"), filepath.Join(rootPath, "build.gradle.kts")}
-	for _, p := range gradlePaths {
-		if _, err := os.Stat(p); err == nil {
-			pkgs, err := j.parseAllFromGradle(p)
-			if err == nil {
-				packages = append(packages, pkgs...)
-			}
-		}
+	if pkgs, err := j.gradlePackages(rootPath); err == nil {
+		packages = append(packages, pkgs...)
 	}
 
 	// Imports
-	javaFiles, err := j.findJavaFiles(rootPath)
+	javaFiles, err := j.findJavaFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -105,56 +95,36 @@ func (j *JavaDetector) GetAllPackages(ctx context.Context, rootPath string) ([]d
 
 // --- Helpers ---
 
-func (j *JavaDetector) parsePomForOTel(pomPath string) ([]domain.Library, error) {
-	file, err := os.Open(pomPath)
+// pomLibraries returns the OpenTelemetry dependencies and BOM imports of the Maven build
+// in rootPath
+func (j *JavaDetector) pomLibraries(rootPath string) ([]domain.Library, error) {
+	pkgs, err := j.pomPackages(rootPath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 	var libs []domain.Library
-	scanner := bufio.NewScanner(file)
-	// Very simple XML regexes to detect artifact coordinates containing opentelemetry
-	// Use a non-backreference pattern to avoid Go's regexp backreference limitation
-	depRegex := regexp.MustCompile(`<(groupId|artifactId)>([^<]+)</(groupId|artifactId)>`) // capture group/artifact ids
-	var groupID, artifactID string
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if m := depRegex.FindStringSubmatch(line); len(m) >= 4 {
-			if m[1] == "groupId" && m[3] == "groupId" {
-				groupID = m[2]
-			}
-			if m[1] == "artifactId" && m[3] == "artifactId" {
-				artifactID = m[2]
-			}
-			if groupID != "" && artifactID != "" {
-				coord := groupID + ":" + artifactID
-				if strings.Contains(coord, "opentelemetry") {
-					libs = append(libs, domain.Library{Name: coord, Language: "java", ImportPath: coord, PackageFile: pomPath})
-				}
-				groupID, artifactID = "", ""
-			}
+	for _, pkg := range pkgs {
+		if strings.Contains(pkg.Name, "opentelemetry") {
+			libs = append(libs, domain.Library{Name: pkg.Name, Version: pkg.Version, Language: "java", ImportPath: pkg.Name, PackageFile: pkg.PackageFile})
 		}
 	}
-	return libs, scanner.Err()
+	return libs, nil
 }
 
-func (j *JavaDetector) parseGradleForOTel(path string) ([]domain.Library, error) {
-	file, err := os.Open(path)
+// gradleLibraries returns the OpenTelemetry dependencies of the Gradle build script in
+// rootPath
+func (j *JavaDetector) gradleLibraries(rootPath string) ([]domain.Library, error) {
+	pkgs, err := j.gradlePackages(rootPath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 	var libs []domain.Library
-	scanner := bufio.NewScanner(file)
-	// Match implementation("group:artifact:version") or implementation 'group:artifact:version'
-	re := regexp.MustCompile(`['"]([a-zA-Z0-9_.\-]+:opentelemetry[^:'"]+)(?::[^'"\)]+)?['"]`)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if m := re.FindStringSubmatch(line); len(m) >= 2 {
-			libs = append(libs, domain.Library{Name: m[1], Language: "java", ImportPath: m[1], PackageFile: path})
+	for _, pkg := range pkgs {
+		if strings.Contains(pkg.Name, "opentelemetry") {
+			libs = append(libs, domain.Library{Name: pkg.Name, Version: pkg.Version, Language: "java", ImportPath: pkg.Name, PackageFile: pkg.PackageFile})
 		}
 	}
-	return libs, scanner.Err()
+	return libs, nil
 }
 
 func (j *JavaDetector) parseJavaImportsForOTel(filePath string) ([]domain.Library, error) {
@@ -175,20 +145,21 @@ func (j *JavaDetector) parseJavaImportsForOTel(filePath string) ([]domain.Librar
 	return libs, scanner.Err()
 }
 
-func (j *JavaDetector) findJavaFiles(rootPath string) ([]string, error) {
+func (j *JavaDetector) findJavaFiles(ctx context.Context, rootPath string) ([]string, error) {
+	return j.findSourceFiles(ctx, rootPath, ".java")
+}
+
+// findSourceFiles recursively finds files with the given extension
+func (j *JavaDetector) findSourceFiles(ctx context.Context, rootPath string, extension string) ([]string, error) {
 	var files []string
-	_ = filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	_ = ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			switch info.Name() {
-			case ".git", "target", "build", "out":
-				return filepath.SkipDir
-			}
+		if d.IsDir() {
 			return nil
 		}
-		if strings.HasSuffix(strings.ToLower(path), ".java") {
+		if strings.HasSuffix(strings.ToLower(path), extension) {
 			files = append(files, path)
 		}
 		return nil
@@ -196,73 +167,83 @@ func (j *JavaDetector) findJavaFiles(rootPath string) ([]string, error) {
 	return files, nil
 }
 
-func (j *JavaDetector) parseAllFromPom(pomPath string) ([]domain.Package, error) {
-	file, err := os.Open(pomPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
+// pomPackages returns the dependencies and imported BOMs of the pom.xml in rootPath and of
+// its <modules>, with versions resolved through properties, local parent POMs,
+// <dependencyManagement> and BOMs. PackageFile is the POM declaring each dependency.
+func (j *JavaDetector) pomPackages(rootPath string) ([]domain.Package, error) {
 	var packages []domain.Package
-	scanner := bufio.NewScanner(file)
-	depStart := regexp.MustCompile(`^<dependency>`) // naive
-	depEnd := regexp.MustCompile(`^</dependency>`)
-	gidRe := regexp.MustCompile(`^<groupId>([^<]+)</groupId>`)
-	aidRe := regexp.MustCompile(`^<artifactId>([^<]+)</artifactId>`)
-	verRe := regexp.MustCompile(`^<version>([^<]+)</version>`)
-	inDep := false
-	var gid, aid, ver string
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if depStart.MatchString(line) {
-			inDep = true
-			gid, aid, ver = "", "", ""
-			continue
+	visited := map[string]bool{}
+	var load func(pomPath string, root bool) error
+	load = func(pomPath string, root bool) error {
+		if visited[pomPath] {
+			return nil
 		}
-		if depEnd.MatchString(line) && inDep {
-			if gid != "" && aid != "" {
-				coord := gid + ":" + aid
-				packages = append(packages, domain.Package{Name: coord, Version: ver, Language: "java", ImportPath: coord, PackageFile: pomPath})
+		visited[pomPath] = true
+		project, err := maven.Load(pomPath)
+		if err != nil {
+			if root {
+				return err
 			}
-			inDep = false
-			continue
-		}
-		if !inDep {
-			continue
+			// A missing or broken module doesn't hide the rest of the build
+			return nil
 		}
-		if m := gidRe.FindStringSubmatch(line); len(m) == 2 {
-			gid = m[1]
+		for _, bom := range project.BOMs {
+			coord := bom.Coordinate()
+			packages = append(packages, domain.Package{Name: coord, Version: bom.Version, Language: "java", ImportPath: coord, PackageFile: project.ManagedBy[coord]})
 		}
-		if m := aidRe.FindStringSubmatch(line); len(m) == 2 {
-			aid = m[1]
+		for _, dep := range project.Dependencies {
+			coord := dep.Coordinate()
+			packages = append(packages, domain.Package{Name: coord, Version: dep.Version, Language: "java", ImportPath: coord, PackageFile: project.DeclaredIn[coord]})
 		}
-		if m := verRe.FindStringSubmatch(line); len(m) == 2 {
-			ver = m[1]
+		for _, module := range project.Modules {
+			if err := load(filepath.Join(module, maven.POMFileName), false); err != nil {
+				return err
+			}
 		}
+		return nil
 	}
-	return packages, scanner.Err()
+	if err := load(filepath.Join(rootPath, maven.POMFileName), true); err != nil {
+		return nil, err
+	}
+	return packages, nil
 }
 
-func (j *JavaDetector) parseAllFromGradle(path string) ([]domain.Package, error) {
-	file, err := os.Open(path)
+// gradlePackages returns the dependencies of the Gradle build scripts in rootPath.
+// libs.* references, including bundles, resolve through the version catalog of the build
+// the project belongs to, so a subproject uses the catalog of its root build. PackageFile
+// is the catalog for those and the build script otherwise.
+func (j *JavaDetector) gradlePackages(rootPath string) ([]domain.Package, error) {
+	var scripts []string
+	for _, name := range gradle.BuildFileNames {
+		path := filepath.Join(rootPath, name)
+		if _, err := os.Stat(path); err == nil {
+			scripts = append(scripts, path)
+		}
+	}
+	if len(scripts) == 0 {
+		return nil, nil
+	}
+	build, err := gradle.FindBuild(rootPath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+
 	var packages []domain.Package
-	scanner := bufio.NewScanner(file)
-	re := regexp.MustCompile(`['"]([a-zA-Z0-9_.\-]+:[a-zA-Z0-9_.\-]+)(?::([^'"\)]+))?['"]`)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if m := re.FindStringSubmatch(line); len(m) >= 2 {
-			coord := m[1]
-			ver := ""
-			if len(m) >= 3 {
-				ver = m[2]
+	for _, script := range scripts {
+		content, err := os.ReadFile(script)
+		if err != nil {
+			return nil, err
+		}
+		for _, dep := range gradle.ParseBuildScript(content, build.Catalog) {
+			coord := dep.Coordinate()
+			file := script
+			if dep.Alias != "" {
+				file = build.CatalogPath
 			}
-			packages = append(packages, domain.Package{Name: coord, Version: ver, Language: "java", ImportPath: coord, PackageFile: path})
+			packages = append(packages, domain.Package{Name: coord, Version: dep.Version, Language: "java", ImportPath: coord, PackageFile: file})
 		}
 	}
-	return packages, scanner.Err()
+	return packages, nil
 }
 
 func (j *JavaDetector) parseAllJavaImports(filePath string) ([]domain.Package, error) {
@@ -0,0 +1,166 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJavaDetectorMultiModulePom(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"pom.xml": `<project>
+  <groupId>com.example</groupId>
+  <artifactId>shop</artifactId>
+  <version>2.0.0</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>api</module>
+  </modules>
+  <properties>
+    <otel.version>1.42.1</otel.version>
+    <otel.instrumentation.version>2.8.0</otel.instrumentation.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>io.opentelemetry</groupId>
+        <artifactId>opentelemetry-bom</artifactId>
+        <version>${otel.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+      <dependency>
+        <groupId>io.opentelemetry.instrumentation</groupId>
+        <artifactId>opentelemetry-instrumentation-annotations</artifactId>
+        <version>${otel.instrumentation.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>`,
+		"api/pom.xml": `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>shop</artifactId>
+    <version>2.0.0</version>
+  </parent>
+  <artifactId>api</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>io.opentelemetry</groupId>
+      <artifactId>opentelemetry-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>io.opentelemetry.instrumentation</groupId>
+      <artifactId>opentelemetry-instrumentation-annotations</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>shared</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewJavaDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]string{}
+	for _, l := range libs {
+		got[l.Name] = l.Version
+	}
+	want := map[string]string{
+		"io.opentelemetry:opentelemetry-bom":                                         "1.42.1",
+		"io.opentelemetry:opentelemetry-api":                                         "1.42.1",
+		"io.opentelemetry.instrumentation:opentelemetry-instrumentation-annotations": "2.8.0",
+	}
+	for name, version := range want {
+		if got[name] != version {
+			t.Errorf("expected %s %s, got %v", name, version, got)
+		}
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	apiPom := filepath.Join(root, "api", "pom.xml")
+	for _, p := range pkgs {
+		if p.Name == "com.example:shared" && (p.Version != "2.0.0" || p.PackageFile != apiPom) {
+			t.Errorf("expected com.example:shared 2.0.0 declared in %s, got %+v", apiPom, p)
+		}
+	}
+}
+
+func TestJavaDetectorGradleVersionCatalog(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"settings.gradle.kts": "rootProject.name = \"shop\"\ninclude(\":services:api\")\n",
+		"gradle/libs.versions.toml": `[versions]
+otel = "1.42.1"
+
+[libraries]
+otel-api = { module = "io.opentelemetry:opentelemetry-api", version.ref = "otel" }
+otel-sdk = { module = "io.opentelemetry:opentelemetry-sdk", version.ref = "otel" }
+guava = "com.google.guava:guava:33.3.1-jre"
+
+[bundles]
+otel = ["otel-api", "otel-sdk"]
+`,
+		"services/api/build.gradle.kts": `dependencies {
+    implementation(libs.bundles.otel)
+    implementation(libs.guava)
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The subproject resolves libs.* through the catalog of the root build
+	d := NewJavaDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), filepath.Join(root, "services", "api"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	catalog := filepath.Join(root, "gradle", "libs.versions.toml")
+	if len(libs) != 2 {
+		t.Fatalf("expected the 2 libraries of the otel bundle, got %+v", libs)
+	}
+	for _, l := range libs {
+		if l.Version != "1.42.1" || l.PackageFile != catalog {
+			t.Errorf("expected %s 1.42.1 from %s, got %+v", l.Name, catalog, l)
+		}
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), filepath.Join(root, "services", "api"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, p := range pkgs {
+		found = found || (p.Name == "com.google.guava:guava" && p.Version == "33.3.1-jre")
+	}
+	if !found {
+		t.Errorf("expected guava from the catalog, got %+v", pkgs)
+	}
+}
@@ -3,14 +3,20 @@ package languages
 import (
 	"bufio"
 	"context"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
+	"github.com/getlawrence/cli/internal/npm"
 )
 
+// javaScriptExtensions are the source file extensions scanned for JavaScript imports
+var javaScriptExtensions = []string{".js", ".mjs", ".cjs"}
+
 // JavaScriptDetector detects JavaScript projects and OpenTelemetry usage
 type JavaScriptDetector struct{}
 
@@ -24,17 +30,11 @@ func (j *JavaScriptDetector) Name() string { return "javascript" }
 func (j *JavaScriptDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
 	var libraries []domain.Library
 
-	// Check package.json
-	pkgJSON := filepath.Join(rootPath, "package.json")
-	if _, err := os.Stat(pkgJSON); err == nil {
-		libs, err := j.parsePackageJSON(pkgJSON)
-		if err == nil {
-			libraries = append(libraries, libs...)
-		}
-	}
+	// Check package.json and its lockfile
+	libraries = append(libraries, j.manifestOTelLibraries(rootPath)...)
 
 	// Scan .js/.mjs for imports/requires
-	jsFiles, err := j.findJavaScriptFiles(rootPath)
+	jsFiles, err := j.findJavaScriptFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -50,24 +50,18 @@ func (j *JavaScriptDetector) GetOTelLibraries(ctx context.Context, rootPath stri
 
 // GetFilePatterns returns patterns for JavaScript files
 func (j *JavaScriptDetector) GetFilePatterns() []string {
-	return []string{"**/*.js", "**/*.mjs", "package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml"}
+	return []string{"**/*.js", "**/*.mjs", "**/*.cjs", npm.ManifestFileName, npm.PackageLockFileName, npm.YarnLockFileName, npm.PnpmLockFileName}
 }
 
 // GetAllPackages finds all packages/dependencies used in the project
 func (j *JavaScriptDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
 	var packages []domain.Package
 
-	// package.json dependencies
-	pkgJSON := filepath.Join(rootPath, "package.json")
-	if _, err := os.Stat(pkgJSON); err == nil {
-		pkgs, err := j.parseAllFromPackageJSON(pkgJSON)
-		if err == nil {
-			packages = append(packages, pkgs...)
-		}
-	}
+	// package.json dependencies, resolved against the lockfile
+	packages = append(packages, j.manifestPackages(rootPath)...)
 
 	// JS imports/requires
-	jsFiles, err := j.findJavaScriptFiles(rootPath)
+	jsFiles, err := j.findJavaScriptFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +84,7 @@ func (j *JavaScriptDetector) parsePackageJSON(path string) ([]domain.Library, er
 
 	var libraries []domain.Library
 	// Match keys under dependencies/devDependencies with @opentelemetry/*
-	re := regexp.MustCompile(`"(@opentelemetry\\/[^"]+)"\s*:\s*"([^"]+)"`)
+	re := regexp.MustCompile(`"(@opentelemetry/[^"]+)"\s*:\s*"([^"]+)"`)
 	matches := re.FindAllStringSubmatch(string(content), -1)
 	for _, m := range matches {
 		if len(m) >= 3 {
@@ -117,7 +111,7 @@ func (j *JavaScriptDetector) parseJSImports(filePath string) ([]domain.Library,
 	var libraries []domain.Library
 	scanner := bufio.NewScanner(file)
 	// import x from '@opentelemetry/api' OR require('@opentelemetry/api')
-	importRE := regexp.MustCompile(`@opentelemetry\\/[^'"\\s]+`)
+	importRE := regexp.MustCompile(`@opentelemetry/[^'"\s]+`)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -134,27 +128,38 @@ func (j *JavaScriptDetector) parseJSImports(filePath string) ([]domain.Library,
 }
 
 // findJavaScriptFiles recursively finds JS files
-func (j *JavaScriptDetector) findJavaScriptFiles(rootPath string) ([]string, error) {
+func (j *JavaScriptDetector) findJavaScriptFiles(ctx context.Context, rootPath string) ([]string, error) {
+	return j.findSourceFiles(ctx, rootPath, javaScriptExtensions)
+}
+
+// findSourceFiles recursively finds files with one of the given extensions, skipping
+// TypeScript declaration files which only describe packages installed elsewhere
+func (j *JavaScriptDetector) findSourceFiles(ctx context.Context, rootPath string, extensions []string) ([]string, error) {
 	var files []string
-	filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			switch info.Name() {
-			case "node_modules", ".git":
-				return filepath.SkipDir
-			}
+		if d.IsDir() || isDeclarationFile(path) {
 			return nil
 		}
-		if strings.HasSuffix(path, ".js") || strings.HasSuffix(path, ".mjs") {
-			files = append(files, path)
+		for _, ext := range extensions {
+			if strings.HasSuffix(path, ext) {
+				files = append(files, path)
+				break
+			}
 		}
 		return nil
 	})
 	return files, nil
 }
 
+// isDeclarationFile reports whether path is a TypeScript declaration file (.d.ts, .d.mts, .d.cts)
+func isDeclarationFile(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasSuffix(base, ".d.ts") || strings.HasSuffix(base, ".d.mts") || strings.HasSuffix(base, ".d.cts")
+}
+
 // deduplicateLibraries removes duplicates
 func (j *JavaScriptDetector) deduplicateLibraries(libs []domain.Library) []domain.Library {
 	seen := map[string]bool{}
@@ -169,27 +174,99 @@ func (j *JavaScriptDetector) deduplicateLibraries(libs []domain.Library) []domai
 	return res
 }
 
-// parseAllFromPackageJSON extracts all deps from package.json (not only otel)
-func (j *JavaScriptDetector) parseAllFromPackageJSON(path string) ([]domain.Package, error) {
-	content, err := os.ReadFile(path)
+// manifestOTelLibraries returns the @opentelemetry/* packages of the project. With a lockfile
+// these are the exact installed versions, transitive ones included, so an API installed at
+// two versions shows up twice; packages the lockfile doesn't pin keep their package.json range.
+func (j *JavaScriptDetector) manifestOTelLibraries(rootPath string) []domain.Library {
+	pkgJSON := filepath.Join(rootPath, npm.ManifestFileName)
+	content, err := os.ReadFile(pkgJSON)
 	if err != nil {
-		return nil, err
+		return nil
 	}
-	// Match "name": "version" pairs in dependency blocks
-	depRE := regexp.MustCompile(`"([@a-zA-Z0-9_\-/]+)"\s*:\s*"([^"]+)"`)
-	var packages []domain.Package
-	for _, m := range depRE.FindAllStringSubmatch(string(content), -1) {
-		if len(m) >= 3 {
-			packages = append(packages, domain.Package{
-				Name:        m[1],
-				Version:     m[2],
-				Language:    "javascript",
-				ImportPath:  m[1],
-				PackageFile: path,
+	libs, _ := j.parsePackageJSON(pkgJSON)
+	deps, _ := npm.Dependencies(content)
+	lockPath, locked := j.readLockfile(rootPath, deps)
+
+	var libraries []domain.Library
+	pinned := make(map[string]bool)
+	for _, pkg := range locked {
+		if !strings.HasPrefix(pkg.Name, "@opentelemetry/") {
+			continue
+		}
+		pinned[pkg.Name] = true
+		libraries = append(libraries, domain.Library{
+			Name:        pkg.Name,
+			Version:     pkg.Version,
+			Language:    "javascript",
+			ImportPath:  pkg.Name,
+			PackageFile: lockPath,
+		})
+	}
+	for _, lib := range libs {
+		if !pinned[lib.Name] {
+			libraries = append(libraries, lib)
+		}
+	}
+	return libraries
+}
+
+// manifestPackages returns the dependencies declared in package.json with the versions the
+// lockfile resolved them to. Transitive packages are only reported for the @opentelemetry
+// scope, where mismatched versions matter; the full tree would drown the instrumentation
+// lookups in packages the project never imports.
+func (j *JavaScriptDetector) manifestPackages(rootPath string) []domain.Package {
+	pkgJSON := filepath.Join(rootPath, npm.ManifestFileName)
+	content, err := os.ReadFile(pkgJSON)
+	if err != nil {
+		return nil
+	}
+	deps, err := npm.Dependencies(content)
+	if err != nil {
+		return nil
+	}
+	lockPath, locked := j.readLockfile(rootPath, deps)
+
+	resolved := make(map[string]string)
+	var transitive []domain.Package
+	for _, pkg := range locked {
+		if pkg.Direct {
+			resolved[pkg.Name] = pkg.Version
+		} else if strings.HasPrefix(pkg.Name, "@opentelemetry/") {
+			transitive = append(transitive, domain.Package{
+				Name:            pkg.Name,
+				Version:         pkg.Version,
+				Language:        "javascript",
+				ImportPath:      pkg.Name,
+				PackageFile:     lockPath,
+				ResolvedVersion: pkg.Version,
+				Transitive:      true,
 			})
 		}
 	}
-	return packages, nil
+
+	var packages []domain.Package
+	for _, dep := range deps {
+		packages = append(packages, domain.Package{
+			Name:            dep.Name,
+			Version:         dep.Range,
+			Language:        "javascript",
+			ImportPath:      dep.Name,
+			PackageFile:     pkgJSON,
+			ResolvedVersion: resolved[dep.Name],
+		})
+	}
+	return append(packages, transitive...)
+}
+
+// readLockfile reads the npm, pnpm or yarn lockfile next to package.json, or the one of the
+// workspace root for workspace members. An unreadable lockfile is treated like a missing
+// one, leaving the package.json ranges unresolved.
+func (j *JavaScriptDetector) readLockfile(rootPath string, deps []npm.Dependency) (string, []npm.LockedPackage) {
+	path, locked, err := npm.ReadLockfile(rootPath, deps)
+	if err != nil {
+		return "", nil
+	}
+	return path, locked
 }
 
 // parseAllJSImports extracts all imports from JS files
@@ -201,15 +278,18 @@ func (j *JavaScriptDetector) parseAllJSImports(filePath string) ([]domain.Packag
 	defer file.Close()
 	var packages []domain.Package
 	scanner := bufio.NewScanner(file)
-	// Common import/require patterns
-	re := regexp.MustCompile(`(?:import\s+[^'";]+from\s+['"]([^'"]+)['"]|require\(\s*['"]([^'"]+)['"]\s*\))`)
+	// Common import/require patterns, including side-effect imports and TypeScript's
+	// `import type { X } from "..."` and `import x = require("...")`
+	re := regexp.MustCompile(`(?:(?:import|export)\s+[^'";]+from\s+['"]([^'"]+)['"]|require\(\s*['"]([^'"]+)['"]\s*\)|^import\s+['"]([^'"]+)['"])`)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		m := re.FindStringSubmatch(line)
 		if len(m) >= 2 {
 			name := m[1]
-			if name == "" && len(m) >= 3 {
-				name = m[2]
+			for _, alt := range m[2:] {
+				if name == "" {
+					name = alt
+				}
 			}
 			if name != "" && j.isThirdParty(name) {
 				root := name
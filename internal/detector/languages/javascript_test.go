@@ -1,6 +1,10 @@
 package languages
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/getlawrence/cli/internal/domain"
@@ -45,3 +49,73 @@ func TestJavaScriptDetector_IsThirdPartyAndDedup(t *testing.T) {
 		t.Fatalf("expected 1 deduped pkg, got %d", len(dedupPkgs))
 	}
 }
+
+func TestJavaScriptDetector_LockfileVersions(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"package.json": `{
+  "name": "checkout",
+  "version": "1.0.0",
+  "dependencies": {
+    "@opentelemetry/api": "^1.7.0",
+    "@opentelemetry/sdk-trace-base": "^1.25.0",
+    "express": "^4.19.0"
+  }
+}`,
+		"package-lock.json": `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": { "dependencies": { "@opentelemetry/api": "^1.7.0", "@opentelemetry/sdk-trace-base": "^1.25.0", "express": "^4.19.0" } },
+    "node_modules/@opentelemetry/api": { "version": "1.7.0" },
+    "node_modules/@opentelemetry/core": { "version": "1.25.1" },
+    "node_modules/@opentelemetry/sdk-trace-base": { "version": "1.25.1" },
+    "node_modules/@opentelemetry/sdk-trace-base/node_modules/@opentelemetry/api": { "version": "1.4.1" },
+    "node_modules/express": { "version": "4.19.2" },
+    "node_modules/qs": { "version": "6.11.0" }
+  }
+}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewJavaScriptDetector()
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byKey := map[string]domain.Package{}
+	for _, p := range pkgs {
+		byKey[p.Name+"@"+p.ResolvedVersion] = p
+	}
+	if len(pkgs) != 5 {
+		t.Fatalf("expected 3 direct and 2 transitive otel packages, got %+v", pkgs)
+	}
+	if p := byKey["express@4.19.2"]; p.Version != "^4.19.0" || p.Transitive || filepath.Base(p.PackageFile) != "package.json" {
+		t.Errorf("expected express declared as ^4.19.0 and resolved to 4.19.2, got %+v", p)
+	}
+	if p := byKey["@opentelemetry/api@1.4.1"]; !p.Transitive || filepath.Base(p.PackageFile) != "package-lock.json" {
+		t.Errorf("expected the nested api 1.4.1 as transitive, got %+v", p)
+	}
+	if p := byKey["@opentelemetry/core@1.25.1"]; !p.Transitive {
+		t.Errorf("expected core as transitive, got %+v", p)
+	}
+	if _, ok := byKey["qs@6.11.0"]; ok {
+		t.Errorf("transitive packages outside the otel scope should be skipped")
+	}
+
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var versions []string
+	for _, l := range libs {
+		versions = append(versions, l.Name+"@"+l.Version)
+	}
+	want := []string{"@opentelemetry/api@1.4.1", "@opentelemetry/api@1.7.0", "@opentelemetry/core@1.25.1", "@opentelemetry/sdk-trace-base@1.25.1"}
+	if !reflect.DeepEqual(versions, want) {
+		t.Errorf("expected exact locked versions %v, got %v", want, versions)
+	}
+}
@@ -0,0 +1,103 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/gradle"
+	"github.com/getlawrence/cli/internal/maven"
+)
+
+// KotlinDetector detects Kotlin projects and OpenTelemetry usage.
+// Kotlin builds on the JVM, so Maven and Gradle manifests are parsed like Java ones
+// and Kotlin imports use the same package syntax.
+type KotlinDetector struct {
+	java JavaDetector
+}
+
+// NewKotlinDetector creates a new Kotlin language detector
+func NewKotlinDetector() *KotlinDetector { return &KotlinDetector{} }
+
+// Name returns the language name
+func (k *KotlinDetector) Name() string { return "kotlin" }
+
+// GetOTelLibraries finds OpenTelemetry libraries in Kotlin projects (Gradle/Maven and imports)
+func (k *KotlinDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
+	var libraries []domain.Library
+
+	if _, err := os.Stat(filepath.Join(rootPath, maven.POMFileName)); err == nil {
+		libs, err := k.java.pomLibraries(rootPath)
+		if err == nil {
+			libraries = append(libraries, libs...)
+		}
+	}
+
+	if libs, err := k.java.gradleLibraries(rootPath); err == nil {
+		libraries = append(libraries, libs...)
+	}
+
+	ktFiles, err := k.java.findSourceFiles(ctx, rootPath, ".kt")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range ktFiles {
+		libs, err := k.java.parseJavaImportsForOTel(f)
+		if err == nil {
+			libraries = append(libraries, libs...)
+		}
+	}
+
+	libraries = k.java.deduplicateLibraries(libraries)
+	for i := range libraries {
+		libraries[i].Language = k.Name()
+	}
+	return libraries, nil
+}
+
+// GetFilePatterns returns patterns for Kotlin files
+func (k *KotlinDetector) GetFilePatterns() []string {
+	return []string{"**/*.kt", "build.gradle.kts", "settings.gradle.kts", "build.gradle", "settings.gradle", gradle.CatalogFileName, "pom.xml"}
+}
+
+// GetAllPackages finds all packages/dependencies used in the Kotlin project
+func (k *KotlinDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	var packages []domain.Package
+
+	if _, err := os.Stat(filepath.Join(rootPath, maven.POMFileName)); err == nil {
+		pkgs, err := k.java.pomPackages(rootPath)
+		if err == nil {
+			packages = append(packages, pkgs...)
+		}
+	}
+
+	if pkgs, err := k.java.gradlePackages(rootPath); err == nil {
+		packages = append(packages, pkgs...)
+	}
+
+	ktFiles, err := k.java.findSourceFiles(ctx, rootPath, ".kt")
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range ktFiles {
+		pkgs, err := k.java.parseAllJavaImports(f)
+		if err != nil {
+			continue
+		}
+		for _, p := range pkgs {
+			// The Kotlin standard library ships with the compiler
+			if strings.HasPrefix(p.ImportPath, "kotlin.") {
+				continue
+			}
+			packages = append(packages, p)
+		}
+	}
+
+	packages = k.java.deduplicatePackages(packages)
+	for i := range packages {
+		packages[i].Language = k.Name()
+	}
+	return packages, nil
+}
@@ -0,0 +1,77 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKotlinDetector_GradleKtsAndImports(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"build.gradle.kts": `plugins {
+    kotlin("jvm") version "2.0.21"
+}
+
+dependencies {
+    implementation("io.ktor:ktor-server-netty:2.3.12")
+    implementation("io.opentelemetry:opentelemetry-api:1.42.1")
+    testImplementation(kotlin("test"))
+}
+`,
+		"src/main/kotlin/com/example/Application.kt": `package com.example
+
+import io.ktor.server.engine.embeddedServer
+import io.opentelemetry.api.GlobalOpenTelemetry
+import io.opentelemetry.context.Context as OtelContext
+import kotlin.system.exitProcess
+`,
+		"src/main/java/Legacy.java": "import io.opentelemetry.ignored.Thing;\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewKotlinDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotLibs := map[string]bool{}
+	for _, l := range libs {
+		if l.Language != "kotlin" {
+			t.Fatalf("expected kotlin language, got %q", l.Language)
+		}
+		gotLibs[l.Name] = true
+	}
+	for _, want := range []string{"io.opentelemetry:opentelemetry-api", "io.opentelemetry.api.GlobalOpenTelemetry", "io.opentelemetry.context.Context"} {
+		if !gotLibs[want] {
+			t.Fatalf("expected library %s, got %v", want, libs)
+		}
+	}
+	if gotLibs["io.opentelemetry.ignored.Thing"] {
+		t.Fatalf("Java sources should not be scanned by the Kotlin detector")
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotPkgs := map[string]string{}
+	for _, p := range pkgs {
+		gotPkgs[p.Name] = p.Version
+	}
+	if gotPkgs["io.ktor:ktor-server-netty"] != "2.3.12" {
+		t.Fatalf("expected ktor dependency from build.gradle.kts, got %v", pkgs)
+	}
+	if _, ok := gotPkgs["kotlin"]; ok {
+		t.Fatalf("kotlin standard library imports should be skipped, got %v", pkgs)
+	}
+}
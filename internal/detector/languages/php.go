@@ -5,12 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
 )
 
 // PHPDetector detects PHP projects and OpenTelemetry usage
@@ -36,7 +38,7 @@ func (p *PHPDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]
 	}
 
 	// Scan .php files for OpenTelemetry namespaces/usages as a heuristic
-	phpFiles, err := p.findPHPFiles(rootPath)
+	phpFiles, err := p.findPHPFiles(ctx, rootPath)
 	if err == nil {
 		for _, f := range phpFiles {
 			libs, perr := p.scanPHPFileForOTel(f)
@@ -125,21 +127,13 @@ func (p *PHPDetector) parseComposerPackages(path string) ([]domain.Package, erro
 	return pkgs, nil
 }
 
-func (p *PHPDetector) findPHPFiles(root string) ([]string, error) {
+func (p *PHPDetector) findPHPFiles(ctx context.Context, root string) ([]string, error) {
 	var files []string
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+	err := ignore.FromContext(ctx, root).Walk(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			name := info.Name()
-			switch name {
-			case "vendor", ".git":
-				return filepath.SkipDir
-			}
-			if strings.HasPrefix(name, ".") {
-				return filepath.SkipDir
-			}
+		if d.IsDir() {
 			return nil
 		}
 		if strings.HasSuffix(strings.ToLower(path), ".php") {
@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
+	"github.com/getlawrence/cli/internal/python"
 )
 
 // PythonDetector detects Python projects and OpenTelemetry usage
@@ -39,18 +42,26 @@ func (p *PythonDetector) GetOTelLibraries(ctx context.Context, rootPath string)
 		libraries = append(libraries, libs...)
 	}
 
-	// Check pyproject.toml
-	pyprojectPath := filepath.Join(rootPath, "pyproject.toml")
-	if _, err := os.Stat(pyprojectPath); err == nil {
-		libs, err := p.parsePyproject(pyprojectPath)
-		if err != nil {
-			return nil, err
+	// Check pyproject.toml, Pipfile, setup.cfg and setup.py, with the versions pinned by their lockfile
+	for _, pkg := range p.manifestPackages(rootPath) {
+		if !strings.HasPrefix(python.NormalizeName(pkg.Name), "opentelemetry") {
+			continue
 		}
-		libraries = append(libraries, libs...)
+		version := pkg.ResolvedVersion
+		if version == "" {
+			version = pkg.Version
+		}
+		libraries = append(libraries, domain.Library{
+			Name:        pkg.Name,
+			Version:     version,
+			Language:    "python",
+			ImportPath:  pkg.Name,
+			PackageFile: pkg.PackageFile,
+		})
 	}
 
 	// Check Python imports
-	pyFiles, err := p.findPythonFiles(rootPath)
+	pyFiles, err := p.findPythonFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +79,8 @@ func (p *PythonDetector) GetOTelLibraries(ctx context.Context, rootPath string)
 
 // GetFilePatterns returns patterns for Python files
 func (p *PythonDetector) GetFilePatterns() []string {
-	return []string{"**/*.py", "requirements.txt", "pyproject.toml", "setup.py", "Pipfile"}
+	return []string{"**/*.py", "requirements.txt", python.PyprojectFileName, python.SetupPyFileName, python.SetupCfgFileName,
+		python.PipfileName, python.PipfileLockFileName, python.PoetryLockFileName, python.UvLockFileName}
 }
 
 // GetAllPackages finds all packages/dependencies used in the Python project
@@ -85,18 +97,11 @@ func (p *PythonDetector) GetAllPackages(ctx context.Context, rootPath string) ([
 		packages = append(packages, pkgs...)
 	}
 
-	// Check pyproject.toml
-	pyprojectPath := filepath.Join(rootPath, "pyproject.toml")
-	if _, err := os.Stat(pyprojectPath); err == nil {
-		pkgs, err := p.parseAllPyproject(pyprojectPath)
-		if err != nil {
-			return nil, err
-		}
-		packages = append(packages, pkgs...)
-	}
+	// Check pyproject.toml, Pipfile, setup.cfg and setup.py
+	packages = append(packages, p.manifestPackages(rootPath)...)
 
 	// Check Python imports
-	pyFiles, err := p.findPythonFiles(rootPath)
+	pyFiles, err := p.findPythonFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -148,50 +153,6 @@ func (p *PythonDetector) parseRequirements(reqPath string) ([]domain.Library, er
 	return libraries, scanner.Err()
 }
 
-// parsePyproject extracts OTel dependencies from pyproject.toml
-func (p *PythonDetector) parsePyproject(pyprojectPath string) ([]domain.Library, error) {
-	file, err := os.Open(pyprojectPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var libraries []domain.Library
-	scanner := bufio.NewScanner(file)
-	inDependencies := false
-
-	// Simple TOML parsing for dependencies section
-	otelRegex := regexp.MustCompile(`"(opentelemetry[a-zA-Z0-9\-_]*)[^"]*"`)
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		if strings.Contains(line, "dependencies") && strings.Contains(line, "=") {
-			inDependencies = true
-			continue
-		}
-
-		if inDependencies && strings.HasPrefix(line, "[") {
-			inDependencies = false
-			continue
-		}
-
-		if inDependencies {
-			matches := otelRegex.FindStringSubmatch(line)
-			if len(matches) >= 2 {
-				libraries = append(libraries, domain.Library{
-					Name:        matches[1],
-					Language:    "python",
-					ImportPath:  matches[1],
-					PackageFile: pyprojectPath,
-				})
-			}
-		}
-	}
-
-	return libraries, scanner.Err()
-}
-
 // parsePythonImports extracts OTel imports from Python source files
 func (p *PythonDetector) parsePythonImports(filePath string) ([]domain.Library, error) {
 	file, err := os.Open(filePath)
@@ -223,21 +184,16 @@ func (p *PythonDetector) parsePythonImports(filePath string) ([]domain.Library,
 }
 
 // findPythonFiles recursively finds all Python files
-func (p *PythonDetector) findPythonFiles(rootPath string) ([]string, error) {
+func (p *PythonDetector) findPythonFiles(ctx context.Context, rootPath string) ([]string, error) {
 	var pyFiles []string
 
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	// Ignored paths (virtualenvs, caches, .gitignore entries) are skipped by the matcher
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip common directories
-		if info.IsDir() && (info.Name() == "__pycache__" || info.Name() == ".git" ||
-			info.Name() == "venv" || info.Name() == ".venv" || info.Name() == "env") {
-			return filepath.SkipDir
-		}
-
-		if strings.HasSuffix(path, ".py") {
+		if !d.IsDir() && strings.HasSuffix(path, ".py") {
 			pyFiles = append(pyFiles, path)
 		}
 
@@ -305,58 +261,80 @@ func (p *PythonDetector) parseAllRequirements(reqPath string) ([]domain.Package,
 	return packages, scanner.Err()
 }
 
-// parseAllPyproject extracts all dependencies from pyproject.toml
-func (p *PythonDetector) parseAllPyproject(pyprojectPath string) ([]domain.Package, error) {
-	file, err := os.Open(pyprojectPath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
+// manifestPackages returns the dependencies declared in pyproject.toml, Pipfile,
+// setup.cfg and setup.py, with the versions resolved by poetry.lock, uv.lock or
+// Pipfile.lock. Lockfiles pin the whole tree; of the transitive packages only the opentelemetry ones are reported,
+// since those are the ones whose versions must line up with the declared ones.
+func (p *PythonDetector) manifestPackages(rootPath string) []domain.Package {
 	var packages []domain.Package
-	scanner := bufio.NewScanner(file)
-	inDependencies := false
-
-	// Regex for matching dependencies in TOML format
-	depRegex := regexp.MustCompile(`^\s*"([^"]+)"\s*=`)
-	depArrayRegex := regexp.MustCompile(`^\s*"([^"]+)"`)
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		// Check for dependencies section
-		if line == "[tool.poetry.dependencies]" || line == "[project]" {
-			inDependencies = true
-			continue
+	declared := make(map[string]bool)
+	add := func(path string, reqs []python.Requirement) {
+		for _, req := range reqs {
+			key := python.NormalizeName(req.Name)
+			if declared[key] {
+				continue
+			}
+			declared[key] = true
+			packages = append(packages, domain.Package{
+				Name:        req.Name,
+				Version:     req.Version(),
+				Language:    "python",
+				ImportPath:  req.Name,
+				PackageFile: path,
+			})
 		}
+	}
 
-		// End of section
-		if strings.HasPrefix(line, "[") && inDependencies {
-			inDependencies = false
-			continue
+	pyprojectPath := filepath.Join(rootPath, python.PyprojectFileName)
+	if content, err := os.ReadFile(pyprojectPath); err == nil {
+		if project, err := python.ParsePyproject(content); err == nil {
+			add(pyprojectPath, project.Dependencies)
 		}
-
-		if inDependencies {
-			// Handle TOML dependency format
-			if matches := depRegex.FindStringSubmatch(line); len(matches) >= 2 {
-				packages = append(packages, domain.Package{
-					Name:        matches[1],
-					Language:    "python",
-					ImportPath:  matches[1],
-					PackageFile: pyprojectPath,
-				})
-			} else if matches := depArrayRegex.FindStringSubmatch(line); len(matches) >= 2 {
-				packages = append(packages, domain.Package{
-					Name:        matches[1],
-					Language:    "python",
-					ImportPath:  matches[1],
-					PackageFile: pyprojectPath,
-				})
-			}
+	}
+	pipfilePath := filepath.Join(rootPath, python.PipfileName)
+	if content, err := os.ReadFile(pipfilePath); err == nil {
+		if reqs, err := python.ParsePipfile(content); err == nil {
+			add(pipfilePath, reqs)
+		}
+	}
+	setupCfgPath := filepath.Join(rootPath, python.SetupCfgFileName)
+	if content, err := os.ReadFile(setupCfgPath); err == nil {
+		add(setupCfgPath, python.ParseSetupCfg(content).Dependencies)
+	}
+	setupPyPath := filepath.Join(rootPath, python.SetupPyFileName)
+	if content, err := os.ReadFile(setupPyPath); err == nil {
+		if reqs, err := python.ParseSetupPy(content); err == nil {
+			add(setupPyPath, reqs)
 		}
 	}
 
-	return packages, scanner.Err()
+	lockPath, locked, err := python.ReadLock(rootPath)
+	if err != nil {
+		return packages
+	}
+	resolved := make(map[string]string)
+	for _, pkg := range locked {
+		key := python.NormalizeName(pkg.Name)
+		resolved[key] = pkg.Version
+		if !declared[key] && strings.HasPrefix(key, "opentelemetry") {
+			declared[key] = true
+			packages = append(packages, domain.Package{
+				Name:            pkg.Name,
+				Version:         pkg.Version,
+				Language:        "python",
+				ImportPath:      pkg.Name,
+				PackageFile:     lockPath,
+				ResolvedVersion: pkg.Version,
+				Transitive:      true,
+			})
+		}
+	}
+	for i := range packages {
+		if packages[i].ResolvedVersion == "" {
+			packages[i].ResolvedVersion = resolved[python.NormalizeName(packages[i].Name)]
+		}
+	}
+	return packages
 }
 
 // parseAllPythonImports extracts all imports from Python source files
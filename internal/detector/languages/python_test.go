@@ -1,6 +1,9 @@
 package languages
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -17,7 +20,8 @@ func TestPythonDetectorGetFilePatterns(t *testing.T) {
 	detector := NewPythonDetector()
 	patterns := detector.GetFilePatterns()
 
-	expectedPatterns := []string{"**/*.py", "requirements.txt", "pyproject.toml", "setup.py", "Pipfile"}
+	expectedPatterns := []string{"**/*.py", "requirements.txt", "pyproject.toml", "setup.py", "setup.cfg",
+		"Pipfile", "Pipfile.lock", "poetry.lock", "uv.lock"}
 
 	if !reflect.DeepEqual(patterns, expectedPatterns) {
 		t.Errorf("GetFilePatterns() = %v, want %v", patterns, expectedPatterns)
@@ -57,3 +61,65 @@ func TestPythonDetectorIsThirdPartyPackage(t *testing.T) {
 		})
 	}
 }
+
+func TestPythonDetectorPoetryLock(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"pyproject.toml": `[tool.poetry]
+name = "billing"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+opentelemetry-sdk = "^1.27.0"
+Flask = "^3.0"
+`,
+		"poetry.lock": `[[package]]
+name = "flask"
+version = "3.0.3"
+
+[[package]]
+name = "opentelemetry-api"
+version = "1.26.0"
+
+[[package]]
+name = "opentelemetry-sdk"
+version = "1.27.0"
+
+[[package]]
+name = "werkzeug"
+version = "3.0.4"
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewPythonDetector()
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pkgs) != 3 {
+		t.Fatalf("expected the 2 declared packages and the transitive opentelemetry-api, got %+v", pkgs)
+	}
+	if pkgs[0].Name != "Flask" || pkgs[0].Version != "3.0" || pkgs[0].ResolvedVersion != "3.0.3" || pkgs[0].Transitive {
+		t.Errorf("expected Flask ^3.0 resolved to 3.0.3, got %+v", pkgs[0])
+	}
+	if pkgs[2].Name != "opentelemetry-api" || pkgs[2].ResolvedVersion != "1.26.0" || !pkgs[2].Transitive || filepath.Base(pkgs[2].PackageFile) != "poetry.lock" {
+		t.Errorf("expected opentelemetry-api 1.26.0 from poetry.lock as transitive, got %+v", pkgs[2])
+	}
+
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var versions []string
+	for _, l := range libs {
+		versions = append(versions, l.Name+"=="+l.Version)
+	}
+	if want := []string{"opentelemetry-sdk==1.27.0", "opentelemetry-api==1.26.0"}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("expected locked versions %v, got %v", want, versions)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
 )
 
 // RubyDetector detects Ruby projects and OpenTelemetry usage
@@ -42,7 +44,7 @@ func (r *RubyDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([
 	}
 
 	// Scan .rb files for require statements
-	rbFiles, err := r.findRubyFiles(rootPath)
+	rbFiles, err := r.findRubyFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +78,7 @@ func (r *RubyDetector) GetAllPackages(ctx context.Context, rootPath string) ([]d
 	}
 
 	// Also glean from require statements in .rb files
-	rbFiles, err := r.findRubyFiles(rootPath)
+	rbFiles, err := r.findRubyFiles(ctx, rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -269,17 +271,13 @@ func (r *RubyDetector) parseAllRubyRequires(path string) ([]domain.Package, erro
 }
 
 // findRubyFiles recursively finds all .rb files
-func (r *RubyDetector) findRubyFiles(rootPath string) ([]string, error) {
+func (r *RubyDetector) findRubyFiles(ctx context.Context, rootPath string) ([]string, error) {
 	var files []string
-	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() {
-			switch info.Name() {
-			case ".git", "vendor", "node_modules":
-				return filepath.SkipDir
-			}
+		if d.IsDir() {
 			return nil
 		}
 		if strings.HasSuffix(path, ".rb") {
@@ -0,0 +1,427 @@
+package languages
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
+)
+
+var (
+	// cargoDependencySectionRe matches Cargo.toml dependency tables, including
+	// dev/build, target-specific and workspace tables
+	cargoDependencySectionRe = regexp.MustCompile(`^\[(?:workspace\.|target\..+\.)?(?:dev-|build-)?dependencies\]$`)
+	// cargoDependencyTableRe matches a single dependency declared as its own table, e.g. [dependencies.serde]
+	cargoDependencyTableRe = regexp.MustCompile(`^\[(?:workspace\.|target\..+\.)?(?:dev-|build-)?dependencies\.([A-Za-z0-9_-]+)\]$`)
+	// cargoEntryRe matches `name = ...` and dotted `name.version = ...` entries
+	cargoEntryRe = regexp.MustCompile(`^([A-Za-z0-9_-]+)(?:\.([A-Za-z-]+))?\s*=\s*(.+)$`)
+	// cargoInlineKeyRe extracts string keys from an inline table value
+	cargoInlineKeyRe = regexp.MustCompile(`\b(version|package)\s*=\s*"([^"]*)"`)
+
+	rustUseRe         = regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?use\s+(?:::)?([A-Za-z_][A-Za-z0-9_]*)`)
+	rustExternCrateRe = regexp.MustCompile(`^\s*extern\s+crate\s+([A-Za-z_][A-Za-z0-9_]*)`)
+	rustModRe         = regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?mod\s+([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// rustBuiltinCrates are path roots that never refer to a dependency
+var rustBuiltinCrates = map[string]bool{
+	"std": true, "core": true, "alloc": true, "proc_macro": true, "test": true,
+	"crate": true, "self": true, "super": true, "Self": true,
+}
+
+// cargoDependency is a dependency declared in Cargo.toml
+type cargoDependency struct {
+	// Name is the crate name on the registry (after applying `package = ...` renames)
+	Name    string
+	Version string
+}
+
+// RustDetector detects Rust projects and OpenTelemetry usage
+type RustDetector struct{}
+
+// NewRustDetector creates a new Rust language detector
+func NewRustDetector() *RustDetector { return &RustDetector{} }
+
+// Name returns the language name
+func (r *RustDetector) Name() string { return "rust" }
+
+// GetOTelLibraries finds OpenTelemetry crates in Rust projects
+func (r *RustDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
+	var libraries []domain.Library
+
+	manifest := filepath.Join(rootPath, "Cargo.toml")
+	if deps, err := r.parseCargoManifest(manifest); err == nil {
+		locked := r.lockedVersions(rootPath)
+		for _, dep := range deps {
+			if !isOTelCrate(dep.Name) {
+				continue
+			}
+			version := dep.Version
+			if v, ok := locked[dep.Name]; ok {
+				version = v
+			}
+			libraries = append(libraries, domain.Library{
+				Name:        dep.Name,
+				Version:     version,
+				Language:    "rust",
+				ImportPath:  crateImportName(dep.Name),
+				PackageFile: manifest,
+			})
+		}
+	}
+
+	rsFiles, err := r.findRustFiles(ctx, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range rsFiles {
+		crates, _, err := r.parseRustImports(file)
+		if err != nil {
+			continue
+		}
+		for _, c := range crates {
+			if isOTelCrate(c) {
+				libraries = append(libraries, domain.Library{
+					Name:       c,
+					Language:   "rust",
+					ImportPath: c,
+				})
+			}
+		}
+	}
+
+	return r.deduplicateLibraries(libraries), nil
+}
+
+// GetAllPackages finds all crates the Rust project depends on. Versions declared
+// in Cargo.toml are replaced with the resolved versions from Cargo.lock when present.
+func (r *RustDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	var packages []domain.Package
+
+	manifest := filepath.Join(rootPath, "Cargo.toml")
+	declared := make(map[string]string)
+	if deps, err := r.parseCargoManifest(manifest); err == nil {
+		locked := r.lockedVersions(rootPath)
+		for _, dep := range deps {
+			version := dep.Version
+			if v, ok := locked[dep.Name]; ok {
+				version = v
+			}
+			declared[crateImportName(dep.Name)] = dep.Name
+			packages = append(packages, domain.Package{
+				Name:        dep.Name,
+				Version:     version,
+				Language:    "rust",
+				ImportPath:  crateImportName(dep.Name),
+				PackageFile: manifest,
+			})
+		}
+	}
+
+	rsFiles, err := r.findRustFiles(ctx, rootPath)
+	if err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool)
+	localModules := make(map[string]bool)
+	for _, file := range rsFiles {
+		crates, mods, err := r.parseRustImports(file)
+		if err != nil {
+			continue
+		}
+		for _, c := range crates {
+			imported[c] = true
+		}
+		for _, m := range mods {
+			localModules[m] = true
+		}
+		// Files under src/ are modules of the crate too (src/config.rs, src/db/mod.rs)
+		localModules[strings.TrimSuffix(filepath.Base(file), ".rs")] = true
+		localModules[filepath.Base(filepath.Dir(file))] = true
+	}
+	for c := range imported {
+		if _, ok := declared[c]; ok || localModules[c] {
+			continue
+		}
+		packages = append(packages, domain.Package{
+			Name:       c,
+			Language:   "rust",
+			ImportPath: c,
+		})
+	}
+
+	return r.deduplicatePackages(packages), nil
+}
+
+// GetFilePatterns returns patterns for Rust files
+func (r *RustDetector) GetFilePatterns() []string {
+	return []string{"**/*.rs", "Cargo.toml", "Cargo.lock"}
+}
+
+// parseCargoManifest extracts dependencies from every dependency table in Cargo.toml
+func (r *RustDetector) parseCargoManifest(path string) ([]cargoDependency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var deps []cargoDependency
+	inSection := false
+	// tableDep is the dependency being described by a [dependencies.<name>] table
+	var tableDep *cargoDependency
+	flushTable := func() {
+		if tableDep != nil {
+			deps = append(deps, *tableDep)
+			tableDep = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripTOMLComment(strings.TrimSpace(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			flushTable()
+			inSection = cargoDependencySectionRe.MatchString(line)
+			if m := cargoDependencyTableRe.FindStringSubmatch(line); m != nil {
+				tableDep = &cargoDependency{Name: m[1]}
+			}
+			continue
+		}
+
+		m := cargoEntryRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		key, subKey, value := m[1], m[2], strings.TrimSpace(m[3])
+
+		if tableDep != nil {
+			switch key {
+			case "version":
+				tableDep.Version = strings.Trim(value, `"'`)
+			case "package":
+				tableDep.Name = strings.Trim(value, `"'`)
+			}
+			continue
+		}
+		if !inSection {
+			continue
+		}
+
+		dep := cargoDependency{Name: key}
+		switch {
+		case subKey == "version":
+			dep.Version = strings.Trim(value, `"'`)
+		case subKey != "":
+			// e.g. serde.workspace = true or serde.features = [...]
+		case strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'"):
+			dep.Version = strings.Trim(value, `"'`)
+		case strings.HasPrefix(value, "{"):
+			for _, kv := range cargoInlineKeyRe.FindAllStringSubmatch(value, -1) {
+				if kv[1] == "version" {
+					dep.Version = kv[2]
+				} else {
+					dep.Name = kv[2]
+				}
+			}
+		}
+		deps = append(deps, dep)
+	}
+	flushTable()
+
+	return deps, scanner.Err()
+}
+
+// lockedVersions returns crate versions resolved in the nearest Cargo.lock. Workspace
+// members share the lockfile at the workspace root, so parent crates are searched too.
+func (r *RustDetector) lockedVersions(rootPath string) map[string]string {
+	versions := make(map[string]string)
+	dir := rootPath
+	for {
+		lockPath := filepath.Join(dir, "Cargo.lock")
+		if _, err := os.Stat(lockPath); err == nil {
+			if pkgs, err := r.parseCargoLock(lockPath); err == nil {
+				for _, p := range pkgs {
+					// A lockfile may resolve several versions of one crate; keep the first
+					if _, ok := versions[p.Name]; !ok {
+						versions[p.Name] = p.Version
+					}
+				}
+			}
+			return versions
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return versions
+		}
+		if _, err := os.Stat(filepath.Join(parent, "Cargo.toml")); err != nil {
+			return versions
+		}
+		dir = parent
+	}
+}
+
+// parseCargoLock extracts the [[package]] entries from Cargo.lock
+func (r *RustDetector) parseCargoLock(path string) ([]cargoDependency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var pkgs []cargoDependency
+	var current *cargoDependency
+	flush := func() {
+		if current != nil && current.Name != "" {
+			pkgs = append(pkgs, *current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "[[package]]" {
+			flush()
+			current = &cargoDependency{}
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			flush()
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if m := cargoEntryRe.FindStringSubmatch(line); m != nil && m[2] == "" {
+			switch m[1] {
+			case "name":
+				current.Name = strings.Trim(m[3], `"`)
+			case "version":
+				current.Version = strings.Trim(m[3], `"`)
+			}
+		}
+	}
+	flush()
+
+	return pkgs, scanner.Err()
+}
+
+// parseRustImports returns the crates referenced by use/extern crate statements and
+// the modules declared with `mod` in a Rust source file
+func (r *RustDetector) parseRustImports(path string) ([]string, []string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	var crates, mods []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := rustUseRe.FindStringSubmatch(line); m != nil {
+			if !rustBuiltinCrates[m[1]] {
+				crates = append(crates, m[1])
+			}
+			continue
+		}
+		if m := rustExternCrateRe.FindStringSubmatch(line); m != nil {
+			if !rustBuiltinCrates[m[1]] {
+				crates = append(crates, m[1])
+			}
+			continue
+		}
+		if m := rustModRe.FindStringSubmatch(line); m != nil {
+			mods = append(mods, m[1])
+		}
+	}
+	return crates, mods, scanner.Err()
+}
+
+// findRustFiles recursively finds all .rs files, skipping Cargo's target directory
+func (r *RustDetector) findRustFiles(ctx context.Context, rootPath string) ([]string, error) {
+	var files []string
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "target" && path != rootPath {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".rs") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// deduplicateLibraries removes duplicate library entries
+func (r *RustDetector) deduplicateLibraries(libs []domain.Library) []domain.Library {
+	seen := make(map[string]bool)
+	var res []domain.Library
+	for _, l := range libs {
+		key := fmt.Sprintf("%s:%s", l.Name, l.Version)
+		if !seen[key] {
+			seen[key] = true
+			res = append(res, l)
+		}
+	}
+	return res
+}
+
+// deduplicatePackages removes duplicate package entries
+func (r *RustDetector) deduplicatePackages(pkgs []domain.Package) []domain.Package {
+	seen := make(map[string]bool)
+	var res []domain.Package
+	for _, p := range pkgs {
+		key := fmt.Sprintf("%s:%s", p.Name, p.Version)
+		if !seen[key] {
+			seen[key] = true
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// isOTelCrate reports whether a crate (by registry or import name) is part of OpenTelemetry
+func isOTelCrate(name string) bool {
+	return strings.HasPrefix(name, "opentelemetry") ||
+		strings.HasSuffix(name, "-opentelemetry") ||
+		strings.HasSuffix(name, "_opentelemetry")
+}
+
+// crateImportName returns the identifier a crate is referenced by in Rust source
+func crateImportName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// stripTOMLComment removes a trailing # comment that is not inside a string
+func stripTOMLComment(line string) string {
+	inString := false
+	for i, c := range line {
+		switch c {
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return strings.TrimSpace(line[:i])
+			}
+		}
+	}
+	return line
+}
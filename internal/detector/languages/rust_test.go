@@ -0,0 +1,107 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRustDetector_ManifestLockfileAndImports(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"Cargo.toml": `[package]
+name = "api"
+version = "0.1.0"
+
+[dependencies]
+opentelemetry = "0.27" # tracing API
+opentelemetry-otlp = { version = "0.27", features = ["grpc-tonic"] }
+tokio = { version = "1", features = ["full"] }
+json = { package = "serde_json", version = "1.0" }
+shared.workspace = true
+
+[dependencies.opentelemetry_sdk]
+version = "0.27"
+features = ["rt-tokio"]
+
+[dev-dependencies]
+pretty_assertions = "1"
+`,
+		"Cargo.lock": `version = 3
+
+[[package]]
+name = "opentelemetry"
+version = "0.27.1"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "tokio"
+version = "1.41.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+		"src/main.rs": `mod handlers;
+use std::env;
+use opentelemetry::global;
+use opentelemetry_otlp::WithExportConfig;
+use handlers::routes;
+use crate::config::Settings;
+extern crate anyhow;
+`,
+		"target/debug/build/out.rs": "use opentelemetry_ignored::Thing;\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewRustDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	libVersions := map[string]string{}
+	for _, l := range libs {
+		if l.PackageFile != "" {
+			libVersions[l.Name] = l.Version
+		}
+	}
+	if libVersions["opentelemetry"] != "0.27.1" {
+		t.Fatalf("expected locked opentelemetry version, got %v", libs)
+	}
+	if libVersions["opentelemetry-otlp"] != "0.27" || libVersions["opentelemetry_sdk"] != "0.27" {
+		t.Fatalf("expected manifest versions for unlocked crates, got %v", libs)
+	}
+	for _, l := range libs {
+		if l.Name == "opentelemetry_ignored" {
+			t.Fatalf("files under target/ should not be scanned")
+		}
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotPkgs := map[string]string{}
+	for _, p := range pkgs {
+		gotPkgs[p.Name] = p.Version
+	}
+	if gotPkgs["tokio"] != "1.41.0" {
+		t.Fatalf("expected locked tokio version, got %v", pkgs)
+	}
+	for _, want := range []string{"serde_json", "shared", "pretty_assertions", "anyhow"} {
+		if _, ok := gotPkgs[want]; !ok {
+			t.Fatalf("expected package %s, got %v", want, pkgs)
+		}
+	}
+	for _, unwanted := range []string{"json", "std", "handlers", "crate", "opentelemetry_otlp"} {
+		if _, ok := gotPkgs[unwanted]; ok {
+			t.Fatalf("did not expect package %s, got %v", unwanted, pkgs)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package languages
+
+import (
+	"context"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/npm"
+	"github.com/getlawrence/cli/internal/tsconfig"
+)
+
+// typeScriptExtensions are the source file extensions scanned for TypeScript imports
+var typeScriptExtensions = []string{".ts", ".tsx", ".mts", ".cts"}
+
+// TypeScriptDetector detects TypeScript projects and OpenTelemetry usage.
+// TypeScript shares npm packaging with JavaScript, so manifests are parsed the same way.
+type TypeScriptDetector struct {
+	js JavaScriptDetector
+}
+
+// NewTypeScriptDetector creates a new TypeScript language detector
+func NewTypeScriptDetector() *TypeScriptDetector { return &TypeScriptDetector{} }
+
+// Name returns the language name
+func (t *TypeScriptDetector) Name() string { return "typescript" }
+
+// GetOTelLibraries finds OpenTelemetry libraries in TypeScript projects
+func (t *TypeScriptDetector) GetOTelLibraries(ctx context.Context, rootPath string) ([]domain.Library, error) {
+	var libraries []domain.Library
+
+	libraries = append(libraries, t.js.manifestOTelLibraries(rootPath)...)
+
+	tsFiles, err := t.js.findSourceFiles(ctx, rootPath, typeScriptExtensions)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range tsFiles {
+		libs, err := t.js.parseJSImports(file)
+		if err == nil {
+			libraries = append(libraries, libs...)
+		}
+	}
+
+	libraries = t.js.deduplicateLibraries(libraries)
+	for i := range libraries {
+		libraries[i].Language = t.Name()
+	}
+	return libraries, nil
+}
+
+// GetFilePatterns returns patterns for TypeScript files
+func (t *TypeScriptDetector) GetFilePatterns() []string {
+	return []string{"**/*.ts", "**/*.tsx", "**/*.mts", "**/*.cts", tsconfig.FileName, npm.ManifestFileName, npm.PackageLockFileName, npm.YarnLockFileName, npm.PnpmLockFileName}
+}
+
+// GetAllPackages finds all packages/dependencies used in the project
+func (t *TypeScriptDetector) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	var packages []domain.Package
+
+	packages = append(packages, t.js.manifestPackages(rootPath)...)
+
+	tsFiles, err := t.js.findSourceFiles(ctx, rootPath, typeScriptExtensions)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range tsFiles {
+		pkgs, err := t.js.parseAllJSImports(file)
+		if err == nil {
+			packages = append(packages, pkgs...)
+		}
+	}
+
+	packages = t.js.deduplicatePackages(packages)
+	for i := range packages {
+		packages[i].Language = t.Name()
+	}
+	return packages, nil
+}
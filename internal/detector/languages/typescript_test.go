@@ -0,0 +1,67 @@
+package languages
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTypeScriptDetector_ImportsFromSources(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"package.json":       `{"dependencies": {"@opentelemetry/api": "^1.9.0", "express": "^4.19.0"}}`,
+		"src/index.ts":       "import express from 'express';\nimport type { Tracer } from \"@opentelemetry/api\";\n",
+		"src/otel.mts":       "import '@opentelemetry/sdk-node';\n",
+		"src/view.tsx":       "import React from 'react';\nimport { helper } from './helper';\n",
+		"src/legacy.cts":     "import fs = require('fs-extra');\n",
+		"types/globals.d.ts": "import '@opentelemetry/ignored';\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := NewTypeScriptDetector()
+	libs, err := d.GetOTelLibraries(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotLibs := map[string]bool{}
+	for _, l := range libs {
+		if l.Language != "typescript" {
+			t.Fatalf("expected typescript language, got %q", l.Language)
+		}
+		gotLibs[l.Name] = true
+	}
+	for _, want := range []string{"@opentelemetry/api", "@opentelemetry/sdk-node"} {
+		if !gotLibs[want] {
+			t.Fatalf("expected library %s, got %v", want, libs)
+		}
+	}
+	if gotLibs["@opentelemetry/ignored"] {
+		t.Fatalf("declaration files should not be scanned")
+	}
+
+	pkgs, err := d.GetAllPackages(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotPkgs := map[string]bool{}
+	for _, p := range pkgs {
+		gotPkgs[p.Name] = true
+	}
+	for _, want := range []string{"express", "react", "fs-extra"} {
+		if !gotPkgs[want] {
+			t.Fatalf("expected package %s, got %v", want, pkgs)
+		}
+	}
+	if gotPkgs["./helper"] {
+		t.Fatalf("relative imports should not be reported as packages")
+	}
+}
@@ -0,0 +1,309 @@
+package detector
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/getlawrence/cli/internal/cpp"
+	"github.com/getlawrence/cli/internal/dotnet"
+	"github.com/getlawrence/cli/internal/ignore"
+	"github.com/getlawrence/cli/internal/mix"
+	"github.com/getlawrence/cli/internal/python"
+)
+
+// Project is a buildable unit rooted at a manifest file such as go.mod or package.json.
+// It owns every source directory of its language below its root that is not claimed
+// by a nested project of the same language.
+type Project struct {
+	// Name is taken from the manifest (module path, package name, artifactId, ...)
+	// and falls back to the directory name
+	Name string `json:"name"`
+	// Directory is the project root relative to the analyzed root ("root" for the top level)
+	Directory string `json:"directory"`
+	// Path is the absolute project root
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	// Manifest is the manifest file name, e.g. "go.mod" or "api.csproj"
+	Manifest string `json:"manifest"`
+	// Directories lists the analyzed source directories owned by the project
+	Directories []string `json:"directories,omitempty"`
+}
+
+// manifestPriority orders manifests of the same language in one directory;
+// the first one present defines the project
+var manifestPriority = []string{
+	"go.mod",
+	"package.json",
+	"tsconfig.json",
+	"pyproject.toml",
+	"setup.py",
+	"setup.cfg",
+	"Pipfile",
+	"requirements.txt",
+	"pom.xml",
+	"build.gradle.kts",
+	"build.gradle",
+	".csproj",
+	".fsproj",
+	".vbproj",
+	"Gemfile",
+	"composer.json",
+	"Cargo.toml",
+	"mix.exs",
+	"CMakeLists.txt",
+	"vcpkg.json",
+	"conanfile.py",
+	"conanfile.txt",
+}
+
+// DetectProjects finds every manifest-defined project under rootPath, skipping paths
+// excluded by the ignore matcher. Projects are ordered by directory, then language.
+func DetectProjects(ctx context.Context, rootPath string) ([]*Project, error) {
+	type candidate struct {
+		path string
+		rank int
+	}
+	// (directory, language) -> best manifest
+	found := make(map[string]map[string]candidate)
+
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		lang := manifestLanguage(path)
+		if lang == "" {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		rank := manifestRank(d.Name())
+		if found[dir] == nil {
+			found[dir] = make(map[string]candidate)
+		}
+		if existing, ok := found[dir][lang]; !ok || rank < existing.rank || (rank == existing.rank && path < existing.path) {
+			found[dir][lang] = candidate{path: path, rank: rank}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*Project
+	for dir, byLang := range found {
+		relDir, _ := filepath.Rel(rootPath, dir)
+		if relDir == "." {
+			relDir = ""
+		}
+		for lang, c := range byLang {
+			if _, ok := byLang[supersededLanguages[lang]]; ok {
+				continue
+			}
+			projects = append(projects, &Project{
+				Name:      manifestProjectName(c.path),
+				Directory: normalizeDirectoryKey(relDir),
+				Path:      dir,
+				Language:  lang,
+				Manifest:  filepath.Base(c.path),
+			})
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Directory != projects[j].Directory {
+			return projects[i].Directory < projects[j].Directory
+		}
+		return projects[i].Language < projects[j].Language
+	})
+	return projects, nil
+}
+
+// OwningProject returns the project of the given language whose root is the nearest
+// ancestor of (or equal to) directory, or nil when no such project exists
+func OwningProject(projects []*Project, directory, language string) *Project {
+	var owner *Project
+	for _, p := range projects {
+		if !strings.EqualFold(p.Language, language) || !containsDirectory(p.Directory, directory) {
+			continue
+		}
+		if owner == nil || owner.Directory == "root" || (p.Directory != "root" && len(p.Directory) > len(owner.Directory)) {
+			owner = p
+		}
+	}
+	return owner
+}
+
+// WithProjectScope returns a context whose ignore matcher also skips the roots of nested
+// projects of the same language, so walks over p only see files p owns
+func WithProjectScope(ctx context.Context, projects []*Project, p *Project) context.Context {
+	var nested []string
+	for _, other := range nestedProjects(projects, p) {
+		nested = append(nested, other.Path)
+	}
+	if len(nested) == 0 {
+		return ctx
+	}
+	matcher := ignore.FromContext(ctx, p.Path)
+	return ignore.WithMatcher(ctx, matcher.ExcludePaths(nested...))
+}
+
+// nestedProjects returns the projects of the same language rooted strictly below p
+func nestedProjects(projects []*Project, p *Project) []*Project {
+	var nested []*Project
+	for _, other := range projects {
+		if other != p && other.Directory != p.Directory &&
+			strings.EqualFold(other.Language, p.Language) && containsDirectory(p.Directory, other.Directory) {
+			nested = append(nested, other)
+		}
+	}
+	return nested
+}
+
+// containsDirectory reports whether the relative directory dir is parent or below it
+func containsDirectory(parent, dir string) bool {
+	if parent == "root" || parent == dir {
+		return true
+	}
+	return dir != "root" && strings.HasPrefix(dir, parent+string(filepath.Separator))
+}
+
+// manifestRank returns the priority of a manifest file name (lower wins)
+func manifestRank(name string) int {
+	for i, m := range manifestPriority {
+		if name == m || (strings.HasPrefix(m, ".") && strings.EqualFold(filepath.Ext(name), m)) {
+			return i
+		}
+	}
+	return len(manifestPriority)
+}
+
+var (
+	goModuleRe        = regexp.MustCompile(`^module\s+"?([^\s"]+)"?`)
+	setupPyNameRe     = regexp.MustCompile(`name\s*=\s*["']([^"']+)["']`)
+	gradleRootRe      = regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`)
+	gemspecNameRe     = regexp.MustCompile(`\.name\s*=\s*["']([^"']+)["']`)
+	assemblyNameRe    = regexp.MustCompile(`<AssemblyName>\s*([^<\s]+)\s*</AssemblyName>`)
+	jsonNameManifests = map[string]bool{"package.json": true, "composer.json": true, "vcpkg.json": true}
+)
+
+// manifestProjectName reads the project name from a manifest, falling back to the directory name
+func manifestProjectName(manifestPath string) string {
+	dir := filepath.Dir(manifestPath)
+	fallback := filepath.Base(dir)
+	name := filepath.Base(manifestPath)
+
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fallback
+	}
+
+	var found string
+	switch {
+	case name == "go.mod":
+		found = firstLineMatch(content, goModuleRe)
+	case jsonNameManifests[name]:
+		var pkg struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(content, &pkg) == nil {
+			found = pkg.Name
+		}
+	case name == "tsconfig.json":
+		// TypeScript projects are named by their package.json
+		found = manifestProjectName(filepath.Join(dir, "package.json"))
+		if found == fallback {
+			found = ""
+		}
+	case name == "pyproject.toml":
+		found = tomlTableName(content, "project", "tool.poetry")
+	case name == "Cargo.toml":
+		found = tomlTableName(content, "package")
+	case name == mix.FileName:
+		found = mix.AppName(content)
+	case name == cpp.CMakeFileName:
+		found = cpp.ProjectName(content)
+	case name == python.SetupCfgFileName:
+		found = python.ParseSetupCfg(content).Name
+	case name == "setup.py" || name == cpp.ConanfilePy:
+		if m := setupPyNameRe.FindSubmatch(content); m != nil {
+			found = string(m[1])
+		}
+	case name == "pom.xml":
+		var pom struct {
+			ArtifactID string `xml:"artifactId"`
+		}
+		if xml.Unmarshal(content, &pom) == nil {
+			found = strings.TrimSpace(pom.ArtifactID)
+		}
+	case name == "build.gradle" || name == "build.gradle.kts":
+		for _, settings := range []string{"settings.gradle.kts", "settings.gradle"} {
+			if data, err := os.ReadFile(filepath.Join(dir, settings)); err == nil {
+				if m := gradleRootRe.FindSubmatch(data); m != nil {
+					found = string(m[1])
+					break
+				}
+			}
+		}
+	case dotnet.IsProjectFile(name):
+		found = strings.TrimSuffix(name, filepath.Ext(name))
+		if m := assemblyNameRe.FindSubmatch(content); m != nil {
+			found = string(m[1])
+		}
+	case name == "Gemfile":
+		if specs, _ := filepath.Glob(filepath.Join(dir, "*.gemspec")); len(specs) > 0 {
+			if data, err := os.ReadFile(specs[0]); err == nil {
+				if m := gemspecNameRe.FindSubmatch(data); m != nil {
+					found = string(m[1])
+				}
+			}
+		}
+	}
+
+	if found == "" {
+		return fallback
+	}
+	return found
+}
+
+// tomlTableName returns the name declared in the first of the given tables of a TOML
+// manifest that has one; nested tables are given as dotted paths, e.g. tool.poetry
+func tomlTableName(content []byte, tables ...string) string {
+	var manifest map[string]any
+	if _, err := toml.Decode(string(content), &manifest); err != nil {
+		return ""
+	}
+	for _, table := range tables {
+		value := any(manifest)
+		for _, key := range strings.Split(table, ".") {
+			parent, _ := value.(map[string]any)
+			value = parent[key]
+		}
+		if t, ok := value.(map[string]any); ok {
+			if name, ok := t["name"].(string); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// firstLineMatch returns the first capture group of re on any line of content
+func firstLineMatch(content []byte, re *regexp.Regexp) string {
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		if m := re.FindStringSubmatch(strings.TrimSpace(scanner.Text())); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package detector
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/ignore"
+	"github.com/getlawrence/cli/internal/logger"
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func TestDetectProjects_NamesFromManifests(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"go.mod":                      "module github.com/acme/platform\n\ngo 1.23\n",
+		"web/package.json":            `{"name": "@acme/web"}`,
+		"web/tsconfig.json":           `{"compilerOptions": {}}`,
+		"ml/pyproject.toml":           "[build-system]\nrequires = []\n\n[project]\nname = \"acme-ml\"\n",
+		"tools/pyproject.toml":        "[tool.poetry]\ndescription = \"\"\"\n[project]\nname = \"not-the-name\"\n\"\"\"\nname = \"acme-tools\"\n",
+		"billing/pom.xml":             "<project><parent><artifactId>parent</artifactId></parent><artifactId>billing</artifactId></project>",
+		"api/Api.csproj":              "<Project></Project>",
+		"php/composer.json":           `{"name": "acme/shop"}`,
+		"engine/Cargo.toml":           "[workspace]\n\n[package]\nname = \"acme-engine\"\nversion = \"0.1.0\"\n",
+		"scripts/requirements.txt":    "requests\n",
+		"orders/build.gradle.kts":     "plugins {\n    kotlin(\"jvm\") version \"2.0.21\"\n}\n",
+		"orders/settings.gradle.kts":  "rootProject.name = \"acme-orders\"\n",
+		"payments/mix.exs":            "defmodule Payments.MixProject do\n  def project, do: [app: :payments, deps: []]\nend\n",
+		"checkout/CMakeLists.txt":     "cmake_minimum_required(VERSION 3.20)\nproject(acme-checkout LANGUAGES CXX)\n",
+		"checkout/vcpkg.json":         `{"name": "checkout", "dependencies": []}`,
+		"firmware/CMakeLists.txt":     "project(firmware LANGUAGES C)\n",
+		"inventory/setup.cfg":         "[metadata]\nname = acme-inventory\n\n[options]\ninstall_requires =\n    requests\n",
+		"pricing/Pricing.fsproj":      "<Project><PropertyGroup><AssemblyName>Acme.Pricing</AssemblyName></PropertyGroup></Project>",
+		"node_modules/x/package.json": `{"name": "ignored"}`,
+	})
+
+	projects, err := DetectProjects(context.Background(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"root":      "github.com/acme/platform",
+		"web":       "@acme/web",
+		"ml":        "acme-ml",
+		"tools":     "acme-tools",
+		"billing":   "billing",
+		"api":       "Api",
+		"php":       "acme/shop",
+		"engine":    "acme-engine",
+		"scripts":   "scripts",
+		"orders":    "acme-orders",
+		"payments":  "payments",
+		"checkout":  "acme-checkout",
+		"inventory": "acme-inventory",
+		"pricing":   "Acme.Pricing",
+	}
+	if len(projects) != len(want) {
+		t.Fatalf("expected %d projects, got %d: %+v", len(want), len(projects), projects)
+	}
+	for _, p := range projects {
+		if want[p.Directory] != p.Name {
+			t.Fatalf("project in %s: got name %q, want %q", p.Directory, p.Name, want[p.Directory])
+		}
+		if p.Directory == "web" && (p.Language != "TypeScript" || p.Manifest != "tsconfig.json") {
+			t.Fatalf("expected web to be a TypeScript project, got %+v", p)
+		}
+		if p.Directory == "orders" && (p.Language != "Kotlin" || p.Manifest != "build.gradle.kts") {
+			t.Fatalf("expected orders to be a Kotlin project, got %+v", p)
+		}
+		if p.Directory == "checkout" && (p.Language != "cpp" || p.Manifest != "CMakeLists.txt") {
+			t.Fatalf("expected checkout to be a C++ CMake project, got %+v", p)
+		}
+	}
+}
+
+func TestOwningProject_NearestAncestorOfSameLanguage(t *testing.T) {
+	projects := []*Project{
+		{Directory: "root", Language: "Go"},
+		{Directory: "services", Language: "JavaScript"},
+		{Directory: filepath.Join("services", "auth"), Language: "Go"},
+	}
+	if p := OwningProject(projects, filepath.Join("services", "auth", "handlers"), "Go"); p != projects[2] {
+		t.Fatalf("expected nested go project, got %+v", p)
+	}
+	if p := OwningProject(projects, filepath.Join("services", "web"), "go"); p != projects[0] {
+		t.Fatalf("expected root go project, got %+v", p)
+	}
+	if p := OwningProject(projects, "tools", "Python"); p != nil {
+		t.Fatalf("expected no python project, got %+v", p)
+	}
+}
+
+// fileCountLanguage reports one package per .go file it sees so tests can check walk scope
+type fileCountLanguage struct{ fakeLanguage }
+
+func (f *fileCountLanguage) GetAllPackages(ctx context.Context, rootPath string) ([]domain.Package, error) {
+	var pkgs []domain.Package
+	err := ignore.FromContext(ctx, rootPath).Walk(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		rel, _ := filepath.Rel(rootPath, path)
+		pkgs = append(pkgs, domain.Package{Name: filepath.ToSlash(rel)})
+		return nil
+	})
+	return pkgs, err
+}
+
+func TestAnalyzeCodebase_GroupsDirectoriesByProject(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"go.mod":            "module example.com/app\n",
+		"main.go":           "package main\n",
+		"src/handlers/h.go": "package handlers\n",
+		"src/models/m.go":   "package models\n",
+		"tools/gen/go.mod":  "module example.com/gen\n",
+		"tools/gen/main.go": "package main\n",
+	})
+
+	ca := NewCodebaseAnalyzer(nil, map[string]Language{"go": &fileCountLanguage{}}, &logger.StdoutLogger{})
+	analysis, err := ca.AnalyzeCodebase(context.Background(), root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(analysis.DirectoryAnalyses) != 2 {
+		t.Fatalf("expected 2 project analyses, got %v", analysis.DirectoryAnalyses)
+	}
+
+	app := analysis.DirectoryAnalyses[AnalysisKey("root", "Go")]
+	if app == nil || app.Project == nil || app.Project.Name != "example.com/app" {
+		t.Fatalf("expected root project analysis, got %+v", app)
+	}
+	if len(app.Project.Directories) != 3 {
+		t.Fatalf("expected root project to own 3 directories, got %v", app.Project.Directories)
+	}
+	for _, pkg := range app.Packages {
+		if filepath.Dir(pkg.Name) == "tools/gen" {
+			t.Fatalf("nested project files leaked into the root project: %v", app.Packages)
+		}
+	}
+
+	gen := analysis.DirectoryAnalyses[AnalysisKey(filepath.Join("tools", "gen"), "Go")]
+	if gen == nil || gen.Project == nil || gen.Project.Name != "example.com/gen" {
+		t.Fatalf("expected nested project analysis, got %+v", gen)
+	}
+}
@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/semver"
+)
+
+// RuleDetector adapts a declarative Rule to the IssueDetector interface
+type RuleDetector struct {
+	rule Rule
+}
+
+// NewRuleDetector creates a detector for a validated rule
+func NewRuleDetector(rule Rule) *RuleDetector {
+	return &RuleDetector{rule: rule}
+}
+
+// ID returns the detector identifier
+func (d *RuleDetector) ID() string {
+	return "rule:" + d.rule.ID
+}
+
+// Name returns the detector name
+func (d *RuleDetector) Name() string {
+	return d.rule.Name
+}
+
+// Description returns what this detector looks for
+func (d *RuleDetector) Description() string {
+	if d.rule.Description != "" {
+		return d.rule.Description
+	}
+	return fmt.Sprintf("Custom rule %s", d.rule.ID)
+}
+
+// Category returns the issue category
+func (d *RuleDetector) Category() domain.Category {
+	return d.rule.Category
+}
+
+// Languages returns which languages this rule applies to
+func (d *RuleDetector) Languages() []string {
+	return d.rule.Languages
+}
+
+// candidate is a dependency-like item a matcher can select
+type candidate struct {
+	name    string
+	version string
+	file    string
+}
+
+// Detect evaluates the rule against a directory
+func (d *RuleDetector) Detect(ctx context.Context, directory *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	for _, m := range d.rule.When {
+		if len(matchCandidates(m, directory)) == 0 {
+			return nil, nil
+		}
+	}
+
+	var issues []domain.Issue
+	for _, m := range d.rule.Require {
+		if len(matchCandidates(m, directory)) > 0 {
+			continue
+		}
+		issue := d.newIssue(directory, "require:"+m.describe())
+		issue.Title = fmt.Sprintf("%s: requires %s", d.rule.Name, m.describe())
+		issue.Description = d.describeIssue(fmt.Sprintf("No %s was found in this directory.", m.describe()))
+		if found := nameMatches(m, directory); len(found) > 0 && m.constraint != nil {
+			issue.Description = d.describeIssue(fmt.Sprintf("Found %s, which does not satisfy %s.", strings.Join(found, ", "), m.Version))
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, m := range d.rule.Forbid {
+		// A library is often found both in the manifest and in imports; keep one finding
+		// per name and prefer the manifest, which has its version, as its location
+		var forbidden []candidate
+		index := make(map[string]int)
+		for _, c := range matchCandidates(m, directory) {
+			if i, ok := index[c.name]; ok {
+				if forbidden[i].file == "" {
+					forbidden[i] = c
+				}
+				continue
+			}
+			index[c.name] = len(forbidden)
+			forbidden = append(forbidden, c)
+		}
+		for _, c := range forbidden {
+			label := c.name
+			if c.version != "" {
+				label = fmt.Sprintf("%s@%s", c.name, c.version)
+			}
+			issue := d.newIssue(directory, "forbid:"+c.name)
+			issue.Title = fmt.Sprintf("%s: forbidden %s", d.rule.Name, label)
+			issue.Description = d.describeIssue(fmt.Sprintf("%s matches the forbidden %s.", label, m.describe()))
+			issue.File = c.file
+			issues = append(issues, issue)
+		}
+	}
+	return issues, nil
+}
+
+// newIssue creates an issue prefilled with the rule's metadata
+func (d *RuleDetector) newIssue(directory *detector.DirectoryAnalysis, suffix string) domain.Issue {
+	return domain.Issue{
+		ID:         fmt.Sprintf("%s_%s", d.ID(), suffix),
+		Severity:   d.rule.Severity,
+		Category:   d.rule.Category,
+		Language:   directory.Language,
+		Suggestion: d.rule.Suggestion,
+		References: d.rule.References,
+	}
+}
+
+// describeIssue prefixes the rule description to a finding-specific detail
+func (d *RuleDetector) describeIssue(detail string) string {
+	if d.rule.Description == "" {
+		return detail
+	}
+	return d.rule.Description + "\n\n" + detail
+}
+
+// candidatesFor returns the directory items a matcher selects from. Packages are
+// matched at the version the lockfile resolved them to, when known.
+func candidatesFor(m Matcher, directory *detector.DirectoryAnalysis) []candidate {
+	var out []candidate
+	if m.Library != "" || m.Dependency != "" {
+		for _, lib := range directory.Libraries {
+			out = append(out, candidate{name: lib.Name, version: lib.Version, file: lib.PackageFile})
+		}
+	}
+	if m.Package != "" || m.Dependency != "" {
+		for _, pkg := range directory.Packages {
+			out = append(out, candidate{name: pkg.Name, version: packageVersion(pkg), file: pkg.PackageFile})
+		}
+	}
+	if m.Instrumentation != "" {
+		for _, inst := range directory.AvailableInstrumentations {
+			out = append(out, candidate{name: inst.Package.Name, version: packageVersion(inst.Package), file: inst.Package.PackageFile})
+		}
+	}
+	return out
+}
+
+func packageVersion(pkg domain.Package) string {
+	if pkg.ResolvedVersion != "" {
+		return pkg.ResolvedVersion
+	}
+	return pkg.Version
+}
+
+// matchCandidates returns the unique items whose name and version satisfy the matcher
+func matchCandidates(m Matcher, directory *detector.DirectoryAnalysis) []candidate {
+	seen := make(map[string]bool)
+	var out []candidate
+	for _, c := range candidatesFor(m, directory) {
+		if !m.pattern.MatchString(c.name) {
+			continue
+		}
+		if m.constraint != nil {
+			v, err := semver.Parse(c.version)
+			if err != nil || !m.constraint.Check(v) {
+				continue
+			}
+		}
+		key := c.name + "@" + c.version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, c)
+	}
+	return out
+}
+
+// nameMatches returns "name@version" labels for items matching the pattern regardless of version
+func nameMatches(m Matcher, directory *detector.DirectoryAnalysis) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, c := range candidatesFor(m, directory) {
+		if !m.pattern.MatchString(c.name) {
+			continue
+		}
+		label := c.name
+		if c.version != "" {
+			label += "@" + c.version
+		}
+		if !seen[label] {
+			seen[label] = true
+			out = append(out, label)
+		}
+	}
+	return out
+}
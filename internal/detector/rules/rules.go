@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/domain"
+	"github.com/getlawrence/cli/internal/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// File is the top-level structure of a rules YAML file
+type File struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a declarative policy evaluated against each DirectoryAnalysis.
+//
+// A rule applies to a directory when its language matches (empty = all) and every
+// When matcher matches. It then emits one issue for each Require matcher that is
+// not satisfied and one issue for each dependency matched by a Forbid matcher.
+type Rule struct {
+	ID          string          `yaml:"id"`
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Severity    domain.Severity `yaml:"severity"`
+	Category    domain.Category `yaml:"category"`
+	Languages   []string        `yaml:"languages"`
+	Suggestion  string          `yaml:"suggestion"`
+	References  []string        `yaml:"references"`
+	When        []Matcher       `yaml:"when"`
+	Require     []Matcher       `yaml:"require"`
+	Forbid      []Matcher       `yaml:"forbid"`
+}
+
+// Matcher selects libraries, packages or instrumentations by glob pattern and
+// optional version constraint. Exactly one of the selector fields must be set.
+type Matcher struct {
+	// Library matches OpenTelemetry libraries
+	Library string `yaml:"library"`
+	// Package matches any declared package
+	Package string `yaml:"package"`
+	// Dependency matches either libraries or packages
+	Dependency string `yaml:"dependency"`
+	// Instrumentation matches packages with available instrumentation
+	Instrumentation string `yaml:"instrumentation"`
+	// Version is a constraint such as ">= 1.30, < 2"
+	Version string `yaml:"version"`
+
+	pattern    *regexp.Regexp
+	constraint *semver.Constraint
+}
+
+// LoadDir loads every *.yaml / *.yml file in dir and returns a detector per rule
+func LoadDir(dir string) ([]*RuleDetector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	var detectors []*RuleDetector
+	seen := make(map[string]string)
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules file %s: %w", path, err)
+		}
+		fileDetectors, err := Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, d := range fileDetectors {
+			if prev, ok := seen[d.ID()]; ok {
+				return nil, fmt.Errorf("duplicate rule id %q in %s (first defined in %s)", d.ID(), path, prev)
+			}
+			seen[d.ID()] = path
+			detectors = append(detectors, d)
+		}
+	}
+	return detectors, nil
+}
+
+// Parse parses rules YAML content and returns a validated detector per rule
+func Parse(content []byte) ([]*RuleDetector, error) {
+	var f File
+	if err := yaml.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse rules YAML: %w", err)
+	}
+	detectors := make([]*RuleDetector, 0, len(f.Rules))
+	for i := range f.Rules {
+		rule := f.Rules[i]
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+		detectors = append(detectors, NewRuleDetector(rule))
+	}
+	return detectors, nil
+}
+
+// validate checks required fields, applies defaults and compiles version constraints
+func (r *Rule) validate() error {
+	if strings.TrimSpace(r.ID) == "" {
+		return fmt.Errorf("rule is missing an id")
+	}
+	if len(r.Require) == 0 && len(r.Forbid) == 0 {
+		return fmt.Errorf("rule %s must define require or forbid matchers", r.ID)
+	}
+
+	if r.Severity == "" {
+		r.Severity = domain.SeverityWarning
+	}
+	switch r.Severity {
+	case domain.SeverityError, domain.SeverityWarning, domain.SeverityInfo:
+	default:
+		return fmt.Errorf("rule %s has invalid severity %q", r.ID, r.Severity)
+	}
+	if r.Category == "" {
+		r.Category = domain.CategoryBestPractice
+	}
+	if r.Name == "" {
+		r.Name = r.ID
+	}
+
+	for _, group := range [][]Matcher{r.When, r.Require, r.Forbid} {
+		for i := range group {
+			if err := group[i].compile(); err != nil {
+				return fmt.Errorf("rule %s: %w", r.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// compile validates the matcher and parses its version constraint
+func (m *Matcher) compile() error {
+	set := 0
+	for _, v := range []string{m.Library, m.Package, m.Dependency, m.Instrumentation} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("matcher must set exactly one of library, package, dependency or instrumentation")
+	}
+	m.pattern = globToRegexp(m.Library + m.Package + m.Dependency + m.Instrumentation)
+	if m.Version != "" {
+		c, err := semver.ParseConstraint(m.Version)
+		if err != nil {
+			return err
+		}
+		m.constraint = &c
+	}
+	return nil
+}
+
+// globToRegexp converts a glob where * matches any run of characters (including "/")
+// and ? matches one character into a case-insensitive anchored regexp
+func globToRegexp(glob string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(glob)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("(?i)^" + quoted + "$")
+}
+
+// describe returns a short human-readable form of the matcher
+func (m Matcher) describe() string {
+	var kind, pattern string
+	switch {
+	case m.Library != "":
+		kind, pattern = "library", m.Library
+	case m.Package != "":
+		kind, pattern = "package", m.Package
+	case m.Dependency != "":
+		kind, pattern = "dependency", m.Dependency
+	default:
+		kind, pattern = "instrumentation", m.Instrumentation
+	}
+	if m.Version != "" {
+		return fmt.Sprintf("%s %s %s", kind, pattern, m.Version)
+	}
+	return fmt.Sprintf("%s %s", kind, pattern)
+}
@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+const sampleRules = `
+rules:
+  - id: go-sdk-version
+    name: OTel SDK must be recent
+    severity: error
+    category: best_practice
+    languages: [go]
+    require:
+      - dependency: go.opentelemetry.io/otel/sdk
+        version: ">= 1.30"
+  - id: no-jaeger
+    name: No Jaeger exporter
+    forbid:
+      - dependency: "*jaeger*"
+  - id: python-distro
+    languages: [python]
+    when:
+      - package: flask
+    require:
+      - package: opentelemetry-distro
+`
+
+func TestParse_DefaultsAndValidation(t *testing.T) {
+	detectors, err := Parse([]byte(sampleRules))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(detectors) != 3 {
+		t.Fatalf("expected 3 detectors, got %d", len(detectors))
+	}
+	if detectors[1].rule.Severity != domain.SeverityWarning || detectors[1].Category() != domain.CategoryBestPractice {
+		t.Fatalf("expected defaults to be applied, got %+v", detectors[1].rule)
+	}
+	if detectors[2].Name() != "python-distro" {
+		t.Fatalf("expected name to default to id, got %q", detectors[2].Name())
+	}
+
+	invalid := []string{
+		"rules: [{id: x}]",
+		"rules: [{id: x, require: [{package: a, library: b}]}]",
+		"rules: [{id: x, severity: fatal, forbid: [{package: a}]}]",
+		"rules: [{id: x, forbid: [{package: a, version: 'latest'}]}]",
+	}
+	for _, content := range invalid {
+		if _, err := Parse([]byte(content)); err == nil {
+			t.Fatalf("expected error for %q", content)
+		}
+	}
+}
+
+func TestRuleDetector_RequireVersion(t *testing.T) {
+	detectors, _ := Parse([]byte(sampleRules))
+	sdkRule := detectors[0]
+
+	old := &detector.DirectoryAnalysis{Language: "Go", Packages: []domain.Package{{Name: "go.opentelemetry.io/otel/sdk", Version: "v1.28.0"}}}
+	issues, err := sdkRule.Detect(context.Background(), old)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Severity != domain.SeverityError {
+		t.Fatalf("expected one error issue for old SDK, got %+v", issues)
+	}
+
+	recent := &detector.DirectoryAnalysis{Language: "Go", Libraries: []domain.Library{{Name: "go.opentelemetry.io/otel/sdk", Version: "v1.31.0"}}}
+	if issues, _ := sdkRule.Detect(context.Background(), recent); len(issues) != 0 {
+		t.Fatalf("expected no issues for recent SDK, got %+v", issues)
+	}
+}
+
+func TestRuleDetector_ForbidAndWhen(t *testing.T) {
+	detectors, _ := Parse([]byte(sampleRules))
+
+	dir := &detector.DirectoryAnalysis{Language: "Go", Libraries: []domain.Library{
+		{Name: "go.opentelemetry.io/otel/exporters/jaeger", Version: "v1.17.0", PackageFile: "/repo/go.mod"},
+	}}
+	issues, _ := detectors[1].Detect(context.Background(), dir)
+	if len(issues) != 1 || issues[0].File != "/repo/go.mod" {
+		t.Fatalf("expected one forbidden-dependency issue, got %+v", issues)
+	}
+
+	noFlask := &detector.DirectoryAnalysis{Language: "Python", Packages: []domain.Package{{Name: "django"}}}
+	if issues, _ := detectors[2].Detect(context.Background(), noFlask); len(issues) != 0 {
+		t.Fatalf("expected rule to be skipped when precondition fails, got %+v", issues)
+	}
+	flask := &detector.DirectoryAnalysis{Language: "Python", Packages: []domain.Package{{Name: "Flask"}}}
+	if issues, _ := detectors[2].Detect(context.Background(), flask); len(issues) != 1 {
+		t.Fatalf("expected missing distro issue, got %+v", issues)
+	}
+}
+
+func TestRuleDetector_ForbidOncePerLibrary(t *testing.T) {
+	detectors, _ := Parse([]byte(sampleRules))
+
+	// The exporter is found in an import first, then in the manifest
+	dir := &detector.DirectoryAnalysis{Language: "Go", Libraries: []domain.Library{
+		{Name: "go.opentelemetry.io/otel/exporters/jaeger"},
+		{Name: "go.opentelemetry.io/otel/exporters/jaeger", Version: "v1.17.0", PackageFile: "/repo/go.mod"},
+	}}
+	issues, _ := detectors[1].Detect(context.Background(), dir)
+	if len(issues) != 1 || issues[0].File != "/repo/go.mod" || issues[0].Title != "No Jaeger exporter: forbidden go.opentelemetry.io/otel/exporters/jaeger@v1.17.0" {
+		t.Fatalf("expected one forbidden-dependency issue at the manifest, got %+v", issues)
+	}
+}
+
+func TestRuleDetector_RequireResolvedVersion(t *testing.T) {
+	detectors, _ := Parse([]byte(sampleRules))
+	sdkRule := detectors[0]
+
+	// The manifest allows an old release, but the lockfile resolved a recent one
+	dir := &detector.DirectoryAnalysis{Language: "Go", Packages: []domain.Package{
+		{Name: "go.opentelemetry.io/otel/sdk", Version: "v1.20.0", ResolvedVersion: "v1.31.0"},
+	}}
+	if issues, _ := sdkRule.Detect(context.Background(), dir); len(issues) != 0 {
+		t.Fatalf("expected the resolved version to satisfy the rule, got %+v", issues)
+	}
+}
+
+func TestLoadDir_RejectsDuplicateIDs(t *testing.T) {
+	dir := t.TempDir()
+	rule := []byte("rules: [{id: dup, forbid: [{package: a}]}]")
+	for _, name := range []string{"a.yaml", "b.yml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), rule, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := LoadDir(dir); err == nil {
+		t.Fatalf("expected duplicate id error")
+	}
+}
@@ -13,6 +13,12 @@ type Issue struct {
 	Column      int      `json:"column,omitempty"`
 	Suggestion  string   `json:"suggestion,omitempty"`
 	References  []string `json:"references,omitempty"`
+	DetectorID  string   `json:"detector_id,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+	// Suppression details are set when the issue is hidden by a baseline or suppression rule
+	Suppressed        bool   `json:"suppressed,omitempty"`
+	SuppressedBy      string `json:"suppressed_by,omitempty"`
+	SuppressionReason string `json:"suppression_reason,omitempty"`
 }
 
 // Severity levels for issues
@@ -44,6 +50,8 @@ const (
 	OpportunityInstallOTEL      OpportunityType = "install_otel"
 	OpportunityInstallComponent OpportunityType = "install_component"
 	OpportunityRemoveComponent  OpportunityType = "remove_component"
+	// OpportunityAddCleanup shuts down an existing provider on exit; Issue locates it
+	OpportunityAddCleanup OpportunityType = "add_cleanup"
 )
 
 // ComponentType represents different types of OTEL components
@@ -16,6 +16,11 @@ type Package struct {
 	Language    string `json:"language"`
 	ImportPath  string `json:"import_path,omitempty"`
 	PackageFile string `json:"package_file,omitempty"`
+	// ResolvedVersion is the exact version installed according to the lockfile, when
+	// Version is a range
+	ResolvedVersion string `json:"resolved_version,omitempty"`
+	// Transitive is set for packages only installed as a dependency of another package
+	Transitive bool `json:"transitive,omitempty"`
 }
 
 // InstrumentationInfo represents available instrumentation for a package
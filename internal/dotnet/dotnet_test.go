@@ -0,0 +1,160 @@
+package dotnet
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func TestParseSolution(t *testing.T) {
+	got := ParseSolution([]byte(`Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "src", "src", "{11111111-1111-1111-1111-111111111111}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api", "src\Api\Api.csproj", "{22222222-2222-2222-2222-222222222222}"
+EndProject
+Project("{F2A71F9B-5D33-465A-A702-920D77279786}") = "Pricing", "src\Pricing\Pricing.fsproj", "{33333333-3333-3333-3333-333333333333}"
+EndProject
+`))
+	want := []string{filepath.Join("src", "Api", "Api.csproj"), filepath.Join("src", "Pricing", "Pricing.fsproj")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLoadProject(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		BuildPropsFileName: `<Project>
+  <PropertyGroup>
+    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>
+    <OTelVersion>1.9.0</OTelVersion>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="StyleCop.Analyzers" />
+  </ItemGroup>
+</Project>`,
+		PackagesPropsFileName: `<Project>
+  <ItemGroup>
+    <PackageVersion Include="OpenTelemetry" Version="$(OTelVersion)" />
+    <PackageVersion Include="OpenTelemetry.Extensions.Hosting" Version="$(OTelVersion)" />
+    <PackageVersion Include="StyleCop.Analyzers" Version="1.1.118" />
+    <GlobalPackageReference Include="Nerdbank.GitVersioning" Version="3.6.143" />
+  </ItemGroup>
+</Project>`,
+		"src/Api/Api.csproj": `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="OpenTelemetry" />
+    <PackageReference Include="OpenTelemetry.Extensions.Hosting" VersionOverride="1.8.1" />
+    <PackageReference Include="Serilog">
+      <Version>3.1.1</Version>
+    </PackageReference>
+  </ItemGroup>
+</Project>`,
+	})
+
+	api := filepath.Join(root, "src", "Api", "Api.csproj")
+	project, err := LoadProject(api)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !project.CentralPackageManagement || project.PackagesProps != filepath.Join(root, PackagesPropsFileName) {
+		t.Fatalf("expected central package management from %s, got %+v", PackagesPropsFileName, project)
+	}
+	props := filepath.Join(root, PackagesPropsFileName)
+	want := []Package{
+		{Name: "OpenTelemetry", Version: "1.9.0", File: props},
+		{Name: "OpenTelemetry.Extensions.Hosting", Version: "1.8.1", File: api},
+		{Name: "Serilog", Version: "3.1.1", File: api},
+		{Name: "StyleCop.Analyzers", Version: "1.1.118", File: props},
+		{Name: "Nerdbank.GitVersioning", Version: "3.6.143", File: props},
+	}
+	if !reflect.DeepEqual(project.Packages, want) {
+		t.Errorf("got %+v, want %+v", project.Packages, want)
+	}
+}
+
+func TestLoadProjectPackagesConfig(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"Legacy.vbproj": `<?xml version="1.0" encoding="utf-8"?>
+<Project ToolsVersion="15.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+  </PropertyGroup>
+</Project>`,
+		PackagesConfigFileName: `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="OpenTelemetry" version="1.9.0" targetFramework="net48" />
+</packages>`,
+	})
+
+	project, err := LoadProject(filepath.Join(root, "Legacy.vbproj"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Package{{Name: "OpenTelemetry", Version: "1.9.0", File: filepath.Join(root, PackagesConfigFileName)}}
+	if project.CentralPackageManagement || !reflect.DeepEqual(project.Packages, want) {
+		t.Errorf("got %+v, want %+v without central package management", project, want)
+	}
+}
+
+func TestFindProjectFile(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"Shop.sln": `Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Core", "src\Core\Core.csproj", "{1}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "Api", "src\Api\Api.csproj", "{2}"
+EndProject
+`,
+		"src/Core/Core.csproj": `<Project Sdk="Microsoft.NET.Sdk"></Project>`,
+		"src/Api/Api.csproj":   `<Project Sdk="Microsoft.NET.Sdk.Web"></Project>`,
+	})
+
+	got, err := FindProjectFile(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(root, "src", "Api", "Api.csproj"); got != want {
+		t.Errorf("expected the solution's web project %s, got %s", want, got)
+	}
+	if _, err := FindProjectFile(filepath.Join(root, "src")); err == nil {
+		t.Error("expected an error for a directory without project or solution")
+	}
+}
+
+func TestAddPackageItems(t *testing.T) {
+	project := `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="Serilog">
+      <Version>3.1.1</Version>
+    </PackageReference>
+  </ItemGroup>
+</Project>`
+	got, added, err := AddPackageReferences([]byte(project), []PackageReference{{Name: "OpenTelemetry"}, {Name: "serilog"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="Serilog">
+      <Version>3.1.1</Version>
+    </PackageReference>
+    <PackageReference Include="OpenTelemetry" />
+  </ItemGroup>
+</Project>`
+	if string(got) != want || !reflect.DeepEqual(added, []string{"OpenTelemetry"}) {
+		t.Errorf("got %v:\n%s\nwant:\n%s", added, got, want)
+	}
+
+	props := "<Project>\n  <PropertyGroup>\n    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>\n  </PropertyGroup>\n</Project>\n"
+	got, _, err = AddPackageVersions([]byte(props), []PackageReference{{Name: "OpenTelemetry", Version: "1.9.0"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = "<Project>\n  <PropertyGroup>\n    <ManagePackageVersionsCentrally>true</ManagePackageVersionsCentrally>\n  </PropertyGroup>\n  <ItemGroup>\n    <PackageVersion Include=\"OpenTelemetry\" Version=\"1.9.0\" />\n  </ItemGroup>\n</Project>\n"
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package dotnet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/textedit"
+)
+
+// AddPackageReferences adds PackageReference items to a project file, skipping the
+// packages it already references. References without a version are written without the
+// Version attribute, as central package management requires. It returns the updated
+// content and the names of the added packages.
+func AddPackageReferences(content []byte, refs []PackageReference) ([]byte, []string, error) {
+	file, err := ParseFile(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addItems(content, "PackageReference", file.PackageReferences, refs)
+}
+
+// AddPackageVersions adds central PackageVersion items to Directory.Packages.props,
+// skipping the packages it already has a version for. It returns the updated content and
+// the names of the added packages.
+func AddPackageVersions(content []byte, refs []PackageReference) ([]byte, []string, error) {
+	file, err := ParseFile(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return addItems(content, "PackageVersion", file.PackageVersions, refs)
+}
+
+// addItems writes one <element Include="..." Version="..." /> per missing package after
+// the last existing element of the same kind, or in a new ItemGroup before </Project>
+func addItems(content []byte, element string, existing, refs []PackageReference) ([]byte, []string, error) {
+	seen := make(map[string]bool)
+	for _, ref := range existing {
+		seen[strings.ToLower(ref.Name)] = true
+	}
+	var items, added []string
+	for _, ref := range refs {
+		key := strings.ToLower(ref.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		item := fmt.Sprintf("<%s Include=%q", element, ref.Name)
+		if ref.Version != "" {
+			item += fmt.Sprintf(" Version=%q", ref.Version)
+		}
+		items = append(items, item+" />")
+		added = append(added, ref.Name)
+	}
+	if len(items) == 0 {
+		return content, nil, nil
+	}
+
+	text := string(content)
+	if at := strings.LastIndex(text, "<"+element+" "); at >= 0 {
+		end := elementEnd(text, at, element)
+		if end < 0 {
+			return nil, nil, fmt.Errorf("malformed %s element", element)
+		}
+		indent := textedit.LineIndent(text, at)
+		var b strings.Builder
+		for _, item := range items {
+			b.WriteString("\n" + indent + item)
+		}
+		return []byte(text[:end] + b.String() + text[end:]), added, nil
+	}
+
+	idx := strings.LastIndex(strings.ToLower(text), "</project>")
+	if idx == -1 {
+		return nil, nil, fmt.Errorf("malformed project file: missing </Project>")
+	}
+	var b strings.Builder
+	b.WriteString("  <ItemGroup>\n")
+	for _, item := range items {
+		b.WriteString("    " + item + "\n")
+	}
+	b.WriteString("  </ItemGroup>\n")
+	return []byte(text[:idx] + b.String() + text[idx:]), added, nil
+}
+
+// elementEnd returns the offset just past the element starting at text[start]: its "/>"
+// or its closing tag
+func elementEnd(text string, start int, element string) int {
+	gt := strings.IndexByte(text[start:], '>')
+	if gt < 0 {
+		return -1
+	}
+	if text[start+gt-1] == '/' {
+		return start + gt + 1
+	}
+	closing := "</" + element + ">"
+	end := strings.Index(text[start:], closing)
+	if end < 0 {
+		return -1
+	}
+	return start + end + len(closing)
+}
@@ -0,0 +1,186 @@
+package dotnet
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+)
+
+// MSBuild and NuGet file names
+const (
+	// PackagesPropsFileName holds the central package versions of a repository
+	PackagesPropsFileName = "Directory.Packages.props"
+	// BuildPropsFileName is imported by every project below it
+	BuildPropsFileName = "Directory.Build.props"
+	// PackagesConfigFileName lists the packages of legacy .NET Framework projects
+	PackagesConfigFileName = "packages.config"
+	// SolutionExtension is the extension of Visual Studio solutions
+	SolutionExtension = ".sln"
+)
+
+// ProjectExtensions are the MSBuild project files of C#, F# and Visual Basic projects
+var ProjectExtensions = []string{".csproj", ".fsproj", ".vbproj"}
+
+// IsProjectFile reports whether name is a C#, F# or Visual Basic project file
+func IsProjectFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, e := range ProjectExtensions {
+		if strings.EqualFold(ext, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageReference is a NuGet package item of a project, props file or packages.config
+type PackageReference struct {
+	Name    string
+	Version string
+	// VersionOverride replaces the central version for a single project
+	VersionOverride string
+}
+
+// File is the package-related content of an MSBuild project or props file. Conditions
+// are not evaluated.
+type File struct {
+	// Properties are keyed by lower-case name, as MSBuild property names are case-insensitive
+	Properties map[string]string
+	// PackageReferences are the PackageReference items
+	PackageReferences []PackageReference
+	// PackageVersions are the central versions declared by PackageVersion items
+	PackageVersions []PackageReference
+	// GlobalPackageReferences are referenced by every project using the central versions
+	GlobalPackageReferences []PackageReference
+}
+
+type xmlProject struct {
+	PropertyGroups []struct {
+		Properties []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageReferences       []xmlPackageItem `xml:"PackageReference"`
+		PackageVersions         []xmlPackageItem `xml:"PackageVersion"`
+		GlobalPackageReferences []xmlPackageItem `xml:"GlobalPackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+// xmlPackageItem accepts the version as an attribute or as child element
+type xmlPackageItem struct {
+	Include                string `xml:"Include,attr"`
+	Version                string `xml:"Version,attr"`
+	VersionOverride        string `xml:"VersionOverride,attr"`
+	VersionElement         string `xml:"Version"`
+	VersionOverrideElement string `xml:"VersionOverride"`
+}
+
+func (i xmlPackageItem) reference() PackageReference {
+	ref := PackageReference{
+		Name:            strings.TrimSpace(i.Include),
+		Version:         strings.TrimSpace(i.Version),
+		VersionOverride: strings.TrimSpace(i.VersionOverride),
+	}
+	if ref.Version == "" {
+		ref.Version = strings.TrimSpace(i.VersionElement)
+	}
+	if ref.VersionOverride == "" {
+		ref.VersionOverride = strings.TrimSpace(i.VersionOverrideElement)
+	}
+	return ref
+}
+
+// ParseFile reads the properties and the package items of an MSBuild file. Items that
+// only Update or Remove packages are ignored.
+func ParseFile(content []byte) (*File, error) {
+	var project xmlProject
+	if err := xml.Unmarshal(content, &project); err != nil {
+		return nil, err
+	}
+
+	file := &File{Properties: make(map[string]string)}
+	for _, group := range project.PropertyGroups {
+		for _, prop := range group.Properties {
+			file.Properties[strings.ToLower(prop.XMLName.Local)] = strings.TrimSpace(prop.Value)
+		}
+	}
+	collect := func(items []xmlPackageItem, into *[]PackageReference) {
+		for _, item := range items {
+			if ref := item.reference(); ref.Name != "" {
+				*into = append(*into, ref)
+			}
+		}
+	}
+	for _, group := range project.ItemGroups {
+		collect(group.PackageReferences, &file.PackageReferences)
+		collect(group.PackageVersions, &file.PackageVersions)
+		collect(group.GlobalPackageReferences, &file.GlobalPackageReferences)
+	}
+	return file, nil
+}
+
+// ParsePackagesConfig returns the packages of a packages.config
+func ParsePackagesConfig(content []byte) ([]PackageReference, error) {
+	var config struct {
+		Packages []struct {
+			ID      string `xml:"id,attr"`
+			Version string `xml:"version,attr"`
+		} `xml:"package"`
+	}
+	if err := xml.Unmarshal(content, &config); err != nil {
+		return nil, err
+	}
+	refs := make([]PackageReference, 0, len(config.Packages))
+	for _, pkg := range config.Packages {
+		if pkg.ID != "" {
+			refs = append(refs, PackageReference{Name: pkg.ID, Version: pkg.Version})
+		}
+	}
+	return refs, nil
+}
+
+// ParseSolution returns the C#, F# and Visual Basic project files listed by a .sln, relative
+// to the solution directory. Solution folders and other project types are skipped.
+func ParseSolution(content []byte) []string {
+	var projects []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "Project(") {
+			continue
+		}
+		// Project("{type}") = "Name", "path\to\Name.csproj", "{guid}"
+		_, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		fields := strings.Split(rest, ",")
+		if len(fields) < 2 {
+			continue
+		}
+		path := strings.Trim(strings.TrimSpace(fields[1]), `"`)
+		if IsProjectFile(path) {
+			projects = append(projects, filepath.FromSlash(strings.ReplaceAll(path, `\`, "/")))
+		}
+	}
+	return projects
+}
+
+// expandProperties substitutes $(Name) references with the given properties; unknown
+// properties expand to nothing, as in MSBuild
+func expandProperties(value string, properties map[string]string) string {
+	// Properties can refer to other properties; the bound stops self references
+	for range 8 {
+		start := strings.Index(value, "$(")
+		if start < 0 {
+			return value
+		}
+		end := strings.IndexByte(value[start:], ')')
+		if end < 0 {
+			return value
+		}
+		name := strings.ToLower(strings.TrimSpace(value[start+2 : start+end]))
+		value = value[:start] + properties[name] + value[start+end+1:]
+	}
+	return value
+}
@@ -0,0 +1,202 @@
+package dotnet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Project is an MSBuild project together with the props files it imports implicitly
+type Project struct {
+	// Path is the project file
+	Path string
+	// BuildProps is the closest Directory.Build.props at or above the project, "" when none
+	BuildProps string
+	// PackagesProps is the closest Directory.Packages.props at or above the project, "" when none
+	PackagesProps string
+	// CentralPackageManagement is set when ManagePackageVersionsCentrally is true: package
+	// versions then live in PackagesProps and PackageReference items have none
+	CentralPackageManagement bool
+	// Packages are the packages the project references, with their effective versions
+	Packages []Package
+}
+
+// Package is a NuGet package referenced by a project
+type Package struct {
+	Name    string
+	Version string
+	// File is where the version is declared: the project, a props file or packages.config
+	File string
+}
+
+// LoadProject reads a project file, the Directory.Build.props and Directory.Packages.props
+// that apply to it and its packages.config, and resolves the version of each package:
+// a VersionOverride, the central PackageVersion when central package management is on,
+// or the version of the reference itself. $(Property) references are expanded.
+func LoadProject(path string) (*Project, error) {
+	project := &Project{Path: path}
+	dir := filepath.Dir(path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := ParseFile(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	// Directory.Build.props is imported first, Directory.Packages.props next, and the
+	// project's own properties win
+	properties := make(map[string]string)
+	var buildProps, packagesProps *File
+	if project.BuildProps, buildProps, err = findProps(dir, BuildPropsFileName); err != nil {
+		return nil, err
+	}
+	if project.PackagesProps, packagesProps, err = findProps(dir, PackagesPropsFileName); err != nil {
+		return nil, err
+	}
+	for _, f := range []*File{buildProps, packagesProps, file} {
+		if f != nil {
+			for name, value := range f.Properties {
+				properties[name] = value
+			}
+		}
+	}
+	project.CentralPackageManagement = strings.EqualFold(expandProperties(properties["managepackageversionscentrally"], properties), "true")
+
+	central := make(map[string]string)
+	if project.CentralPackageManagement && packagesProps != nil {
+		for _, ref := range packagesProps.PackageVersions {
+			central[strings.ToLower(ref.Name)] = expandProperties(ref.Version, properties)
+		}
+	}
+
+	seen := make(map[string]bool)
+	add := func(ref PackageReference, declaredIn string) {
+		key := strings.ToLower(ref.Name)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		pkg := Package{Name: ref.Name, File: declaredIn}
+		switch version, ok := central[key]; {
+		case ref.VersionOverride != "":
+			pkg.Version = expandProperties(ref.VersionOverride, properties)
+		case ok:
+			pkg.Version, pkg.File = version, project.PackagesProps
+		default:
+			pkg.Version = expandProperties(ref.Version, properties)
+		}
+		project.Packages = append(project.Packages, pkg)
+	}
+
+	for _, ref := range file.PackageReferences {
+		add(ref, path)
+	}
+	if buildProps != nil {
+		for _, ref := range buildProps.PackageReferences {
+			add(ref, project.BuildProps)
+		}
+	}
+	if project.CentralPackageManagement && packagesProps != nil {
+		for _, ref := range packagesProps.GlobalPackageReferences {
+			add(ref, project.PackagesProps)
+		}
+	}
+
+	configPath := filepath.Join(dir, PackagesConfigFileName)
+	if content, err := os.ReadFile(configPath); err == nil {
+		refs, err := ParsePackagesConfig(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		for _, ref := range refs {
+			add(ref, configPath)
+		}
+	}
+	return project, nil
+}
+
+// FindProjectFile returns the project file in dir. Without one, a solution in dir selects
+// the first application project it lists, or its first project.
+func FindProjectFile(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var solutions []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if IsProjectFile(entry.Name()) {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+		if strings.EqualFold(filepath.Ext(entry.Name()), SolutionExtension) {
+			solutions = append(solutions, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	for _, solution := range solutions {
+		content, err := os.ReadFile(solution)
+		if err != nil {
+			continue
+		}
+		var first string
+		for _, rel := range ParseSolution(content) {
+			path := filepath.Join(dir, rel)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			if first == "" {
+				first = path
+			}
+			if isApplication(path) {
+				return path, nil
+			}
+		}
+		if first != "" {
+			return first, nil
+		}
+	}
+	return "", fmt.Errorf("no .csproj, .fsproj or .vbproj file found in %s", dir)
+}
+
+// isApplication reports whether a project builds an executable or uses a web or worker SDK
+func isApplication(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	file, err := ParseFile(content)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(file.Properties["outputtype"]) {
+	case "exe", "winexe":
+		return true
+	}
+	text := string(content)
+	return strings.Contains(text, `Sdk="Microsoft.NET.Sdk.Web"`) || strings.Contains(text, `Sdk="Microsoft.NET.Sdk.Worker"`)
+}
+
+// findProps parses the closest props file with the given name at or above dir
+func findProps(dir, name string) (string, *File, error) {
+	for {
+		path := filepath.Join(dir, name)
+		if content, err := os.ReadFile(path); err == nil {
+			file, err := ParseFile(content)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			return path, file, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil, nil
+		}
+		dir = parent
+	}
+}
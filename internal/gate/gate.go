@@ -0,0 +1,164 @@
+package gate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+// severityRank orders severities from least to most severe
+var severityRank = map[domain.Severity]int{
+	domain.SeverityInfo:    1,
+	domain.SeverityWarning: 2,
+	domain.SeverityError:   3,
+}
+
+// Policy describes when an analysis should fail the quality gate
+type Policy struct {
+	// FailOn fails the gate when any issue is at or above this severity (empty = disabled)
+	FailOn domain.Severity
+	// MaxIssues fails the gate when a category has more than the given number of issues
+	MaxIssues map[domain.Category]int
+}
+
+// Violation describes a single reason the gate failed
+type Violation struct {
+	Rule      string `json:"rule"`
+	Message   string `json:"message"`
+	Count     int    `json:"count"`
+	Threshold int    `json:"threshold"`
+}
+
+// Verdict is the machine-readable gate result
+type Verdict struct {
+	Passed     bool           `json:"passed"`
+	FailOn     string         `json:"fail_on,omitempty"`
+	MaxIssues  map[string]int `json:"max_issues,omitempty"`
+	Violations []Violation    `json:"violations"`
+	Counts     map[string]int `json:"counts_by_severity"`
+}
+
+// ParseSeverity validates a --fail-on value
+func ParseSeverity(value string) (domain.Severity, error) {
+	s := domain.Severity(strings.ToLower(strings.TrimSpace(value)))
+	if s == "" {
+		return "", nil
+	}
+	if _, ok := severityRank[s]; !ok {
+		return "", fmt.Errorf("invalid severity %q (expected error, warning or info)", value)
+	}
+	return s, nil
+}
+
+// ParseCategory validates a category name
+func ParseCategory(value string) (domain.Category, error) {
+	c := domain.Category(strings.ToLower(strings.TrimSpace(value)))
+	for _, known := range Categories() {
+		if c == known {
+			return c, nil
+		}
+	}
+	names := make([]string, 0, len(Categories()))
+	for _, known := range Categories() {
+		names = append(names, string(known))
+	}
+	return "", fmt.Errorf("invalid category %q (expected one of: %s)", value, strings.Join(names, ", "))
+}
+
+// Categories returns all known issue categories
+func Categories() []domain.Category {
+	return []domain.Category{
+		domain.CategoryMissingOtel,
+		domain.CategoryConfiguration,
+		domain.CategoryInstrumentation,
+		domain.CategoryPerformance,
+		domain.CategorySecurity,
+		domain.CategoryBestPractice,
+		domain.CategoryDeprecated,
+	}
+}
+
+// Enabled reports whether the policy has any rule configured
+func (p Policy) Enabled() bool {
+	return p.FailOn != "" || len(p.MaxIssues) > 0
+}
+
+// Evaluate checks the active (non-suppressed) issues against the policy
+func (p Policy) Evaluate(analysis *detector.Analysis) *Verdict {
+	verdict := &Verdict{
+		Passed:     true,
+		FailOn:     string(p.FailOn),
+		Violations: []Violation{},
+		Counts:     map[string]int{},
+	}
+
+	bySeverity := make(map[domain.Severity]int)
+	byCategory := make(map[domain.Category]int)
+	if analysis != nil {
+		for _, dirAnalysis := range analysis.DirectoryAnalyses {
+			for _, issue := range dirAnalysis.Issues {
+				bySeverity[issue.Severity]++
+				byCategory[issue.Category]++
+			}
+		}
+	}
+	for sev, n := range bySeverity {
+		verdict.Counts[string(sev)] = n
+	}
+
+	if p.FailOn != "" {
+		threshold := severityRank[p.FailOn]
+		count := 0
+		for sev, n := range bySeverity {
+			if severityRank[sev] >= threshold {
+				count += n
+			}
+		}
+		if count > 0 {
+			verdict.Violations = append(verdict.Violations, Violation{
+				Rule:    "fail_on",
+				Message: fmt.Sprintf("%d issue(s) at or above %s severity", count, p.FailOn),
+				Count:   count,
+			})
+		}
+	}
+
+	if len(p.MaxIssues) > 0 {
+		verdict.MaxIssues = make(map[string]int, len(p.MaxIssues))
+		categories := make([]string, 0, len(p.MaxIssues))
+		for cat, max := range p.MaxIssues {
+			verdict.MaxIssues[string(cat)] = max
+			categories = append(categories, string(cat))
+		}
+		sort.Strings(categories)
+		for _, cat := range categories {
+			max := p.MaxIssues[domain.Category(cat)]
+			if count := byCategory[domain.Category(cat)]; count > max {
+				verdict.Violations = append(verdict.Violations, Violation{
+					Rule:      "max_issues:" + cat,
+					Message:   fmt.Sprintf("%d %s issue(s) exceed the limit of %d", count, cat, max),
+					Count:     count,
+					Threshold: max,
+				})
+			}
+		}
+	}
+
+	verdict.Passed = len(verdict.Violations) == 0
+	return verdict
+}
+
+// Line returns a one-line human-readable verdict
+func (v *Verdict) Line() string {
+	if v.Passed {
+		return "PASSED"
+	}
+	msgs := make([]string, 0, len(v.Violations))
+	for _, violation := range v.Violations {
+		msgs = append(msgs, violation.Message)
+	}
+	return "FAILED (" + strings.Join(msgs, "; ") + ")"
+}
@@ -0,0 +1,67 @@
+package gate
+
+import (
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+func analysisWith(issues ...domain.Issue) *detector.Analysis {
+	return &detector.Analysis{DirectoryAnalyses: map[string]*detector.DirectoryAnalysis{
+		"root": {Directory: "root", Issues: issues},
+	}}
+}
+
+func TestEvaluate_FailOnSeverityThreshold(t *testing.T) {
+	a := analysisWith(
+		domain.Issue{Severity: domain.SeverityInfo, Category: domain.CategoryInstrumentation},
+		domain.Issue{Severity: domain.SeverityWarning, Category: domain.CategoryMissingOtel},
+	)
+
+	if v := (Policy{FailOn: domain.SeverityError}).Evaluate(a); !v.Passed {
+		t.Fatalf("expected pass with fail-on=error, got %+v", v)
+	}
+	v := (Policy{FailOn: domain.SeverityWarning}).Evaluate(a)
+	if v.Passed || len(v.Violations) != 1 || v.Violations[0].Count != 1 {
+		t.Fatalf("expected one warning violation, got %+v", v)
+	}
+	if v := (Policy{FailOn: domain.SeverityInfo}).Evaluate(a); v.Passed || v.Violations[0].Count != 2 {
+		t.Fatalf("expected both issues to count for fail-on=info, got %+v", v)
+	}
+}
+
+func TestEvaluate_CategoryThresholds(t *testing.T) {
+	a := analysisWith(
+		domain.Issue{Severity: domain.SeverityInfo, Category: domain.CategoryInstrumentation},
+		domain.Issue{Severity: domain.SeverityInfo, Category: domain.CategoryInstrumentation},
+	)
+	policy := Policy{MaxIssues: map[domain.Category]int{
+		domain.CategoryInstrumentation: 1,
+		domain.CategorySecurity:        0,
+	}}
+	v := policy.Evaluate(a)
+	if v.Passed || len(v.Violations) != 1 || v.Violations[0].Rule != "max_issues:instrumentation" || v.Violations[0].Threshold != 1 {
+		t.Fatalf("unexpected verdict: %+v", v)
+	}
+
+	policy.MaxIssues[domain.CategoryInstrumentation] = 2
+	if v := policy.Evaluate(a); !v.Passed {
+		t.Fatalf("expected pass at the limit, got %+v", v)
+	}
+}
+
+func TestParseSeverityAndCategory(t *testing.T) {
+	if s, err := ParseSeverity("Warning"); err != nil || s != domain.SeverityWarning {
+		t.Fatalf("ParseSeverity(Warning) = %q, %v", s, err)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Fatalf("expected error for unknown severity")
+	}
+	if _, err := ParseCategory("missing_library"); err == nil {
+		t.Fatalf("expected error for unknown category")
+	}
+	if c, err := ParseCategory("best_practice"); err != nil || c != domain.CategoryBestPractice {
+		t.Fatalf("ParseCategory(best_practice) = %q, %v", c, err)
+	}
+}
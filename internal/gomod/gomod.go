@@ -0,0 +1,141 @@
+package gomod
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// File names read by the Go toolchain
+const (
+	ModFileName     = "go.mod"
+	SumFileName     = "go.sum"
+	WorkFileName    = "go.work"
+	WorkSumFileName = "go.work.sum"
+	// VendorModulesFileName lists the vendored modules, relative to the module or workspace root
+	VendorModulesFileName = "vendor/modules.txt"
+)
+
+// Require is a require directive of go.mod
+type Require struct {
+	Path    string
+	Version string
+	// Indirect is set for requirements marked "// indirect": modules needed by dependencies only
+	Indirect bool
+}
+
+// Module holds the module path and the requirements of a go.mod
+type Module struct {
+	Path     string
+	Requires []Require
+}
+
+// ParseMod reads the module directive and the require directives of a go.mod, in both
+// the single-line and the block form
+func ParseMod(content []byte) Module {
+	var mod Module
+	forEachDirective(content, func(verb string, args []string, comment string) {
+		switch {
+		case verb == "module" && len(args) >= 1:
+			mod.Path = args[0]
+		case verb == "require" && len(args) >= 2:
+			mod.Requires = append(mod.Requires, Require{
+				Path:     args[0],
+				Version:  args[1],
+				Indirect: isIndirect(comment),
+			})
+		}
+	})
+	return mod
+}
+
+// ParseWork returns the module directories of the use directives of a go.work, as written:
+// relative to the go.work directory unless absolute
+func ParseWork(content []byte) []string {
+	var dirs []string
+	forEachDirective(content, func(verb string, args []string, _ string) {
+		if verb == "use" && len(args) >= 1 {
+			dirs = append(dirs, args[0])
+		}
+	})
+	return dirs
+}
+
+// ParseSum returns, for each module path, the versions go.sum has a content hash for.
+// Entries hashing only a go.mod file are left out: those versions took part in version
+// selection but were never built.
+func ParseSum(content []byte) map[string][]string {
+	versions := make(map[string][]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		versions[fields[0]] = append(versions[fields[0]], fields[1])
+	}
+	return versions
+}
+
+// ParseVendorModules returns the version of each module recorded in vendor/modules.txt.
+// For replaced modules the version of the original requirement is kept.
+func ParseVendorModules(content []byte) map[string]string {
+	versions := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		// "## explicit" annotations and package lines are not module headers
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "# "))
+		if len(fields) >= 2 && fields[1] != "=>" {
+			versions[fields[0]] = fields[1]
+		}
+	}
+	return versions
+}
+
+// forEachDirective calls fn with the verb, arguments and trailing comment of every
+// directive in a go.mod or go.work file. Directives inside a block, e.g. "require (",
+// are reported with the verb of the block. Quoted arguments are unquoted.
+func forEachDirective(content []byte, fn func(verb string, args []string, comment string)) {
+	block := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line, comment, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if block != "" {
+			if fields[0] == ")" {
+				block = ""
+				continue
+			}
+			fn(block, unquote(fields), comment)
+			continue
+		}
+		if len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		fn(fields[0], unquote(fields[1:]), comment)
+	}
+}
+
+// unquote strips the quotes of quoted module paths and directories
+func unquote(fields []string) []string {
+	for i, f := range fields {
+		if len(f) >= 2 && (f[0] == '"' || f[0] == '`') && f[len(f)-1] == f[0] {
+			fields[i] = f[1 : len(f)-1]
+		}
+	}
+	return fields
+}
+
+// isIndirect reports whether a directive comment marks the requirement as indirect
+func isIndirect(comment string) bool {
+	comment = strings.TrimSpace(comment)
+	return comment == "indirect" || strings.HasPrefix(comment, "indirect;")
+}
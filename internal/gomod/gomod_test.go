@@ -0,0 +1,164 @@
+package gomod
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func TestParseMod(t *testing.T) {
+	mod := ParseMod([]byte(`// Checkout service
+module "example.com/checkout"
+
+go 1.23
+
+require go.opentelemetry.io/otel v1.28.0
+
+require (
+	github.com/gin-gonic/gin v1.10.0 // web framework
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	golang.org/x/net v0.27.0 // indirect; needed by grpc
+)
+
+replace (
+	example.com/shared => ../shared
+)
+
+exclude github.com/gin-gonic/gin v1.9.0
+`))
+	want := Module{
+		Path: "example.com/checkout",
+		Requires: []Require{
+			{Path: "go.opentelemetry.io/otel", Version: "v1.28.0"},
+			{Path: "github.com/gin-gonic/gin", Version: "v1.10.0"},
+			{Path: "go.opentelemetry.io/otel/metric", Version: "v1.28.0", Indirect: true},
+			{Path: "golang.org/x/net", Version: "v0.27.0", Indirect: true},
+		},
+	}
+	if !reflect.DeepEqual(mod, want) {
+		t.Errorf("got %+v, want %+v", mod, want)
+	}
+}
+
+func TestParseWorkSumAndVendor(t *testing.T) {
+	uses := ParseWork([]byte("go 1.23\n\nuse ./tools\n\nuse (\n\t./services/api // api\n\t\"./libs/shared\"\n)\n"))
+	if want := []string{"./tools", "./services/api", "./libs/shared"}; !reflect.DeepEqual(uses, want) {
+		t.Errorf("ParseWork: got %v, want %v", uses, want)
+	}
+
+	sums := ParseSum([]byte(`go.opentelemetry.io/otel v1.27.0/go.mod h1:abc=
+go.opentelemetry.io/otel v1.28.0 h1:def=
+go.opentelemetry.io/otel v1.28.0/go.mod h1:ghi=
+`))
+	if want := map[string][]string{"go.opentelemetry.io/otel": {"v1.28.0"}}; !reflect.DeepEqual(sums, want) {
+		t.Errorf("ParseSum: got %v, want %v", sums, want)
+	}
+
+	vendored := ParseVendorModules([]byte(`# go.opentelemetry.io/otel v1.28.0
+## explicit; go 1.21
+go.opentelemetry.io/otel
+go.opentelemetry.io/otel/attribute
+# example.com/shared v0.0.0-00010101000000-000000000000 => ../shared
+## explicit
+# example.com/shared => ../shared
+`))
+	want := map[string]string{
+		"go.opentelemetry.io/otel": "v1.28.0",
+		"example.com/shared":       "v0.0.0-00010101000000-000000000000",
+	}
+	if !reflect.DeepEqual(vendored, want) {
+		t.Errorf("ParseVendorModules: got %v, want %v", vendored, want)
+	}
+}
+
+func TestFindOwner(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"go.work":                       "go 1.23\n\nuse (\n\t./services/api\n\t./services/worker\n)\n",
+		"services/api/go.mod":           "module example.com/api\n",
+		"services/api/internal/h.go":    "package internal\n",
+		"services/worker/go.mod":        "module example.com/worker\n",
+		"services/worker/tools/go.mod":  "module example.com/worker/tools\n",
+		"services/worker/tools/main.go": "package main\n",
+		"scripts/gen.go":                "package main\n",
+	})
+
+	tests := []struct {
+		dir       string
+		module    string
+		workspace bool
+		ok        bool
+	}{
+		{"services/api/internal", "services/api", true, true},
+		{"services/worker", "services/worker", true, true},
+		// A nested module owns its tree even when the workspace does not use it
+		{"services/worker/tools", "services/worker/tools", false, true},
+		{"scripts", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			owner, ok, err := FindOwner(filepath.Join(root, tt.dir))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.ok {
+				t.Fatalf("FindOwner() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if owner.ModuleDir != filepath.Join(root, tt.module) {
+				t.Errorf("ModuleDir = %s, want %s", owner.ModuleDir, filepath.Join(root, tt.module))
+			}
+			if (owner.Workspace != nil) != tt.workspace {
+				t.Errorf("Workspace = %+v, want workspace %v", owner.Workspace, tt.workspace)
+			}
+		})
+	}
+}
+
+func TestSelectedVersions(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"go.work": "go 1.23\n\nuse (\n\t./api\n\t./worker\n)\n",
+		"api/go.mod": `module example.com/api
+
+require (
+	go.opentelemetry.io/otel v1.26.0
+	go.opentelemetry.io/otel/trace v1.26.0 // indirect
+)
+`,
+		"worker/go.mod": "module example.com/worker\n\nrequire go.opentelemetry.io/otel v1.28.0\n",
+		"api/go.sum":    "go.opentelemetry.io/otel/trace v1.28.0 h1:a=\ngo.opentelemetry.io/otel/trace v1.28.0/go.mod h1:b=\ngo.opentelemetry.io/otel/trace v1.29.0/go.mod h1:c=\n",
+	})
+
+	owner, ok, err := FindOwner(filepath.Join(root, "api"))
+	if err != nil || !ok {
+		t.Fatalf("FindOwner() = %v, %v", ok, err)
+	}
+	selected, err := owner.SelectedVersions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"go.opentelemetry.io/otel":       "v1.28.0",
+		"go.opentelemetry.io/otel/trace": "v1.28.0",
+	}
+	if !reflect.DeepEqual(selected, want) {
+		t.Errorf("got %v, want %v", selected, want)
+	}
+
+	// A vendored workspace is authoritative
+	testutil.WriteFiles(t, root, map[string]string{
+		VendorModulesFileName: "# go.opentelemetry.io/otel v1.28.0\n## explicit\n",
+	})
+	selected, err = owner.SelectedVersions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"go.opentelemetry.io/otel": "v1.28.0"}; !reflect.DeepEqual(selected, want) {
+		t.Errorf("vendored: got %v, want %v", selected, want)
+	}
+}
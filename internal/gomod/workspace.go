@@ -0,0 +1,183 @@
+package gomod
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/semver"
+)
+
+// Workspace is a go.work file and the modules it uses
+type Workspace struct {
+	// Dir is the directory of go.work
+	Dir string
+	// Modules are the absolute directories of the used modules
+	Modules []string
+}
+
+// Owner is the module that owns a directory, and the workspace that module belongs to
+type Owner struct {
+	// ModuleDir is the directory of the owning go.mod
+	ModuleDir string
+	// Workspace is nil when the module is not used by a go.work
+	Workspace *Workspace
+}
+
+// ReadWorkspace parses the go.work in dir
+func ReadWorkspace(dir string) (*Workspace, error) {
+	path := filepath.Join(dir, WorkFileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ws := &Workspace{Dir: dir}
+	for _, use := range ParseWork(content) {
+		if !filepath.IsAbs(use) {
+			use = filepath.Join(dir, use)
+		}
+		ws.Modules = append(ws.Modules, filepath.Clean(use))
+	}
+	return ws, nil
+}
+
+// FindWorkspace parses the go.work in dir or the closest parent that has one. It returns
+// nil when there is none.
+func FindWorkspace(dir string) (*Workspace, error) {
+	workDir, ok := findUp(dir, WorkFileName)
+	if !ok {
+		return nil, nil
+	}
+	ws, err := ReadWorkspace(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Join(workDir, WorkFileName), err)
+	}
+	return ws, nil
+}
+
+// Uses reports whether the workspace uses the module in moduleDir
+func (ws *Workspace) Uses(moduleDir string) bool {
+	moduleDir = filepath.Clean(moduleDir)
+	for _, dir := range ws.Modules {
+		if dir == moduleDir {
+			return true
+		}
+	}
+	return false
+}
+
+// FindOwner resolves the module that owns dir: the closest go.mod at or above it, as the
+// go command does, and the go.work that uses that module, if any. Nested modules of a
+// workspace therefore own their own trees. It returns false when dir is not inside a module.
+func FindOwner(dir string) (Owner, bool, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Owner{}, false, err
+	}
+	moduleDir, ok := findUp(abs, ModFileName)
+	if !ok {
+		return Owner{}, false, nil
+	}
+	owner := Owner{ModuleDir: moduleDir}
+
+	ws, err := FindWorkspace(abs)
+	if err != nil {
+		return Owner{}, false, err
+	}
+	if ws != nil && ws.Uses(moduleDir) {
+		owner.Workspace = ws
+	}
+	return owner, true, nil
+}
+
+// ModFile returns the path of the owning go.mod
+func (o Owner) ModFile() string {
+	return filepath.Join(o.ModuleDir, ModFileName)
+}
+
+// ReadModule parses the owning go.mod
+func (o Owner) ReadModule() (Module, error) {
+	content, err := os.ReadFile(o.ModFile())
+	if err != nil {
+		return Module{}, err
+	}
+	return ParseMod(content), nil
+}
+
+// SelectedVersions returns the version the build uses for each module required in the
+// owner's scope: the module itself, or every module of its workspace. A vendor/modules.txt
+// is authoritative. Otherwise the highest version required within the scope wins, as
+// minimal version selection does, raised to the highest version go.sum (and go.work.sum)
+// has downloaded, which accounts for requirements of dependencies.
+func (o Owner) SelectedVersions() (map[string]string, error) {
+	root, modules := o.ModuleDir, []string{o.ModuleDir}
+	sums := []string{filepath.Join(o.ModuleDir, SumFileName)}
+	if o.Workspace != nil {
+		root, modules = o.Workspace.Dir, o.Workspace.Modules
+		sums = []string{filepath.Join(root, WorkSumFileName)}
+		for _, dir := range modules {
+			sums = append(sums, filepath.Join(dir, SumFileName))
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(root, VendorModulesFileName)); err == nil {
+		return ParseVendorModules(content), nil
+	}
+
+	selected := make(map[string]string)
+	for _, dir := range modules {
+		content, err := os.ReadFile(filepath.Join(dir, ModFileName))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, req := range ParseMod(content).Requires {
+			selected[req.Path] = maxVersion(selected[req.Path], req.Version)
+		}
+	}
+	for _, path := range sums {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for module, versions := range ParseSum(content) {
+			if _, required := selected[module]; !required {
+				continue
+			}
+			for _, v := range versions {
+				selected[module] = maxVersion(selected[module], v)
+			}
+		}
+	}
+	return selected, nil
+}
+
+// maxVersion returns the higher of two module versions; an empty version is the lowest
+func maxVersion(a, b string) string {
+	if a == "" {
+		return b
+	}
+	va, errA := semver.Parse(strings.TrimSuffix(a, "+incompatible"))
+	vb, errB := semver.Parse(strings.TrimSuffix(b, "+incompatible"))
+	if errA != nil || errB != nil || va.Compare(vb) >= 0 {
+		return a
+	}
+	return b
+}
+
+// findUp returns the first of dir and its parents that contains name
+func findUp(dir, name string) (string, bool) {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
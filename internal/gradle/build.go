@@ -0,0 +1,109 @@
+package gradle
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// configurations lists the dependency configurations read from build scripts
+const configurations = `implementation|api|compileOnly|runtimeOnly|testImplementation|testRuntimeOnly|annotationProcessor|kapt|ksp`
+
+var (
+	// stringNotationRe matches implementation 'g:a:v', implementation("g:a:v") and
+	// implementation(platform("g:a:v")) in Groovy and Kotlin DSL
+	stringNotationRe = regexp.MustCompile(`\b(` + configurations + `)\s*\(?\s*((?:platform|enforcedPlatform)\s*\(\s*)?['"]([A-Za-z0-9_.\-]+):([A-Za-z0-9_.\-]+)(?::([^'"]*))?['"]`)
+	// mapNotationRe matches group: 'g', name: 'a', version: 'v' and the Kotlin DSL named
+	// arguments group = "g", name = "a", version = "v"
+	mapNotationRe = regexp.MustCompile(`\b(` + configurations + `)\s*\(?\s*group\s*[:=]\s*['"]([^'"]+)['"]\s*,\s*name\s*[:=]\s*['"]([^'"]+)['"](?:\s*,\s*version\s*[:=]\s*['"]([^'"]+)['"])?`)
+	// catalogRe matches implementation(libs.otel.api), implementation libs.bundles.otel and
+	// implementation(platform(libs.otel.bom))
+	catalogRe = regexp.MustCompile(`\b(` + configurations + `)\s*\(?\s*((?:platform|enforcedPlatform)\s*\(\s*)?` + CatalogAccessor + `((?:\.[A-Za-z_][A-Za-z0-9_]*)+)`)
+)
+
+// Dependency is a dependency declared in a build script
+type Dependency struct {
+	Configuration string
+	Group         string
+	Name          string
+	Version       string
+	// Platform is set for platform() and enforcedPlatform() dependencies, i.e. BOMs
+	Platform bool
+	// Alias is the catalog alias the dependency is referenced through, "" for string and
+	// map notation
+	Alias string
+}
+
+// Coordinate returns group:name
+func (d Dependency) Coordinate() string {
+	return d.Group + ":" + d.Name
+}
+
+// ParseBuildScript returns the dependencies declared in a Groovy or Kotlin DSL build
+// script. References to the catalog, including bundles, are resolved with catalog, which
+// may be nil. Comments are skipped.
+func ParseBuildScript(content []byte, catalog *Catalog) []Dependency {
+	text := stripComments(string(content))
+
+	type match struct {
+		at  int
+		dep Dependency
+	}
+	var matches []match
+	for _, m := range stringNotationRe.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, match{m[0], Dependency{
+			Configuration: text[m[2]:m[3]],
+			Platform:      m[4] >= 0,
+			Group:         text[m[6]:m[7]],
+			Name:          text[m[8]:m[9]],
+			Version:       group(text, m, 5),
+		}})
+	}
+	for _, m := range mapNotationRe.FindAllStringSubmatchIndex(text, -1) {
+		matches = append(matches, match{m[0], Dependency{
+			Configuration: text[m[2]:m[3]],
+			Group:         text[m[4]:m[5]],
+			Name:          text[m[6]:m[7]],
+			Version:       group(text, m, 4),
+		}})
+	}
+	if catalog != nil {
+		for _, m := range catalogRe.FindAllStringSubmatchIndex(text, -1) {
+			configuration, platform := text[m[2]:m[3]], m[4] >= 0
+			accessor := strings.TrimPrefix(text[m[6]:m[7]], ".")
+			accessor = strings.TrimSuffix(strings.TrimSuffix(accessor, ".asProvider"), ".get")
+			var libs []Library
+			if name, ok := strings.CutPrefix(accessor, "bundles."); ok {
+				libs, _ = catalog.Bundle(name)
+			} else if lib, ok := catalog.Lookup(accessor); ok {
+				libs = []Library{lib}
+			}
+			for _, lib := range libs {
+				matches = append(matches, match{m[0], Dependency{
+					Configuration: configuration,
+					Group:         lib.Group,
+					Name:          lib.Name,
+					Version:       lib.Version,
+					Platform:      platform,
+					Alias:         lib.Alias,
+				}})
+			}
+		}
+	}
+
+	// Keep the order of the script
+	slices.SortStableFunc(matches, func(a, b match) int { return a.at - b.at })
+	deps := make([]Dependency, 0, len(matches))
+	for _, m := range matches {
+		deps = append(deps, m.dep)
+	}
+	return deps
+}
+
+// group returns the n-th capture group of a submatch index, "" when it did not match
+func group(text string, m []int, n int) string {
+	if m[2*n] < 0 {
+		return ""
+	}
+	return text[m[2*n]:m[2*n+1]]
+}
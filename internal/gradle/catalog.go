@@ -0,0 +1,180 @@
+package gradle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// CatalogFileName is the default version catalog, relative to the build root. Gradle
+// exposes it to build scripts as libs.
+const CatalogFileName = "gradle/libs.versions.toml"
+
+// CatalogAccessor is the name build scripts use for the default catalog
+const CatalogAccessor = "libs"
+
+// Library is an entry of the [libraries] table of a version catalog
+type Library struct {
+	Alias string
+	Group string
+	Name  string
+	// Version is the resolved version, "" when the library has none (e.g. it is managed by
+	// a platform)
+	Version string
+	// VersionRef is the [versions] key the version comes from
+	VersionRef string
+}
+
+// Coordinate returns group:name
+func (l Library) Coordinate() string {
+	return l.Group + ":" + l.Name
+}
+
+// Catalog is a gradle/libs.versions.toml
+type Catalog struct {
+	Versions  map[string]string
+	Libraries map[string]Library
+	Bundles   map[string][]string
+}
+
+// ParseCatalog reads a version catalog. Rich versions resolve to their strictly, require
+// or prefer version, in that order.
+func ParseCatalog(content []byte) (*Catalog, error) {
+	var raw struct {
+		Versions  map[string]any      `toml:"versions"`
+		Libraries map[string]any      `toml:"libraries"`
+		Bundles   map[string][]string `toml:"bundles"`
+	}
+	if _, err := toml.Decode(string(content), &raw); err != nil {
+		return nil, err
+	}
+
+	catalog := &Catalog{
+		Versions:  make(map[string]string),
+		Libraries: make(map[string]Library),
+		Bundles:   make(map[string][]string),
+	}
+	for key, value := range raw.Versions {
+		catalog.Versions[key] = richVersion(value)
+	}
+	for alias, value := range raw.Libraries {
+		lib := Library{Alias: alias}
+		switch v := value.(type) {
+		case string:
+			// "group:name:version"
+			parts := strings.SplitN(v, ":", 3)
+			if len(parts) < 2 {
+				return nil, fmt.Errorf("invalid library notation for %s: %q", alias, v)
+			}
+			lib.Group, lib.Name = parts[0], parts[1]
+			if len(parts) == 3 {
+				lib.Version = parts[2]
+			}
+		case map[string]any:
+			if module, ok := v["module"].(string); ok {
+				lib.Group, lib.Name, _ = strings.Cut(module, ":")
+			} else {
+				lib.Group, _ = v["group"].(string)
+				lib.Name, _ = v["name"].(string)
+			}
+			switch version := v["version"].(type) {
+			case string:
+				lib.Version = version
+			case map[string]any:
+				if ref, ok := version["ref"].(string); ok {
+					lib.VersionRef = ref
+					lib.Version = catalog.Versions[ref]
+				} else {
+					lib.Version = richVersion(version)
+				}
+			}
+		}
+		if lib.Group == "" || lib.Name == "" {
+			return nil, fmt.Errorf("library %s has no module", alias)
+		}
+		catalog.Libraries[alias] = lib
+	}
+	for name, aliases := range raw.Bundles {
+		catalog.Bundles[name] = aliases
+	}
+	return catalog, nil
+}
+
+// richVersion returns the version of a [versions] entry or a version table
+func richVersion(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		for _, key := range []string{"strictly", "require", "prefer"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Accessor returns the path build scripts use for an alias: Gradle maps '-', '_' and
+// '.' to '.', so otel-api, otel_api and otel.api are all libs.otel.api
+func Accessor(alias string) string {
+	return strings.NewReplacer("-", ".", "_", ".").Replace(alias)
+}
+
+// Lookup returns the library referenced by an accessor path, e.g. "otel.api" for
+// libs.otel.api
+func (c *Catalog) Lookup(accessor string) (Library, bool) {
+	for alias, lib := range c.Libraries {
+		if Accessor(alias) == accessor {
+			return lib, true
+		}
+	}
+	return Library{}, false
+}
+
+// Bundle returns the libraries of the bundle referenced by an accessor path, e.g. "otel"
+// for libs.bundles.otel
+func (c *Catalog) Bundle(accessor string) ([]Library, bool) {
+	for name, aliases := range c.Bundles {
+		if Accessor(name) != accessor {
+			continue
+		}
+		var libs []Library
+		for _, alias := range aliases {
+			if lib, ok := c.Libraries[alias]; ok {
+				libs = append(libs, lib)
+			}
+		}
+		return libs, true
+	}
+	return nil, false
+}
+
+// AliasFor returns the alias of the library for group:name
+func (c *Catalog) AliasFor(group, name string) (string, bool) {
+	for alias, lib := range c.Libraries {
+		if lib.Group == group && lib.Name == name {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+// Reference returns the expression referencing a library from a build script. An alias
+// that is also the prefix of another one, like otel-sdk next to otel-sdk-trace, needs
+// asProvider().
+func (c *Catalog) Reference(alias string) string {
+	accessor := Accessor(alias)
+	for other := range c.Libraries {
+		if strings.HasPrefix(Accessor(other), accessor+".") {
+			return CatalogAccessor + "." + accessor + ".asProvider()"
+		}
+	}
+	return CatalogAccessor + "." + accessor
+}
+
+// BundleReference returns the expression referencing a bundle from a build script
+func BundleReference(name string) string {
+	return CatalogAccessor + ".bundles." + Accessor(name)
+}
@@ -0,0 +1,121 @@
+package gradle
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// tableHeaderRe matches a table header line, e.g. [libraries]
+var tableHeaderRe = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*\]\s*(?:#.*)?$`)
+
+// catalogTables lists the tables of a version catalog in their conventional order
+var catalogTables = []string{"versions", "libraries", "bundles", "plugins"}
+
+// AddCatalogEntries adds versions, libraries and bundles to a version catalog, skipping
+// the keys it already has. Entries go after the last entry of their table, and missing
+// tables are appended, so formatting and comments are preserved. content may be empty to
+// create a catalog.
+func AddCatalogEntries(content []byte, versions map[string]string, libraries []Library, bundles map[string][]string) ([]byte, error) {
+	existing := &Catalog{}
+	if len(content) > 0 {
+		var err error
+		if existing, err = ParseCatalog(content); err != nil {
+			return nil, err
+		}
+	}
+
+	var versionLines, libraryLines, bundleLines []string
+	for _, key := range slices.Sorted(maps.Keys(versions)) {
+		if _, ok := existing.Versions[key]; !ok {
+			versionLines = append(versionLines, fmt.Sprintf("%s = %q\n", key, versions[key]))
+		}
+	}
+	for _, lib := range libraries {
+		if _, ok := existing.Libraries[lib.Alias]; ok {
+			continue
+		}
+		line := fmt.Sprintf("%s = { module = %q", lib.Alias, lib.Coordinate())
+		switch {
+		case lib.VersionRef != "":
+			line += fmt.Sprintf(", version.ref = %q", lib.VersionRef)
+		case lib.Version != "":
+			line += fmt.Sprintf(", version = %q", lib.Version)
+		}
+		libraryLines = append(libraryLines, line+" }\n")
+	}
+	for _, name := range slices.Sorted(maps.Keys(bundles)) {
+		if _, ok := existing.Bundles[name]; ok {
+			continue
+		}
+		quoted := make([]string, len(bundles[name]))
+		for i, alias := range bundles[name] {
+			quoted[i] = fmt.Sprintf("%q", alias)
+		}
+		bundleLines = append(bundleLines, fmt.Sprintf("%s = [%s]\n", name, strings.Join(quoted, ", ")))
+	}
+
+	text := string(content)
+	text = addTableLines(text, "versions", versionLines)
+	text = addTableLines(text, "libraries", libraryLines)
+	text = addTableLines(text, "bundles", bundleLines)
+	return []byte(text), nil
+}
+
+// addTableLines inserts complete lines after the last entry of a table, appending the
+// table when it is missing
+func addTableLines(text, table string, entries []string) string {
+	if len(entries) == 0 {
+		return text
+	}
+	lines := strings.SplitAfter(text, "\n")
+	start, end, ok := tableSpan(lines, table)
+	if !ok {
+		// Keep the conventional order: a missing table goes before the tables following it
+		for _, next := range catalogTables[slices.Index(catalogTables, table)+1:] {
+			if at, _, found := tableSpan(lines, next); found {
+				block := "[" + table + "]\n" + strings.Join(entries, "") + "\n"
+				return strings.Join(lines[:at], "") + block + strings.Join(lines[at:], "")
+			}
+		}
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		if text != "" {
+			text += "\n"
+		}
+		return text + "[" + table + "]\n" + strings.Join(entries, "")
+	}
+
+	// Skip the blank lines separating the table from the next one
+	last := end - 1
+	for last > start && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+	before := strings.Join(lines[:last+1], "")
+	if !strings.HasSuffix(before, "\n") {
+		before += "\n"
+	}
+	return before + strings.Join(entries, "") + strings.Join(lines[last+1:], "")
+}
+
+// tableSpan returns the header line of a table and the line that ends it: the next
+// header, or len(lines)
+func tableSpan(lines []string, table string) (int, int, bool) {
+	start := -1
+	for idx, line := range lines {
+		m := tableHeaderRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if start >= 0 {
+			return start, idx, true
+		}
+		if m[1] == table {
+			start = idx
+		}
+	}
+	return start, len(lines), start >= 0
+}
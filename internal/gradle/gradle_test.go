@@ -0,0 +1,159 @@
+package gradle
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+const testCatalog = `[versions]
+otel = "1.42.1"
+otel-instrumentation = { strictly = "2.8.0" }
+
+[libraries]
+otel-bom = { module = "io.opentelemetry:opentelemetry-bom", version.ref = "otel" }
+otel-api = { group = "io.opentelemetry", name = "opentelemetry-api" }
+otel_sdk = "io.opentelemetry:opentelemetry-sdk:1.42.1"
+otel-sdk-trace = { module = "io.opentelemetry:opentelemetry-sdk-trace", version = { require = "1.42.0" } }
+otel-annotations = { module = "io.opentelemetry.instrumentation:opentelemetry-instrumentation-annotations", version.ref = "otel-instrumentation" }
+
+[bundles]
+otel = ["otel-api", "otel_sdk"]
+`
+
+func TestParseCatalog(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]Library{
+		"otel-bom":         {Alias: "otel-bom", Group: "io.opentelemetry", Name: "opentelemetry-bom", Version: "1.42.1", VersionRef: "otel"},
+		"otel-api":         {Alias: "otel-api", Group: "io.opentelemetry", Name: "opentelemetry-api"},
+		"otel_sdk":         {Alias: "otel_sdk", Group: "io.opentelemetry", Name: "opentelemetry-sdk", Version: "1.42.1"},
+		"otel-sdk-trace":   {Alias: "otel-sdk-trace", Group: "io.opentelemetry", Name: "opentelemetry-sdk-trace", Version: "1.42.0"},
+		"otel-annotations": {Alias: "otel-annotations", Group: "io.opentelemetry.instrumentation", Name: "opentelemetry-instrumentation-annotations", Version: "2.8.0", VersionRef: "otel-instrumentation"},
+	}
+	if !reflect.DeepEqual(catalog.Libraries, want) {
+		t.Errorf("got %+v, want %+v", catalog.Libraries, want)
+	}
+	if lib, ok := catalog.Lookup("otel.sdk"); !ok || lib.Alias != "otel_sdk" {
+		t.Errorf("expected libs.otel.sdk to resolve to otel_sdk, got %+v", lib)
+	}
+	if got := catalog.Reference("otel_sdk"); got != "libs.otel.sdk.asProvider()" {
+		t.Errorf("expected asProvider() next to otel-sdk-trace, got %s", got)
+	}
+	if got := catalog.Reference("otel-api"); got != "libs.otel.api" {
+		t.Errorf("got %s", got)
+	}
+	if libs, ok := catalog.Bundle("otel"); !ok || len(libs) != 2 {
+		t.Errorf("expected the otel bundle to have 2 libraries, got %+v", libs)
+	}
+}
+
+func TestParseBuildScript(t *testing.T) {
+	catalog, err := ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := `plugins {
+    kotlin("jvm") version "2.0.21"
+}
+
+dependencies {
+    implementation(platform(libs.otel.bom))
+    implementation(libs.bundles.otel)
+    // implementation(libs.otel.annotations)
+    implementation(libs.otel.sdk.asProvider())
+    implementation("io.ktor:ktor-server-netty:2.3.12")
+    testImplementation(group = "org.junit.jupiter", name = "junit-jupiter", version = "5.11.0")
+}
+`
+	got := ParseBuildScript([]byte(script), catalog)
+	want := []Dependency{
+		{Configuration: "implementation", Group: "io.opentelemetry", Name: "opentelemetry-bom", Version: "1.42.1", Platform: true, Alias: "otel-bom"},
+		{Configuration: "implementation", Group: "io.opentelemetry", Name: "opentelemetry-api", Alias: "otel-api"},
+		{Configuration: "implementation", Group: "io.opentelemetry", Name: "opentelemetry-sdk", Version: "1.42.1", Alias: "otel_sdk"},
+		{Configuration: "implementation", Group: "io.opentelemetry", Name: "opentelemetry-sdk", Version: "1.42.1", Alias: "otel_sdk"},
+		{Configuration: "implementation", Group: "io.ktor", Name: "ktor-server-netty", Version: "2.3.12"},
+		{Configuration: "testImplementation", Group: "org.junit.jupiter", Name: "junit-jupiter", Version: "5.11.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestFindBuild(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"settings.gradle": `rootProject.name = 'shop'
+include ':api', ':services:worker'
+include 'legacy' // moved below
+project(':legacy').projectDir = file('modules/legacy')
+`,
+		CatalogFileName:                     testCatalog,
+		"services/worker/build.gradle":      "",
+		"modules/legacy/build.gradle":       "",
+		"tools/standalone/build.gradle.kts": "",
+	})
+
+	build, err := FindBuild(filepath.Join(root, "services", "worker"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{filepath.Join(root, "api"), filepath.Join(root, "services", "worker"), filepath.Join(root, "modules", "legacy")}
+	if build.Dir != root || !reflect.DeepEqual(build.Projects, want) || build.Catalog == nil || build.KotlinDSL() {
+		t.Fatalf("unexpected build %+v", build)
+	}
+	if !build.Includes(filepath.Join(root, "modules", "legacy")) {
+		t.Error("expected the projectDir of :legacy to be included")
+	}
+
+	// A directory the settings don't include is a build of its own
+	standalone := filepath.Join(root, "tools", "standalone")
+	build, err = FindBuild(standalone)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if build.Dir != standalone || build.Catalog != nil || BuildFile(standalone) != filepath.Join(standalone, "build.gradle.kts") {
+		t.Errorf("unexpected build %+v", build)
+	}
+}
+
+func TestAddCatalogEntries(t *testing.T) {
+	content := `# Shared versions
+[libraries]
+ktor-server-netty = { module = "io.ktor:ktor-server-netty", version = "2.3.12" }
+
+[plugins]
+kotlin-jvm = { id = "org.jetbrains.kotlin.jvm", version = "2.0.21" }
+`
+	got, err := AddCatalogEntries([]byte(content),
+		map[string]string{"opentelemetry": "1.42.1"},
+		[]Library{
+			{Alias: "opentelemetry-api", Group: "io.opentelemetry", Name: "opentelemetry-api", VersionRef: "opentelemetry"},
+			{Alias: "ktor-server-netty", Group: "io.ktor", Name: "ktor-server-netty", Version: "3.0.0"},
+		},
+		map[string][]string{"opentelemetry": {"opentelemetry-api"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `# Shared versions
+[versions]
+opentelemetry = "1.42.1"
+
+[libraries]
+ktor-server-netty = { module = "io.ktor:ktor-server-netty", version = "2.3.12" }
+opentelemetry-api = { module = "io.opentelemetry:opentelemetry-api", version.ref = "opentelemetry" }
+
+[bundles]
+opentelemetry = ["opentelemetry-api"]
+
+[plugins]
+kotlin-jvm = { id = "org.jetbrains.kotlin.jvm", version = "2.0.21" }
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+package gradle
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SettingsFileNames lists the settings scripts defining a build, Kotlin DSL first
+var SettingsFileNames = []string{"settings.gradle.kts", "settings.gradle"}
+
+// BuildFileNames lists the build scripts of a project in the order they are preferred
+var BuildFileNames = []string{"build.gradle", "build.gradle.kts"}
+
+var (
+	// includeRe matches include("a", ":b:c") and include 'a', 'b'
+	includeRe = regexp.MustCompile(`(?m)^\s*include\s*\(?((?:\s*['"][^'"]+['"]\s*,?)+)\)?`)
+	// projectDirRe matches project(":a").projectDir = file("path")
+	projectDirRe = regexp.MustCompile(`project\s*\(\s*['"]([^'"]+)['"]\s*\)\s*\.projectDir\s*=\s*file\s*\(\s*['"]([^'"]+)['"]\s*\)`)
+	quotedRe     = regexp.MustCompile(`['"]([^'"]+)['"]`)
+)
+
+// Build is a Gradle build: the root holding the settings script, its included projects
+// and its version catalog
+type Build struct {
+	// Dir is the root directory of the build
+	Dir string
+	// Settings is the settings script, "" for a single-project build without one
+	Settings string
+	// Projects are the directories of the included projects
+	Projects []string
+	// Catalog is gradle/libs.versions.toml, nil when the build has none
+	Catalog *Catalog
+	// CatalogPath is where the catalog is, or would be created
+	CatalogPath string
+}
+
+// ParseIncludes returns the directories of the projects included by a settings script,
+// relative to the build root. A project path like :services:api maps to services/api
+// unless its projectDir is set with file().
+func ParseIncludes(content []byte) []string {
+	text := stripComments(string(content))
+	dirs := make(map[string]string)
+	for _, m := range projectDirRe.FindAllStringSubmatch(text, -1) {
+		dirs[strings.TrimPrefix(m[1], ":")] = filepath.FromSlash(m[2])
+	}
+
+	var projects []string
+	seen := make(map[string]bool)
+	for _, m := range includeRe.FindAllStringSubmatch(text, -1) {
+		for _, q := range quotedRe.FindAllStringSubmatch(m[1], -1) {
+			path := strings.TrimPrefix(q[1], ":")
+			if path == "" || seen[path] {
+				continue
+			}
+			seen[path] = true
+			dir, ok := dirs[path]
+			if !ok {
+				dir = filepath.Join(strings.Split(path, ":")...)
+			}
+			projects = append(projects, dir)
+		}
+	}
+	return projects
+}
+
+// FindBuild returns the build containing the project in dir: the closest settings script
+// at or above dir that includes it, or a single-project build rooted at dir
+func FindBuild(dir string) (*Build, error) {
+	build := &Build{Dir: dir}
+	for current := dir; ; {
+		if settings := findSettings(current); settings != "" {
+			content, err := os.ReadFile(settings)
+			if err != nil {
+				return nil, err
+			}
+			projects := ParseIncludes(content)
+			for i, project := range projects {
+				projects[i] = filepath.Join(current, project)
+			}
+			if current == dir || containsPath(projects, dir) {
+				build = &Build{Dir: current, Settings: settings, Projects: projects}
+			}
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	build.CatalogPath = filepath.Join(build.Dir, filepath.FromSlash(CatalogFileName))
+	content, err := os.ReadFile(build.CatalogPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return build, nil
+		}
+		return nil, err
+	}
+	if build.Catalog, err = ParseCatalog(content); err != nil {
+		return nil, err
+	}
+	return build, nil
+}
+
+// KotlinDSL reports whether the build's settings script uses the Kotlin DSL
+func (b *Build) KotlinDSL() bool {
+	return strings.HasSuffix(b.Settings, ".kts")
+}
+
+// Includes reports whether dir is one of the build's included projects
+func (b *Build) Includes(dir string) bool {
+	return containsPath(b.Projects, dir)
+}
+
+// BuildFile returns the build script of the project in dir, or "" if there is none
+func BuildFile(dir string) string {
+	for _, name := range BuildFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func findSettings(dir string) string {
+	for _, name := range SettingsFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if filepath.Clean(p) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripComments removes // and /* */ comments, leaving strings alone
+func stripComments(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case strings.HasPrefix(text[i:], "//"):
+			end := strings.IndexByte(text[i:], '\n')
+			if end < 0 {
+				return b.String()
+			}
+			i += end - 1
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+		case c == '"' || c == '\'':
+			start := i
+			for i++; i < len(text) && text[i] != c && text[i] != '\n'; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(text) {
+				i = len(text) - 1
+			}
+			b.WriteString(text[start : i+1])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,356 @@
+package ignore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// FileName is the project-level ignore file read from the analyzed root
+const FileName = ".lawrenceignore"
+
+// gitignoreName is the per-directory git ignore file
+const gitignoreName = ".gitignore"
+
+// DefaultPatterns are always applied before any ignore file. They cover hidden
+// entries such as .git and .venv, and dependency caches; a later "!pattern"
+// re-includes them.
+var DefaultPatterns = []string{
+	".*",
+	"node_modules/",
+	"vendor/",
+	"__pycache__/",
+	"venv/",
+}
+
+// rule is a single compiled gitignore pattern
+type rule struct {
+	pattern string
+	negate  bool
+	dirOnly bool
+	re      *regexp.Regexp
+}
+
+// Matcher decides whether paths under a root are ignored. It combines the default
+// patterns, every .gitignore between the root and a path, the root .lawrenceignore
+// and extra exclude globs. Rules are evaluated in that order and the last match wins.
+// A Matcher is safe for concurrent use.
+type Matcher struct {
+	root     string
+	defaults []rule
+	project  []rule
+	cache    *gitignoreCache
+}
+
+// gitignoreCache holds parsed .gitignore files by relative directory; it is shared
+// between a matcher and the copies returned by ExcludePaths
+type gitignoreCache struct {
+	mu    sync.Mutex
+	rules map[string][]rule
+}
+
+// New creates a matcher for root, reading root/.lawrenceignore when present and
+// appending excludes (gitignore syntax) after it
+func New(root string, excludes []string) (*Matcher, error) {
+	m := newMatcher(root)
+
+	content, err := os.ReadFile(filepath.Join(m.root, FileName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+	m.project = append(m.project, parseLines(content)...)
+
+	for _, exclude := range excludes {
+		if r, ok := parsePattern(exclude); ok {
+			m.project = append(m.project, r)
+		}
+	}
+	return m, nil
+}
+
+// newMatcher creates a matcher with only the default patterns
+func newMatcher(root string) *Matcher {
+	if abs, err := filepath.Abs(root); err == nil {
+		root = abs
+	}
+	m := &Matcher{
+		root:  root,
+		cache: &gitignoreCache{rules: make(map[string][]rule)},
+	}
+	for _, p := range DefaultPatterns {
+		if r, ok := parsePattern(p); ok {
+			m.defaults = append(m.defaults, r)
+		}
+	}
+	return m
+}
+
+// ExcludePaths returns a copy of the matcher that additionally ignores the given
+// paths (files or directories) under the root; paths outside the root are dropped
+func (m *Matcher) ExcludePaths(paths ...string) *Matcher {
+	excluded := *m
+	excluded.project = append([]rule(nil), m.project...)
+	for _, path := range paths {
+		rel, ok := m.relative(path)
+		if !ok {
+			continue
+		}
+		if r, ok := parsePattern("/" + escapeGlob(rel)); ok {
+			excluded.project = append(excluded.project, r)
+		}
+	}
+	return &excluded
+}
+
+// escapeGlob quotes glob metacharacters so a literal path can be used as a pattern
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, c := range path {
+		switch c {
+		case '*', '?', '[', '\\', '!', '#':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Root returns the absolute directory the matcher is anchored at
+func (m *Matcher) Root() string {
+	return m.root
+}
+
+// Match reports whether path (absolute or relative to the working directory) is
+// ignored, either directly or because one of its parent directories is ignored
+func (m *Matcher) Match(path string, isDir bool) bool {
+	rel, ok := m.relative(path)
+	if !ok {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.matchRelative(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return m.matchRelative(rel, isDir)
+}
+
+// Walk walks the tree rooted at dir like filepath.WalkDir, skipping ignored files
+// and not descending into ignored directories. dir itself is never skipped.
+func (m *Matcher) Walk(dir string, fn fs.WalkDirFunc) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && path != dir {
+			if rel, ok := m.relative(path); ok && m.matchRelative(rel, d.IsDir()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		return fn(path, d, err)
+	})
+}
+
+// relative converts path to a slash-separated path relative to the matcher root
+func (m *Matcher) relative(path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(m.root, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// matchRelative evaluates the rules for rel without considering its parents
+func (m *Matcher) matchRelative(rel string, isDir bool) bool {
+	ignored := false
+	apply := func(rules []rule, path string) {
+		for _, r := range rules {
+			if r.dirOnly && !isDir {
+				continue
+			}
+			if r.re.MatchString(path) {
+				ignored = !r.negate
+			}
+		}
+	}
+
+	apply(m.defaults, rel)
+
+	// .gitignore files from the root down to the path's parent, each relative to its own directory
+	dir := ""
+	apply(m.gitignore(dir), rel)
+	parts := strings.Split(rel, "/")
+	for _, part := range parts[:len(parts)-1] {
+		dir = joinRel(dir, part)
+		apply(m.gitignore(dir), strings.TrimPrefix(rel, dir+"/"))
+	}
+
+	apply(m.project, rel)
+	return ignored
+}
+
+// gitignore returns the cached rules of the .gitignore in the relative directory dir
+func (m *Matcher) gitignore(dir string) []rule {
+	m.cache.mu.Lock()
+	defer m.cache.mu.Unlock()
+	if rules, ok := m.cache.rules[dir]; ok {
+		return rules
+	}
+	// Unreadable ignore files are treated as empty, as git does
+	content, _ := os.ReadFile(filepath.Join(m.root, filepath.FromSlash(dir), gitignoreName))
+	rules := parseLines(content)
+	m.cache.rules[dir] = rules
+	return rules
+}
+
+func joinRel(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
+// parseLines parses the content of an ignore file
+func parseLines(content []byte) []rule {
+	var rules []rule
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if r, ok := parsePattern(scanner.Text()); ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// parsePattern compiles one gitignore line. Blank lines and comments yield false.
+func parsePattern(line string) (rule, bool) {
+	line = strings.TrimRight(line, "\r")
+	if !strings.HasSuffix(line, `\ `) {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule{}, false
+	}
+
+	r := rule{pattern: line}
+	switch {
+	case strings.HasPrefix(line, "!"):
+		r.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return rule{}, false
+	}
+
+	// A slash anywhere but the end anchors the pattern to the ignore file's directory
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		// Invalid patterns are ignored, matching git's behaviour
+		return rule{}, false
+	}
+	r.re = re
+	return r, true
+}
+
+// globToRegexp translates gitignore glob syntax (*, ?, [...], **) to a regexp fragment
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				atStart := i == 0 || glob[i-1] == '/'
+				i++
+				switch {
+				case atStart && i+1 < len(glob) && glob[i+1] == '/':
+					// "**/" matches zero or more directories
+					b.WriteString("(?:.*/)?")
+					i++
+				case atStart && i+1 == len(glob):
+					// trailing "/**" matches everything inside
+					b.WriteString(".*")
+				default:
+					b.WriteString("[^/]*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+type contextKey struct{}
+
+// WithMatcher returns a context carrying m for detectors and injectors further down the call chain
+func WithMatcher(ctx context.Context, m *Matcher) context.Context {
+	return context.WithValue(ctx, contextKey{}, m)
+}
+
+// FromContext returns the matcher stored in ctx when it covers root. Otherwise it
+// builds one anchored at root that reads root's ignore files without extra excludes.
+func FromContext(ctx context.Context, root string) *Matcher {
+	if m, ok := ctx.Value(contextKey{}).(*Matcher); ok && m != nil {
+		if abs, err := filepath.Abs(root); err == nil {
+			if abs == m.root {
+				return m
+			}
+			if _, inside := m.relative(abs); inside {
+				return m
+			}
+		}
+	}
+	m, err := New(root, nil)
+	if err != nil {
+		return newMatcher(root)
+	}
+	return m
+}
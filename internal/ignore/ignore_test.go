@@ -0,0 +1,138 @@
+package ignore
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func walkFiles(t *testing.T, m *Matcher, root string) []string {
+	t.Helper()
+	var files []string
+	err := m.Walk(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestParsePattern_Semantics(t *testing.T) {
+	cases := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"*.log", "a/b/debug.log", false, true},
+		{"/root.txt", "root.txt", false, true},
+		{"/root.txt", "sub/root.txt", false, false},
+		{"gen/", "gen", true, true},
+		{"gen/", "gen", false, false},
+		{"docs/*.md", "docs/a.md", false, true},
+		{"docs/*.md", "docs/sub/a.md", false, false},
+		{"**/fixtures", "a/b/fixtures", true, true},
+		{"a/**/z", "a/z", false, true},
+		{"a/**/z", "a/b/c/z", false, true},
+		{"third_party/**", "third_party/x/y.go", false, true},
+		{"file[0-9].go", "file3.go", false, true},
+		{"file[!0-9].go", "file3.go", false, false},
+	}
+	for _, tc := range cases {
+		r, ok := parsePattern(tc.pattern)
+		if !ok {
+			t.Fatalf("parsePattern(%q) returned no rule", tc.pattern)
+		}
+		got := (!r.dirOnly || tc.isDir) && r.re.MatchString(tc.path)
+		if got != tc.want {
+			t.Fatalf("pattern %q on %q (dir=%v) = %v, want %v", tc.pattern, tc.path, tc.isDir, got, tc.want)
+		}
+	}
+	if _, ok := parsePattern("# comment"); ok {
+		t.Fatalf("expected comments to be skipped")
+	}
+}
+
+func TestWalk_NestedGitignoreAndNegation(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		".gitignore":              "*.gen.go\n!keep.gen.go\n",
+		"main.go":                 "",
+		"api.gen.go":              "",
+		"keep.gen.go":             "",
+		"svc/.gitignore":          "fixtures/\n",
+		"svc/main.go":             "",
+		"svc/fixtures/data.go":    "",
+		"other/fixtures/data.go":  "",
+		"node_modules/x/index.js": "",
+		".hidden/secret.go":       "",
+	})
+
+	m, err := New(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := walkFiles(t, m, root)
+	want := []string{"keep.gen.go", "main.go", "other/fixtures/data.go", "svc/main.go"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNew_LawrenceignoreAndExcludes(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		FileName:             "generated/\n!build/\n",
+		"generated/a.go":     "",
+		"build/tool.go":      "",
+		"testdata/sample.go": "",
+		"main.go":            "",
+	})
+
+	m, err := New(root, []string{"testdata"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := walkFiles(t, m, root)
+	want := []string{"build/tool.go", "main.go"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if !m.Match(filepath.Join(root, "generated", "deep", "x.go"), false) {
+		t.Fatalf("expected files under an ignored directory to match")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	root := t.TempDir()
+	m, err := New(root, []string{"skip"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := WithMatcher(context.Background(), m)
+	if FromContext(ctx, filepath.Join(root, "svc")) != m {
+		t.Fatalf("expected context matcher for a subdirectory of its root")
+	}
+	other := t.TempDir()
+	if got := FromContext(ctx, other); got == m || got.Root() == m.Root() {
+		t.Fatalf("expected a new matcher for an unrelated root")
+	}
+}
@@ -0,0 +1,180 @@
+package maven
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func TestInterpolate(t *testing.T) {
+	props := map[string]string{"otel.version": "1.42.1", "alias": "${otel.version}", "loop": "${loop}"}
+	tests := map[string]string{
+		"${otel.version}":        "1.42.1",
+		" ${alias}-alpha ":       "1.42.1-alpha",
+		"${env.OTEL_VERSION}":    "${env.OTEL_VERSION}",
+		"1.0.0":                  "1.0.0",
+		"${loop}":                "${loop}",
+		"${otel.version":         "${otel.version",
+		"${alias}/${missing}/x":  "1.42.1/${missing}/x",
+		"${otel.version}.${nil}": "1.42.1.${nil}",
+	}
+	for value, want := range tests {
+		if got := interpolate(value, props); got != want {
+			t.Errorf("interpolate(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		POMFileName: `<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <groupId>com.example</groupId>
+  <artifactId>shop</artifactId>
+  <version>2.0.0</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>services/api</module>
+  </modules>
+  <properties>
+    <otel.version>1.41.0</otel.version>
+    <otel.instrumentation.version>2.8.0</otel.instrumentation.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>io.opentelemetry</groupId>
+        <artifactId>opentelemetry-bom</artifactId>
+        <version>${otel.version}</version>
+        <type>pom</type>
+        <scope>import</scope>
+      </dependency>
+      <dependency>
+        <groupId>io.opentelemetry.instrumentation</groupId>
+        <artifactId>opentelemetry-instrumentation-annotations</artifactId>
+        <version>${otel.instrumentation.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+  <dependencies>
+    <dependency>
+      <groupId>org.slf4j</groupId>
+      <artifactId>slf4j-api</artifactId>
+      <version>2.0.16</version>
+    </dependency>
+  </dependencies>
+</project>`,
+		"services/api/" + POMFileName: `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>shop</artifactId>
+    <version>2.0.0</version>
+    <relativePath>../..</relativePath>
+  </parent>
+  <artifactId>api</artifactId>
+  <properties>
+    <otel.version>1.42.1</otel.version>
+  </properties>
+  <dependencies>
+    <dependency>
+      <groupId>io.opentelemetry</groupId>
+      <artifactId>opentelemetry-api</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>io.opentelemetry.instrumentation</groupId>
+      <artifactId>opentelemetry-instrumentation-annotations</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>shared</artifactId>
+      <version>${project.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+	})
+
+	rootPom := filepath.Join(root, POMFileName)
+	apiPom := filepath.Join(root, "services", "api", POMFileName)
+	project, err := Load(apiPom)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(project.Parents, []string{rootPom}) || project.RootPath() != rootPom {
+		t.Fatalf("expected %s as the parent, got %v", rootPom, project.Parents)
+	}
+
+	// The child's otel.version overrides the parent's, also in the parent's BOM import
+	bom, ok := project.BOMFor("io.opentelemetry")
+	if !ok || bom.Version != "1.42.1" || project.ManagedBy[bom.Coordinate()] != rootPom {
+		t.Fatalf("expected opentelemetry-bom 1.42.1 from %s, got %+v", rootPom, project.BOMs)
+	}
+	want := []Dependency{
+		{GroupID: "io.opentelemetry", ArtifactID: "opentelemetry-api", Version: "1.42.1"},
+		{GroupID: "io.opentelemetry.instrumentation", ArtifactID: "opentelemetry-instrumentation-annotations", Version: "2.8.0"},
+		{GroupID: "com.example", ArtifactID: "shared", Version: "2.0.0"},
+		{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "2.0.16"},
+	}
+	if !reflect.DeepEqual(project.Dependencies, want) {
+		t.Errorf("got %+v, want %+v", project.Dependencies, want)
+	}
+	if project.DeclaredIn["org.slf4j:slf4j-api"] != rootPom || project.DeclaredIn["com.example:shared"] != apiPom {
+		t.Errorf("unexpected declaring POMs %v", project.DeclaredIn)
+	}
+	if !project.Manages("io.opentelemetry", "opentelemetry-sdk") || project.Manages("io.opentelemetry.instrumentation", "opentelemetry-spring-boot-starter") {
+		t.Errorf("expected the BOM to manage its own group only, got %+v", project.Managed)
+	}
+
+	parent, err := Load(rootPom)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{filepath.Join(root, "services", "api")}; !reflect.DeepEqual(parent.Modules, want) {
+		t.Errorf("got modules %v, want %v", parent.Modules, want)
+	}
+}
+
+func TestLoadWithoutLocalParent(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		POMFileName: `<project>
+  <groupId>com.example</groupId>
+  <artifactId>other</artifactId>
+  <version>1.0.0</version>
+  <properties>
+    <otel.version>1.0.0</otel.version>
+  </properties>
+</project>`,
+		"app/" + POMFileName: `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.3.4</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>io.opentelemetry</groupId>
+      <artifactId>opentelemetry-api</artifactId>
+      <version>${otel.version}</version>
+    </dependency>
+  </dependencies>
+</project>`,
+	})
+
+	// ../pom.xml is another project: it's not the parent
+	project, err := Load(filepath.Join(root, "app", POMFileName))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(project.Parents) != 0 {
+		t.Errorf("expected no local parent, got %v", project.Parents)
+	}
+	if got := project.Dependencies[0].Version; got != "${otel.version}" {
+		t.Errorf("expected the unresolved reference to be kept, got %q", got)
+	}
+	if got := project.Properties["project.groupId"]; got != "org.springframework.boot" {
+		t.Errorf("expected the groupId inherited from the parent, got %q", got)
+	}
+}
@@ -0,0 +1,108 @@
+package maven
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// POMFileName is the Maven project descriptor
+const POMFileName = "pom.xml"
+
+// Dependency is a <dependency> of a POM, either a regular one or an entry of
+// <dependencyManagement>
+type Dependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Scope      string `xml:"scope"`
+}
+
+// Coordinate returns groupId:artifactId
+func (d Dependency) Coordinate() string {
+	return d.GroupID + ":" + d.ArtifactID
+}
+
+// IsBOMImport reports whether a managed dependency imports a bill of materials
+func (d Dependency) IsBOMImport() bool {
+	return d.Type == "pom" && d.Scope == "import"
+}
+
+// Parent is the <parent> of a POM
+type Parent struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	// RelativePath is nil when not set, which means ../pom.xml; an empty
+	// <relativePath/> disables the local lookup
+	RelativePath *string `xml:"relativePath"`
+}
+
+// POM is the raw content of a pom.xml, before inheritance and interpolation
+type POM struct {
+	Parent     *Parent `xml:"parent"`
+	GroupID    string  `xml:"groupId"`
+	ArtifactID string  `xml:"artifactId"`
+	Version    string  `xml:"version"`
+	Packaging  string  `xml:"packaging"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Modules              []string `xml:"modules>module"`
+	DependencyManagement struct {
+		Dependencies []Dependency `xml:"dependencies>dependency"`
+	} `xml:"dependencyManagement"`
+	Dependencies []Dependency `xml:"dependencies>dependency"`
+}
+
+// ParsePOM reads a pom.xml
+func ParsePOM(content []byte) (*POM, error) {
+	var pom POM
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return nil, err
+	}
+	return &pom, nil
+}
+
+// EffectiveGroupID returns the groupId, inherited from the parent when not set
+func (p *POM) EffectiveGroupID() string {
+	if p.GroupID == "" && p.Parent != nil {
+		return strings.TrimSpace(p.Parent.GroupID)
+	}
+	return strings.TrimSpace(p.GroupID)
+}
+
+// EffectiveVersion returns the version, inherited from the parent when not set
+func (p *POM) EffectiveVersion() string {
+	if p.Version == "" && p.Parent != nil {
+		return strings.TrimSpace(p.Parent.Version)
+	}
+	return strings.TrimSpace(p.Version)
+}
+
+// interpolate substitutes ${name} references with properties. References to unknown
+// properties, such as ${env.HOME}, are kept.
+func interpolate(value string, properties map[string]string) string {
+	value = strings.TrimSpace(value)
+	// Properties can refer to other properties; the bound stops self references
+	for range 8 {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			return value
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end < 0 {
+			return value
+		}
+		resolved, ok := properties[value[start+2:start+end]]
+		if !ok {
+			// Leave the reference, and the rest of the value, alone
+			return value
+		}
+		value = value[:start] + resolved + value[start+end+1:]
+	}
+	return value
+}
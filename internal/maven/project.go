@@ -0,0 +1,239 @@
+package maven
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// knownBOMs maps a groupId to the OpenTelemetry bill of materials managing its stable artifacts
+var knownBOMs = map[string]string{
+	"io.opentelemetry":                 "opentelemetry-bom",
+	"io.opentelemetry.instrumentation": "opentelemetry-instrumentation-bom",
+}
+
+// KnownBOM returns the OpenTelemetry BOM managing the stable artifacts of groupID, or ""
+func KnownBOM(groupID string) string {
+	return knownBOMs[groupID]
+}
+
+// Project is the effective model of a pom.xml: its properties, managed versions and
+// dependencies merged along the chain of parents found on disk, with ${...} references
+// interpolated. Parents that are only in a repository are not resolved.
+type Project struct {
+	// Path is the pom.xml
+	Path string
+	// Parents are the pom.xml files of the local parents, closest first
+	Parents []string
+	// Properties include the project.* built-ins
+	Properties map[string]string
+	// Managed are the versions of <dependencyManagement>, keyed by groupId:artifactId
+	Managed map[string]Dependency
+	// BOMs are the imported bills of materials
+	BOMs []Dependency
+	// Dependencies are the declared and inherited dependencies. Versions come from the
+	// dependency, <dependencyManagement> or the imported BOM of the same groupId.
+	Dependencies []Dependency
+	// ManagedBy maps the coordinate of a managed dependency or imported BOM to the POM whose
+	// <dependencyManagement> declares it
+	ManagedBy map[string]string
+	// DeclaredIn maps the coordinate of a dependency to the POM declaring it
+	DeclaredIn map[string]string
+	// Modules are the absolute directories of the <modules>
+	Modules []string
+}
+
+// Load builds the effective model of the pom.xml at path
+func Load(path string) (*Project, error) {
+	chain, err := loadChain(path)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &Project{
+		Path:       path,
+		Properties: make(map[string]string),
+		Managed:    make(map[string]Dependency),
+		ManagedBy:  make(map[string]string),
+		DeclaredIn: make(map[string]string),
+	}
+	for _, link := range chain[1:] {
+		project.Parents = append(project.Parents, link.path)
+	}
+
+	// Properties are inherited, the child's win
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, entry := range chain[i].pom.Properties.Entries {
+			project.Properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+		}
+	}
+	self := chain[0].pom
+	builtins := map[string]string{
+		"project.groupId":    self.EffectiveGroupID(),
+		"project.artifactId": strings.TrimSpace(self.ArtifactID),
+		"project.version":    self.EffectiveVersion(),
+		"project.basedir":    filepath.Dir(path),
+		"basedir":            filepath.Dir(path),
+	}
+	builtins["pom.version"] = builtins["project.version"]
+	if self.Parent != nil {
+		builtins["project.parent.version"] = strings.TrimSpace(self.Parent.Version)
+		builtins["project.parent.groupId"] = strings.TrimSpace(self.Parent.GroupID)
+	}
+	for name, value := range builtins {
+		if value != "" {
+			project.Properties[name] = value
+		}
+	}
+
+	// Managed versions: the closest declaration wins, BOMs apply after explicit entries
+	for _, link := range chain {
+		for _, dep := range link.pom.DependencyManagement.Dependencies {
+			dep = project.resolve(dep)
+			key := dep.Coordinate()
+			if dep.IsBOMImport() {
+				if _, seen := project.ManagedBy[key]; !seen {
+					project.ManagedBy[key] = link.path
+					project.BOMs = append(project.BOMs, dep)
+				}
+				continue
+			}
+			if _, seen := project.Managed[key]; !seen {
+				project.Managed[key] = dep
+				project.ManagedBy[key] = link.path
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, link := range chain {
+		for _, dep := range link.pom.Dependencies {
+			dep = project.resolve(dep)
+			key := dep.Coordinate()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			project.DeclaredIn[key] = link.path
+			if dep.Version == "" {
+				if managed, ok := project.Managed[key]; ok {
+					dep.Version = managed.Version
+				} else if bom, ok := project.BOMFor(dep.GroupID); ok {
+					dep.Version = bom.Version
+					project.ManagedBy[key] = project.ManagedBy[bom.Coordinate()]
+				}
+			}
+			project.Dependencies = append(project.Dependencies, dep)
+		}
+	}
+
+	dir := filepath.Dir(path)
+	for _, module := range self.Modules {
+		project.Modules = append(project.Modules, filepath.Join(dir, project.interpolate(module)))
+	}
+	return project, nil
+}
+
+// BOMFor returns the imported BOM managing the artifacts of groupID: a BOM manages the
+// artifacts of its own groupId
+func (p *Project) BOMFor(groupID string) (Dependency, bool) {
+	for _, bom := range p.BOMs {
+		if bom.GroupID == groupID {
+			return bom, true
+		}
+	}
+	return Dependency{}, false
+}
+
+// Manages reports whether the version of groupId:artifactId is provided by
+// <dependencyManagement> or an imported BOM, so the dependency can be declared without one
+func (p *Project) Manages(groupID, artifactID string) bool {
+	if _, ok := p.Managed[groupID+":"+artifactID]; ok {
+		return true
+	}
+	_, ok := p.BOMFor(groupID)
+	return ok
+}
+
+// RootPath returns the pom.xml of the outermost local parent, or the project itself
+func (p *Project) RootPath() string {
+	if len(p.Parents) == 0 {
+		return p.Path
+	}
+	return p.Parents[len(p.Parents)-1]
+}
+
+func (p *Project) interpolate(value string) string {
+	return interpolate(value, p.Properties)
+}
+
+func (p *Project) resolve(dep Dependency) Dependency {
+	dep.GroupID = p.interpolate(dep.GroupID)
+	dep.ArtifactID = p.interpolate(dep.ArtifactID)
+	dep.Version = p.interpolate(dep.Version)
+	dep.Type = strings.TrimSpace(dep.Type)
+	dep.Scope = strings.TrimSpace(dep.Scope)
+	return dep
+}
+
+// chainLink is a POM of the parent chain
+type chainLink struct {
+	path string
+	pom  *POM
+}
+
+// loadChain reads the POM at path followed by its local parents
+func loadChain(path string) ([]chainLink, error) {
+	var chain []chainLink
+	visited := make(map[string]bool)
+	for path != "" && !visited[path] {
+		visited[path] = true
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if len(chain) > 0 {
+				break
+			}
+			return nil, err
+		}
+		pom, err := ParsePOM(content)
+		if err != nil {
+			if len(chain) > 0 {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if len(chain) > 0 && !isParent(chain[len(chain)-1].pom.Parent, pom) {
+			break
+		}
+		chain = append(chain, chainLink{path: path, pom: pom})
+		path = parentPath(path, pom.Parent)
+	}
+	return chain, nil
+}
+
+// parentPath returns where the parent POM is looked for, "" when it is not local
+func parentPath(path string, parent *Parent) string {
+	if parent == nil {
+		return ""
+	}
+	rel := "../" + POMFileName
+	if parent.RelativePath != nil {
+		rel = strings.TrimSpace(*parent.RelativePath)
+		if rel == "" {
+			return ""
+		}
+	}
+	candidate := filepath.Join(filepath.Dir(path), filepath.FromSlash(rel))
+	if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+		candidate = filepath.Join(candidate, POMFileName)
+	}
+	return candidate
+}
+
+// isParent reports whether pom is the one referenced by parent
+func isParent(parent *Parent, pom *POM) bool {
+	return parent != nil &&
+		strings.TrimSpace(parent.ArtifactID) == strings.TrimSpace(pom.ArtifactID) &&
+		strings.TrimSpace(parent.GroupID) == pom.EffectiveGroupID()
+}
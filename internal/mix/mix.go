@@ -0,0 +1,268 @@
+package mix
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/textedit"
+)
+
+// FileName is the Mix project file
+const FileName = "mix.exs"
+
+// ErrNoDepsList is returned when mix.exs has no literal list in its deps function
+var ErrNoDepsList = errors.New("mix.exs has no deps list")
+
+var (
+	// depsFunctionRe matches the head of the deps function: `defp deps do` or `defp deps, do:`
+	depsFunctionRe = regexp.MustCompile(`(?m)^[ \t]*defp?[ \t]+deps(?:\(\))?[ \t]*(?:do\b|,[ \t]*do:)`)
+	// depEntryRe matches the name and optional requirement of a dependency tuple
+	depEntryRe = regexp.MustCompile(`^\{\s*:([a-z_][A-Za-z0-9_]*)\s*(?:,\s*"([^"]*)")?`)
+	// appNameRe matches the OTP application name in the project keyword list
+	appNameRe = regexp.MustCompile(`\bapp:\s*:([a-z_][A-Za-z0-9_]*)`)
+	// lockfileRe matches a custom lockfile location, as used by umbrella children
+	lockfileRe = regexp.MustCompile(`\blockfile:\s*"([^"]+)"`)
+	// lockEntryRe matches a Hex package in mix.lock: "name": {:hex, :package, "1.2.3", ...
+	lockEntryRe = regexp.MustCompile(`(?m)^\s*"([^"]+)":\s*\{:hex,\s*:[A-Za-z0-9_]+,\s*"([^"]+)"`)
+)
+
+// Dependency is an entry of the deps list in mix.exs
+type Dependency struct {
+	// Name is the dependency atom without the leading colon, e.g. "phoenix"
+	Name string
+	// Requirement is the version requirement, e.g. "~> 1.7"; empty for git, path and umbrella deps
+	Requirement string
+	// Local is true for path and in_umbrella dependencies
+	Local bool
+}
+
+// depsList locates the deps list literal in mix.exs
+type depsList struct {
+	open, close int
+	// lastCode is the offset of the last character of code before the closing bracket
+	lastCode int
+	// entries are the [start, end) offsets of the top-level tuples
+	entries [][2]int
+}
+
+// ParseDeps returns the dependencies declared in the deps function of mix.exs
+func ParseDeps(content []byte) ([]Dependency, error) {
+	list, err := findDepsList(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, span := range list.entries {
+		entry := string(content[span[0]:span[1]])
+		m := depEntryRe.FindStringSubmatch(entry)
+		if m == nil {
+			continue
+		}
+		deps = append(deps, Dependency{
+			Name:        m[1],
+			Requirement: m[2],
+			Local:       strings.Contains(entry, "path:") || strings.Contains(entry, "in_umbrella:"),
+		})
+	}
+	return deps, nil
+}
+
+// AppName returns the OTP application name declared in mix.exs, or "" when there is none
+func AppName(content []byte) string {
+	if m := appNameRe.FindSubmatch(content); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+// AddDeps appends dependency tuples to the deps list of mix.exs, skipping the ones already
+// declared. Entries use the indentation of the existing ones. It returns the updated content
+// and the names that were added.
+func AddDeps(content []byte, deps []Dependency) ([]byte, []string, error) {
+	text := string(content)
+	list, err := findDepsList(text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, span := range list.entries {
+		if m := depEntryRe.FindStringSubmatch(text[span[0]:span[1]]); m != nil {
+			existing[m[1]] = true
+		}
+	}
+
+	var entries, added []string
+	for _, dep := range deps {
+		if dep.Name == "" || existing[dep.Name] {
+			continue
+		}
+		existing[dep.Name] = true
+		entries = append(entries, FormatDep(dep))
+		added = append(added, dep.Name)
+	}
+	if len(entries) == 0 {
+		return content, nil, nil
+	}
+
+	closeIndent := textedit.LineIndent(text, list.close)
+	indent := closeIndent + "  "
+	if len(list.entries) > 0 && strings.Contains(text[list.open:list.entries[0][0]], "\n") {
+		indent = textedit.LineIndent(text, list.entries[0][0])
+	}
+
+	separator := ""
+	if list.lastCode != list.open && text[list.lastCode] != ',' {
+		separator = ","
+	}
+	newEntries := indent + strings.Join(entries, ",\n"+indent) + "\n"
+
+	var b strings.Builder
+	b.WriteString(text[:list.lastCode+1])
+	b.WriteString(separator)
+	if eol := strings.IndexByte(text[list.lastCode:list.close], '\n'); eol >= 0 {
+		// The closing bracket is on a later line: add the entries after the last one,
+		// keeping any trailing comment on its line
+		eol += list.lastCode
+		b.WriteString(text[list.lastCode+1 : eol+1])
+		b.WriteString(newEntries)
+		b.WriteString(text[eol+1:])
+	} else {
+		// Single-line list, e.g. [{:jason, "~> 1.4"}]: continue it over several lines
+		b.WriteString("\n")
+		b.WriteString(newEntries)
+		b.WriteString(closeIndent)
+		b.WriteString(text[list.close:])
+	}
+	return []byte(b.String()), added, nil
+}
+
+// FormatDep renders a dependency tuple, e.g. {:opentelemetry, "~> 1.5"}
+func FormatDep(dep Dependency) string {
+	if dep.Requirement == "" {
+		return fmt.Sprintf("{:%s, \">= 0.0.0\"}", dep.Name)
+	}
+	return fmt.Sprintf("{:%s, %q}", dep.Name, dep.Requirement)
+}
+
+// Requirement returns the requirement Mix generates for a release: "~> 1.5" for 1.5.2,
+// and "~> 0.3.1" for pre-1.0 releases whose minor versions may break compatibility
+func Requirement(version string) string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return ""
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) >= 2 && parts[0] != "0" {
+		return "~> " + parts[0] + "." + parts[1]
+	}
+	return "~> " + version
+}
+
+// LockedVersions returns the Hex package versions resolved in the project's mix.lock.
+// Umbrella children point at the umbrella lockfile with the lockfile option.
+func LockedVersions(projectDir string) map[string]string {
+	versions := make(map[string]string)
+	lockPath := filepath.Join(projectDir, "mix.lock")
+	if content, err := os.ReadFile(filepath.Join(projectDir, FileName)); err == nil {
+		if m := lockfileRe.FindSubmatch(content); m != nil {
+			lockPath = filepath.Join(projectDir, filepath.FromSlash(string(m[1])))
+		}
+	}
+	content, err := os.ReadFile(lockPath)
+	if err != nil {
+		return versions
+	}
+	for _, m := range lockEntryRe.FindAllSubmatch(content, -1) {
+		versions[string(m[1])] = string(m[2])
+	}
+	return versions
+}
+
+// FindProject returns the directory of the nearest mix.exs at or above dir, or ""
+func FindProject(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, FileName)); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// findDepsList finds the list literal returned by the deps function, skipping strings,
+// charlists and comments while matching brackets
+func findDepsList(text string) (*depsList, error) {
+	loc := depsFunctionRe.FindStringIndex(text)
+	if loc == nil {
+		return nil, ErrNoDepsList
+	}
+	i := skipSpaceAndComments(text, loc[1])
+	if i >= len(text) || text[i] != '[' {
+		return nil, ErrNoDepsList
+	}
+
+	list := &depsList{open: i, lastCode: i}
+	depth := 0
+	entryStart := -1
+	for ; i < len(text); i++ {
+		c := text[i]
+		switch c {
+		case '#':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			continue
+		case '"', '\'':
+			for i++; i < len(text) && text[i] != c; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+		case '[', '{', '(':
+			if depth == 1 && c == '{' {
+				entryStart = i
+			}
+			depth++
+		case ']', '}', ')':
+			depth--
+			if depth == 0 {
+				list.close = i
+				return list, nil
+			}
+			if depth == 1 && c == '}' && entryStart >= 0 {
+				list.entries = append(list.entries, [2]int{entryStart, i + 1})
+				entryStart = -1
+			}
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		list.lastCode = i
+	}
+	return nil, ErrNoDepsList
+}
+
+// skipSpaceAndComments returns the offset of the next character that is not whitespace or a comment
+func skipSpaceAndComments(text string, i int) int {
+	for i < len(text) {
+		switch text[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		case '#':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
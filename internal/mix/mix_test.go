@@ -0,0 +1,132 @@
+package mix
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const phoenixMix = `defmodule Orders.MixProject do
+  use Mix.Project
+
+  def project do
+    [
+      app: :orders,
+      version: "0.1.0",
+      deps: deps()
+    ]
+  end
+
+  # Run "mix help deps" to learn about dependencies.
+  defp deps do
+    [
+      {:phoenix, "~> 1.7.10"},
+      {:ecto_sql, "~> 3.10"},
+      {:shared, in_umbrella: true},
+      {:heroicons, github: "tailwindlabs/heroicons", tag: "v2.1.1", app: false},
+      {:jason, "~> 1.2"} # JSON [encoding]
+    ]
+  end
+end
+`
+
+func TestParseDeps(t *testing.T) {
+	deps, err := ParseDeps([]byte(phoenixMix))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Dependency{
+		{Name: "phoenix", Requirement: "~> 1.7.10"},
+		{Name: "ecto_sql", Requirement: "~> 3.10"},
+		{Name: "shared", Local: true},
+		{Name: "heroicons"},
+		{Name: "jason", Requirement: "~> 1.2"},
+	}
+	if len(deps) != len(want) {
+		t.Fatalf("expected %d deps, got %+v", len(want), deps)
+	}
+	for i := range want {
+		if deps[i] != want[i] {
+			t.Errorf("dep %d: expected %+v, got %+v", i, want[i], deps[i])
+		}
+	}
+	if got := AppName([]byte(phoenixMix)); got != "orders" {
+		t.Errorf("expected app name orders, got %q", got)
+	}
+}
+
+func TestAddDeps(t *testing.T) {
+	add := []Dependency{
+		{Name: "opentelemetry", Requirement: Requirement("1.5.0")},
+		{Name: "opentelemetry_exporter"},
+		{Name: "phoenix", Requirement: "~> 1.7"},
+	}
+
+	out, added, err := AddDeps([]byte(phoenixMix), add)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(added) != 2 {
+		t.Fatalf("expected two added deps, got %v", added)
+	}
+	want := `      {:jason, "~> 1.2"}, # JSON [encoding]
+      {:opentelemetry, "~> 1.5"},
+      {:opentelemetry_exporter, ">= 0.0.0"}
+    ]
+  end
+end
+`
+	if got := string(out); len(got) < len(want) || got[len(got)-len(want):] != want {
+		t.Fatalf("unexpected mix.exs:\n%s", out)
+	}
+
+	// Adding again is a no-op
+	again, added, err := AddDeps(out, add)
+	if err != nil || len(added) != 0 || string(again) != string(out) {
+		t.Fatalf("expected AddDeps to be idempotent, added %v, err %v", added, err)
+	}
+}
+
+func TestAddDeps_SingleLineAndMissingList(t *testing.T) {
+	src := "defmodule A.MixProject do\n  defp deps, do: [{:jason, \"~> 1.4\"}]\nend\n"
+	out, _, err := AddDeps([]byte(src), []Dependency{{Name: "opentelemetry", Requirement: "~> 1.5"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "defmodule A.MixProject do\n  defp deps, do: [{:jason, \"~> 1.4\"},\n    {:opentelemetry, \"~> 1.5\"}\n  ]\nend\n"
+	if string(out) != want {
+		t.Fatalf("unexpected mix.exs:\n%s", out)
+	}
+
+	dynamic := "defmodule A.MixProject do\n  defp deps do\n    base_deps() ++ extra_deps()\n  end\nend\n"
+	if _, _, err := AddDeps([]byte(dynamic), []Dependency{{Name: "opentelemetry"}}); err != ErrNoDepsList {
+		t.Fatalf("expected ErrNoDepsList, got %v", err)
+	}
+}
+
+func TestLockedVersions_UmbrellaLockfile(t *testing.T) {
+	root := t.TempDir()
+	app := filepath.Join(root, "apps", "orders")
+	if err := os.MkdirAll(app, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	lock := `%{
+  "opentelemetry": {:hex, :opentelemetry, "1.5.0", "7dda6551edfc3050ea4b0b40c0d2570423d6372b97e9c60793263ef62c53c3c2", [:rebar3], [], "hexpm", "cdf4f51d17b592fc592b9a75f86a6f2ab4e4c2ea1a8e6a44e4a6a8ab1e0f8ac1"},
+  "heroicons": {:git, "https://github.com/tailwindlabs/heroicons.git", "88ab3a0d790e6a47404cba02800a6b25d2afae50", [tag: "v2.1.1"]},
+}
+`
+	if err := os.WriteFile(filepath.Join(root, "mix.lock"), []byte(lock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(app, FileName), []byte(`lockfile: "../../mix.lock",`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	versions := LockedVersions(app)
+	if len(versions) != 1 || versions["opentelemetry"] != "1.5.0" {
+		t.Fatalf("unexpected locked versions: %v", versions)
+	}
+	if got := FindProject(filepath.Join(app, "lib", "orders")); got != app {
+		t.Fatalf("expected project %s, got %s", app, got)
+	}
+}
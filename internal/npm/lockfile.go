@@ -0,0 +1,188 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/semver"
+)
+
+// Manifest and lockfile names
+const (
+	ManifestFileName    = "package.json"
+	PackageLockFileName = "package-lock.json"
+	YarnLockFileName    = "yarn.lock"
+	PnpmLockFileName    = "pnpm-lock.yaml"
+
+	PnpmWorkspaceFileName = "pnpm-workspace.yaml"
+)
+
+// lockFileNames are the lockfiles consulted by ReadLockfile, in order
+var lockFileNames = []string{PackageLockFileName, PnpmLockFileName, YarnLockFileName}
+
+// Dependency is a dependency declared in package.json
+type Dependency struct {
+	Name string
+	// Range is the declared version range, e.g. "^1.25.0"
+	Range string
+}
+
+// LockedPackage is a package version installed according to a lockfile. A package
+// installed at several versions yields one entry per version.
+type LockedPackage struct {
+	Name    string
+	Version string
+	// Direct is set when the project's package.json depends on this version itself
+	Direct bool
+}
+
+// Dependencies returns the dependencies, devDependencies, optionalDependencies and
+// peerDependencies declared in package.json, sorted by name
+func Dependencies(content []byte) ([]Dependency, error) {
+	var manifest struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+	}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+
+	// Later groups don't override earlier ones, so a package both in peerDependencies
+	// and devDependencies keeps the range it is installed with
+	ranges := make(map[string]string)
+	for _, group := range []map[string]string{manifest.Dependencies, manifest.DevDependencies, manifest.OptionalDependencies, manifest.PeerDependencies} {
+		for name, r := range group {
+			if _, ok := ranges[name]; !ok {
+				ranges[name] = r
+			}
+		}
+	}
+	deps := make([]Dependency, 0, len(ranges))
+	for name, r := range ranges {
+		deps = append(deps, Dependency{Name: name, Range: r})
+	}
+	sort.Slice(deps, func(a, b int) bool { return deps[a].Name < deps[b].Name })
+	return deps, nil
+}
+
+// ReadLockfile parses the lockfile of the project in dir: package-lock.json, pnpm-lock.yaml
+// or yarn.lock, in that order. deps are the package.json dependencies, which yarn lockfiles
+// need to tell direct dependencies apart. A workspace member has no lockfile of its own and
+// is resolved from the one of its workspace root, the nearest parent directory with a
+// pnpm-workspace.yaml or a package.json declaring workspaces. It returns the lockfile path,
+// or "" when the project has no lockfile.
+func ReadLockfile(dir string, deps []Dependency) (string, []LockedPackage, error) {
+	if path, packages, err := readLockfile(dir, "", deps); path != "" {
+		return path, packages, err
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", nil, nil
+	}
+	for root := filepath.Dir(dir); root != filepath.Dir(root); root = filepath.Dir(root) {
+		if !isWorkspaceRoot(root) {
+			continue
+		}
+		member, err := filepath.Rel(root, dir)
+		if err != nil {
+			return "", nil, nil
+		}
+		return readLockfile(root, filepath.ToSlash(member), deps)
+	}
+	return "", nil, nil
+}
+
+// readLockfile parses the lockfile in dir for the workspace member at the relative path
+// member, "" being the package in dir itself
+func readLockfile(dir, member string, deps []Dependency) (string, []LockedPackage, error) {
+	for _, name := range lockFileNames {
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var packages []LockedPackage
+		switch name {
+		case PackageLockFileName:
+			packages, err = ParsePackageLock(content, member)
+		case PnpmLockFileName:
+			packages, err = ParsePnpmLock(content, member)
+		default:
+			packages, err = ParseYarnLock(content, deps, member)
+		}
+		if err != nil {
+			return path, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return path, packages, nil
+	}
+	return "", nil, nil
+}
+
+// isWorkspaceRoot reports whether dir is the root of a pnpm, npm or yarn workspace
+func isWorkspaceRoot(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, PnpmWorkspaceFileName)); err == nil {
+		return true
+	}
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		return false
+	}
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	return json.Unmarshal(content, &manifest) == nil && len(manifest.Workspaces) > 0 && string(manifest.Workspaces) != "null"
+}
+
+// splitSpec splits "name@version" or a "name@range" descriptor into its parts, keeping
+// the @ of scoped names
+func splitSpec(spec string) (string, string, bool) {
+	if len(spec) < 2 {
+		return "", "", false
+	}
+	at := strings.Index(spec[1:], "@")
+	if at < 0 {
+		return "", "", false
+	}
+	return spec[:at+1], spec[at+2:], true
+}
+
+// lockedSet collects locked packages, one entry per name and version
+type lockedSet struct {
+	packages []LockedPackage
+	index    map[string]int
+}
+
+func (s *lockedSet) add(name, version string, direct bool) {
+	if s.index == nil {
+		s.index = make(map[string]int)
+	}
+	key := name + "@" + version
+	if i, ok := s.index[key]; ok {
+		s.packages[i].Direct = s.packages[i].Direct || direct
+		return
+	}
+	s.index[key] = len(s.packages)
+	s.packages = append(s.packages, LockedPackage{Name: name, Version: version, Direct: direct})
+}
+
+// sorted returns the packages ordered by name, then by semantic version
+func (s *lockedSet) sorted() []LockedPackage {
+	sort.SliceStable(s.packages, func(a, b int) bool {
+		pa, pb := s.packages[a], s.packages[b]
+		if pa.Name != pb.Name {
+			return pa.Name < pb.Name
+		}
+		c, err := semver.Compare(pa.Version, pb.Version)
+		if err != nil {
+			return pa.Version < pb.Version
+		}
+		return c < 0
+	})
+	return s.packages
+}
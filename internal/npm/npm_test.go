@@ -0,0 +1,381 @@
+package npm
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+var manifestDeps = []Dependency{
+	{Name: "@opentelemetry/api", Range: "^1.7.0"},
+	{Name: "@opentelemetry/sdk-trace-base", Range: "^1.25.0"},
+}
+
+func TestDependencies(t *testing.T) {
+	deps, err := Dependencies([]byte(`{
+  "name": "checkout",
+  "version": "1.0.0",
+  "scripts": { "start": "node index.js" },
+  "dependencies": { "express": "^4.19.0", "@opentelemetry/api": "^1.7.0" },
+  "devDependencies": { "typescript": "~5.4.0" },
+  "peerDependencies": { "express": "4" }
+}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Dependency{
+		{Name: "@opentelemetry/api", Range: "^1.7.0"},
+		{Name: "express", Range: "^4.19.0"},
+		{Name: "typescript", Range: "~5.4.0"},
+	}
+	if !reflect.DeepEqual(deps, want) {
+		t.Errorf("got %+v, want %+v", deps, want)
+	}
+}
+
+func TestParseLockfiles(t *testing.T) {
+	// Each lockfile installs api 1.7.0 for the project and 1.4.1 nested under the
+	// exporter, sdk-trace-base directly and core transitively
+	want := []LockedPackage{
+		{Name: "@opentelemetry/api", Version: "1.4.1"},
+		{Name: "@opentelemetry/api", Version: "1.7.0", Direct: true},
+		{Name: "@opentelemetry/core", Version: "1.25.0"},
+		{Name: "@opentelemetry/sdk-trace-base", Version: "1.25.0", Direct: true},
+	}
+
+	tests := []struct {
+		name    string
+		parse   func([]byte) ([]LockedPackage, error)
+		content string
+	}{
+		{
+			name:  "package-lock v3",
+			parse: func(content []byte) ([]LockedPackage, error) { return ParsePackageLock(content, "") },
+			content: `{
+  "name": "checkout",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {
+      "name": "checkout",
+      "dependencies": { "@opentelemetry/api": "^1.7.0", "@opentelemetry/sdk-trace-base": "^1.25.0" }
+    },
+    "node_modules/@opentelemetry/api": { "version": "1.7.0" },
+    "node_modules/@opentelemetry/core": { "version": "1.25.0" },
+    "node_modules/@opentelemetry/legacy-exporter/node_modules/@opentelemetry/api": { "version": "1.4.1" },
+    "node_modules/@opentelemetry/sdk-trace-base": { "version": "1.25.0" },
+    "node_modules/shared": { "resolved": "packages/shared", "link": true },
+    "packages/shared": { "version": "0.1.0" }
+  }
+}`,
+		},
+		{
+			name:  "yarn classic",
+			parse: func(content []byte) ([]LockedPackage, error) { return ParseYarnLock(content, manifestDeps, "") },
+			content: `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@opentelemetry/api@>=1.0.0 <1.5.0":
+  version "1.4.1"
+  resolved "https://registry.yarnpkg.com/@opentelemetry/api/-/api-1.4.1.tgz"
+
+"@opentelemetry/api@^1.3.0", "@opentelemetry/api@^1.7.0":
+  version "1.7.0"
+
+"@opentelemetry/core@1.25.0":
+  version "1.25.0"
+  dependencies:
+    "@opentelemetry/semantic-conventions" "1.25.0"
+
+"@opentelemetry/sdk-trace-base@^1.25.0":
+  version "1.25.0"
+`,
+		},
+		{
+			name:  "yarn berry",
+			parse: func(content []byte) ([]LockedPackage, error) { return ParseYarnLock(content, nil, "") },
+			content: `__metadata:
+  version: 8
+  cacheKey: 10c0
+
+"@opentelemetry/api@npm:>=1.0.0 <1.5.0":
+  version: 1.4.1
+  resolution: "@opentelemetry/api@npm:1.4.1"
+  languageName: node
+  linkType: hard
+
+"@opentelemetry/api@npm:^1.3.0, @opentelemetry/api@npm:^1.7.0":
+  version: 1.7.0
+  resolution: "@opentelemetry/api@npm:1.7.0"
+
+"@opentelemetry/core@npm:1.25.0":
+  version: 1.25.0
+  resolution: "@opentelemetry/core@npm:1.25.0"
+
+"@opentelemetry/sdk-trace-base@npm:^1.25.0":
+  version: 1.25.0
+  resolution: "@opentelemetry/sdk-trace-base@npm:1.25.0"
+
+"checkout@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "checkout@workspace:."
+  dependencies:
+    "@opentelemetry/api": "npm:^1.7.0"
+    "@opentelemetry/sdk-trace-base": "npm:^1.25.0"
+  languageName: unknown
+  linkType: soft
+`,
+		},
+		{
+			name:  "pnpm v6",
+			parse: func(content []byte) ([]LockedPackage, error) { return ParsePnpmLock(content, "") },
+			content: `lockfileVersion: '6.0'
+
+dependencies:
+  '@opentelemetry/api':
+    specifier: ^1.7.0
+    version: 1.7.0
+  '@opentelemetry/sdk-trace-base':
+    specifier: ^1.25.0
+    version: 1.25.0(@opentelemetry/api@1.7.0)
+
+packages:
+
+  /@opentelemetry/api@1.4.1:
+    resolution: {integrity: sha512-x}
+    dev: false
+
+  /@opentelemetry/api@1.7.0:
+    resolution: {integrity: sha512-y}
+    dev: false
+
+  /@opentelemetry/core@1.25.0(@opentelemetry/api@1.7.0):
+    resolution: {integrity: sha512-z}
+    peerDependencies:
+      '@opentelemetry/api': '>=1.0.0 <1.10.0'
+
+  /@opentelemetry/sdk-trace-base@1.25.0(@opentelemetry/api@1.7.0):
+    resolution: {integrity: sha512-w}
+`,
+		},
+		{
+			name:  "pnpm v9",
+			parse: func(content []byte) ([]LockedPackage, error) { return ParsePnpmLock(content, "") },
+			content: `lockfileVersion: '9.0'
+
+importers:
+
+  .:
+    dependencies:
+      '@opentelemetry/api':
+        specifier: ^1.7.0
+        version: 1.7.0
+      '@opentelemetry/sdk-trace-base':
+        specifier: ^1.25.0
+        version: 1.25.0(@opentelemetry/api@1.7.0)
+
+  packages/worker:
+    dependencies:
+      '@opentelemetry/core':
+        specifier: 1.25.0
+        version: 1.25.0(@opentelemetry/api@1.7.0)
+
+packages:
+
+  '@opentelemetry/api@1.4.1':
+    resolution: {integrity: sha512-x}
+
+  '@opentelemetry/api@1.7.0':
+    resolution: {integrity: sha512-y}
+
+  '@opentelemetry/core@1.25.0':
+    resolution: {integrity: sha512-z}
+
+  '@opentelemetry/sdk-trace-base@1.25.0':
+    resolution: {integrity: sha512-w}
+
+snapshots:
+
+  '@opentelemetry/api@1.7.0': {}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParsePackageLockV1Unsupported(t *testing.T) {
+	if _, err := ParsePackageLock([]byte(`{"lockfileVersion": 1, "dependencies": {}}`), ""); err == nil {
+		t.Fatal("expected an error for lockfileVersion 1")
+	}
+}
+
+func TestReadLockfile(t *testing.T) {
+	dir := t.TempDir()
+	path, packages, err := ReadLockfile(dir, manifestDeps)
+	if err != nil || path != "" || packages != nil {
+		t.Fatalf("expected no lockfile, got %q %+v %v", path, packages, err)
+	}
+
+	content := "\"@opentelemetry/api@^1.7.0\":\n  version \"1.7.2\"\n"
+	if err := os.WriteFile(filepath.Join(dir, YarnLockFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path, packages, err = ReadLockfile(dir, manifestDeps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filepath.Base(path) != YarnLockFileName || len(packages) != 1 || packages[0].Version != "1.7.2" || !packages[0].Direct {
+		t.Errorf("expected the direct api 1.7.2 from yarn.lock, got %q %+v", path, packages)
+	}
+}
+
+func TestReadLockfileWorkspace(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		files    map[string]string
+		want     []LockedPackage
+	}{
+		{
+			name:     "npm",
+			manifest: `{"name": "shop", "workspaces": ["packages/*"]}`,
+			files: map[string]string{PackageLockFileName: `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": { "name": "shop", "workspaces": ["packages/*"] },
+    "node_modules/@opentelemetry/api": { "version": "1.7.0" },
+    "node_modules/@opentelemetry/core": { "version": "1.25.0" },
+    "node_modules/checkout": { "resolved": "packages/checkout", "link": true },
+    "packages/checkout": {
+      "name": "checkout",
+      "dependencies": { "@opentelemetry/api": "^1.4.0", "@opentelemetry/core": "^1.25.0" }
+    },
+    "packages/checkout/node_modules/@opentelemetry/api": { "version": "1.4.1" },
+    "packages/worker/node_modules/@opentelemetry/api": { "version": "1.9.0" }
+  }
+}`},
+			want: []LockedPackage{
+				{Name: "@opentelemetry/api", Version: "1.4.1", Direct: true},
+				{Name: "@opentelemetry/api", Version: "1.7.0"},
+				{Name: "@opentelemetry/core", Version: "1.25.0", Direct: true},
+			},
+		},
+		{
+			name:     "pnpm",
+			manifest: `{"name": "shop"}`,
+			files: map[string]string{
+				PnpmWorkspaceFileName: "packages:\n  - packages/*\n",
+				PnpmLockFileName: `lockfileVersion: '9.0'
+
+importers:
+
+  .:
+    dependencies:
+      '@opentelemetry/api':
+        specifier: ^1.7.0
+        version: 1.7.0
+
+  packages/checkout:
+    dependencies:
+      '@opentelemetry/api':
+        specifier: ~1.4.0
+        version: 1.4.1
+
+packages:
+
+  '@opentelemetry/api@1.4.1':
+    resolution: {integrity: sha512-x}
+
+  '@opentelemetry/api@1.7.0':
+    resolution: {integrity: sha512-y}
+`,
+			},
+			want: []LockedPackage{
+				{Name: "@opentelemetry/api", Version: "1.4.1", Direct: true},
+				{Name: "@opentelemetry/api", Version: "1.7.0"},
+			},
+		},
+		{
+			name:     "yarn berry",
+			manifest: `{"name": "shop", "workspaces": {"packages": ["packages/*"]}}`,
+			files: map[string]string{YarnLockFileName: `__metadata:
+  version: 8
+
+"@opentelemetry/api@npm:^1.7.0":
+  version: 1.7.0
+  resolution: "@opentelemetry/api@npm:1.7.0"
+
+"@opentelemetry/api@npm:~1.4.0":
+  version: 1.4.1
+  resolution: "@opentelemetry/api@npm:1.4.1"
+
+"checkout@workspace:packages/checkout":
+  version: 0.0.0-use.local
+  resolution: "checkout@workspace:packages/checkout"
+  dependencies:
+    "@opentelemetry/api": "npm:~1.4.0"
+
+"shop@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "shop@workspace:."
+  dependencies:
+    "@opentelemetry/api": "npm:^1.7.0"
+`},
+			want: []LockedPackage{
+				{Name: "@opentelemetry/api", Version: "1.4.1", Direct: true},
+				{Name: "@opentelemetry/api", Version: "1.7.0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			files := map[string]string{
+				ManifestFileName:                        tt.manifest,
+				"packages/checkout/" + ManifestFileName: `{"name": "checkout"}`,
+			}
+			for name, content := range tt.files {
+				files[name] = content
+			}
+			testutil.WriteFiles(t, root, files)
+
+			path, got, err := ReadLockfile(filepath.Join(root, "packages", "checkout"), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if filepath.Dir(path) != root {
+				t.Errorf("expected the workspace root lockfile, got %q", path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadLockfileOutsideWorkspace(t *testing.T) {
+	// A lockfile further up belongs to another project unless it is a workspace root
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		PackageLockFileName:              `{"lockfileVersion": 3, "packages": {"": {}}}`,
+		"tools/lint/" + ManifestFileName: `{"name": "lint"}`,
+	})
+	if path, packages, err := ReadLockfile(filepath.Join(root, "tools", "lint"), nil); err != nil || path != "" || packages != nil {
+		t.Errorf("expected no lockfile, got %q %+v %v", path, packages, err)
+	}
+}
@@ -0,0 +1,68 @@
+package npm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// packageLockEntry is an entry of the package-lock.json "packages" map
+type packageLockEntry struct {
+	Version              string            `json:"version"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// ParsePackageLock returns the packages installed according to an npm lockfile of
+// lockfileVersion 2 or 3 for the workspace member at the relative path member, "" being
+// the root package. Packages the member declares are direct where it resolves them, in
+// its own node_modules or else the hoisted top-level one; everything else is transitive.
+// Packages nested in other members' node_modules aren't installed for the member.
+func ParsePackageLock(content []byte, member string) ([]LockedPackage, error) {
+	var lock struct {
+		LockfileVersion int                         `json:"lockfileVersion"`
+		Packages        map[string]packageLockEntry `json:"packages"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	if lock.Packages == nil {
+		return nil, fmt.Errorf("unsupported lockfileVersion %d, run npm install with npm 7 or later", lock.LockfileVersion)
+	}
+
+	project, ok := lock.Packages[member]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a workspace of the lockfile", member)
+	}
+	declared := make(map[string]bool)
+	for _, group := range []map[string]string{project.Dependencies, project.DevDependencies, project.OptionalDependencies, project.PeerDependencies} {
+		for name := range group {
+			declared[name] = true
+		}
+	}
+	nested := member + "/node_modules/"
+	resolved := func(name string) string {
+		if _, ok := lock.Packages[nested+name]; member != "" && ok {
+			return nested + name
+		}
+		return "node_modules/" + name
+	}
+
+	var set lockedSet
+	for path, entry := range lock.Packages {
+		i := strings.LastIndex(path, "node_modules/")
+		if i < 0 || entry.Link || entry.Version == "" {
+			// The root package, workspace sources and symlinks to them
+			continue
+		}
+		if member != "" && !strings.HasPrefix(path, "node_modules/") && !strings.HasPrefix(path, nested) {
+			continue
+		}
+		name := path[i+len("node_modules/"):]
+		set.add(name, entry.Version, declared[name] && path == resolved(name))
+	}
+	return set.sorted(), nil
+}
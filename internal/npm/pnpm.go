@@ -0,0 +1,94 @@
+package npm
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// pnpmDependency is a dependency of a pnpm importer. Lockfile v6+ write a specifier and
+// version mapping; earlier versions only the version.
+type pnpmDependency struct {
+	Version string
+}
+
+func (d *pnpmDependency) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		d.Version = node.Value
+		return nil
+	}
+	var dep struct {
+		Version string `yaml:"version"`
+	}
+	if err := node.Decode(&dep); err != nil {
+		return err
+	}
+	d.Version = dep.Version
+	return nil
+}
+
+// pnpmImporter holds the dependencies of a workspace package
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmDependency `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmDependency `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmDependency `yaml:"optionalDependencies"`
+}
+
+// ParsePnpmLock returns the packages installed according to a pnpm-lock.yaml of lockfile
+// version 6 to 9. Packages the importer of the workspace member at the relative path
+// member depends on are direct, "" being the root importer.
+func ParsePnpmLock(content []byte, member string) ([]LockedPackage, error) {
+	var lock struct {
+		pnpmImporter `yaml:",inline"`
+		Importers    map[string]pnpmImporter `yaml:"importers"`
+		Packages     map[string]yaml.Node    `yaml:"packages"`
+	}
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	// Single-package lockfiles before v9 keep the root dependencies at the top level
+	importer, ok := lock.Importers[workspacePath(member)]
+	switch {
+	case !ok && member != "":
+		return nil, fmt.Errorf("%s is not an importer of the lockfile", member)
+	case !ok:
+		importer = lock.pnpmImporter
+	}
+	direct := make(map[string]bool)
+	for _, group := range []map[string]pnpmDependency{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+		for name, dep := range group {
+			direct[name+"@"+pnpmVersion(dep.Version)] = true
+		}
+	}
+
+	var set lockedSet
+	for key := range lock.Packages {
+		// v6 keys look like /@opentelemetry/sdk-trace-base@1.25.0(@opentelemetry/api@1.7.0),
+		// v9 ones like @opentelemetry/sdk-trace-base@1.25.0
+		name, version, ok := splitSpec(pnpmVersion(strings.TrimPrefix(key, "/")))
+		if !ok {
+			continue
+		}
+		set.add(name, version, direct[name+"@"+version])
+	}
+	return set.sorted(), nil
+}
+
+// pnpmVersion strips the peer dependency suffix pnpm appends to versions, e.g.
+// 1.25.0(@opentelemetry/api@1.7.0)
+func pnpmVersion(version string) string {
+	if i := strings.Index(version, "("); i >= 0 {
+		return version[:i]
+	}
+	return version
+}
+
+// workspacePath returns the path pnpm and yarn key a workspace member by, "." for the root
+func workspacePath(member string) string {
+	if member == "" {
+		return "."
+	}
+	return member
+}
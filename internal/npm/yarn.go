@@ -0,0 +1,103 @@
+package npm
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yarnBerryEntry is an entry of a Yarn 2+ lockfile
+type yarnBerryEntry struct {
+	Version      string            `yaml:"version"`
+	Resolution   string            `yaml:"resolution"`
+	Dependencies map[string]string `yaml:"dependencies"`
+}
+
+// ParseYarnLock returns the packages installed according to a yarn.lock, either the
+// Yarn 1 format or the YAML one of Yarn 2+ (berry). Yarn 1 lockfiles don't record which
+// packages the project declares, so an entry is direct when one of its descriptors
+// matches a package.json dependency (or, for berry, one of the workspace at the relative
+// path member, "" being the root workspace).
+func ParseYarnLock(content []byte, deps []Dependency, member string) ([]LockedPackage, error) {
+	direct := make(map[string]bool)
+	for _, dep := range deps {
+		direct[dep.Name+"@"+dep.Range] = true
+		direct[dep.Name+"@npm:"+dep.Range] = true
+	}
+	if bytes.Contains(content, []byte("__metadata:")) {
+		return parseYarnBerryLock(content, direct, workspacePath(member))
+	}
+	return parseYarnClassicLock(content, direct)
+}
+
+// parseYarnClassicLock parses the Yarn 1 format:
+//
+//	"@opentelemetry/api@^1.4.0", "@opentelemetry/api@^1.7.0":
+//	  version "1.7.0"
+func parseYarnClassicLock(content []byte, direct map[string]bool) ([]LockedPackage, error) {
+	var set lockedSet
+	var name string
+	var isDirect bool
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			name, isDirect = "", false
+			for _, desc := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				desc = strings.Trim(strings.TrimSpace(desc), `"`)
+				if n, _, ok := splitSpec(desc); ok {
+					name = n
+					isDirect = isDirect || direct[desc]
+				}
+			}
+			continue
+		}
+		field := strings.TrimSpace(line)
+		if name == "" || !strings.HasPrefix(field, "version ") {
+			continue
+		}
+		version := strings.TrimSpace(strings.TrimPrefix(field, "version "))
+		if unquoted, err := strconv.Unquote(version); err == nil {
+			version = unquoted
+		}
+		set.add(name, version, isDirect)
+		name = ""
+	}
+	return set.sorted(), scanner.Err()
+}
+
+// parseYarnBerryLock parses the YAML lockfile of Yarn 2+. Workspace entries are the
+// project's own packages; the one at workspace lists the direct dependencies.
+func parseYarnBerryLock(content []byte, direct map[string]bool, workspace string) ([]LockedPackage, error) {
+	var lock map[string]yarnBerryEntry
+	if err := yaml.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+	for _, entry := range lock {
+		if strings.HasSuffix(entry.Resolution, "@workspace:"+workspace) {
+			for name, desc := range entry.Dependencies {
+				direct[name+"@"+desc] = true
+			}
+		}
+	}
+
+	var set lockedSet
+	for key, entry := range lock {
+		name, reference, ok := splitSpec(entry.Resolution)
+		if key == "__metadata" || !ok || entry.Version == "" || strings.HasPrefix(reference, "workspace:") {
+			continue
+		}
+		isDirect := false
+		for _, desc := range strings.Split(key, ",") {
+			isDirect = isDirect || direct[strings.TrimSpace(desc)]
+		}
+		set.add(name, entry.Version, isDirect)
+	}
+	return set.sorted(), nil
+}
@@ -0,0 +1,300 @@
+package python
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/getlawrence/cli/internal/textedit"
+)
+
+// ErrNoProjectTable is returned when pyproject.toml has no [project] table to add dependencies to
+var ErrNoProjectTable = errors.New("pyproject.toml has no [project] table")
+
+var (
+	// tableHeaderRe matches a table header line, e.g. [tool.poetry.dependencies]
+	tableHeaderRe = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*\]\s*(?:#.*)?$`)
+	// arrayTableHeaderRe matches an array of tables header line, e.g. [[tool.uv.index]]
+	arrayTableHeaderRe = regexp.MustCompile(`^\s*\[\[.*\]\]\s*(?:#.*)?$`)
+	// dependenciesKeyRe matches the start of the PEP 621 dependencies array
+	dependenciesKeyRe = regexp.MustCompile(`^\s*dependencies\s*=\s*\[`)
+)
+
+// AddProjectDependencies appends requirements to the PEP 621 dependencies array of
+// pyproject.toml, skipping the distributions it already lists, and creates the key when
+// the [project] table has none. Formatting and comments are preserved. It returns the
+// updated content and the requirements that were added.
+func AddProjectDependencies(content []byte, reqs []Requirement) ([]byte, []string, error) {
+	var raw rawPyproject
+	meta, err := toml.Decode(string(content), &raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", PyprojectFileName, err)
+	}
+	if !meta.IsDefined("project") {
+		return nil, nil, ErrNoProjectTable
+	}
+	var existing requirementSet
+	for _, spec := range raw.Project.Dependencies {
+		existing.addString(spec)
+	}
+	added := missingRequirements(&existing, reqs)
+	if len(added) == 0 {
+		return content, nil, nil
+	}
+
+	text := string(content)
+	lines := strings.SplitAfter(text, "\n")
+	start, end, ok := tableSpan(lines, "project")
+	if !ok {
+		return nil, nil, fmt.Errorf("the [project] table of %s is not declared with a header", PyprojectFileName)
+	}
+
+	offset := 0
+	for idx, line := range lines {
+		if idx > start && idx < end && dependenciesKeyRe.MatchString(line) {
+			open := offset + strings.Index(line, "[")
+			return []byte(appendToArray(text, open, added)), added, nil
+		}
+		offset += len(line)
+	}
+
+	// No dependencies key yet: add one after the last key of the table
+	insertAt := lastEntryLine(lines, start, end) + 1
+	var b strings.Builder
+	b.WriteString("dependencies = [\n")
+	for _, req := range added {
+		fmt.Fprintf(&b, "    %q,\n", req)
+	}
+	b.WriteString("]\n")
+	return []byte(insertLines(lines, insertAt, b.String())), added, nil
+}
+
+// AddTableDependencies adds `name = "constraint"` entries to a table of distribution names,
+// such as [tool.poetry.dependencies] or the [packages] of a Pipfile, skipping the ones it
+// already has. The table is appended when missing. It returns the updated content and the
+// names that were added.
+func AddTableDependencies(content []byte, table string, reqs []Requirement) ([]byte, []string, error) {
+	var doc map[string]any
+	if _, err := toml.Decode(string(content), &doc); err != nil {
+		return nil, nil, err
+	}
+	var existing requirementSet
+	current, defined := lookupTable(doc, table)
+	for name := range current {
+		existing.add(Requirement{Name: name})
+	}
+
+	var entries []string
+	var added []string
+	for _, req := range reqs {
+		if existing.seen[NormalizeName(req.Name)] {
+			continue
+		}
+		existing.add(req)
+		constraint := req.Specifier
+		if constraint == "" {
+			constraint = "*"
+		}
+		entries = append(entries, fmt.Sprintf("%s = %q\n", req.Name, constraint))
+		added = append(added, req.Name)
+	}
+	if len(entries) == 0 {
+		return content, nil, nil
+	}
+
+	text := string(content)
+	lines := strings.SplitAfter(text, "\n")
+	start, end, ok := tableSpan(lines, table)
+	if !ok {
+		if defined {
+			return nil, nil, fmt.Errorf("table [%s] is not declared with a header", table)
+		}
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		if text != "" {
+			text += "\n"
+		}
+		return []byte(text + "[" + table + "]\n" + strings.Join(entries, "")), added, nil
+	}
+	return []byte(insertLines(lines, lastEntryLine(lines, start, end)+1, strings.Join(entries, ""))), added, nil
+}
+
+// missingRequirements returns the requirements whose distributions are not in existing,
+// rendered in PEP 508 form
+func missingRequirements(existing *requirementSet, reqs []Requirement) []string {
+	var added []string
+	for _, req := range reqs {
+		key := NormalizeName(req.Name)
+		if existing.seen[key] {
+			continue
+		}
+		existing.add(req)
+		added = append(added, req.String())
+	}
+	return added
+}
+
+// lookupTable follows a dotted table name through a decoded TOML document
+func lookupTable(doc map[string]any, table string) (map[string]any, bool) {
+	current := doc
+	for _, key := range strings.Split(table, ".") {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+// tableSpan returns the header line of a table and the line that ends it: the next
+// header, or len(lines)
+func tableSpan(lines []string, table string) (int, int, bool) {
+	start := -1
+	for idx, line := range lines {
+		m := tableHeaderRe.FindStringSubmatch(line)
+		if m == nil && !arrayTableHeaderRe.MatchString(line) {
+			continue
+		}
+		if start >= 0 {
+			return start, idx, true
+		}
+		if m != nil && m[1] == table {
+			start = idx
+		}
+	}
+	return start, len(lines), start >= 0
+}
+
+// lastEntryLine returns the last line of a table that is not blank, so new entries go
+// before the blank lines separating it from the next table
+func lastEntryLine(lines []string, start, end int) int {
+	last := end - 1
+	for last > start && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+	return last
+}
+
+// insertLines inserts block, made of complete lines, before lines[at]
+func insertLines(lines []string, at int, block string) string {
+	before := strings.Join(lines[:at], "")
+	if before != "" && !strings.HasSuffix(before, "\n") {
+		before += "\n"
+	}
+	return before + block + strings.Join(lines[at:], "")
+}
+
+// appendToArray appends quoted strings to the TOML array opening at text[open]. Multi-line
+// arrays get one item per line with the indentation of the existing items; single-line
+// arrays stay on one line.
+func appendToArray(text string, open int, items []string) string {
+	closeAt, lastValue := scanArray(text, open)
+	if closeAt < 0 {
+		return text
+	}
+
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+
+	needComma := lastValue >= 0 && text[lastValue] != ','
+	if !strings.Contains(text[open:closeAt], "\n") {
+		sep := ""
+		if lastValue >= 0 {
+			sep = " "
+			if needComma {
+				sep = ", "
+			}
+		}
+		return text[:closeAt] + sep + strings.Join(quoted, ", ") + text[closeAt:]
+	}
+
+	indent := textedit.LineIndent(text, open) + "    "
+	if lastValue >= 0 {
+		indent = textedit.LineIndent(text, lastValue)
+	}
+	var b strings.Builder
+	for _, q := range quoted {
+		b.WriteString(indent + q + ",\n")
+	}
+
+	// Insert on the line of the closing bracket, or before it when items share that line
+	closeLine := strings.LastIndex(text[:closeAt], "\n") + 1
+	var out string
+	if strings.TrimSpace(text[closeLine:closeAt]) == "" {
+		out = text[:closeLine] + b.String() + text[closeLine:]
+	} else {
+		sep := "\n"
+		if needComma {
+			sep = ",\n"
+		}
+		out = text[:closeAt] + sep + b.String() + textedit.LineIndent(text, open) + text[closeAt:]
+		needComma = false
+	}
+	if needComma {
+		out = out[:lastValue+1] + "," + out[lastValue+1:]
+	}
+	return out
+}
+
+// scanArray returns the offset of the bracket closing the array opening at text[open],
+// and the offset of the last character of its last value (or trailing comma), -1 when
+// the array is empty. Strings and comments are skipped.
+func scanArray(text string, open int) (int, int) {
+	depth, lastValue := 0, -1
+	for i := open; i < len(text); i++ {
+		switch c := text[i]; c {
+		case '"', '\'':
+			end := strings.IndexByte(text[i+1:], c)
+			if c == '"' {
+				end = closingQuote(text, i+1)
+			}
+			if end < 0 {
+				return -1, -1
+			}
+			i += end + 1
+			lastValue = i
+		case '#':
+			if nl := strings.IndexByte(text[i:], '\n'); nl >= 0 {
+				i += nl
+			} else {
+				i = len(text)
+			}
+		case '[':
+			depth++
+			if i != open {
+				lastValue = i
+			}
+		case ']':
+			depth--
+			if depth == 0 {
+				return i, lastValue
+			}
+			lastValue = i
+		case ' ', '\t', '\r', '\n':
+		default:
+			lastValue = i
+		}
+	}
+	return -1, -1
+}
+
+// closingQuote returns the index, relative to from, of the double quote ending a basic
+// string, honouring backslash escapes
+func closingQuote(text string, from int) int {
+	for i := from; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i - from
+		}
+	}
+	return -1
+}
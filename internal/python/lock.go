@@ -0,0 +1,119 @@
+package python
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Lockfile names
+const (
+	PoetryLockFileName  = "poetry.lock"
+	UvLockFileName      = "uv.lock"
+	PipfileLockFileName = "Pipfile.lock"
+)
+
+// LockedPackage is a distribution version pinned by a lockfile
+type LockedPackage struct {
+	Name    string
+	Version string
+}
+
+// ReadLock parses the lockfile in dir: poetry.lock, uv.lock or Pipfile.lock, in that order.
+// It returns the lockfile path, or "" when dir has no lockfile. Lockfiles pin the whole
+// dependency tree, transitive packages included.
+func ReadLock(dir string) (string, []LockedPackage, error) {
+	parsers := []struct {
+		file  string
+		parse func([]byte) ([]LockedPackage, error)
+	}{
+		{PoetryLockFileName, ParsePoetryLock},
+		{UvLockFileName, ParseUvLock},
+		{PipfileLockFileName, ParsePipfileLock},
+	}
+	for _, p := range parsers {
+		path := filepath.Join(dir, p.file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		packages, err := p.parse(content)
+		if err != nil {
+			return path, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return path, packages, nil
+	}
+	return "", nil, nil
+}
+
+// ParsePoetryLock returns the packages of a poetry.lock
+func ParsePoetryLock(content []byte) ([]LockedPackage, error) {
+	var lock struct {
+		Package []LockedPackage `toml:"package"`
+	}
+	if _, err := toml.Decode(string(content), &lock); err != nil {
+		return nil, err
+	}
+	return lock.Package, nil
+}
+
+// ParseUvLock returns the packages of a uv.lock, leaving out the project itself, workspace
+// members and local path dependencies, which are installed from source
+func ParseUvLock(content []byte) ([]LockedPackage, error) {
+	var lock struct {
+		Package []struct {
+			Name    string         `toml:"name"`
+			Version string         `toml:"version"`
+			Source  map[string]any `toml:"source"`
+		} `toml:"package"`
+	}
+	if _, err := toml.Decode(string(content), &lock); err != nil {
+		return nil, err
+	}
+
+	var packages []LockedPackage
+	for _, pkg := range lock.Package {
+		if pkg.Source["editable"] != nil || pkg.Source["virtual"] != nil || pkg.Source["directory"] != nil {
+			continue
+		}
+		packages = append(packages, LockedPackage{Name: pkg.Name, Version: pkg.Version})
+	}
+	return packages, nil
+}
+
+// pipfileLockEntry is a package of a Pipfile.lock section
+type pipfileLockEntry struct {
+	Version string `json:"version"`
+}
+
+// ParsePipfileLock returns the default and develop packages of a Pipfile.lock
+func ParsePipfileLock(content []byte) ([]LockedPackage, error) {
+	var lock struct {
+		Default map[string]pipfileLockEntry `json:"default"`
+		Develop map[string]pipfileLockEntry `json:"develop"`
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, err
+	}
+
+	var packages []LockedPackage
+	seen := make(map[string]bool)
+	for _, group := range []map[string]pipfileLockEntry{lock.Default, lock.Develop} {
+		for _, name := range slices.Sorted(maps.Keys(group)) {
+			// VCS and path dependencies have no version
+			version := strings.TrimPrefix(group[name].Version, "==")
+			if version == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			packages = append(packages, LockedPackage{Name: name, Version: version})
+		}
+	}
+	return packages, nil
+}
@@ -0,0 +1,26 @@
+package python
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PipfileName is the Pipenv manifest
+const PipfileName = "Pipfile"
+
+// ParsePipfile returns the [packages] and [dev-packages] of a Pipfile
+func ParsePipfile(content []byte) ([]Requirement, error) {
+	var raw struct {
+		Packages    map[string]any `toml:"packages"`
+		DevPackages map[string]any `toml:"dev-packages"`
+	}
+	if _, err := toml.Decode(string(content), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PipfileName, err)
+	}
+
+	var set requirementSet
+	addTableRequirements(&set, raw.Packages)
+	addTableRequirements(&set, raw.DevPackages)
+	return set.requirements, nil
+}
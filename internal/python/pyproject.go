@@ -0,0 +1,134 @@
+package python
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PyprojectFileName is the PEP 518 project file
+const PyprojectFileName = "pyproject.toml"
+
+// Pyproject holds what the analysis and the installer need from pyproject.toml
+type Pyproject struct {
+	// Name is the PEP 621 project name, or the Poetry one
+	Name string
+	// Dependencies lists the PEP 621 dependencies, optional dependencies and PEP 735
+	// dependency groups, followed by the Poetry dependencies and groups
+	Dependencies []Requirement
+	// HasProjectTable reports a PEP 621 [project] table
+	HasProjectTable bool
+	// Poetry reports a [tool.poetry] table
+	Poetry bool
+}
+
+type rawPyproject struct {
+	Project struct {
+		Name                 string              `toml:"name"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	// Entries are requirement strings or {include-group = "..."} tables
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			Name            string         `toml:"name"`
+			Dependencies    map[string]any `toml:"dependencies"`
+			DevDependencies map[string]any `toml:"dev-dependencies"`
+			Group           map[string]struct {
+				Dependencies map[string]any `toml:"dependencies"`
+			} `toml:"group"`
+		} `toml:"poetry"`
+		Uv struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+	} `toml:"tool"`
+}
+
+// ParsePyproject parses pyproject.toml
+func ParsePyproject(content []byte) (*Pyproject, error) {
+	var raw rawPyproject
+	meta, err := toml.Decode(string(content), &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PyprojectFileName, err)
+	}
+
+	project := &Pyproject{
+		Name:            raw.Project.Name,
+		HasProjectTable: meta.IsDefined("project"),
+		Poetry:          meta.IsDefined("tool", "poetry"),
+	}
+	if project.Name == "" {
+		project.Name = raw.Tool.Poetry.Name
+	}
+
+	var set requirementSet
+	for _, spec := range raw.Project.Dependencies {
+		set.addString(spec)
+	}
+	for _, extra := range slices.Sorted(maps.Keys(raw.Project.OptionalDependencies)) {
+		for _, spec := range raw.Project.OptionalDependencies[extra] {
+			set.addString(spec)
+		}
+	}
+	for _, group := range slices.Sorted(maps.Keys(raw.DependencyGroups)) {
+		for _, entry := range raw.DependencyGroups[group] {
+			if spec, ok := entry.(string); ok {
+				set.addString(spec)
+			}
+		}
+	}
+	for _, spec := range raw.Tool.Uv.DevDependencies {
+		set.addString(spec)
+	}
+
+	poetry := raw.Tool.Poetry
+	tables := []map[string]any{poetry.Dependencies, poetry.DevDependencies}
+	for _, group := range slices.Sorted(maps.Keys(poetry.Group)) {
+		tables = append(tables, poetry.Group[group].Dependencies)
+	}
+	for _, table := range tables {
+		addTableRequirements(&set, table)
+	}
+
+	project.Dependencies = set.requirements
+	return project, nil
+}
+
+// addTableRequirements adds the dependencies of a Poetry or Pipfile table, where each key is
+// a distribution and each value a constraint. The python key is the interpreter constraint.
+func addTableRequirements(set *requirementSet, table map[string]any) {
+	for _, name := range slices.Sorted(maps.Keys(table)) {
+		if name == "python" {
+			continue
+		}
+		set.add(Requirement{Name: name, Specifier: tableConstraint(table[name])})
+	}
+}
+
+// tableConstraint returns the version constraint of a Poetry or Pipfile dependency value:
+// a string, a table with a version key, or a list of tables with per-platform constraints.
+// "*" means any version.
+func tableConstraint(value any) string {
+	var constraint string
+	switch v := value.(type) {
+	case string:
+		constraint = v
+	case map[string]any:
+		constraint, _ = v["version"].(string)
+	case []map[string]any:
+		if len(v) > 0 {
+			constraint = tableConstraint(v[0])
+		}
+	case []any:
+		if len(v) > 0 {
+			constraint = tableConstraint(v[0])
+		}
+	}
+	if constraint == "*" {
+		return ""
+	}
+	return constraint
+}
@@ -0,0 +1,420 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Requirement
+		version string
+	}{
+		{"opentelemetry-sdk>=1.27,<2", Requirement{Name: "opentelemetry-sdk", Specifier: ">=1.27,<2"}, "1.27"},
+		{"requests[socks] == 2.31.0 ; python_version > '3.8'", Requirement{Name: "requests", Specifier: "== 2.31.0"}, "2.31.0"},
+		{"flask", Requirement{Name: "flask"}, ""},
+		{"pkg @ https://example.com/pkg.whl", Requirement{Name: "pkg"}, ""},
+	}
+	for _, tt := range tests {
+		got, ok := ParseRequirement(tt.in)
+		if !ok || got != tt.want || got.Version() != tt.version {
+			t.Errorf("ParseRequirement(%q) = %+v (version %q), want %+v (version %q)", tt.in, got, got.Version(), tt.want, tt.version)
+		}
+	}
+	if NormalizeName("OpenTelemetry_Exporter.OTLP") != "opentelemetry-exporter-otlp" {
+		t.Errorf("unexpected normalized name %q", NormalizeName("OpenTelemetry_Exporter.OTLP"))
+	}
+}
+
+func TestParsePyproject(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Pyproject
+	}{
+		{
+			name: "PEP 621 with extras and dependency groups",
+			content: `[build-system]
+requires = ["hatchling"]
+
+[project]
+name = "checkout"
+dependencies = [
+  "flask>=3.0",  # web
+  "opentelemetry-api~=1.27",
+]
+
+[project.optional-dependencies]
+otlp = ["opentelemetry-exporter-otlp-proto-http==1.27.0"]
+
+[dependency-groups]
+dev = ["pytest>=8", {include-group = "lint"}]
+lint = ["ruff"]
+`,
+			want: Pyproject{
+				Name: "checkout",
+				Dependencies: []Requirement{
+					{Name: "flask", Specifier: ">=3.0"},
+					{Name: "opentelemetry-api", Specifier: "~=1.27"},
+					{Name: "opentelemetry-exporter-otlp-proto-http", Specifier: "==1.27.0"},
+					{Name: "pytest", Specifier: ">=8"},
+					{Name: "ruff"},
+				},
+				HasProjectTable: true,
+			},
+		},
+		{
+			name: "Poetry tables",
+			content: `[tool.poetry]
+name = "billing"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+opentelemetry-sdk = "^1.27.0"
+requests = { version = "2.31.0", extras = ["socks"] }
+numpy = [
+  { version = "1.26.4", python = "<3.13" },
+  { version = "2.1.0", python = ">=3.13" },
+]
+local-lib = { path = "../lib", develop = true }
+
+[tool.poetry.group.test.dependencies]
+pytest = "*"
+`,
+			want: Pyproject{
+				Name: "billing",
+				Dependencies: []Requirement{
+					{Name: "local-lib"},
+					{Name: "numpy", Specifier: "1.26.4"},
+					{Name: "opentelemetry-sdk", Specifier: "^1.27.0"},
+					{Name: "requests", Specifier: "2.31.0"},
+					{Name: "pytest"},
+				},
+				Poetry: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePyproject([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParsePyproject([]byte("[project\nname = 1")); err == nil {
+		t.Error("expected an error for invalid TOML")
+	}
+}
+
+func TestParsePipfileAndSetupCfg(t *testing.T) {
+	reqs, err := ParsePipfile([]byte(`[[source]]
+url = "https://pypi.org/simple"
+name = "pypi"
+
+[packages]
+opentelemetry-distro = "==0.48b0"
+django = {version = ">=4.2", extras = ["argon2"]}
+requests = "*"
+
+[dev-packages]
+pytest = "*"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Requirement{
+		{Name: "django", Specifier: ">=4.2"},
+		{Name: "opentelemetry-distro", Specifier: "==0.48b0"},
+		{Name: "requests"},
+		{Name: "pytest"},
+	}
+	if !reflect.DeepEqual(reqs, want) {
+		t.Errorf("Pipfile: got %+v, want %+v", reqs, want)
+	}
+
+	cfg := ParseSetupCfg([]byte(`[metadata]
+name = inventory
+
+[options]
+packages = find:
+install_requires =
+    opentelemetry-api>=1.27  # tracing
+    requests
+    importlib-metadata; python_version < "3.10"
+
+[options.extras_require]
+otlp = opentelemetry-exporter-otlp==1.27.0
+`))
+	wantCfg := SetupCfg{
+		Name: "inventory",
+		Dependencies: []Requirement{
+			{Name: "opentelemetry-api", Specifier: ">=1.27"},
+			{Name: "requests"},
+			{Name: "importlib-metadata"},
+			{Name: "opentelemetry-exporter-otlp", Specifier: "==1.27.0"},
+		},
+	}
+	if !reflect.DeepEqual(cfg, wantCfg) {
+		t.Errorf("setup.cfg: got %+v, want %+v", cfg, wantCfg)
+	}
+}
+
+func TestParseSetupPy(t *testing.T) {
+	reqs, err := ParseSetupPy([]byte(`from setuptools import setup, find_packages
+
+REQUIRES = [
+    "opentelemetry-api>=1.27",
+    'requests',
+]
+
+setup(
+    name="inventory",
+    packages=find_packages(),
+    install_requires=REQUIRES,
+    extras_require={
+        "otlp": ["opentelemetry-exporter-otlp==1.27.0", f"pkg=={VERSION}"],
+        "dev": read_requirements("dev.txt"),
+    },
+)
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Requirement{
+		{Name: "opentelemetry-api", Specifier: ">=1.27"},
+		{Name: "requests"},
+		{Name: "opentelemetry-exporter-otlp", Specifier: "==1.27.0"},
+	}
+	if !reflect.DeepEqual(reqs, want) {
+		t.Errorf("setup.py: got %+v, want %+v", reqs, want)
+	}
+}
+
+func TestLockfiles(t *testing.T) {
+	poetry, err := ParsePoetryLock([]byte(`[[package]]
+name = "opentelemetry-api"
+version = "1.27.0"
+description = "OpenTelemetry Python API"
+optional = false
+python-versions = ">=3.8"
+
+[package.dependencies]
+deprecated = ">=1.2.6"
+
+[[package]]
+name = "deprecated"
+version = "1.2.14"
+
+[metadata]
+lock-version = "2.0"
+`))
+	if err != nil {
+		t.Fatalf("poetry.lock: unexpected error: %v", err)
+	}
+	if want := []LockedPackage{{"opentelemetry-api", "1.27.0"}, {"deprecated", "1.2.14"}}; !reflect.DeepEqual(poetry, want) {
+		t.Errorf("poetry.lock: got %+v, want %+v", poetry, want)
+	}
+
+	uv, err := ParseUvLock([]byte(`version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "checkout"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [{ name = "opentelemetry-sdk" }]
+
+[[package]]
+name = "opentelemetry-sdk"
+version = "1.27.0"
+source = { registry = "https://pypi.org/simple" }
+`))
+	if err != nil {
+		t.Fatalf("uv.lock: unexpected error: %v", err)
+	}
+	if want := []LockedPackage{{"opentelemetry-sdk", "1.27.0"}}; !reflect.DeepEqual(uv, want) {
+		t.Errorf("uv.lock: got %+v, want %+v", uv, want)
+	}
+
+	pipenv, err := ParsePipfileLock([]byte(`{
+  "_meta": {"hash": {"sha256": "abc"}},
+  "default": {
+    "opentelemetry-api": {"hashes": [], "version": "==1.27.0"},
+    "mylib": {"git": "https://example.com/mylib.git", "ref": "abc"}
+  },
+  "develop": {"pytest": {"version": "==8.3.2"}}
+}`))
+	if err != nil {
+		t.Fatalf("Pipfile.lock: unexpected error: %v", err)
+	}
+	if want := []LockedPackage{{"opentelemetry-api", "1.27.0"}, {"pytest", "8.3.2"}}; !reflect.DeepEqual(pipenv, want) {
+		t.Errorf("Pipfile.lock: got %+v, want %+v", pipenv, want)
+	}
+}
+
+func TestDetectTool(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  Tool
+	}{
+		{"poetry lockfile", map[string]string{PoetryLockFileName: "", PyprojectFileName: "[project]\nname = \"a\"\n"}, ToolPoetry},
+		{"uv lockfile", map[string]string{UvLockFileName: "", PyprojectFileName: "[project]\nname = \"a\"\n"}, ToolUv},
+		{"Pipfile", map[string]string{PipfileName: "[packages]\n"}, ToolPipenv},
+		{"poetry without lockfile", map[string]string{PyprojectFileName: "[tool.poetry]\nname = \"a\"\n"}, ToolPoetry},
+		{"requirements", map[string]string{"requirements.txt": "flask\n"}, ToolPip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := DetectTool(dir); got != tt.want {
+				t.Errorf("DetectTool() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddProjectDependencies(t *testing.T) {
+	reqs := []Requirement{
+		{Name: "opentelemetry-api", Specifier: "==1.27.0"},
+		{Name: "Flask", Specifier: "==3.0.3"},
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "multi-line array",
+			content: `[project]
+name = "checkout"
+dependencies = [
+    "flask>=3.0",
+    "requests[socks]>=2"  # http client
+]
+
+[tool.ruff]
+line-length = 100
+`,
+			want: `[project]
+name = "checkout"
+dependencies = [
+    "flask>=3.0",
+    "requests[socks]>=2",  # http client
+    "opentelemetry-api==1.27.0",
+]
+
+[tool.ruff]
+line-length = 100
+`,
+		},
+		{
+			name: "single-line array",
+			content: `[project]
+name = "checkout"
+dependencies = ["flask>=3.0"]
+`,
+			want: `[project]
+name = "checkout"
+dependencies = ["flask>=3.0", "opentelemetry-api==1.27.0"]
+`,
+		},
+		{
+			name: "no dependencies key",
+			content: `[project]
+name = "checkout"
+version = "0.1.0"
+
+[tool.uv]
+package = true
+`,
+			want: `[project]
+name = "checkout"
+version = "0.1.0"
+dependencies = [
+    "opentelemetry-api==1.27.0",
+    "Flask==3.0.3",
+]
+
+[tool.uv]
+package = true
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := AddProjectDependencies([]byte(tt.content), reqs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if _, err := ParsePyproject(got); err != nil {
+				t.Errorf("result is not valid TOML: %v", err)
+			}
+		})
+	}
+
+	if _, _, err := AddProjectDependencies([]byte("[tool.poetry]\nname = \"a\"\n"), reqs); err != ErrNoProjectTable {
+		t.Errorf("expected ErrNoProjectTable, got %v", err)
+	}
+}
+
+func TestAddTableDependencies(t *testing.T) {
+	reqs := []Requirement{
+		{Name: "opentelemetry-sdk", Specifier: "==1.27.0"},
+		{Name: "requests"},
+	}
+
+	content := `[tool.poetry]
+name = "billing"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+Requests = "^2.31"
+
+[build-system]
+requires = ["poetry-core"]
+`
+	got, added, err := AddTableDependencies([]byte(content), "tool.poetry.dependencies", reqs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `[tool.poetry]
+name = "billing"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+Requests = "^2.31"
+opentelemetry-sdk = "==1.27.0"
+
+[build-system]
+requires = ["poetry-core"]
+`
+	if string(got) != want || !reflect.DeepEqual(added, []string{"opentelemetry-sdk"}) {
+		t.Errorf("got %v:\n%s\nwant:\n%s", added, got, want)
+	}
+
+	got, _, err = AddTableDependencies([]byte("[[source]]\nname = \"pypi\"\n"), "packages", reqs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = "[[source]]\nname = \"pypi\"\n\n[packages]\nopentelemetry-sdk = \"==1.27.0\"\nrequests = \"*\"\n"
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
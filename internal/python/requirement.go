@@ -0,0 +1,72 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// requirementRe matches a PEP 508 requirement: name, optional extras, then the version
+	// specifier up to an environment marker or URL, e.g. requests[socks]>=2.31; python_version>"3.8"
+	requirementRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*\(?([^;@)]*)\)?`)
+	// specifierVersionRe matches the version of the first clause of a specifier
+	specifierVersionRe = regexp.MustCompile(`[0-9][A-Za-z0-9.*+!-]*`)
+	// nameSeparatorRe matches the runs of separators PEP 503 normalizes to a single dash
+	nameSeparatorRe = regexp.MustCompile(`[-_.]+`)
+)
+
+// Requirement is a dependency on a distribution
+type Requirement struct {
+	Name string
+	// Specifier is the version specifier, e.g. ">=1.27,<2"; empty when any version will do
+	Specifier string
+}
+
+// ParseRequirement parses a PEP 508 requirement string such as "opentelemetry-sdk>=1.27"
+func ParseRequirement(s string) (Requirement, bool) {
+	m := requirementRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Requirement{}, false
+	}
+	return Requirement{Name: m[1], Specifier: strings.TrimSpace(m[2])}, true
+}
+
+// Version returns the version of the first specifier clause, e.g. 1.27 for ">=1.27,<2",
+// or "" for requirements without a version
+func (r Requirement) Version() string {
+	return specifierVersionRe.FindString(r.Specifier)
+}
+
+// String renders the requirement in PEP 508 form
+func (r Requirement) String() string {
+	return r.Name + r.Specifier
+}
+
+// NormalizeName normalizes a distribution name as PEP 503 does, so Foo_Bar and foo-bar compare equal
+func NormalizeName(name string) string {
+	return strings.ToLower(nameSeparatorRe.ReplaceAllString(name, "-"))
+}
+
+// requirementSet collects requirements in order, keeping the first one of each distribution
+type requirementSet struct {
+	requirements []Requirement
+	seen         map[string]bool
+}
+
+func (s *requirementSet) add(r Requirement) {
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	key := NormalizeName(r.Name)
+	if s.seen[key] {
+		return
+	}
+	s.seen[key] = true
+	s.requirements = append(s.requirements, r)
+}
+
+func (s *requirementSet) addString(spec string) {
+	if r, ok := ParseRequirement(spec); ok {
+		s.add(r)
+	}
+}
@@ -0,0 +1,67 @@
+package python
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// SetupCfgFileName is the declarative setuptools configuration
+const SetupCfgFileName = "setup.cfg"
+
+// SetupCfg holds the project name and the requirements declared in setup.cfg
+type SetupCfg struct {
+	Name         string
+	Dependencies []Requirement
+}
+
+// ParseSetupCfg reads the [metadata] name, the [options] install_requires and the
+// [options.extras_require] of setup.cfg. Multi-line values continue on indented lines.
+func ParseSetupCfg(content []byte) SetupCfg {
+	var cfg SetupCfg
+	var set requirementSet
+	section, key := "", ""
+	addValue := func(value string) {
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		if value = strings.TrimSpace(value); value == "" {
+			return
+		}
+		switch {
+		case section == "options" && key == "install_requires", section == "options.extras_require":
+			set.addString(value)
+		case section == "metadata" && key == "name":
+			cfg.Name = value
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			if key != "" {
+				addValue(trimmed)
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section, key = strings.TrimSpace(trimmed[1:len(trimmed)-1]), ""
+			continue
+		}
+		name, value, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			key = ""
+			continue
+		}
+		key = strings.TrimSpace(name)
+		addValue(value)
+	}
+
+	cfg.Dependencies = set.requirements
+	return cfg
+}
@@ -0,0 +1,111 @@
+package python
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+// SetupPyFileName is the setuptools build script
+const SetupPyFileName = "setup.py"
+
+// ParseSetupPy returns the install_requires and extras_require passed to setup() in
+// setup.py. Only literal lists are read, inline or assigned to a module-level name;
+// requirements computed at build time are not known without running the script.
+func ParseSetupPy(content []byte) ([]Requirement, error) {
+	parser := sitter.NewParser()
+	parser.SetLanguage(python.GetLanguage())
+	tree, err := parser.ParseCtx(context.Background(), nil, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", SetupPyFileName, err)
+	}
+	defer tree.Close()
+
+	// Requirements are often listed in a constant passed to setup()
+	root := tree.RootNode()
+	constants := make(map[string]*sitter.Node)
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		statement := root.NamedChild(i)
+		if statement.Type() != "expression_statement" || statement.NamedChildCount() == 0 {
+			continue
+		}
+		assignment := statement.NamedChild(0)
+		left, right := assignment.ChildByFieldName("left"), assignment.ChildByFieldName("right")
+		if assignment.Type() == "assignment" && left != nil && right != nil && left.Type() == "identifier" {
+			constants[left.Content(content)] = right
+		}
+	}
+	resolve := func(n *sitter.Node) *sitter.Node {
+		if n.Type() == "identifier" {
+			return constants[n.Content(content)]
+		}
+		return n
+	}
+
+	var set requirementSet
+	addList := func(n *sitter.Node) {
+		if n = resolve(n); n == nil || (n.Type() != "list" && n.Type() != "tuple") {
+			return
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			if item := n.NamedChild(i); item.Type() == "string" {
+				if value, ok := stringLiteral(item.Content(content)); ok {
+					set.addString(value)
+				}
+			}
+		}
+	}
+
+	var visit func(n *sitter.Node)
+	visit = func(n *sitter.Node) {
+		if n.Type() == "call" {
+			function := n.ChildByFieldName("function")
+			args := n.ChildByFieldName("arguments")
+			if function != nil && args != nil && isSetupCall(function.Content(content)) {
+				for i := 0; i < int(args.NamedChildCount()); i++ {
+					arg := args.NamedChild(i)
+					name, value := arg.ChildByFieldName("name"), arg.ChildByFieldName("value")
+					if arg.Type() != "keyword_argument" || name == nil || value == nil {
+						continue
+					}
+					switch name.Content(content) {
+					case "install_requires":
+						addList(value)
+					case "extras_require":
+						if value = resolve(value); value == nil || value.Type() != "dictionary" {
+							continue
+						}
+						for j := 0; j < int(value.NamedChildCount()); j++ {
+							if extra := value.NamedChild(j).ChildByFieldName("value"); extra != nil {
+								addList(extra)
+							}
+						}
+					}
+				}
+			}
+		}
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			visit(n.NamedChild(i))
+		}
+	}
+	visit(root)
+	return set.requirements, nil
+}
+
+// isSetupCall reports whether a callee is setuptools' or distutils' setup
+func isSetupCall(callee string) bool {
+	return callee == "setup" || strings.HasSuffix(callee, ".setup")
+}
+
+// stringLiteral returns the contents of a Python string literal without prefixes and
+// quotes; f-strings are only known at run time
+func stringLiteral(literal string) (string, bool) {
+	prefix := strings.ToLower(literal[:strings.IndexAny(literal, `"'`)+1])
+	if strings.Contains(prefix, "f") {
+		return "", false
+	}
+	return strings.Trim(strings.TrimLeft(literal, "rRbBuU"), `"'`), true
+}
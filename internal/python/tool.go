@@ -0,0 +1,43 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Tool is the package manager that owns a Python project's dependencies
+type Tool string
+
+// Supported package managers
+const (
+	ToolPip    Tool = "pip"
+	ToolPoetry Tool = "poetry"
+	ToolUv     Tool = "uv"
+	ToolPipenv Tool = "pipenv"
+)
+
+// DetectTool picks the package manager of the project in dir from its lockfile: poetry.lock,
+// uv.lock or Pipfile.lock. Without a lockfile, a Pipfile means Pipenv and a [tool.poetry]
+// table Poetry; anything else is installed with pip.
+func DetectTool(dir string) Tool {
+	locks := []struct {
+		file string
+		tool Tool
+	}{
+		{PoetryLockFileName, ToolPoetry},
+		{UvLockFileName, ToolUv},
+		{PipfileLockFileName, ToolPipenv},
+		{PipfileName, ToolPipenv},
+	}
+	for _, l := range locks {
+		if _, err := os.Stat(filepath.Join(dir, l.file)); err == nil {
+			return l.tool
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, PyprojectFileName)); err == nil {
+		if project, err := ParsePyproject(content); err == nil && project.Poetry {
+			return ToolPoetry
+		}
+	}
+	return ToolPip
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+// HTMLRenderer renders a standalone HTML report
+type HTMLRenderer struct {
+	tmpl *template.Template
+}
+
+// NewHTMLRenderer creates a new HTML renderer
+func NewHTMLRenderer() *HTMLRenderer {
+	funcs := template.FuncMap{
+		"upper":    func(s domain.Severity) string { return strings.ToUpper(string(s)) },
+		"location": issueLocation,
+		"depLabel": dependencyLabel,
+		"instTags": func(inst domain.InstrumentationInfo) string { return strings.Join(instrumentationTags(inst), ", ") },
+	}
+	return &HTMLRenderer{tmpl: template.Must(template.New("report").Funcs(funcs).Parse(htmlReportTemplate))}
+}
+
+// Format returns the output format name
+func (r *HTMLRenderer) Format() string {
+	return "html"
+}
+
+// htmlReportData is the view model passed to the HTML template
+type htmlReportData struct {
+	RootPath    string
+	Detailed    bool
+	Directories []*detector.DirectoryAnalysis
+	Errors      map[string]string
+	Summary     string
+	Gate        string
+}
+
+// Render writes the HTML report
+func (r *HTMLRenderer) Render(w io.Writer, report *Report) error {
+	data := htmlReportData{
+		Detailed:    report.Detailed,
+		Directories: sortedDirectories(report.Analysis),
+		Summary:     Summarize(report.Analysis).Line(),
+	}
+	if report.Gate != nil {
+		data.Gate = report.Gate.Line()
+	}
+	if report.Analysis != nil {
+		data.RootPath = report.Analysis.RootPath
+		data.Errors = report.Analysis.Errors
+	}
+	if err := r.tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Lawrence Analysis Report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1 { margin-bottom: 0.25rem; }
+.root { color: #59636e; margin-top: 0; }
+section { border: 1px solid #d1d9e0; border-radius: 6px; padding: 1rem 1.25rem; margin: 1rem 0; }
+table { border-collapse: collapse; width: 100%; margin: 0.5rem 0; }
+th, td { text-align: left; padding: 0.35rem 0.6rem; border-bottom: 1px solid #d1d9e0; vertical-align: top; }
+.sev { font-weight: 600; font-size: 0.8rem; padding: 0.1rem 0.4rem; border-radius: 4px; }
+.sev-error { background: #ffebe9; color: #cf222e; }
+.sev-warning { background: #fff8c5; color: #9a6700; }
+.sev-info { background: #ddf4ff; color: #0969da; }
+.muted { color: #59636e; }
+footer { margin-top: 2rem; font-weight: 600; }
+</style>
+</head>
+<body>
+<h1>Lawrence Analysis Report</h1>
+{{if .RootPath}}<p class="root">{{.RootPath}}</p>{{end}}
+{{if not .Directories}}<p>No analysis results to display.</p>{{end}}
+{{range .Directories}}
+<section>
+<h2>{{.Directory}} <span class="muted">({{.Language}})</span></h2>
+{{with .Project}}<p class="muted">Project <strong>{{.Name}}</strong> from {{.Manifest}}, {{len .Directories}} directories</p>{{end}}
+<p class="muted">Libraries: {{len .Libraries}} &middot; Packages: {{len .Packages}} &middot; Instrumentations: {{len .AvailableInstrumentations}} &middot; Issues: {{len .Issues}}</p>
+{{if $.Detailed}}
+<details><summary>Libraries</summary><ul>{{range .Libraries}}<li>{{depLabel .Name .Version .PackageFile}}</li>{{else}}<li>none</li>{{end}}</ul></details>
+<details><summary>Packages</summary><ul>{{range .Packages}}<li>{{depLabel .Name .Version .PackageFile}}</li>{{else}}<li>none</li>{{end}}</ul></details>
+<details><summary>Instrumentations</summary><ul>{{range .AvailableInstrumentations}}<li>{{.Package.Name}}: {{.Title}} <span class="muted">({{instTags .}})</span>{{if .URLs.Repo}} &ndash; <a href="{{.URLs.Repo}}">{{.URLs.Repo}}</a>{{end}}</li>{{else}}<li>none</li>{{end}}</ul></details>
+{{end}}
+{{if .Issues}}
+<table>
+<thead><tr><th>Severity</th><th>Category</th><th>Issue</th><th>Location</th></tr></thead>
+<tbody>
+{{range .Issues}}<tr>
+<td><span class="sev sev-{{.Severity}}">{{upper .Severity}}</span></td>
+<td>{{.Category}}</td>
+<td><strong>{{.Title}}</strong>{{if .Description}}<br><span class="muted">{{.Description}}</span>{{end}}{{if .Suggestion}}<br>Suggestion: {{.Suggestion}}{{end}}{{range .References}}<br><a href="{{.}}">{{.}}</a>{{end}}</td>
+<td>{{location .}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+{{else}}<p>No issues found.</p>{{end}}
+</section>
+{{end}}
+{{if .Errors}}<section><h2>Failed directories</h2><ul>{{range $dir, $err := .Errors}}<li><strong>{{$dir}}</strong>: {{$err}}</li>{{end}}</ul></section>{{end}}
+<footer>Summary: {{.Summary}}{{if .Gate}}<br>Gate: {{.Gate}}{{end}}</footer>
+</body>
+</html>
+`
@@ -0,0 +1,44 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONRenderer renders the machine-readable JSON report
+type JSONRenderer struct{}
+
+// NewJSONRenderer creates a new JSON renderer
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+// Format returns the output format name
+func (r *JSONRenderer) Format() string {
+	return "json"
+}
+
+// Render writes the JSON report
+func (r *JSONRenderer) Render(w io.Writer, report *Report) error {
+	// Aggregate issues from all directories for backward compatibility.
+	// Suppressed and baselined issues are included and carry "suppressed": true.
+	allIssues := make([]interface{}, 0)
+	for _, dirAnalysis := range sortedDirectories(report.Analysis) {
+		for _, it := range dirAnalysis.Issues {
+			allIssues = append(allIssues, it)
+		}
+		for _, it := range dirAnalysis.SuppressedIssues {
+			allIssues = append(allIssues, it)
+		}
+	}
+
+	result := map[string]interface{}{
+		"analysis":   report.Analysis,
+		"all_issues": allIssues,
+		"summary":    summaryFields(report),
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,155 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+)
+
+// MarkdownRenderer renders a Markdown report suitable for PR comments
+type MarkdownRenderer struct{}
+
+// NewMarkdownRenderer creates a new Markdown renderer
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+// Format returns the output format name
+func (r *MarkdownRenderer) Format() string {
+	return "markdown"
+}
+
+// Render writes the Markdown report
+func (r *MarkdownRenderer) Render(w io.Writer, report *Report) error {
+	var b strings.Builder
+
+	b.WriteString("# Lawrence Analysis Report\n\n")
+	if report.Analysis != nil && report.Analysis.RootPath != "" {
+		fmt.Fprintf(&b, "Root: `%s`\n\n", report.Analysis.RootPath)
+	}
+
+	directories := sortedDirectories(report.Analysis)
+	if len(directories) == 0 {
+		b.WriteString("No analysis results to display.\n")
+	}
+
+	for _, dirAnalysis := range directories {
+		fmt.Fprintf(&b, "## `%s` (%s)\n\n", dirAnalysis.Directory, dirAnalysis.Language)
+		if project := dirAnalysis.Project; project != nil {
+			fmt.Fprintf(&b, "Project **%s** from `%s`, %d directories\n\n", markdownEscape(project.Name), project.Manifest, len(project.Directories))
+		}
+		b.WriteString("| Libraries | Packages | Instrumentations | Issues |\n")
+		b.WriteString("|---:|---:|---:|---:|\n")
+		fmt.Fprintf(&b, "| %d | %d | %d | %d |\n\n",
+			len(dirAnalysis.Libraries), len(dirAnalysis.Packages), len(dirAnalysis.AvailableInstrumentations), len(dirAnalysis.Issues))
+
+		if report.Detailed {
+			r.renderDependencies(&b, dirAnalysis)
+		}
+		r.renderIssues(&b, dirAnalysis)
+	}
+
+	if report.Analysis != nil && len(report.Analysis.Errors) > 0 {
+		b.WriteString("## Failed directories\n\n")
+		for _, dir := range sortedKeys(report.Analysis.Errors) {
+			fmt.Fprintf(&b, "- `%s`: %s\n", dir, markdownEscape(report.Analysis.Errors[dir]))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "---\n\n**Summary:** %s\n", Summarize(report.Analysis).Line())
+	if report.Gate != nil {
+		fmt.Fprintf(&b, "\n**Gate:** %s\n", markdownEscape(report.Gate.Line()))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderDependencies writes collapsible library, package and instrumentation lists
+func (r *MarkdownRenderer) renderDependencies(b *strings.Builder, dirAnalysis *detector.DirectoryAnalysis) {
+	section := func(title string, items []string) {
+		fmt.Fprintf(b, "<details><summary>%s (%d)</summary>\n\n", title, len(items))
+		if len(items) == 0 {
+			b.WriteString("- none\n")
+		}
+		for _, item := range items {
+			fmt.Fprintf(b, "- %s\n", item)
+		}
+		b.WriteString("\n</details>\n\n")
+	}
+
+	libs := make([]string, 0, len(dirAnalysis.Libraries))
+	for _, lib := range dirAnalysis.Libraries {
+		libs = append(libs, markdownEscape(dependencyLabel(lib.Name, lib.Version, lib.PackageFile)))
+	}
+	section("Libraries", libs)
+
+	pkgs := make([]string, 0, len(dirAnalysis.Packages))
+	for _, pkg := range dirAnalysis.Packages {
+		pkgs = append(pkgs, markdownEscape(dependencyLabel(pkg.Name, pkg.Version, pkg.PackageFile)))
+	}
+	section("Packages", pkgs)
+
+	insts := make([]string, 0, len(dirAnalysis.AvailableInstrumentations))
+	for _, inst := range dirAnalysis.AvailableInstrumentations {
+		label := fmt.Sprintf("%s: %s (%s)", inst.Package.Name, inst.Title, strings.Join(instrumentationTags(inst), ", "))
+		label = markdownEscape(label)
+		if inst.URLs.Repo != "" {
+			label = fmt.Sprintf("%s - <%s>", label, inst.URLs.Repo)
+		}
+		insts = append(insts, label)
+	}
+	section("Instrumentations", insts)
+}
+
+// renderIssues writes an issue table followed by suggestions
+func (r *MarkdownRenderer) renderIssues(b *strings.Builder, dirAnalysis *detector.DirectoryAnalysis) {
+	if len(dirAnalysis.Issues) == 0 {
+		b.WriteString("No issues found.\n\n")
+		return
+	}
+
+	b.WriteString("| Severity | Category | Issue | Location |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, issue := range dirAnalysis.Issues {
+		loc := issueLocation(issue)
+		if loc != "" {
+			loc = "`" + loc + "`"
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n",
+			strings.ToUpper(string(issue.Severity)), issue.Category, markdownCell(issue.Title), loc)
+	}
+	b.WriteString("\n")
+
+	for _, issue := range dirAnalysis.Issues {
+		if strings.TrimSpace(issue.Suggestion) == "" && len(issue.References) == 0 {
+			continue
+		}
+		fmt.Fprintf(b, "- **%s**", markdownEscape(issue.Title))
+		if strings.TrimSpace(issue.Suggestion) != "" {
+			fmt.Fprintf(b, ": %s", markdownCell(issue.Suggestion))
+		}
+		b.WriteString("\n")
+		for _, ref := range issue.References {
+			fmt.Fprintf(b, "  - <%s>\n", ref)
+		}
+	}
+	b.WriteString("\n")
+}
+
+// markdownEscape escapes characters that would otherwise be treated as markup
+func markdownEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(s)
+}
+
+// markdownCell escapes text for use inside a single table cell
+func markdownCell(s string) string {
+	s = markdownEscape(s)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "<br>")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
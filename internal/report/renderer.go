@@ -0,0 +1,212 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/baseline"
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/gate"
+)
+
+// Report bundles everything a renderer needs to produce output
+type Report struct {
+	Analysis  *detector.Analysis
+	Detectors []detector.IssueDetector
+	Version   string
+	Detailed  bool
+	// Gate is the quality gate verdict, nil when no gate is configured
+	Gate *gate.Verdict
+}
+
+// Renderer writes a report in a specific output format
+type Renderer interface {
+	// Format returns the output format name used with --output
+	Format() string
+	// Render writes the report to w
+	Render(w io.Writer, report *Report) error
+}
+
+// Registry manages the available renderers keyed by format
+type Registry struct {
+	renderers map[string]Renderer
+}
+
+// NewRegistry creates a registry with all built-in renderers
+func NewRegistry() *Registry {
+	r := &Registry{renderers: make(map[string]Renderer)}
+	r.Register(NewTextRenderer())
+	r.Register(NewJSONRenderer())
+	r.Register(NewYAMLRenderer())
+	r.Register(NewSARIFRenderer())
+	r.Register(NewMarkdownRenderer())
+	r.Register(NewHTMLRenderer())
+	return r
+}
+
+// Register adds or replaces a renderer for its format
+func (r *Registry) Register(renderer Renderer) {
+	r.renderers[strings.ToLower(renderer.Format())] = renderer
+}
+
+// Get returns the renderer for a format
+func (r *Registry) Get(format string) (Renderer, error) {
+	renderer, ok := r.renderers[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported output format: %s (available: %s)", format, strings.Join(r.Formats(), ", "))
+	}
+	return renderer, nil
+}
+
+// Formats returns the registered format names in sorted order
+func (r *Registry) Formats() []string {
+	formats := make([]string, 0, len(r.renderers))
+	for f := range r.renderers {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Summary holds the totals shown in every report footer
+type Summary struct {
+	Directories int
+	// Analyses counts (directory, language) pairs; it exceeds Directories when a directory has several languages
+	Analyses         int
+	Projects         int
+	Languages        []string
+	Libraries        int
+	Packages         int
+	Instrumentations int
+	Issues           int
+	Suppressed       int
+	Baselined        int
+}
+
+// Summarize computes report totals across all directories
+func Summarize(analysis *detector.Analysis) Summary {
+	var s Summary
+	if analysis == nil {
+		return s
+	}
+	detectedLanguages := make(map[string]bool)
+	directories := make(map[string]bool)
+	projects := make(map[*detector.Project]bool)
+	for _, dirAnalysis := range analysis.DirectoryAnalyses {
+		if dirAnalysis == nil {
+			continue
+		}
+		directories[dirAnalysis.Directory] = true
+		if dirAnalysis.Project != nil {
+			projects[dirAnalysis.Project] = true
+		}
+		s.Analyses++
+		if dirAnalysis.Language != "" {
+			detectedLanguages[strings.ToLower(dirAnalysis.Language)] = true
+		}
+		s.Libraries += len(dirAnalysis.Libraries)
+		s.Packages += len(dirAnalysis.Packages)
+		s.Instrumentations += len(dirAnalysis.AvailableInstrumentations)
+		s.Issues += len(dirAnalysis.Issues)
+		for _, issue := range dirAnalysis.SuppressedIssues {
+			if issue.SuppressedBy == baseline.SourceBaseline {
+				s.Baselined++
+			} else {
+				s.Suppressed++
+			}
+		}
+	}
+	s.Directories = len(directories)
+	s.Projects = len(projects)
+	for lang := range detectedLanguages {
+		s.Languages = append(s.Languages, lang)
+	}
+	sort.Strings(s.Languages)
+	return s
+}
+
+// Line returns the one-line summary used by the human-readable formats
+func (s Summary) Line() string {
+	directories := fmt.Sprintf("%d directories", s.Directories)
+	if s.Analyses > s.Directories {
+		directories += fmt.Sprintf(" (%d directory/language pairs)", s.Analyses)
+	}
+	if s.Projects > 0 {
+		directories = fmt.Sprintf("%d projects, %s", s.Projects, directories)
+	}
+	line := fmt.Sprintf("%s, %d languages [%s], %d libraries, %d packages, %d instrumentations, %d issues",
+		directories, len(s.Languages), strings.Join(s.Languages, ", "), s.Libraries, s.Packages, s.Instrumentations, s.Issues,
+	)
+	if s.Suppressed > 0 || s.Baselined > 0 {
+		line += fmt.Sprintf(" (%d suppressed, %d baselined)", s.Suppressed, s.Baselined)
+	}
+	return line
+}
+
+// Fields returns the summary in the shape used by the structured formats
+func (s Summary) Fields() map[string]interface{} {
+	return map[string]interface{}{
+		"total_directories":      s.Directories,
+		"total_analyses":         s.Analyses,
+		"total_projects":         s.Projects,
+		"total_languages":        len(s.Languages),
+		"total_libraries":        s.Libraries,
+		"total_packages":         s.Packages,
+		"total_instrumentations": s.Instrumentations,
+		"total_issues":           s.Issues,
+		"total_suppressed":       s.Suppressed,
+		"total_baselined":        s.Baselined,
+	}
+}
+
+// summaryFields returns the structured summary including the gate verdict when present
+func summaryFields(report *Report) map[string]interface{} {
+	fields := Summarize(report.Analysis).Fields()
+	if report.Gate != nil {
+		fields["gate"] = report.Gate
+	}
+	return fields
+}
+
+// sortedDirectories returns the directory analyses in stable (directory, language) order
+func sortedDirectories(analysis *detector.Analysis) []*detector.DirectoryAnalysis {
+	if analysis == nil {
+		return nil
+	}
+	directories := make([]string, 0, len(analysis.DirectoryAnalyses))
+	for dir := range analysis.DirectoryAnalyses {
+		directories = append(directories, dir)
+	}
+	sort.Strings(directories)
+
+	out := make([]*detector.DirectoryAnalysis, 0, len(directories))
+	for _, dir := range directories {
+		if dirAnalysis := analysis.DirectoryAnalyses[dir]; dirAnalysis != nil {
+			out = append(out, dirAnalysis)
+		}
+	}
+	return out
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// joinNonEmpty joins the non-blank parts with a single space
+func joinNonEmpty(parts ...string) string {
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if strings.TrimSpace(p) != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " ")
+}
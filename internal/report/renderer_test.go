@@ -0,0 +1,169 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+func sampleAnalysis() *detector.Analysis {
+	return &detector.Analysis{
+		RootPath: "/repo",
+		DirectoryAnalyses: map[string]*detector.DirectoryAnalysis{
+			"svc": {
+				Directory: "svc",
+				Language:  "Go",
+				Libraries: []domain.Library{{Name: "go.opentelemetry.io/otel", Version: "v1.30.0"}},
+				Packages:  []domain.Package{{Name: "github.com/gin-gonic/gin"}},
+			},
+			"root": {
+				Directory: "root",
+				Language:  "Python",
+				Issues: []domain.Issue{{
+					ID:         "missing_otel_libraries",
+					Title:      "No <OpenTelemetry> libraries | detected",
+					Severity:   domain.SeverityWarning,
+					Category:   domain.CategoryMissingOtel,
+					Suggestion: "Add the SDK",
+				}},
+			},
+		},
+	}
+}
+
+func TestRegistry_GetKnownAndUnknownFormats(t *testing.T) {
+	reg := NewRegistry()
+	for _, f := range []string{"text", "json", "yaml", "sarif", "markdown", "html", "JSON"} {
+		if _, err := reg.Get(f); err != nil {
+			t.Fatalf("Get(%q) returned error: %v", f, err)
+		}
+	}
+	if _, err := reg.Get("xml"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
+
+func TestSummarize_LineMatchesAllFormats(t *testing.T) {
+	summary := Summarize(sampleAnalysis())
+	want := "2 directories, 2 languages [go, python], 1 libraries, 1 packages, 0 instrumentations, 1 issues"
+	if summary.Line() != want {
+		t.Fatalf("Line() = %q, want %q", summary.Line(), want)
+	}
+
+	reg := NewRegistry()
+	for _, f := range []string{"text", "markdown", "html"} {
+		r, _ := reg.Get(f)
+		var buf bytes.Buffer
+		if err := r.Render(&buf, &Report{Analysis: sampleAnalysis(), Detailed: true}); err != nil {
+			t.Fatalf("%s render failed: %v", f, err)
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Fatalf("%s output missing summary footer:\n%s", f, buf.String())
+		}
+		// Directories are rendered in sorted order
+		if strings.Index(buf.String(), "root") > strings.Index(buf.String(), "svc") {
+			t.Fatalf("%s output not grouped in directory order", f)
+		}
+	}
+}
+
+func TestJSONRenderer_KeepsLegacyShape(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewJSONRenderer().Render(&buf, &Report{Analysis: sampleAnalysis()}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	for _, key := range []string{"analysis", "all_issues", "summary"} {
+		if _, ok := out[key]; !ok {
+			t.Fatalf("missing key %q", key)
+		}
+	}
+	if n := out["summary"].(map[string]interface{})["total_issues"]; n != float64(1) {
+		t.Fatalf("unexpected total_issues: %v", n)
+	}
+}
+
+func TestYAMLRenderer_GroupsByDirectory(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewYAMLRenderer().Render(&buf, &Report{Analysis: sampleAnalysis()}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	var out struct {
+		RootPath    string `yaml:"root_path"`
+		Directories []struct {
+			Directory string `yaml:"directory"`
+			Language  string `yaml:"language"`
+		} `yaml:"directories"`
+		Summary map[string]int `yaml:"summary"`
+	}
+	if err := yaml.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid YAML: %v\n%s", err, buf.String())
+	}
+	if len(out.Directories) != 2 || out.Directories[0].Directory != "root" || out.Directories[1].Language != "Go" {
+		t.Fatalf("unexpected directories: %+v", out.Directories)
+	}
+	if out.Summary["total_libraries"] != 1 {
+		t.Fatalf("unexpected summary: %+v", out.Summary)
+	}
+}
+
+func TestMarkdownAndHTML_EscapeIssueText(t *testing.T) {
+	var md bytes.Buffer
+	if err := NewMarkdownRenderer().Render(&md, &Report{Analysis: sampleAnalysis()}); err != nil {
+		t.Fatalf("markdown render failed: %v", err)
+	}
+	if !strings.Contains(md.String(), `No &lt;OpenTelemetry&gt; libraries \| detected`) {
+		t.Fatalf("markdown issue title not escaped:\n%s", md.String())
+	}
+
+	var html bytes.Buffer
+	if err := NewHTMLRenderer().Render(&html, &Report{Analysis: sampleAnalysis()}); err != nil {
+		t.Fatalf("html render failed: %v", err)
+	}
+	if strings.Contains(html.String(), "<OpenTelemetry>") {
+		t.Fatalf("html issue title not escaped")
+	}
+}
+
+func TestSummary_CountsSuppressedAndBaselined(t *testing.T) {
+	analysis := sampleAnalysis()
+	analysis.DirectoryAnalyses["svc"].SuppressedIssues = []domain.Issue{
+		{Title: "a", Suppressed: true, SuppressedBy: "baseline"},
+		{Title: "b", Suppressed: true, SuppressedBy: "config"},
+	}
+	summary := Summarize(analysis)
+	if summary.Suppressed != 1 || summary.Baselined != 1 {
+		t.Fatalf("unexpected counts: %+v", summary)
+	}
+	if !strings.HasSuffix(summary.Line(), "(1 suppressed, 1 baselined)") {
+		t.Fatalf("summary line missing counts: %q", summary.Line())
+	}
+
+	var buf bytes.Buffer
+	if err := NewJSONRenderer().Render(&buf, &Report{Analysis: analysis}); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	var out struct {
+		AllIssues []domain.Issue `json:"all_issues"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	suppressed := 0
+	for _, issue := range out.AllIssues {
+		if issue.Suppressed {
+			suppressed++
+		}
+	}
+	if len(out.AllIssues) != 3 || suppressed != 2 {
+		t.Fatalf("expected 3 issues with 2 marked suppressed, got %d/%d", len(out.AllIssues), suppressed)
+	}
+}
@@ -0,0 +1,289 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/baseline"
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName       = "lawrence"
+	toolInfoURI    = "https://github.com/getlawrence/cli"
+)
+
+// SARIFLog is the top-level SARIF 2.1.0 document
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun describes a single invocation of the analyzer
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the tool that produced the results
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver describes the analysis tool component and its rules
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule is a reporting descriptor for a single issue detector
+type SARIFRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     SARIFMessage           `json:"shortDescription"`
+	FullDescription      *SARIFMessage          `json:"fullDescription,omitempty"`
+	DefaultConfiguration *SARIFConfiguration    `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFConfiguration holds the default configuration of a rule
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain text message
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a single finding
+type SARIFResult struct {
+	RuleID       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Level        string                 `json:"level"`
+	Message      SARIFMessage           `json:"message"`
+	Locations    []SARIFLocation        `json:"locations,omitempty"`
+	Fingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+	Suppressions []SARIFSuppression     `json:"suppressions,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+// SARIFSuppression records why a result was suppressed
+type SARIFSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// SARIFLocation wraps a physical location
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation points to a file and optional region
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is a URI relative to the analyzed root
+type SARIFArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// SARIFRegion is a line/column region within a file
+type SARIFRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIFRenderer renders the report as a SARIF 2.1.0 log
+type SARIFRenderer struct{}
+
+// NewSARIFRenderer creates a new SARIF renderer
+func NewSARIFRenderer() *SARIFRenderer {
+	return &SARIFRenderer{}
+}
+
+// Format returns the output format name
+func (r *SARIFRenderer) Format() string {
+	return "sarif"
+}
+
+// Render writes the SARIF log
+func (r *SARIFRenderer) Render(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(BuildSARIF(report.Analysis, report.Detectors, report.Version))
+}
+
+// BuildSARIF converts an analysis into a SARIF log with one rule per registered detector
+func BuildSARIF(analysis *detector.Analysis, detectors []detector.IssueDetector, version string) *SARIFLog {
+	rules := make([]SARIFRule, 0, len(detectors))
+	ruleIndex := make(map[string]int, len(detectors))
+	for _, d := range detectors {
+		if _, ok := ruleIndex[d.ID()]; ok {
+			continue
+		}
+		ruleIndex[d.ID()] = len(rules)
+		rule := SARIFRule{
+			ID:               d.ID(),
+			Name:             sarifRuleName(d.Name()),
+			ShortDescription: SARIFMessage{Text: d.Name()},
+			Properties: map[string]interface{}{
+				"category": string(d.Category()),
+			},
+		}
+		if d.Description() != "" {
+			rule.FullDescription = &SARIFMessage{Text: d.Description()}
+		}
+		if langs := d.Languages(); len(langs) > 0 {
+			rule.Properties["languages"] = langs
+		}
+		rules = append(rules, rule)
+	}
+
+	results := make([]SARIFResult, 0)
+	rootPath := ""
+	if analysis != nil {
+		rootPath = analysis.RootPath
+	}
+	for _, dirAnalysis := range sortedDirectories(analysis) {
+		issues := append(append([]domain.Issue{}, dirAnalysis.Issues...), dirAnalysis.SuppressedIssues...)
+		for _, issue := range issues {
+			ruleID := issue.DetectorID
+			if ruleID == "" {
+				ruleID = issue.ID
+			}
+			idx, ok := ruleIndex[ruleID]
+			if !ok {
+				// Issues from unregistered detectors still need a descriptor to be valid SARIF
+				idx = len(rules)
+				ruleIndex[ruleID] = idx
+				rules = append(rules, SARIFRule{
+					ID:               ruleID,
+					ShortDescription: SARIFMessage{Text: issue.Title},
+					Properties:       map[string]interface{}{"category": string(issue.Category)},
+				})
+			}
+			results = append(results, buildSARIFResult(rootPath, dirAnalysis.Directory, ruleID, idx, issue))
+		}
+	}
+
+	return &SARIFLog{
+		Schema:  sarifSchemaURI,
+		Version: sarifVersion,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{Driver: SARIFDriver{
+				Name:           toolName,
+				Version:        version,
+				InformationURI: toolInfoURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// buildSARIFResult maps a single issue to a SARIF result
+func buildSARIFResult(rootPath, directory, ruleID string, ruleIndex int, issue domain.Issue) SARIFResult {
+	text := issue.Title
+	if strings.TrimSpace(issue.Description) != "" {
+		text = issue.Title + "\n\n" + issue.Description
+	}
+
+	props := map[string]interface{}{
+		"issueId":   issue.ID,
+		"category":  string(issue.Category),
+		"directory": directory,
+	}
+	if issue.Language != "" {
+		props["language"] = issue.Language
+	}
+	if issue.Suggestion != "" {
+		props["suggestion"] = issue.Suggestion
+	}
+	if len(issue.References) > 0 {
+		props["references"] = issue.References
+	}
+
+	location := SARIFPhysicalLocation{
+		ArtifactLocation: SARIFArtifactLocation{
+			URI:       sarifURI(rootPath, directory, issue.File),
+			URIBaseID: "%SRCROOT%",
+		},
+	}
+	if issue.Line > 0 {
+		location.Region = &SARIFRegion{StartLine: issue.Line, StartColumn: issue.Column}
+	}
+
+	result := SARIFResult{
+		RuleID:     ruleID,
+		RuleIndex:  ruleIndex,
+		Level:      sarifLevel(issue.Severity),
+		Message:    SARIFMessage{Text: text},
+		Locations:  []SARIFLocation{{PhysicalLocation: location}},
+		Properties: props,
+	}
+	if issue.Fingerprint != "" {
+		result.Fingerprints = map[string]string{"lawrence/v1": issue.Fingerprint}
+	}
+	if issue.Suppressed {
+		kind := "external"
+		if issue.SuppressedBy == baseline.SourceInline {
+			kind = "inSource"
+		}
+		result.Suppressions = []SARIFSuppression{{Kind: kind, Justification: issue.SuppressionReason}}
+	}
+	return result
+}
+
+// sarifLevel maps issue severity to a SARIF result level
+func sarifLevel(severity domain.Severity) string {
+	switch severity {
+	case domain.SeverityError:
+		return "error"
+	case domain.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifURI returns a forward-slash path relative to the analyzed root.
+// Issues without a file point at their directory.
+func sarifURI(rootPath, directory, file string) string {
+	path := file
+	switch {
+	case path == "":
+		if directory == "" || directory == "root" {
+			return "."
+		}
+		path = directory
+	case filepath.IsAbs(path):
+		if rel, err := filepath.Rel(rootPath, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	case directory != "" && directory != "root":
+		path = filepath.Join(directory, path)
+	}
+	return filepath.ToSlash(path)
+}
+
+// sarifRuleName converts a human-readable detector name into a PascalCase rule name
+func sarifRuleName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(name) {
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+type stubDetector struct {
+	id       string
+	category domain.Category
+}
+
+func (s stubDetector) ID() string                { return s.id }
+func (s stubDetector) Name() string              { return "Stub detector" }
+func (s stubDetector) Description() string       { return "Finds stub problems" }
+func (s stubDetector) Category() domain.Category { return s.category }
+func (s stubDetector) Languages() []string       { return nil }
+func (s stubDetector) Detect(ctx context.Context, analysis *detector.DirectoryAnalysis) ([]domain.Issue, error) {
+	return nil, nil
+}
+
+func TestBuildSARIF_RulesAndResults(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "repo")
+	analysis := &detector.Analysis{
+		RootPath: root,
+		DirectoryAnalyses: map[string]*detector.DirectoryAnalysis{
+			"svc": {
+				Directory: "svc",
+				Language:  "Go",
+				Issues: []domain.Issue{{
+					ID:          "stub_1",
+					DetectorID:  "stub",
+					Title:       "Stub problem",
+					Description: "details",
+					Severity:    domain.SeverityError,
+					Category:    domain.CategoryConfiguration,
+					File:        filepath.Join(root, "svc", "main.go"),
+					Line:        12,
+					Column:      3,
+					Suggestion:  "fix it",
+					References:  []string{"https://example.com"},
+				}},
+			},
+			"root": {
+				Directory: "root",
+				Language:  "Python",
+				Issues: []domain.Issue{{
+					ID:       "other",
+					Title:    "Unregistered",
+					Severity: domain.SeverityInfo,
+					Category: domain.CategoryBestPractice,
+				}},
+			},
+		},
+	}
+
+	log := BuildSARIF(analysis, []detector.IssueDetector{
+		stubDetector{id: "stub", category: domain.CategoryConfiguration},
+		stubDetector{id: "unused", category: domain.CategorySecurity},
+	}, "1.2.3")
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Fatalf("expected 3 rules (2 registered + 1 synthesized), got %d", len(run.Tool.Driver.Rules))
+	}
+	if run.Tool.Driver.Rules[0].ID != "stub" || run.Tool.Driver.Rules[0].Name != "StubDetector" {
+		t.Fatalf("unexpected first rule: %+v", run.Tool.Driver.Rules[0])
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+
+	// Directories are sorted, so root comes first
+	rootResult := run.Results[0]
+	if rootResult.RuleID != "other" || rootResult.RuleIndex != 2 || rootResult.Level != "note" {
+		t.Fatalf("unexpected root result: %+v", rootResult)
+	}
+	if uri := rootResult.Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "." {
+		t.Fatalf("expected root uri '.', got %q", uri)
+	}
+
+	svcResult := run.Results[1]
+	if svcResult.RuleID != "stub" || svcResult.RuleIndex != 0 || svcResult.Level != "error" {
+		t.Fatalf("unexpected svc result: %+v", svcResult)
+	}
+	loc := svcResult.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "svc/main.go" {
+		t.Fatalf("expected relative uri, got %q", loc.ArtifactLocation.URI)
+	}
+	if loc.Region == nil || loc.Region.StartLine != 12 || loc.Region.StartColumn != 3 {
+		t.Fatalf("unexpected region: %+v", loc.Region)
+	}
+	if svcResult.Properties["suggestion"] != "fix it" {
+		t.Fatalf("expected suggestion in properties, got %v", svcResult.Properties)
+	}
+
+	if _, err := json.Marshal(log); err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+}
+
+func TestSarifURI_RelativeFileInSubdirectory(t *testing.T) {
+	if got := sarifURI("/repo", "api", "go.mod"); got != "api/go.mod" {
+		t.Fatalf("sarifURI = %q, want api/go.mod", got)
+	}
+	if got := sarifURI("/repo", "api", ""); got != "api" {
+		t.Fatalf("sarifURI = %q, want api", got)
+	}
+}
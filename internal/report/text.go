@@ -0,0 +1,181 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/getlawrence/cli/internal/detector"
+	"github.com/getlawrence/cli/internal/domain"
+)
+
+// TextRenderer renders the human-readable console report
+type TextRenderer struct{}
+
+// NewTextRenderer creates a new text renderer
+func NewTextRenderer() *TextRenderer {
+	return &TextRenderer{}
+}
+
+// Format returns the output format name
+func (r *TextRenderer) Format() string {
+	return "text"
+}
+
+// Render writes the text report
+func (r *TextRenderer) Render(w io.Writer, report *Report) error {
+	directories := sortedDirectories(report.Analysis)
+	if len(directories) == 0 && (report.Analysis == nil || len(report.Analysis.Errors) == 0) {
+		_, err := fmt.Fprintf(w, "No analysis results to display.\n")
+		return err
+	}
+
+	for _, dirAnalysis := range directories {
+		// Header
+		fmt.Fprintf(w, "Directory: %s\n", dirAnalysis.Directory)
+		fmt.Fprintf(w, "Language: %s\n", dirAnalysis.Language)
+		if project := dirAnalysis.Project; project != nil {
+			fmt.Fprintf(w, "Project: %s (%s, %d directories)\n", project.Name, project.Manifest, len(project.Directories))
+		}
+
+		// Libraries
+		if report.Detailed {
+			fmt.Fprintf(w, "Libraries:\n")
+			if len(dirAnalysis.Libraries) == 0 {
+				fmt.Fprintf(w, "  - none\n")
+			} else {
+				for _, lib := range dirAnalysis.Libraries {
+					fmt.Fprintf(w, "  - %s\n", dependencyLabel(lib.Name, lib.Version, lib.PackageFile))
+				}
+			}
+		} else {
+			fmt.Fprintf(w, "Libraries: %d\n", len(dirAnalysis.Libraries))
+		}
+
+		// Packages
+		if report.Detailed {
+			fmt.Fprintf(w, "Packages:\n")
+			if len(dirAnalysis.Packages) == 0 {
+				fmt.Fprintf(w, "  - none\n")
+			} else {
+				for _, pkg := range dirAnalysis.Packages {
+					fmt.Fprintf(w, "  - %s\n", dependencyLabel(pkg.Name, pkg.Version, pkg.PackageFile))
+				}
+			}
+		} else {
+			fmt.Fprintf(w, "Packages: %d\n", len(dirAnalysis.Packages))
+		}
+
+		// Instrumentations
+		if report.Detailed {
+			fmt.Fprintf(w, "Instrumentations:\n")
+			if len(dirAnalysis.AvailableInstrumentations) == 0 {
+				fmt.Fprintf(w, "  - none\n")
+			} else {
+				for _, inst := range dirAnalysis.AvailableInstrumentations {
+					meta := ""
+					if tags := instrumentationTags(inst); len(tags) > 0 {
+						meta = fmt.Sprintf(" (%s)", strings.Join(tags, ", "))
+					}
+					suffix := meta
+					if inst.URLs.Repo != "" {
+						suffix = joinNonEmpty(suffix, fmt.Sprintf("- %s", inst.URLs.Repo))
+					}
+					fmt.Fprintf(w, "  - %s: %s%s\n", inst.Package.Name, inst.Title, suffix)
+				}
+			}
+		} else {
+			fmt.Fprintf(w, "Instrumentations: %d\n", len(dirAnalysis.AvailableInstrumentations))
+		}
+
+		// Issues
+		r.renderIssues(w, dirAnalysis)
+
+		// Spacer between directories
+		fmt.Fprintf(w, "\n")
+	}
+
+	if report.Analysis != nil && len(report.Analysis.Errors) > 0 {
+		fmt.Fprintf(w, "Failed directories (%d):\n", len(report.Analysis.Errors))
+		for _, dir := range sortedKeys(report.Analysis.Errors) {
+			fmt.Fprintf(w, "  - %s: %s\n", dir, report.Analysis.Errors[dir])
+		}
+		fmt.Fprintf(w, "\n")
+	}
+
+	// Summary footer
+	_, err := fmt.Fprintf(w, "Summary: %s\n", Summarize(report.Analysis).Line())
+	if err == nil && report.Gate != nil {
+		_, err = fmt.Fprintf(w, "Gate: %s\n", report.Gate.Line())
+	}
+	return err
+}
+
+// renderIssues writes the issue block for a directory
+func (r *TextRenderer) renderIssues(w io.Writer, dirAnalysis *detector.DirectoryAnalysis) {
+	if len(dirAnalysis.Issues) == 0 {
+		fmt.Fprintf(w, "Issues: 0\n")
+		return
+	}
+	fmt.Fprintf(w, "Issues (%d):\n", len(dirAnalysis.Issues))
+	for _, issue := range dirAnalysis.Issues {
+		header := fmt.Sprintf("[%s][%s] %s", strings.ToUpper(string(issue.Severity)), string(issue.Category), issue.Title)
+		fmt.Fprintf(w, "  - %s\n", header)
+		if strings.TrimSpace(issue.Description) != "" {
+			fmt.Fprintf(w, "    Description: %s\n", issue.Description)
+		}
+		if strings.TrimSpace(issue.Suggestion) != "" {
+			fmt.Fprintf(w, "    Suggestion: %s\n", issue.Suggestion)
+		}
+		if len(issue.References) > 0 {
+			fmt.Fprintf(w, "    References:\n")
+			for _, ref := range issue.References {
+				fmt.Fprintf(w, "      - %s\n", ref)
+			}
+		}
+		if loc := issueLocation(issue); loc != "" {
+			fmt.Fprintf(w, "    Location: %s\n", loc)
+		}
+	}
+}
+
+// dependencyLabel formats a library or package as "name (version) [file]"
+func dependencyLabel(name, version, file string) string {
+	label := name
+	if version != "" {
+		label = joinNonEmpty(label, fmt.Sprintf("(%s)", version))
+	}
+	if file != "" {
+		label = joinNonEmpty(label, fmt.Sprintf("[%s]", file))
+	}
+	return label
+}
+
+// instrumentationTags returns the descriptive tags shown next to an instrumentation
+func instrumentationTags(inst domain.InstrumentationInfo) []string {
+	tags := make([]string, 0, 3)
+	if inst.IsFirstParty {
+		tags = append(tags, "first-party")
+	}
+	if inst.IsAvailable {
+		tags = append(tags, "available")
+	} else {
+		tags = append(tags, "unavailable")
+	}
+	if inst.RegistryType != "" {
+		tags = append(tags, inst.RegistryType)
+	}
+	return tags
+}
+
+// issueLocation formats the file and line of an issue, or "" when unknown
+func issueLocation(issue domain.Issue) string {
+	locParts := make([]string, 0, 2)
+	if strings.TrimSpace(issue.File) != "" {
+		locParts = append(locParts, issue.File)
+	}
+	if issue.Line > 0 {
+		locParts = append(locParts, fmt.Sprintf("line %d", issue.Line))
+	}
+	return strings.Join(locParts, ": ")
+}
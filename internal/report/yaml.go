@@ -0,0 +1,52 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLRenderer renders the report as YAML grouped by directory
+type YAMLRenderer struct{}
+
+// NewYAMLRenderer creates a new YAML renderer
+func NewYAMLRenderer() *YAMLRenderer {
+	return &YAMLRenderer{}
+}
+
+// Format returns the output format name
+func (r *YAMLRenderer) Format() string {
+	return "yaml"
+}
+
+// Render writes the YAML report
+func (r *YAMLRenderer) Render(w io.Writer, report *Report) error {
+	rootPath := ""
+	if report.Analysis != nil {
+		rootPath = report.Analysis.RootPath
+	}
+	doc := map[string]interface{}{
+		"root_path":   rootPath,
+		"directories": sortedDirectories(report.Analysis),
+		"summary":     summaryFields(report),
+	}
+
+	// Round-trip through JSON so YAML keys match the JSON field names
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(generic); err != nil {
+		return fmt.Errorf("failed to write YAML: %w", err)
+	}
+	return encoder.Close()
+}
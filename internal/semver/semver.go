@@ -0,0 +1,327 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version. Missing minor/patch components are zero.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Revision is a fourth numeric component, as in .NET versions; it sorts after the
+	// version without it
+	Revision   int
+	Prerelease string
+	// Post is the number of a PEP 440 post-release, e.g. "1" for "1.0.post1"; a
+	// post-release sorts after its release
+	Post     string
+	Original string
+	// parts is the number of numeric components given, which decides what a
+	// compatible release (~=, ~>) may change
+	parts int
+}
+
+// versionRe extracts the numeric core and qualifier from loosely formatted versions
+// such as "v1.2.3", "^1.2", "==1.30.0", "1.2.3-beta.1+build", "0.45b0", "1.2.3.4" or
+// "5.4.2.Final"
+var versionRe = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:\.(\d+))?(?:[-.]?((?:alpha|beta|rc|a|b|dev|pre|preview)[\w.]*|[0-9A-Za-z]+(?:\.[0-9A-Za-z]+)*))?`)
+
+// releaseQualifierRe matches qualifiers marking a release rather than a prerelease,
+// e.g. Maven's "Final", "RELEASE" and "GA"
+var releaseQualifierRe = regexp.MustCompile(`(?i)^(final|release|ga)$`)
+
+// postReleaseRe matches a PEP 440 post-release qualifier
+var postReleaseRe = regexp.MustCompile(`(?i)^post(\d*)$`)
+
+// wildcardRe matches a version with a wildcard component, e.g. "1.2.x", "1.*" or "x"
+var wildcardRe = regexp.MustCompile(`^v?(?:(\d+)(?:\.(\d+))?(?:\.(\d+))?\.)?[xX*]$`)
+
+// Parse extracts a version from s, ignoring range operators and a leading "v".
+// Wildcards such as "1.2.x" are ranges rather than versions and are rejected.
+func Parse(s string) (Version, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return Version{}, fmt.Errorf("empty version")
+	}
+	if wildcardRe.MatchString(strings.TrimLeft(trimmed, "<>=!^~ ")) {
+		return Version{}, fmt.Errorf("invalid version %s: wildcards are ranges", s)
+	}
+	m := versionRe.FindStringSubmatch(trimmed)
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid version: %s", s)
+	}
+	v := Version{Original: s, parts: 1}
+	v.Major, _ = strconv.Atoi(m[1])
+	for i, part := range []*int{&v.Minor, &v.Patch, &v.Revision} {
+		if m[i+2] != "" {
+			*part, _ = strconv.Atoi(m[i+2])
+			v.parts = i + 2
+		}
+	}
+	// Build metadata (+...) is not part of precedence
+	qualifier := strings.SplitN(m[5], "+", 2)[0]
+	switch post := postReleaseRe.FindStringSubmatch(qualifier); {
+	case post != nil:
+		v.Post = post[1]
+		if v.Post == "" {
+			v.Post = "0"
+		}
+	case !releaseQualifierRe.MatchString(qualifier):
+		v.Prerelease = qualifier
+	}
+	return v, nil
+}
+
+// MustParse is like Parse but panics on invalid input; intended for constants and tests
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// String returns the canonical major.minor.patch[.revision][-prerelease][.postN] form
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Revision != 0 {
+		s += fmt.Sprintf(".%d", v.Revision)
+	}
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Post != "" {
+		s += ".post" + v.Post
+	}
+	return s
+}
+
+// IsPrerelease reports whether the version has a prerelease tag
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Compare returns -1, 0 or 1 when v is lower than, equal to or greater than o.
+// A release sorts after any prerelease of the same core version, and before its
+// post-releases.
+func (v Version) Compare(o Version) int {
+	for _, d := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}, {v.Revision, o.Revision}} {
+		if d[0] != d[1] {
+			if d[0] < d[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	default:
+		if c := comparePrerelease(v.Prerelease, o.Prerelease); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case v.Post == o.Post:
+		return 0
+	case v.Post == "":
+		return -1
+	case o.Post == "":
+		return 1
+	}
+	return comparePrerelease(v.Post, o.Post)
+}
+
+// comparePrerelease compares dot-separated prerelease identifiers per semver rules
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(as[i], bs[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// Compare parses and compares two version strings
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// constraintTermRe matches a single comparison such as ">= 1.30" or "^1.2"
+var constraintTermRe = regexp.MustCompile(`^(>=|<=|!=|==|=|>|<|\^|~>|~=|~)?\s*(.+)$`)
+
+// Constraint is a set of comparisons that must all hold, e.g. ">= 1.30, < 2"
+type Constraint struct {
+	terms []constraintTerm
+	raw   string
+}
+
+type constraintTerm struct {
+	op      string
+	version Version
+}
+
+// ParseConstraint parses comma- or space-separated comparisons. Supported operators are
+// =, ==, !=, >, >=, <, <=, ^ (same major), ~ (same minor) and ~> and ~= (compatible
+// release: only the last given component may increase, so ~=1.30 means >=1.30, <2).
+// A wildcard matches the versions starting with the components before it, so 1.2.x
+// means >=1.2.0, <1.3.0 and * matches any release; with another operator it stands
+// for 0, e.g. >=1.x means >=1.0.
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{raw: s}
+	normalized := strings.ReplaceAll(s, ",", " ")
+	fields := strings.Fields(normalized)
+	// Re-join operators separated from their version by whitespace (">= 1.2")
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if isOperator(field) && i+1 < len(fields) {
+			field += fields[i+1]
+			i++
+		}
+		m := constraintTermRe.FindStringSubmatch(field)
+		if m == nil {
+			return Constraint{}, fmt.Errorf("invalid constraint: %s", s)
+		}
+		if w := wildcardRe.FindStringSubmatch(m[2]); w != nil {
+			c.terms = append(c.terms, wildcardTerm(m[1], w))
+			continue
+		}
+		v, err := Parse(m[2])
+		if err != nil {
+			return Constraint{}, fmt.Errorf("invalid constraint %q: %w", s, err)
+		}
+		op := m[1]
+		if op == "" || op == "==" {
+			op = "="
+		}
+		c.terms = append(c.terms, constraintTerm{op: op, version: v})
+	}
+	if len(c.terms) == 0 {
+		return Constraint{}, fmt.Errorf("empty constraint")
+	}
+	return c, nil
+}
+
+// wildcardTerm returns the comparison for a wildcard version whose numeric components
+// were matched by wildcardRe
+func wildcardTerm(op string, m []string) constraintTerm {
+	v := Version{Original: m[0]}
+	for i, part := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		if m[i+1] != "" {
+			*part, _ = strconv.Atoi(m[i+1])
+			v.parts = i + 1
+		}
+	}
+	switch {
+	case op != "" && op != "=" && op != "==":
+		return constraintTerm{op: op, version: v}
+	case v.parts == 0:
+		return constraintTerm{op: ">=", version: v}
+	}
+	// A compatible release may change only the wildcard and what follows it
+	v.parts++
+	return constraintTerm{op: "~>", version: v}
+}
+
+func isOperator(s string) bool {
+	switch s {
+	case ">=", "<=", "!=", "==", "=", ">", "<", "^", "~>", "~=", "~":
+		return true
+	}
+	return false
+}
+
+// String returns the constraint as written
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Check reports whether v satisfies every term of the constraint
+func (c Constraint) Check(v Version) bool {
+	for _, t := range c.terms {
+		cmp := v.Compare(t.version)
+		ok := false
+		switch t.op {
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "^":
+			ok = cmp >= 0 && v.Major == t.version.Major
+			if t.version.Major == 0 {
+				ok = ok && v.Minor == t.version.Minor
+			}
+		case "~":
+			ok = cmp >= 0 && v.Major == t.version.Major && v.Minor == t.version.Minor
+		case "~>", "~=":
+			ok = cmp >= 0 && compatibleRelease(v, t.version)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// compatibleRelease reports whether v keeps every component of base but the last given
+// one, e.g. 1.x for 1.30 and 1.30.x for 1.30.0
+func compatibleRelease(v, base Version) bool {
+	fixed := base.parts - 1
+	if fixed < 1 {
+		fixed = 1
+	}
+	got := []int{v.Major, v.Minor, v.Patch, v.Revision}
+	want := []int{base.Major, base.Minor, base.Patch, base.Revision}
+	for i := 0; i < fixed; i++ {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
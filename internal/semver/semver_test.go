@@ -0,0 +1,93 @@
+package semver
+
+import "testing"
+
+func TestParse_LooseFormats(t *testing.T) {
+	cases := map[string]string{
+		"v1.30.0":            "1.30.0",
+		"^1.2":               "1.2.0",
+		"==1.27.0":           "1.27.0",
+		"~> 2.1.3":           "2.1.3",
+		"1.2.3-beta.1+build": "1.2.3-beta.1",
+		"0.45b0":             "0.45.0-b0",
+		"3":                  "3.0.0",
+		"1.2.3.4":            "1.2.3.4",
+		"5.4.2.Final":        "5.4.2",
+		"2.0.0.RELEASE":      "2.0.0",
+		"1.0.post1":          "1.0.0.post1",
+	}
+	for in, want := range cases {
+		v, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", in, err)
+		}
+		if v.String() != want {
+			t.Fatalf("Parse(%q) = %s, want %s", in, v.String(), want)
+		}
+	}
+	if _, err := Parse("latest"); err == nil {
+		t.Fatalf("expected error for non-numeric version")
+	}
+	for _, in := range []string{"1.2.x", "1.x", "^1.X", "2.*"} {
+		if v, err := Parse(in); err == nil {
+			t.Fatalf("expected error for wildcard %q, got %s", in, v)
+		}
+	}
+}
+
+func TestCompare_Ordering(t *testing.T) {
+	ordered := []string{"0.9.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-beta", "1.0.0-rc.1", "1.0.0", "1.0.post1", "1.0.post10", "1.0.0.1", "1.0.1", "1.10.0", "2.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		c, err := Compare(ordered[i], ordered[i+1])
+		if err != nil || c != -1 {
+			t.Fatalf("Compare(%s, %s) = %d, %v; want -1", ordered[i], ordered[i+1], c, err)
+		}
+	}
+	if c, _ := Compare("v1.2", "1.2.0"); c != 0 {
+		t.Fatalf("expected v1.2 == 1.2.0")
+	}
+	if c, _ := Compare("1.2.3.Final", "1.2.3"); c != 0 {
+		t.Fatalf("expected 1.2.3.Final == 1.2.3")
+	}
+}
+
+func TestConstraint_Check(t *testing.T) {
+	cases := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">= 1.30", "1.30.0", true},
+		{">= 1.30", "1.29.9", false},
+		{">=1.30, <2", "2.0.0", false},
+		{"^1.2.0", "1.9.0", true},
+		{"^0.2.0", "0.3.0", false},
+		{"~1.2.0", "1.2.9", true},
+		{"~1.2.0", "1.3.0", false},
+		{"!= 1.0.0", "1.0.0", false},
+		{"1.4.0", "v1.4.0", true},
+		{"~=1.30", "1.45.0", true},
+		{"~=1.30", "2.0.0", false},
+		{"~=1.30.0", "1.30.5", true},
+		{"~=1.30.0", "1.31.0", false},
+		{"~> 2.1", "2.9.0", true},
+		{"1.2.x", "1.2.7", true},
+		{"1.2.x", "1.3.0", false},
+		{"1.x", "1.45.0", true},
+		{"1.x", "2.0.0", false},
+		{"0.x", "0.9.0", true},
+		{"=1.2.*", "1.2.0", true},
+		{"*", "3.1.0", true},
+		{">= 1.x", "1.0.0", true},
+		{">= 1.x", "0.9.0", false},
+	}
+	for _, tc := range cases {
+		c, err := ParseConstraint(tc.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q) error: %v", tc.constraint, err)
+		}
+		if got := c.Check(MustParse(tc.version)); got != tc.want {
+			t.Fatalf("%q.Check(%s) = %v, want %v", tc.constraint, tc.version, got, tc.want)
+		}
+	}
+}
@@ -34,6 +34,11 @@ type TemplateData struct {
 	TraceHeaders      map[string]string `json:"trace_headers,omitempty"`
 	TraceHeadersJSON  string            `json:"trace_headers_json,omitempty"`
 	TraceInsecure     bool              `json:"trace_insecure,omitempty"`
+
+	// Node.js preload hints for compiled TypeScript: the node flag (--require or --import)
+	// and the emitted bootstrap path, both derived from tsconfig.json
+	PreloadFlag string `json:"preload_flag,omitempty"`
+	PreloadPath string `json:"preload_path,omitempty"`
 }
 
 // AgentPromptData contains all data needed for agent prompt generation
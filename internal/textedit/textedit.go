@@ -0,0 +1,15 @@
+// Package textedit holds helpers shared by the manifest and source editors that splice
+// text into existing files while keeping their layout
+package textedit
+
+import "strings"
+
+// LineIndent returns the leading whitespace of the line containing offset
+func LineIndent(text string, offset int) string {
+	start := strings.LastIndexByte(text[:offset], '\n') + 1
+	end := start
+	for end < len(text) && (text[end] == ' ' || text[end] == '\t') {
+		end++
+	}
+	return text[start:end]
+}
@@ -0,0 +1,23 @@
+package textedit
+
+import "testing"
+
+func TestLineIndent(t *testing.T) {
+	text := "deps = [\n\t  \"a\",\n]\n"
+	cases := []struct {
+		offset int
+		want   string
+	}{
+		{0, ""},
+		{5, ""},
+		{9, "\t  "},
+		{12, "\t  "},
+		{len(text) - 2, ""},
+		{len(text), ""},
+	}
+	for _, c := range cases {
+		if got := LineIndent(text, c.offset); got != c.want {
+			t.Errorf("offset %d: got %q, want %q", c.offset, got, c.want)
+		}
+	}
+}
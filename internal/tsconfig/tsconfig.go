@@ -0,0 +1,290 @@
+package tsconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the TypeScript project configuration file
+const FileName = "tsconfig.json"
+
+// maxExtendsDepth bounds "extends" chains so cyclic configs cannot loop forever
+const maxExtendsDepth = 8
+
+// Config holds the compiler options that decide where compiled JavaScript is emitted
+// and whether Node.js loads it as CommonJS or as an ES module
+type Config struct {
+	// Dir is the directory containing the tsconfig.json
+	Dir string
+	// OutDir and RootDir are relative to Dir; empty when not set
+	OutDir  string
+	RootDir string
+	// Module is the lowercased compilerOptions.module value, e.g. "commonjs" or "nodenext"
+	Module string
+	// PackageType is the "type" field of the package.json next to the tsconfig
+	PackageType string
+}
+
+type rawConfig struct {
+	Extends         json.RawMessage `json:"extends"`
+	CompilerOptions struct {
+		OutDir  *string `json:"outDir"`
+		RootDir *string `json:"rootDir"`
+		Module  *string `json:"module"`
+	} `json:"compilerOptions"`
+}
+
+// Load reads dir/tsconfig.json, following relative "extends" entries. Options of the
+// extending file win over the ones it inherits, as with tsc.
+func Load(dir string) (*Config, error) {
+	cfg := &Config{Dir: dir}
+	if err := load(filepath.Join(dir, FileName), dir, cfg, 0); err != nil {
+		return nil, err
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(content, &pkg) == nil {
+			cfg.PackageType = pkg.Type
+		}
+	}
+	return cfg, nil
+}
+
+// load applies the options of path to cfg; options already set by an extending file are kept
+func load(path, baseDir string, cfg *Config, depth int) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var raw rawConfig
+	if err := json.Unmarshal(StripJSONC(content), &raw); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	// Paths in an inherited config are relative to that config's directory
+	configDir := filepath.Dir(path)
+	relPath := func(p string) string {
+		rel, err := filepath.Rel(baseDir, filepath.Join(configDir, p))
+		if err != nil {
+			return p
+		}
+		return filepath.ToSlash(rel)
+	}
+	opts := raw.CompilerOptions
+	if cfg.OutDir == "" && opts.OutDir != nil {
+		cfg.OutDir = relPath(*opts.OutDir)
+	}
+	if cfg.RootDir == "" && opts.RootDir != nil {
+		cfg.RootDir = relPath(*opts.RootDir)
+	}
+	if cfg.Module == "" && opts.Module != nil {
+		cfg.Module = strings.ToLower(*opts.Module)
+	}
+
+	if depth >= maxExtendsDepth {
+		return nil
+	}
+	for _, parent := range extendsPaths(raw.Extends) {
+		// Package references ("@tsconfig/node20/tsconfig.json") would need node module resolution
+		if !strings.HasPrefix(parent, ".") && !filepath.IsAbs(parent) {
+			continue
+		}
+		parentPath := parent
+		if !filepath.IsAbs(parentPath) {
+			parentPath = filepath.Join(configDir, parent)
+		}
+		if filepath.Ext(parentPath) != ".json" {
+			parentPath += ".json"
+		}
+		if err := load(parentPath, baseDir, cfg, depth+1); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// extendsPaths decodes "extends", which is a string or (TypeScript 5+) an array of strings.
+// Later entries override earlier ones, so they are returned first.
+func extendsPaths(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var single string
+	if json.Unmarshal(raw, &single) == nil {
+		return []string{single}
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) != nil {
+		return nil
+	}
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+	return list
+}
+
+// Find loads the nearest tsconfig.json at or above dir, stopping at root.
+// It returns nil without error when there is none.
+func Find(dir, root string) (*Config, error) {
+	dir, root = filepath.Clean(dir), filepath.Clean(root)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, FileName)); err == nil {
+			return Load(dir)
+		}
+		parent := filepath.Dir(dir)
+		if dir == root || parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// ESM reports whether the compiled output runs as ES modules. The node16/nodenext
+// settings follow the package.json "type" field; es20xx/esnext always emit ESM.
+func (c *Config) ESM() bool {
+	switch c.Module {
+	case "commonjs", "amd", "umd", "system", "none":
+		return false
+	case "node16", "node18", "nodenext", "":
+		return c.PackageType == "module"
+	default:
+		// es6, es2015, es2020, es2022, esnext, preserve
+		return true
+	}
+}
+
+// PreloadFlag returns the node flag that loads a module before the application:
+// --import for ES modules and --require for CommonJS
+func (c *Config) PreloadFlag() string {
+	if c.ESM() {
+		return "--import"
+	}
+	return "--require"
+}
+
+// ImportSpecifier returns the relative specifier for importing the module at modulePath
+// (without extension) from a file in fromDir. Node's ESM resolution, which tsc enforces
+// under node16/nodenext, requires the emitted ".js" extension.
+func (c *Config) ImportSpecifier(fromDir, modulePath string) string {
+	rel, err := filepath.Rel(fromDir, modulePath)
+	if err != nil {
+		rel = filepath.Base(modulePath)
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	switch c.Module {
+	case "node16", "node18", "nodenext":
+		if c.ESM() {
+			rel += ".js"
+		}
+	}
+	return rel
+}
+
+// SourceDir returns the directory TypeScript sources live in: rootDir when set, else Dir
+func (c *Config) SourceDir() string {
+	if c.RootDir == "" {
+		return c.Dir
+	}
+	return filepath.Join(c.Dir, filepath.FromSlash(c.RootDir))
+}
+
+// EmittedPath returns the path, relative to Dir and prefixed with "./", of the JavaScript
+// file tsc emits for the given TypeScript source
+func (c *Config) EmittedPath(source string) string {
+	rel, err := filepath.Rel(c.SourceDir(), source)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel, _ = filepath.Rel(c.Dir, source)
+	}
+	switch ext := filepath.Ext(rel); ext {
+	case ".mts":
+		rel = strings.TrimSuffix(rel, ext) + ".mjs"
+	case ".cts":
+		rel = strings.TrimSuffix(rel, ext) + ".cjs"
+	case ".ts", ".tsx":
+		rel = strings.TrimSuffix(rel, ext) + ".js"
+	}
+	if c.OutDir != "" {
+		rel = filepath.Join(filepath.FromSlash(c.OutDir), rel)
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+// StripJSONC removes comments and trailing commas so tsconfig files, which tsc parses
+// leniently, can be decoded with encoding/json. String contents are left untouched.
+func StripJSONC(content []byte) []byte {
+	out := make([]byte, 0, len(content))
+	inString := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if inString {
+			out = append(out, c)
+			switch c {
+			case '\\':
+				if i+1 < len(content) {
+					i++
+					out = append(out, content[i])
+				}
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+			if i < len(content) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i+1 < len(content) && !(content[i] == '*' && content[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == ',' && (nextSignificant(content, i+1) == '}' || nextSignificant(content, i+1) == ']'):
+			// trailing comma
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// nextSignificant returns the next byte after whitespace and comments, or 0 at the end
+func nextSignificant(content []byte, i int) byte {
+	for i < len(content) {
+		switch c := content[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '/' && i+1 < len(content) && content[i+1] == '/':
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(content) && content[i+1] == '*':
+			i += 2
+			for i+1 < len(content) && !(content[i] == '*' && content[i+1] == '/') {
+				i++
+			}
+			i += 2
+		default:
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package tsconfig
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/getlawrence/cli/internal/testutil"
+)
+
+func TestLoad_CommentsTrailingCommasAndExtends(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{
+		"tsconfig.base.json": `{
+  "compilerOptions": { "module": "NodeNext", "outDir": "build" }
+}`,
+		"app/tsconfig.json": `{
+  // inherit module settings
+  "extends": "../tsconfig.base.json",
+  "compilerOptions": {
+    /* emitted next to the sources */
+    "outDir": "./dist",
+    "rootDir": "src", // trailing comma below
+  },
+}`,
+		"app/package.json": `{"name": "app", "type": "module"}`,
+	})
+
+	cfg, err := Load(filepath.Join(root, "app"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OutDir != "dist" || cfg.RootDir != "src" || cfg.Module != "nodenext" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if !cfg.ESM() || cfg.PreloadFlag() != "--import" {
+		t.Fatalf("nodenext with type=module should be ESM")
+	}
+	if got := cfg.ImportSpecifier(filepath.Join(root, "app", "src", "routes"), filepath.Join(root, "app", "src", "otel")); got != "../otel.js" {
+		t.Fatalf("expected ESM specifier with extension, got %s", got)
+	}
+	if got := cfg.EmittedPath(filepath.Join(root, "app", "src", "otel.ts")); got != "./dist/otel.js" {
+		t.Fatalf("unexpected emitted path: %s", got)
+	}
+}
+
+func TestConfig_ModuleKinds(t *testing.T) {
+	cases := []struct {
+		module, pkgType string
+		flag            string
+	}{
+		{"commonjs", "module", "--require"},
+		{"nodenext", "", "--require"},
+		{"node16", "module", "--import"},
+		{"esnext", "", "--import"},
+		{"es2022", "commonjs", "--import"},
+		{"", "module", "--import"},
+		{"", "", "--require"},
+	}
+	for _, c := range cases {
+		cfg := &Config{Module: c.module, PackageType: c.pkgType}
+		if got := cfg.PreloadFlag(); got != c.flag {
+			t.Fatalf("module=%q type=%q: got %s, want %s", c.module, c.pkgType, got, c.flag)
+		}
+	}
+	if got := (&Config{Module: "esnext"}).ImportSpecifier("src", filepath.Join("src", "otel")); got != "./otel" {
+		t.Fatalf("bundler-style ESM should not need an extension, got %s", got)
+	}
+}
+
+func TestFind_StopsAtRoot(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFiles(t, root, map[string]string{"web/tsconfig.json": `{"compilerOptions": {"module": "commonjs"}}`})
+
+	cfg, err := Find(filepath.Join(root, "web", "src", "routes"), root)
+	if err != nil || cfg == nil {
+		t.Fatalf("expected to find web/tsconfig.json, got %v, %v", cfg, err)
+	}
+	if cfg.Dir != filepath.Join(root, "web") {
+		t.Fatalf("unexpected config dir %s", cfg.Dir)
+	}
+
+	cfg, err = Find(filepath.Join(root, "api"), root)
+	if err != nil || cfg != nil {
+		t.Fatalf("expected no config, got %+v, %v", cfg, err)
+	}
+}
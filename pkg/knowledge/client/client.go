@@ -274,6 +274,8 @@ func isMainSDK(name string) bool {
 		"@opentelemetry/sdk-node",      // Main Node.js SDK
 		"@opentelemetry/sdk-web",       // Main Web SDK
 		"go.opentelemetry.io/otel/sdk", // Main Go SDK
+		"opentelemetry_sdk",            // Main Rust SDK
+		"opentelemetry-cpp",            // C++ API and SDK package
 		// Add other main SDKs for other languages as needed
 	}
 
@@ -52,6 +52,9 @@ func (f *DefaultProviderFactory) registerDefaultProviders(path string, logger lo
 		types.ComponentLanguageCSharp,
 		types.ComponentLanguagePHP,
 		types.ComponentLanguageRuby,
+		types.ComponentLanguageRust,
+		types.ComponentLanguageElixir,
+		types.ComponentLanguageCpp,
 	}
 
 	for _, lang := range allLanguages {
@@ -486,6 +489,9 @@ func (p *GenericLanguageProvider) GetPackageManagerType() string {
 		types.ComponentLanguageCSharp: "nuget",
 		types.ComponentLanguagePHP:    "composer",
 		types.ComponentLanguageRuby:   "gem",
+		types.ComponentLanguageRust:   "cargo",
+		types.ComponentLanguageElixir: "hex",
+		types.ComponentLanguageCpp:    "vcpkg",
 	}
 	return packageManagers[p.language]
 }
@@ -649,6 +655,9 @@ func (p *GenericPackageManagerProvider) GetPackageManagerType() string {
 		types.ComponentLanguageCSharp: "nuget",
 		types.ComponentLanguagePHP:    "composer",
 		types.ComponentLanguageRuby:   "gem",
+		types.ComponentLanguageRust:   "cargo",
+		types.ComponentLanguageElixir: "hex",
+		types.ComponentLanguageCpp:    "vcpkg",
 	}
 	return packageManagers[p.language]
 }
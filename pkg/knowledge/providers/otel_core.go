@@ -194,6 +194,28 @@ func (p *OTELCoreProvider) getCorePackagesForLanguage(language string) []CorePac
 			{Name: "opentelemetry-sdk", Type: "sdk", MinVersion: "1.0.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
 			{Name: "opentelemetry-exporter-otlp", Type: "exporter", MinVersion: "1.0.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
 		}
+	case "rust":
+		// The Rust crates are still 0.x; minor releases may contain breaking changes
+		return []CorePackage{
+			{Name: "opentelemetry", Type: "api", MinVersion: "0.28.0", MaxVersion: "1.0.0", Stability: "beta", Lifecycle: "beta", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry_sdk", Type: "sdk", MinVersion: "0.28.0", MaxVersion: "1.0.0", Stability: "beta", Lifecycle: "beta", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry-otlp", Type: "exporter", MinVersion: "0.28.0", MaxVersion: "1.0.0", Stability: "beta", Lifecycle: "beta", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry-semantic-conventions", Type: "semconv", MinVersion: "0.28.0", MaxVersion: "1.0.0", Stability: "beta", Lifecycle: "beta", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry-stdout", Type: "exporter", MinVersion: "0.28.0", MaxVersion: "1.0.0", Stability: "beta", Lifecycle: "beta", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry-zipkin", Type: "exporter", MinVersion: "0.28.0", MaxVersion: "1.0.0", Stability: "beta", Lifecycle: "beta", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+		}
+	case "elixir":
+		return []CorePackage{
+			{Name: "opentelemetry_api", Type: "api", MinVersion: "1.0.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry", Type: "sdk", MinVersion: "1.0.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry_exporter", Type: "exporter", MinVersion: "1.0.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+			{Name: "opentelemetry_semantic_conventions", Type: "semconv", MinVersion: "0.2.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+		}
+	case "cpp":
+		// opentelemetry-cpp ships the API, SDK and exporters as a single vcpkg/Conan package
+		return []CorePackage{
+			{Name: "opentelemetry-cpp", Type: "sdk", MinVersion: "1.0.0", MaxVersion: "2.0.0", Stability: "stable", Lifecycle: "stable", SpecCompliance: "v1.0+", BreakingChanges: []string{}},
+		}
 	default:
 		return []CorePackage{}
 	}
@@ -277,6 +299,12 @@ func (p *OTELCoreProvider) fetchPackageMetadata(packageName string) (*PackageMet
 		return p.fetchComposerPackageMetadata(packageName)
 	case "ruby":
 		return p.fetchRubyGemsPackageMetadata(packageName)
+	case "rust":
+		return p.fetchCratesPackageMetadata(packageName)
+	case "elixir":
+		return p.fetchHexPackageMetadata(packageName)
+	case "cpp":
+		return p.fetchCppPackageMetadata(packageName)
 	default:
 		return nil, fmt.Errorf("unsupported language for package metadata: %s", langStr)
 	}
@@ -495,6 +523,120 @@ func (p *OTELCoreProvider) fetchRubyGemsPackageMetadata(packageName string) (*Pa
 	return metadata, nil
 }
 
+// fetchCratesPackageMetadata fetches package metadata from crates.io
+func (p *OTELCoreProvider) fetchCratesPackageMetadata(packageName string) (*PackageMetadata, error) {
+	url := fmt.Sprintf("https://crates.io/api/v1/crates/%s", packageName)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// crates.io rejects requests without a User-Agent
+	req.Header.Set("User-Agent", "lawrence-cli (https://github.com/getlawrence/cli)")
+	resp, err := p.registryClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("crates.io returned status %d", resp.StatusCode)
+	}
+
+	var crateData struct {
+		Crate map[string]interface{} `json:"crate"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&crateData); err != nil {
+		return nil, err
+	}
+
+	metadata := &PackageMetadata{
+		Name:        packageName,
+		Description: p.extractString(crateData.Crate, "description"),
+		Version:     p.extractString(crateData.Crate, "max_stable_version"),
+		Homepage:    p.extractString(crateData.Crate, "homepage"),
+		Repository:  p.extractString(crateData.Crate, "repository"),
+		License:     "Apache-2.0", // Licenses are per version on crates.io; OpenTelemetry uses Apache 2.0
+		Maintainers: []string{},
+	}
+
+	return metadata, nil
+}
+
+// fetchHexPackageMetadata fetches package metadata from hex.pm
+func (p *OTELCoreProvider) fetchHexPackageMetadata(packageName string) (*PackageMetadata, error) {
+	url := fmt.Sprintf("https://hex.pm/api/packages/%s", packageName)
+	resp, err := p.registryClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hex.pm returned status %d", resp.StatusCode)
+	}
+
+	var hexData struct {
+		LatestStableVersion string `json:"latest_stable_version"`
+		Meta                struct {
+			Description string            `json:"description"`
+			Licenses    []string          `json:"licenses"`
+			Links       map[string]string `json:"links"`
+		} `json:"meta"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&hexData); err != nil {
+		return nil, err
+	}
+
+	metadata := &PackageMetadata{
+		Name:        packageName,
+		Description: hexData.Meta.Description,
+		Version:     hexData.LatestStableVersion,
+		Homepage:    fmt.Sprintf("https://hex.pm/packages/%s", packageName),
+		Repository:  hexData.Meta.Links["GitHub"],
+		License:     strings.Join(hexData.Meta.Licenses, ", "),
+		Maintainers: []string{},
+	}
+
+	return metadata, nil
+}
+
+// fetchCppPackageMetadata fetches the latest opentelemetry-cpp release from GitHub.
+// vcpkg and Conan have no metadata API; their ports follow the upstream releases.
+func (p *OTELCoreProvider) fetchCppPackageMetadata(packageName string) (*PackageMetadata, error) {
+	if packageName != "opentelemetry-cpp" {
+		return nil, fmt.Errorf("no release metadata available for C++ package %s", packageName)
+	}
+	resp, err := p.registryClient.Get("https://api.github.com/repos/open-telemetry/opentelemetry-cpp/releases/latest")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub releases returned status %d", resp.StatusCode)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		Name    string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, err
+	}
+
+	metadata := &PackageMetadata{
+		Name:        packageName,
+		Description: "The OpenTelemetry C++ Client",
+		Version:     strings.TrimPrefix(release.TagName, "v"),
+		Homepage:    "https://opentelemetry.io/docs/languages/cpp/",
+		Repository:  "https://github.com/open-telemetry/opentelemetry-cpp",
+		License:     "Apache-2.0",
+		Maintainers: []string{},
+	}
+
+	return metadata, nil
+}
+
 // Helper functions for extracting data from JSON responses
 func (p *OTELCoreProvider) extractString(data map[string]interface{}, key string) string {
 	if value, ok := data[key].(string); ok {
@@ -580,6 +722,25 @@ func (p *OTELCoreProvider) determineRepository(packageName string) string {
 		if strings.Contains(packageName, "opentelemetry") {
 			return "https://github.com/open-telemetry/opentelemetry-ruby"
 		}
+	case "rust":
+		if strings.HasPrefix(packageName, "opentelemetry") {
+			return "https://github.com/open-telemetry/opentelemetry-rust"
+		}
+	case "elixir":
+		switch {
+		case packageName == "opentelemetry" || packageName == "opentelemetry_api" || packageName == "opentelemetry_exporter" || packageName == "opentelemetry_semantic_conventions":
+			return "https://github.com/open-telemetry/opentelemetry-erlang"
+		case strings.HasPrefix(packageName, "opentelemetry_"):
+			// Instrumentation libraries live in the contrib repository
+			return "https://github.com/open-telemetry/opentelemetry-erlang-contrib"
+		}
+	case "cpp":
+		if packageName == "opentelemetry-cpp" {
+			return "https://github.com/open-telemetry/opentelemetry-cpp"
+		}
+		if strings.HasPrefix(packageName, "opentelemetry") {
+			return "https://github.com/open-telemetry/opentelemetry-cpp-contrib"
+		}
 	}
 
 	// Default fallback
@@ -605,6 +766,12 @@ func (p *OTELCoreProvider) generateRegistryURL(packageName string) string {
 		return fmt.Sprintf("https://packagist.org/packages/%s", packageName)
 	case "ruby":
 		return fmt.Sprintf("https://rubygems.org/gems/%s", packageName)
+	case "rust":
+		return fmt.Sprintf("https://crates.io/crates/%s", packageName)
+	case "elixir":
+		return fmt.Sprintf("https://hex.pm/packages/%s", packageName)
+	case "cpp":
+		return fmt.Sprintf("https://vcpkg.io/en/package/%s", packageName)
 	default:
 		return ""
 	}
@@ -852,6 +1019,51 @@ func (p *OTELCoreProvider) getVersionCompatibilityMatrix(language string) []Vers
 				Notes:              "All packages in 1.x series are compatible",
 			},
 		}
+	case "rust":
+		return []VersionCompatibilityMatrix{
+			{
+				Language:       "rust",
+				APIVersion:     "0.28.0",
+				SDKVersion:     "0.28.0",
+				SemConvVersion: "0.28.0",
+				ExporterVersions: map[string]string{
+					"otlp": "0.28.0",
+				},
+				PropagatorVersions: map[string]string{},
+				Compatible:         true,
+				Notes:              "opentelemetry, opentelemetry_sdk and opentelemetry-otlp must share the same minor version",
+			},
+		}
+	case "elixir":
+		return []VersionCompatibilityMatrix{
+			{
+				Language:       "elixir",
+				APIVersion:     "1.0.0",
+				SDKVersion:     "1.0.0",
+				SemConvVersion: "0.2.0",
+				ExporterVersions: map[string]string{
+					"otlp": "1.0.0",
+				},
+				PropagatorVersions: map[string]string{},
+				Compatible:         true,
+				Notes:              "All packages in 1.x series are compatible",
+			},
+		}
+	case "cpp":
+		return []VersionCompatibilityMatrix{
+			{
+				Language:       "cpp",
+				APIVersion:     "1.0.0",
+				SDKVersion:     "1.0.0",
+				SemConvVersion: "1.0.0",
+				ExporterVersions: map[string]string{
+					"otlp": "1.0.0",
+				},
+				PropagatorVersions: map[string]string{},
+				Compatible:         true,
+				Notes:              "API, SDK and exporters are released together in opentelemetry-cpp",
+			},
+		}
 	default:
 		return []VersionCompatibilityMatrix{}
 	}
@@ -1023,6 +1235,45 @@ func (p *OTELCoreProvider) getSpecificationCompliance(language string) []Specifi
 				Notes: "Partial compliance - missing some advanced features",
 			},
 		}
+	case "rust":
+		return []SpecificationCompliance{
+			{
+				SpecVersion:     "v1.0",
+				Language:        "rust",
+				ComplianceLevel: "partial",
+				Features: []string{
+					"traces", "metrics", "logs", "context_propagation", "propagators", "exporters",
+				},
+				MissingFeatures: []string{},
+				Notes:           "Traces, metrics and logs are implemented; crates are pre-1.0 and may change between minor versions",
+			},
+		}
+	case "elixir":
+		return []SpecificationCompliance{
+			{
+				SpecVersion:     "v1.0",
+				Language:        "elixir",
+				ComplianceLevel: "partial",
+				Features: []string{
+					"traces", "context_propagation", "propagators", "exporters",
+				},
+				MissingFeatures: []string{"metrics", "logs"},
+				Notes:           "Traces are stable; metrics and logs are experimental",
+			},
+		}
+	case "cpp":
+		return []SpecificationCompliance{
+			{
+				SpecVersion:     "v1.0",
+				Language:        "cpp",
+				ComplianceLevel: "full",
+				Features: []string{
+					"traces", "metrics", "logs", "context_propagation", "propagators", "exporters",
+				},
+				MissingFeatures: []string{},
+				Notes:           "Traces, metrics and logs are stable",
+			},
+		}
 	default:
 		return []SpecificationCompliance{}
 	}
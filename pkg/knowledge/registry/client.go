@@ -47,6 +47,9 @@ func (c *Client) GetComponentsByLanguage(language string) ([]RegistryComponent,
 		"csharp":     "dotnet",
 		"php":        "php",
 		"ruby":       "ruby",
+		"rust":       "rust",
+		"elixir":     "erlang",
+		"cpp":        "cpp",
 	}
 
 	githubLang := langMap[language]
@@ -158,7 +161,7 @@ func (c *Client) parseComponentFromFile(filePath string) (*RegistryComponent, er
 // GetComponentByName fetches a specific component by name from all languages
 func (c *Client) GetComponentByName(name string) (*RegistryComponent, error) {
 	// Check all supported languages
-	languages := []string{"javascript", "go", "python", "java", "csharp", "php", "ruby"}
+	languages := []string{"javascript", "go", "python", "java", "csharp", "php", "ruby", "rust", "elixir", "cpp"}
 
 	for _, lang := range languages {
 		components, err := c.GetComponentsByLanguage(lang)
@@ -179,7 +182,7 @@ func (c *Client) GetComponentByName(name string) (*RegistryComponent, error) {
 // GetAllComponents fetches all components from all languages
 func (c *Client) GetAllComponents() ([]RegistryComponent, error) {
 	var allComponents []RegistryComponent
-	languages := []string{"javascript", "go", "python", "java", "csharp", "php", "ruby"}
+	languages := []string{"javascript", "go", "python", "java", "csharp", "php", "ruby", "rust", "elixir", "cpp"}
 
 	for _, lang := range languages {
 		components, err := c.GetComponentsByLanguage(lang)
@@ -195,7 +198,7 @@ func (c *Client) GetAllComponents() ([]RegistryComponent, error) {
 
 // GetSupportedLanguages returns the list of supported languages
 func (c *Client) GetSupportedLanguages() []string {
-	return []string{"javascript", "go", "python", "java", "csharp", "php", "ruby"}
+	return []string{"javascript", "go", "python", "java", "csharp", "php", "ruby", "rust", "elixir", "cpp"}
 }
 
 // GetRegistryStats returns statistics about the local registry
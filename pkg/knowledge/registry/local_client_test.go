@@ -24,7 +24,7 @@ func TestGetSupportedLanguages(t *testing.T) {
 	client := NewClient("/test/path", &logger.StdoutLogger{})
 	languages := client.GetSupportedLanguages()
 
-	expectedLanguages := []string{"javascript", "go", "python", "java", "csharp", "php", "ruby"}
+	expectedLanguages := []string{"javascript", "go", "python", "java", "csharp", "php", "ruby", "rust", "elixir", "cpp"}
 
 	if len(languages) != len(expectedLanguages) {
 		t.Errorf("Expected %d languages, got %d", len(expectedLanguages), len(languages))
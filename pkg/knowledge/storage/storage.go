@@ -1150,7 +1150,26 @@ func (s *Storage) GetComponentByName(name string) *types.Component {
 		LIMIT 1
 	`
 
-	row := s.db.QueryRow(query, name)
+	return s.scanComponent(s.db.QueryRow(query, name))
+}
+
+// GetComponentByNameAndLanguage returns the component of a language by name. Ecosystems
+// reuse names, e.g. opentelemetry is both a Rust crate and an Elixir package.
+func (s *Storage) GetComponentByNameAndLanguage(name string, language types.ComponentLanguage) *types.Component {
+	query := `
+		SELECT id, name, type, category, status, support_level, language, description,
+		       repository, registry_url, homepage, tags, maintainers, license,
+		       last_updated, instrumentation_targets, documentation_url,
+		       examples_url, migration_guide_url
+		FROM components
+		WHERE name = ? AND language = ?
+	`
+
+	return s.scanComponent(s.db.QueryRow(query, name, string(language)))
+}
+
+// scanComponent reads a component row along with its versions, nil when there is none
+func (s *Storage) scanComponent(row *sql.Row) *types.Component {
 	var component types.Component
 	var id int64
 	var tagsJSON, maintainersJSON, targetsJSON string
@@ -28,6 +28,9 @@ const (
 	ComponentLanguageCSharp     ComponentLanguage = "csharp"
 	ComponentLanguagePHP        ComponentLanguage = "php"
 	ComponentLanguageRuby       ComponentLanguage = "ruby"
+	ComponentLanguageRust       ComponentLanguage = "rust"
+	ComponentLanguageElixir     ComponentLanguage = "elixir"
+	ComponentLanguageCpp        ComponentLanguage = "cpp"
 )
 
 // ComponentCategory represents the category of a component